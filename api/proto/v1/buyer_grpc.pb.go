@@ -0,0 +1,260 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: buyer.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BuyerService_ListBuyers_FullMethodName  = "/bootcamp.v1.BuyerService/ListBuyers"
+	BuyerService_GetBuyer_FullMethodName    = "/bootcamp.v1.BuyerService/GetBuyer"
+	BuyerService_CreateBuyer_FullMethodName = "/bootcamp.v1.BuyerService/CreateBuyer"
+	BuyerService_UpdateBuyer_FullMethodName = "/bootcamp.v1.BuyerService/UpdateBuyer"
+	BuyerService_DeleteBuyer_FullMethodName = "/bootcamp.v1.BuyerService/DeleteBuyer"
+)
+
+// BuyerServiceClient is the client API for BuyerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BuyerServiceClient interface {
+	ListBuyers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBuyersResponse, error)
+	GetBuyer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Buyer, error)
+	// CreateBuyer ignores the id of the buyer sent and answers the one given.
+	CreateBuyer(ctx context.Context, in *Buyer, opts ...grpc.CallOption) (*Buyer, error)
+	UpdateBuyer(ctx context.Context, in *UpdateBuyerRequest, opts ...grpc.CallOption) (*Buyer, error)
+	DeleteBuyer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type buyerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBuyerServiceClient(cc grpc.ClientConnInterface) BuyerServiceClient {
+	return &buyerServiceClient{cc}
+}
+
+func (c *buyerServiceClient) ListBuyers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBuyersResponse, error) {
+	out := new(ListBuyersResponse)
+	err := c.cc.Invoke(ctx, BuyerService_ListBuyers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buyerServiceClient) GetBuyer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Buyer, error) {
+	out := new(Buyer)
+	err := c.cc.Invoke(ctx, BuyerService_GetBuyer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buyerServiceClient) CreateBuyer(ctx context.Context, in *Buyer, opts ...grpc.CallOption) (*Buyer, error) {
+	out := new(Buyer)
+	err := c.cc.Invoke(ctx, BuyerService_CreateBuyer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buyerServiceClient) UpdateBuyer(ctx context.Context, in *UpdateBuyerRequest, opts ...grpc.CallOption) (*Buyer, error) {
+	out := new(Buyer)
+	err := c.cc.Invoke(ctx, BuyerService_UpdateBuyer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buyerServiceClient) DeleteBuyer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BuyerService_DeleteBuyer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BuyerServiceServer is the server API for BuyerService service.
+// All implementations must embed UnimplementedBuyerServiceServer
+// for forward compatibility
+type BuyerServiceServer interface {
+	ListBuyers(context.Context, *emptypb.Empty) (*ListBuyersResponse, error)
+	GetBuyer(context.Context, *IDRequest) (*Buyer, error)
+	// CreateBuyer ignores the id of the buyer sent and answers the one given.
+	CreateBuyer(context.Context, *Buyer) (*Buyer, error)
+	UpdateBuyer(context.Context, *UpdateBuyerRequest) (*Buyer, error)
+	DeleteBuyer(context.Context, *IDRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedBuyerServiceServer()
+}
+
+// UnimplementedBuyerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBuyerServiceServer struct {
+}
+
+func (UnimplementedBuyerServiceServer) ListBuyers(context.Context, *emptypb.Empty) (*ListBuyersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuyers not implemented")
+}
+func (UnimplementedBuyerServiceServer) GetBuyer(context.Context, *IDRequest) (*Buyer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuyer not implemented")
+}
+func (UnimplementedBuyerServiceServer) CreateBuyer(context.Context, *Buyer) (*Buyer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBuyer not implemented")
+}
+func (UnimplementedBuyerServiceServer) UpdateBuyer(context.Context, *UpdateBuyerRequest) (*Buyer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBuyer not implemented")
+}
+func (UnimplementedBuyerServiceServer) DeleteBuyer(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBuyer not implemented")
+}
+func (UnimplementedBuyerServiceServer) mustEmbedUnimplementedBuyerServiceServer() {}
+
+// UnsafeBuyerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BuyerServiceServer will
+// result in compilation errors.
+type UnsafeBuyerServiceServer interface {
+	mustEmbedUnimplementedBuyerServiceServer()
+}
+
+func RegisterBuyerServiceServer(s grpc.ServiceRegistrar, srv BuyerServiceServer) {
+	s.RegisterService(&BuyerService_ServiceDesc, srv)
+}
+
+func _BuyerService_ListBuyers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuyerServiceServer).ListBuyers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuyerService_ListBuyers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuyerServiceServer).ListBuyers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuyerService_GetBuyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuyerServiceServer).GetBuyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuyerService_GetBuyer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuyerServiceServer).GetBuyer(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuyerService_CreateBuyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Buyer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuyerServiceServer).CreateBuyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuyerService_CreateBuyer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuyerServiceServer).CreateBuyer(ctx, req.(*Buyer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuyerService_UpdateBuyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBuyerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuyerServiceServer).UpdateBuyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuyerService_UpdateBuyer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuyerServiceServer).UpdateBuyer(ctx, req.(*UpdateBuyerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuyerService_DeleteBuyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuyerServiceServer).DeleteBuyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuyerService_DeleteBuyer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuyerServiceServer).DeleteBuyer(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BuyerService_ServiceDesc is the grpc.ServiceDesc for BuyerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BuyerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bootcamp.v1.BuyerService",
+	HandlerType: (*BuyerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBuyers",
+			Handler:    _BuyerService_ListBuyers_Handler,
+		},
+		{
+			MethodName: "GetBuyer",
+			Handler:    _BuyerService_GetBuyer_Handler,
+		},
+		{
+			MethodName: "CreateBuyer",
+			Handler:    _BuyerService_CreateBuyer_Handler,
+		},
+		{
+			MethodName: "UpdateBuyer",
+			Handler:    _BuyerService_UpdateBuyer_Handler,
+		},
+		{
+			MethodName: "DeleteBuyer",
+			Handler:    _BuyerService_DeleteBuyer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "buyer.proto",
+}
@@ -0,0 +1,5 @@
+// Package pb holds the protobuf messages and gRPC services of the API, generated from the .proto files
+// next to it. Regenerate them with go generate after changing those.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative common.proto seller.proto product.proto section.proto warehouse.proto employee.proto buyer.proto order.proto
@@ -0,0 +1,101 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/buyer"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Buyer struct {
+	pb.UnimplementedBuyerServiceServer
+	s buyer.Service
+}
+
+func NewBuyer(s buyer.Service) *Buyer {
+	return &Buyer{s: s}
+}
+
+func (b *Buyer) ListBuyers(ctx context.Context, _ *emptypb.Empty) (*pb.ListBuyersResponse, error) {
+	buyers, err := b.s.GetAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	res := &pb.ListBuyersResponse{}
+	for _, by := range buyers {
+		res.Buyers = append(res.Buyers, buyerToPB(by))
+	}
+	return res, nil
+}
+
+func (b *Buyer) GetBuyer(ctx context.Context, req *pb.IDRequest) (*pb.Buyer, error) {
+	by, err := b.s.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buyerToPB(by), nil
+}
+
+func (b *Buyer) CreateBuyer(ctx context.Context, req *pb.Buyer) (*pb.Buyer, error) {
+	request := domain.BuyerRequest{
+		CardNumberID: req.GetCardNumberId(),
+		FirstName:    req.GetFirstName(),
+		LastName:     req.GetLastName(),
+	}
+	if err := validator.New().Struct(&request); err != nil {
+		return nil, invalid(err)
+	}
+
+	by, err := b.s.Create(ctx, domain.Buyer{
+		CardNumberID: request.CardNumberID,
+		FirstName:    request.FirstName,
+		LastName:     request.LastName,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buyerToPB(by), nil
+}
+
+// UpdateBuyer applies the names set on the buyer as it is now, as PATCH does.
+func (b *Buyer) UpdateBuyer(ctx context.Context, req *pb.UpdateBuyerRequest) (*pb.Buyer, error) {
+	id := int(req.GetId())
+	by, err := b.s.Get(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if req.FirstName != nil {
+		by.FirstName = req.GetFirstName()
+	}
+	if req.LastName != nil {
+		by.LastName = req.GetLastName()
+	}
+	request := domain.BuyerRequest{CardNumberID: by.CardNumberID, FirstName: by.FirstName, LastName: by.LastName}
+	if err := validator.New().Struct(&request); err != nil {
+		return nil, invalid(err)
+	}
+
+	if err := b.s.Update(ctx, by, id); err != nil {
+		return nil, statusError(err)
+	}
+	return buyerToPB(by), nil
+}
+
+func (b *Buyer) DeleteBuyer(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := b.s.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func buyerToPB(b domain.Buyer) *pb.Buyer {
+	return &pb.Buyer{
+		Id:           int64(b.ID),
+		CardNumberId: b.CardNumberID,
+		FirstName:    b.FirstName,
+		LastName:     b.LastName,
+	}
+}
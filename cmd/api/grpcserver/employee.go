@@ -0,0 +1,140 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/employee"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Employee struct {
+	pb.UnimplementedEmployeeServiceServer
+	employeeService employee.Service
+}
+
+func NewEmployee(s employee.Service) *Employee {
+	return &Employee{employeeService: s}
+}
+
+func (e *Employee) ListEmployees(ctx context.Context, req *pb.ListEmployeesRequest) (*pb.ListEmployeesResponse, error) {
+	getAll := e.employeeService.GetAll
+	if req.GetIncludeDeleted() {
+		getAll = e.employeeService.GetAllWithDeleted
+	}
+	employees, err := getAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	res := &pb.ListEmployeesResponse{}
+	for _, emp := range employees {
+		res.Employees = append(res.Employees, employeeToPB(emp))
+	}
+	return res, nil
+}
+
+func (e *Employee) GetEmployee(ctx context.Context, req *pb.IDRequest) (*pb.Employee, error) {
+	emp, err := e.employeeService.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return employeeToPB(emp), nil
+}
+
+func (e *Employee) CreateEmployee(ctx context.Context, req *pb.Employee) (*pb.Employee, error) {
+	request := domain.EmployeeRequest{
+		CardNumberID: req.GetCardNumberId(),
+		FirstName:    req.GetFirstName(),
+		LastName:     req.GetLastName(),
+		WarehouseID:  int(req.GetWarehouseId()),
+	}
+	if err := validator.New().Struct(request); err != nil {
+		return nil, invalid(err)
+	}
+
+	emp, err := e.employeeService.Create(ctx, domain.Employee{
+		CardNumberID: request.CardNumberID,
+		FirstName:    request.FirstName,
+		LastName:     request.LastName,
+		WarehouseID:  request.WarehouseID,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return employeeToPB(emp), nil
+}
+
+// UpdateEmployee applies the fields set on the employee as it is now, as PATCH does.
+func (e *Employee) UpdateEmployee(ctx context.Context, req *pb.UpdateEmployeeRequest) (*pb.Employee, error) {
+	emp, err := e.employeeService.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	request := domain.EmployeeRequest{
+		CardNumberID: emp.CardNumberID,
+		FirstName:    emp.FirstName,
+		LastName:     emp.LastName,
+		WarehouseID:  emp.WarehouseID,
+	}
+	if req.CardNumberId != nil {
+		request.CardNumberID = req.GetCardNumberId()
+	}
+	if req.FirstName != nil {
+		request.FirstName = req.GetFirstName()
+	}
+	if req.LastName != nil {
+		request.LastName = req.GetLastName()
+	}
+	if req.WarehouseId != nil {
+		request.WarehouseID = int(req.GetWarehouseId())
+	}
+	if err := validator.New().Struct(request); err != nil {
+		return nil, invalid(err)
+	}
+
+	emp, err = e.employeeService.Update(ctx, domain.Employee{
+		ID:           emp.ID,
+		CardNumberID: request.CardNumberID,
+		FirstName:    request.FirstName,
+		LastName:     request.LastName,
+		WarehouseID:  request.WarehouseID,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return employeeToPB(emp), nil
+}
+
+func (e *Employee) DeleteEmployee(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := e.employeeService.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (e *Employee) RestoreEmployee(ctx context.Context, req *pb.IDRequest) (*pb.Employee, error) {
+	emp, err := e.employeeService.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return employeeToPB(emp), nil
+}
+
+func (e *Employee) HardDeleteEmployee(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := e.employeeService.HardDelete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func employeeToPB(e domain.Employee) *pb.Employee {
+	return &pb.Employee{
+		Id:           int64(e.ID),
+		CardNumberId: e.CardNumberID,
+		FirstName:    e.FirstName,
+		LastName:     e.LastName,
+		WarehouseId:  int64(e.WarehouseID),
+	}
+}
@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/buyer"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/employee"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/purchaseorder"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codes of the domain errors, the rest being Internal. Rows referenced by the one written but missing
+// make the write fail its precondition, while the row named by the call itself is NotFound.
+var codesByError = []struct {
+	err  error
+	code codes.Code
+}{
+	{seller.ErrNotFound, codes.NotFound},
+	{seller.ErrInvalidLocality, codes.NotFound},
+	{product.ErrNotFound, codes.NotFound},
+	{section.ErrSectionNotFound, codes.NotFound},
+	{warehouse.ErrNotFound, codes.NotFound},
+	{warehouse.ErrLocalityNotFound, codes.NotFound},
+	{employee.ErrNotFound, codes.NotFound},
+	{buyer.ErrNotFound, codes.NotFound},
+
+	{seller.ErrConflict, codes.AlreadyExists},
+	{product.ErrExists, codes.AlreadyExists},
+	{section.ErrExistsSectionNumber, codes.AlreadyExists},
+	{warehouse.ErrExist, codes.AlreadyExists},
+	{employee.ErrExistsCardId, codes.AlreadyExists},
+	{buyer.ErrAlreadyExists, codes.AlreadyExists},
+	{inboundorder.ErrOrderNumberExtists, codes.AlreadyExists},
+
+	{section.ErrWareHouseNotFound, codes.FailedPrecondition},
+	{section.ErrProductTypeNotFound, codes.FailedPrecondition},
+	{employee.ErrWarehouseNotfound, codes.FailedPrecondition},
+	{purchaseorder.ErrBuyerNotFound, codes.FailedPrecondition},
+	{inboundorder.ErrEmployeeNotFound, codes.FailedPrecondition},
+	{inboundorder.ErrProductBatchNotFound, codes.FailedPrecondition},
+	{inboundorder.ErrWarehouseNotFound, codes.FailedPrecondition},
+	{buyer.ErrCantChange, codes.FailedPrecondition},
+	{softdelete.ErrNotDeleted, codes.FailedPrecondition},
+	{softdelete.ErrHasDependents, codes.FailedPrecondition},
+
+	{etag.ErrPreconditionFailed, codes.Aborted},
+}
+
+// statusError turns an error of the services into the status answered for it.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, c := range codesByError {
+		if errors.Is(err, c.err) {
+			return status.Error(c.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// invalid answers a request refused before reaching the services.
+func invalid(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,47 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_StatusError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not found", seller.ErrNotFound, codes.NotFound},
+		{"duplicated", section.ErrExistsSectionNumber, codes.AlreadyExists},
+		{"missing reference", inboundorder.ErrEmployeeNotFound, codes.FailedPrecondition},
+		{"wrapped dependents", fmt.Errorf("%w: 2 products", softdelete.ErrHasDependents), codes.FailedPrecondition},
+		{"stale version", etag.ErrPreconditionFailed, codes.Aborted},
+		{"anything else", errors.New("connection refused"), codes.Internal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			err := statusError(c.err)
+
+			// assert
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, c.code, st.Code())
+			assert.Equal(t, c.err.Error(), st.Message())
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		assert.NoError(t, statusError(nil))
+	})
+}
@@ -0,0 +1,131 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/cmd/api/middleware"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The metadata keys stand for the headers of the same name on the REST API.
+const (
+	ActorMetadata   = "x-actor"
+	IfMatchMetadata = "if-match"
+	ETagMetadata    = "etag"
+	APIKeyMetadata  = "x-api-key"
+	DefaultActor    = "anonymous"
+)
+
+// reads of versioned rows, answered with their ETag
+var versionedReads = map[string]string{
+	pb.SellerService_GetSeller_FullMethodName:       "sellers",
+	pb.ProductService_GetProduct_FullMethodName:     "products",
+	pb.SectionService_GetSection_FullMethodName:     "sections",
+	pb.WarehouseService_GetWarehouse_FullMethodName: "warehouses",
+	pb.EmployeeService_GetEmployee_FullMethodName:   "employees",
+}
+
+// writes of versioned rows, checked against their If-Match
+var versionedWrites = map[string]bool{
+	pb.SellerService_UpdateSeller_FullMethodName:       true,
+	pb.SellerService_DeleteSeller_FullMethodName:       true,
+	pb.ProductService_UpdateProduct_FullMethodName:     true,
+	pb.ProductService_DeleteProduct_FullMethodName:     true,
+	pb.SectionService_UpdateSection_FullMethodName:     true,
+	pb.SectionService_DeleteSection_FullMethodName:     true,
+	pb.WarehouseService_UpdateWarehouse_FullMethodName: true,
+	pb.WarehouseService_DeleteWarehouse_FullMethodName: true,
+	pb.EmployeeService_UpdateEmployee_FullMethodName:   true,
+	pb.EmployeeService_DeleteEmployee_FullMethodName:   true,
+}
+
+// calls on soft deleted rows, kept to the admin API keys
+var adminMethods = map[string]bool{
+	pb.SellerService_RestoreSeller_FullMethodName:          true,
+	pb.SellerService_HardDeleteSeller_FullMethodName:       true,
+	pb.ProductService_RestoreProduct_FullMethodName:        true,
+	pb.ProductService_HardDeleteProduct_FullMethodName:     true,
+	pb.SectionService_RestoreSection_FullMethodName:        true,
+	pb.SectionService_HardDeleteSection_FullMethodName:     true,
+	pb.WarehouseService_RestoreWarehouse_FullMethodName:    true,
+	pb.WarehouseService_HardDeleteWarehouse_FullMethodName: true,
+	pb.EmployeeService_RestoreEmployee_FullMethodName:      true,
+	pb.EmployeeService_HardDeleteEmployee_FullMethodName:   true,
+}
+
+// Actor stores who performs the call, taken from the x-actor metadata, so that the changes it makes reach
+// the audit trail under their name.
+func Actor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		actor := first(ctx, ActorMetadata)
+		if actor == "" {
+			actor = DefaultActor
+		}
+		return handler(audit.WithActor(ctx, actor), req)
+	}
+}
+
+// Version is the gRPC side of middleware.Version: reads of a versioned row answer its version in the etag
+// header metadata, and writes pass the if-match version down, refused with FailedPrecondition when it is
+// missing and required.
+func Version(db *sql.DB, required bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if table, ok := versionedReads[info.FullMethod]; ok {
+			if r, ok := req.(*pb.IDRequest); ok {
+				if version, err := etag.Version(ctx, db, table, r.GetId()); err == nil {
+					grpc.SetHeader(ctx, metadata.Pairs(ETagMetadata, etag.Format(version)))
+				}
+			}
+		}
+
+		if versionedWrites[info.FullMethod] {
+			ifMatch := first(ctx, IfMatchMetadata)
+			if ifMatch == "" && required {
+				return nil, status.Error(codes.FailedPrecondition, etag.ErrPreconditionRequired.Error())
+			}
+			if version, ok := etag.Parse(ifMatch); ok {
+				ctx = etag.WithVersion(ctx, version)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Admin is the gRPC side of middleware.Admin: restores, hard deletes and listings asking for soft deleted
+// rows are refused with PermissionDenied unless the x-api-key metadata is one of keys.
+func Admin(keys []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		guarded := adminMethods[info.FullMethod]
+		if r, ok := req.(interface{ GetIncludeDeleted() bool }); ok && r.GetIncludeDeleted() {
+			guarded = true
+		}
+		if guarded && !middleware.IsAdminKey(keys, first(ctx, APIKeyMetadata)) {
+			return nil, status.Error(codes.PermissionDenied, middleware.ErrAdminOnly.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Invalidation lets the writes of the call drop the entries of c read from the tables they touch.
+func Invalidation(c cache.Cache) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(cache.WithCache(ctx, c), req)
+	}
+}
+
+// first returns the first value of the incoming metadata key, "" when it was not sent.
+func first(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
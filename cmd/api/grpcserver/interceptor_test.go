@@ -0,0 +1,164 @@
+package grpcserver
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ______________________________________________________
+// tools
+
+// answers the context the handler was called with
+func contextHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	return ctx, nil
+}
+
+func call(t *testing.T, interceptor grpc.UnaryServerInterceptor, ctx context.Context, method string, req interface{}) (context.Context, error) {
+	res, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, contextHandler)
+	if err != nil {
+		return nil, err
+	}
+	return res.(context.Context), nil
+}
+
+// ______________________________________________________
+// tests
+
+func Test_Admin(t *testing.T) {
+	keys := []string{"admin-key"}
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyMetadata, "admin-key"))
+
+	t.Run("listing without deleted rows is open", func(t *testing.T) {
+		// act
+		_, err := call(t, Admin(keys), context.Background(), pb.SellerService_ListSellers_FullMethodName, &pb.ListSellersRequest{})
+
+		// assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("include_deleted is denied without an admin key", func(t *testing.T) {
+		// act
+		_, err := call(t, Admin(keys), context.Background(), pb.SellerService_ListSellers_FullMethodName, &pb.ListSellersRequest{IncludeDeleted: true})
+
+		// assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("restore and hard delete are denied without an admin key", func(t *testing.T) {
+		// act
+		_, errRestore := call(t, Admin(keys), context.Background(), pb.SellerService_RestoreSeller_FullMethodName, &pb.IDRequest{Id: 1})
+		_, errHardDelete := call(t, Admin(keys), context.Background(), pb.SellerService_HardDeleteSeller_FullMethodName, &pb.IDRequest{Id: 1})
+
+		// assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(errRestore))
+		assert.Equal(t, codes.PermissionDenied, status.Code(errHardDelete))
+	})
+
+	t.Run("admin key is let through", func(t *testing.T) {
+		// act
+		_, errList := call(t, Admin(keys), admin, pb.SellerService_ListSellers_FullMethodName, &pb.ListSellersRequest{IncludeDeleted: true})
+		_, errRestore := call(t, Admin(keys), admin, pb.SellerService_RestoreSeller_FullMethodName, &pb.IDRequest{Id: 1})
+
+		// assert
+		assert.NoError(t, errList)
+		assert.NoError(t, errRestore)
+	})
+}
+
+func Test_Actor(t *testing.T) {
+	t.Run("from metadata", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorMetadata, "maria"))
+
+		// act
+		got, err := call(t, Actor(), ctx, pb.SellerService_CreateSeller_FullMethodName, &pb.Seller{})
+
+		// assert
+		assert.NoError(t, err)
+		actor, _ := audit.Actor(got)
+		assert.Equal(t, "maria", actor)
+	})
+
+	t.Run("anonymous by default", func(t *testing.T) {
+		// act
+		got, err := call(t, Actor(), context.Background(), pb.SellerService_CreateSeller_FullMethodName, &pb.Seller{})
+
+		// assert
+		assert.NoError(t, err)
+		actor, _ := audit.Actor(got)
+		assert.Equal(t, DefaultActor, actor)
+	})
+}
+
+func Test_Version(t *testing.T) {
+	t.Run("write passes the if-match version down", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IfMatchMetadata, `"3"`))
+
+		// act
+		got, err := call(t, Version(nil, true), ctx, pb.SellerService_UpdateSeller_FullMethodName, &pb.UpdateSellerRequest{Id: 1})
+
+		// assert
+		assert.NoError(t, err)
+		version, ok := etag.Expected(got)
+		assert.True(t, ok)
+		assert.Equal(t, 3, version)
+	})
+
+	t.Run("write without if-match refused when required", func(t *testing.T) {
+		// act
+		_, err := call(t, Version(nil, true), context.Background(), pb.ProductService_DeleteProduct_FullMethodName, &pb.IDRequest{Id: 1})
+
+		// assert
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("write without if-match allowed when not required", func(t *testing.T) {
+		// act
+		got, err := call(t, Version(nil, false), context.Background(), pb.ProductService_DeleteProduct_FullMethodName, &pb.IDRequest{Id: 1})
+
+		// assert
+		assert.NoError(t, err)
+		_, ok := etag.Expected(got)
+		assert.False(t, ok)
+	})
+
+	t.Run("unversioned write", func(t *testing.T) {
+		// act
+		_, err := call(t, Version(nil, true), context.Background(), pb.BuyerService_UpdateBuyer_FullMethodName, &pb.UpdateBuyerRequest{Id: 1})
+
+		// assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("read answers the etag", func(t *testing.T) {
+		// arrange
+		conn, mock := dial(t, func(s *grpc.Server) {
+			pb.RegisterSellerServiceServer(s, NewSeller(sellerServiceWith(t, func(m *serviceMockSeller) {
+				m.On("GetByID", anything, 1).Return(sellerFixture, nil)
+			})))
+		})
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM sellers WHERE id=?")).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+		var header metadata.MD
+
+		// act
+		_, err := pb.NewSellerServiceClient(conn).GetSeller(context.Background(), &pb.IDRequest{Id: 1}, grpc.Header(&header))
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`"4"`}, header.Get(ETagMetadata))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
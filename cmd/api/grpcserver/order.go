@@ -0,0 +1,90 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/purchaseorder"
+)
+
+var ErrDateFormat = errors.New("Date format incorrect")
+
+// Order serves the creation of purchase and inbound orders, the only operations REST has on them.
+type Order struct {
+	pb.UnimplementedOrderServiceServer
+	purchaseOrders purchaseorder.Service
+	inboundOrders  inboundorder.Service
+}
+
+func NewOrder(purchaseOrders purchaseorder.Service, inboundOrders inboundorder.Service) *Order {
+	return &Order{purchaseOrders: purchaseOrders, inboundOrders: inboundOrders}
+}
+
+func (o *Order) CreatePurchaseOrder(ctx context.Context, req *pb.PurchaseOrder) (*pb.PurchaseOrder, error) {
+	order := domain.Purchase_Orders{
+		Order_number:      req.GetOrderNumber(),
+		Order_date:        req.GetOrderDate(),
+		Tracking_code:     req.GetTrackingCode(),
+		Buyer_id:          int(req.GetBuyerId()),
+		Product_record_id: int(req.GetProductRecordId()),
+		Order_Status_id:   int(req.GetOrderStatusId()),
+	}
+	if err := validator.New().Struct(&order); err != nil {
+		return nil, invalid(err)
+	}
+
+	order, err := o.purchaseOrders.Create(ctx, order)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.PurchaseOrder{
+		Id:              int64(order.ID),
+		OrderNumber:     order.Order_number,
+		OrderDate:       order.Order_date,
+		TrackingCode:    order.Tracking_code,
+		BuyerId:         int64(order.Buyer_id),
+		ProductRecordId: int64(order.Product_record_id),
+		OrderStatusId:   int64(order.Order_Status_id),
+	}, nil
+}
+
+func (o *Order) CreateInboundOrder(ctx context.Context, req *pb.InboundOrder) (*pb.InboundOrder, error) {
+	request := domain.InboundOrderRequest{
+		OrderDate:      req.GetOrderDate(),
+		OrderNumber:    req.GetOrderNumber(),
+		EmployeeID:     int(req.GetEmployeeId()),
+		ProductBatchID: int(req.GetProductBatchId()),
+		WarehouseID:    int(req.GetWarehouseId()),
+	}
+	if err := validator.New().Struct(request); err != nil {
+		return nil, invalid(err)
+	}
+	date, err := time.Parse("2006-01-02", request.OrderDate)
+	if err != nil {
+		return nil, invalid(ErrDateFormat)
+	}
+
+	order, err := o.inboundOrders.Create(ctx, domain.InboundOrder{
+		OrderDate:      date.Format("2006-01-02"),
+		OrderNumber:    request.OrderNumber,
+		EmployeeID:     request.EmployeeID,
+		ProductBatchID: request.ProductBatchID,
+		WarehouseID:    request.WarehouseID,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.InboundOrder{
+		Id:             int64(order.ID),
+		OrderDate:      order.OrderDate,
+		OrderNumber:    order.OrderNumber,
+		EmployeeId:     int64(order.EmployeeID),
+		ProductBatchId: int64(order.ProductBatchID),
+		WarehouseId:    int64(order.WarehouseID),
+	}, nil
+}
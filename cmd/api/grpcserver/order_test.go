@@ -0,0 +1,129 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/purchaseorder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ______________________________________________________
+// tools
+
+type serviceMockPurchaseOrder struct {
+	mock.Mock
+}
+
+func (r *serviceMockPurchaseOrder) Create(ctx context.Context, p domain.Purchase_Orders) (domain.Purchase_Orders, error) {
+	args := r.Mock.Called(ctx, p)
+	return args.Get(0).(domain.Purchase_Orders), args.Error(1)
+}
+
+type serviceMockInboundOrder struct {
+	mock.Mock
+}
+
+func (r *serviceMockInboundOrder) Create(ctx context.Context, i domain.InboundOrder) (domain.InboundOrder, error) {
+	args := r.Mock.Called(ctx, i)
+	return args.Get(0).(domain.InboundOrder), args.Error(1)
+}
+
+// ______________________________________________________
+// tests
+
+func Test_Order_CreatePurchaseOrder(t *testing.T) {
+	request := &pb.PurchaseOrder{OrderNumber: "PO-1", OrderDate: "2023-03-01", TrackingCode: "T1", BuyerId: 1, ProductRecordId: 2, OrderStatusId: 1}
+
+	t.Run("ok", func(t *testing.T) {
+		// arrange
+		purchaseOrders := &serviceMockPurchaseOrder{}
+		purchaseOrders.On("Create", anything, mock.Anything).Return(domain.Purchase_Orders{ID: 5, Order_number: "PO-1", Buyer_id: 1}, nil)
+		server := NewOrder(purchaseOrders, &serviceMockInboundOrder{})
+
+		// act
+		res, err := server.CreatePurchaseOrder(context.Background(), request)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), res.Id)
+		purchaseOrders.AssertExpectations(t)
+	})
+
+	t.Run("buyer not found", func(t *testing.T) {
+		// arrange
+		purchaseOrders := &serviceMockPurchaseOrder{}
+		purchaseOrders.On("Create", anything, mock.Anything).Return(domain.Purchase_Orders{}, purchaseorder.ErrBuyerNotFound)
+		server := NewOrder(purchaseOrders, &serviceMockInboundOrder{})
+
+		// act
+		_, err := server.CreatePurchaseOrder(context.Background(), request)
+
+		// assert
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		// arrange
+		server := NewOrder(&serviceMockPurchaseOrder{}, &serviceMockInboundOrder{})
+
+		// act
+		_, err := server.CreatePurchaseOrder(context.Background(), &pb.PurchaseOrder{OrderNumber: "PO-1"})
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func Test_Order_CreateInboundOrder(t *testing.T) {
+	request := func(date string) *pb.InboundOrder {
+		return &pb.InboundOrder{OrderDate: date, OrderNumber: "IO-1", EmployeeId: 1, ProductBatchId: 2, WarehouseId: 3}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		// arrange
+		inboundOrders := &serviceMockInboundOrder{}
+		inboundOrders.On("Create", anything, domain.InboundOrder{OrderDate: "2023-03-01", OrderNumber: "IO-1", EmployeeID: 1, ProductBatchID: 2, WarehouseID: 3}).
+			Return(domain.InboundOrder{ID: 7, OrderDate: "2023-03-01", OrderNumber: "IO-1", EmployeeID: 1, ProductBatchID: 2, WarehouseID: 3}, nil)
+		server := NewOrder(&serviceMockPurchaseOrder{}, inboundOrders)
+
+		// act
+		res, err := server.CreateInboundOrder(context.Background(), request("2023-03-01"))
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), res.Id)
+		inboundOrders.AssertExpectations(t)
+	})
+
+	t.Run("bad date", func(t *testing.T) {
+		// arrange
+		server := NewOrder(&serviceMockPurchaseOrder{}, &serviceMockInboundOrder{})
+
+		// act
+		_, err := server.CreateInboundOrder(context.Background(), request("01/03/2023"))
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, ErrDateFormat.Error(), status.Convert(err).Message())
+	})
+
+	t.Run("order number taken", func(t *testing.T) {
+		// arrange
+		inboundOrders := &serviceMockInboundOrder{}
+		inboundOrders.On("Create", anything, mock.Anything).Return(domain.InboundOrder{}, inboundorder.ErrOrderNumberExtists)
+		server := NewOrder(&serviceMockPurchaseOrder{}, inboundOrders)
+
+		// act
+		_, err := server.CreateInboundOrder(context.Background(), request("2023-03-01"))
+
+		// assert
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
@@ -0,0 +1,161 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Product struct {
+	pb.UnimplementedProductServiceServer
+	productService product.Service
+}
+
+func NewProduct(s product.Service) *Product {
+	return &Product{productService: s}
+}
+
+func (p *Product) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	getAll := p.productService.GetAll
+	if req.GetIncludeDeleted() {
+		getAll = p.productService.GetAllWithDeleted
+	}
+	products, err := getAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	res := &pb.ListProductsResponse{}
+	for _, prod := range products {
+		res.Products = append(res.Products, productToPB(prod))
+	}
+	return res, nil
+}
+
+func (p *Product) GetProduct(ctx context.Context, req *pb.IDRequest) (*pb.Product, error) {
+	prod, err := p.productService.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return productToPB(prod), nil
+}
+
+func (p *Product) CreateProduct(ctx context.Context, req *pb.Product) (*pb.Product, error) {
+	prod := productFromPB(req)
+	prod.ID = 0
+	if err := validator.New().Struct(&prod); err != nil {
+		return nil, invalid(err)
+	}
+
+	prod, err := p.productService.Create(ctx, prod)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return productToPB(prod), nil
+}
+
+// UpdateProduct applies the fields set on the product as it is now, as PATCH does.
+func (p *Product) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	prod, err := p.productService.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if req.Description != nil {
+		prod.Description = req.GetDescription()
+	}
+	if req.ExpirationRate != nil {
+		prod.ExpirationRate = int(req.GetExpirationRate())
+	}
+	if req.FreezingRate != nil {
+		prod.FreezingRate = int(req.GetFreezingRate())
+	}
+	if req.Height != nil {
+		prod.Height = req.GetHeight()
+	}
+	if req.Length != nil {
+		prod.Length = req.GetLength()
+	}
+	if req.Netweight != nil {
+		prod.Netweight = req.GetNetweight()
+	}
+	if req.ProductCode != nil {
+		prod.ProductCode = req.GetProductCode()
+	}
+	if req.RecommendedFreezingTemperature != nil {
+		prod.RecomFreezTemp = req.GetRecommendedFreezingTemperature()
+	}
+	if req.Width != nil {
+		prod.Width = req.GetWidth()
+	}
+	if req.ProductTypeId != nil {
+		prod.ProductTypeID = int(req.GetProductTypeId())
+	}
+	if req.SellerId != nil {
+		prod.SellerID = int(req.GetSellerId())
+	}
+
+	prod, err = p.productService.Update(ctx, prod)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return productToPB(prod), nil
+}
+
+func (p *Product) DeleteProduct(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := p.productService.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (p *Product) RestoreProduct(ctx context.Context, req *pb.IDRequest) (*pb.Product, error) {
+	prod, err := p.productService.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return productToPB(prod), nil
+}
+
+func (p *Product) HardDeleteProduct(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := p.productService.HardDelete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func productToPB(p domain.Product) *pb.Product {
+	return &pb.Product{
+		Id:                             int64(p.ID),
+		Description:                    p.Description,
+		ExpirationRate:                 int32(p.ExpirationRate),
+		FreezingRate:                   int32(p.FreezingRate),
+		Height:                         p.Height,
+		Length:                         p.Length,
+		Netweight:                      p.Netweight,
+		ProductCode:                    p.ProductCode,
+		RecommendedFreezingTemperature: p.RecomFreezTemp,
+		Width:                          p.Width,
+		ProductTypeId:                  int64(p.ProductTypeID),
+		SellerId:                       int64(p.SellerID),
+	}
+}
+
+func productFromPB(p *pb.Product) domain.Product {
+	return domain.Product{
+		ID:             int(p.GetId()),
+		Description:    p.GetDescription(),
+		ExpirationRate: int(p.GetExpirationRate()),
+		FreezingRate:   int(p.GetFreezingRate()),
+		Height:         p.GetHeight(),
+		Length:         p.GetLength(),
+		Netweight:      p.GetNetweight(),
+		ProductCode:    p.GetProductCode(),
+		RecomFreezTemp: p.GetRecommendedFreezingTemperature(),
+		Width:          p.GetWidth(),
+		ProductTypeID:  int(p.GetProductTypeId()),
+		SellerID:       int(p.GetSellerId()),
+	}
+}
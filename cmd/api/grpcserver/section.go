@@ -0,0 +1,148 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Section struct {
+	pb.UnimplementedSectionServiceServer
+	s section.Service
+}
+
+func NewSection(s section.Service) *Section {
+	return &Section{s: s}
+}
+
+func (s *Section) ListSections(ctx context.Context, req *pb.ListSectionsRequest) (*pb.ListSectionsResponse, error) {
+	getAll := s.s.GetAll
+	if req.GetIncludeDeleted() {
+		getAll = s.s.GetAllWithDeleted
+	}
+	sections, err := getAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	res := &pb.ListSectionsResponse{}
+	for _, sec := range sections {
+		res.Sections = append(res.Sections, sectionToPB(sec))
+	}
+	return res, nil
+}
+
+func (s *Section) GetSection(ctx context.Context, req *pb.IDRequest) (*pb.Section, error) {
+	sec, err := s.s.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sectionToPB(sec), nil
+}
+
+func (s *Section) CreateSection(ctx context.Context, req *pb.Section) (*pb.Section, error) {
+	sec := sectionFromPB(req)
+	sec.ID = 0
+	if err := validator.New().Struct(&sec); err != nil {
+		return nil, invalid(err)
+	}
+
+	sec, err := s.s.Create(ctx, sec)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sectionToPB(sec), nil
+}
+
+// UpdateSection applies the fields set on the section as it is now, as PATCH does.
+func (s *Section) UpdateSection(ctx context.Context, req *pb.UpdateSectionRequest) (*pb.Section, error) {
+	sec, err := s.s.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if req.SectionNumber != nil {
+		sec.SectionNumber = int(req.GetSectionNumber())
+	}
+	if req.CurrentTemperature != nil {
+		sec.CurrentTemperature = int(req.GetCurrentTemperature())
+	}
+	if req.MinimumTemperature != nil {
+		sec.MinimumTemperature = int(req.GetMinimumTemperature())
+	}
+	if req.CurrentCapacity != nil {
+		sec.CurrentCapacity = int(req.GetCurrentCapacity())
+	}
+	if req.MinimumCapacity != nil {
+		sec.MinimumCapacity = int(req.GetMinimumCapacity())
+	}
+	if req.MaximumCapacity != nil {
+		sec.MaximumCapacity = int(req.GetMaximumCapacity())
+	}
+	if req.WarehouseId != nil {
+		sec.WarehouseID = int(req.GetWarehouseId())
+	}
+	if req.ProductTypeId != nil {
+		sec.ProductTypeID = int(req.GetProductTypeId())
+	}
+	if err := validator.New().Struct(&sec); err != nil {
+		return nil, invalid(err)
+	}
+
+	if err := s.s.Update(ctx, sec); err != nil {
+		return nil, statusError(err)
+	}
+	return sectionToPB(sec), nil
+}
+
+func (s *Section) DeleteSection(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := s.s.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Section) RestoreSection(ctx context.Context, req *pb.IDRequest) (*pb.Section, error) {
+	sec, err := s.s.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sectionToPB(sec), nil
+}
+
+func (s *Section) HardDeleteSection(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := s.s.HardDelete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func sectionToPB(s domain.Section) *pb.Section {
+	return &pb.Section{
+		Id:                 int64(s.ID),
+		SectionNumber:      int32(s.SectionNumber),
+		CurrentTemperature: int32(s.CurrentTemperature),
+		MinimumTemperature: int32(s.MinimumTemperature),
+		CurrentCapacity:    int32(s.CurrentCapacity),
+		MinimumCapacity:    int32(s.MinimumCapacity),
+		MaximumCapacity:    int32(s.MaximumCapacity),
+		WarehouseId:        int64(s.WarehouseID),
+		ProductTypeId:      int64(s.ProductTypeID),
+	}
+}
+
+func sectionFromPB(s *pb.Section) domain.Section {
+	return domain.Section{
+		ID:                 int(s.GetId()),
+		SectionNumber:      int(s.GetSectionNumber()),
+		CurrentTemperature: int(s.GetCurrentTemperature()),
+		MinimumTemperature: int(s.GetMinimumTemperature()),
+		CurrentCapacity:    int(s.GetCurrentCapacity()),
+		MinimumCapacity:    int(s.GetMinimumCapacity()),
+		MaximumCapacity:    int(s.GetMaximumCapacity()),
+		WarehouseID:        int(s.GetWarehouseId()),
+		ProductTypeID:      int(s.GetProductTypeId()),
+	}
+}
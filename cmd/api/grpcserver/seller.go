@@ -0,0 +1,147 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var ErrInvalidCID = errors.New("invalid cid")
+
+type Seller struct {
+	pb.UnimplementedSellerServiceServer
+	sellerService seller.Service
+}
+
+func NewSeller(s seller.Service) *Seller {
+	return &Seller{sellerService: s}
+}
+
+func (s *Seller) ListSellers(ctx context.Context, req *pb.ListSellersRequest) (*pb.ListSellersResponse, error) {
+	getAll := s.sellerService.GetAll
+	if req.GetIncludeDeleted() {
+		getAll = s.sellerService.GetAllWithDeleted
+	}
+	sellers, err := getAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	res := &pb.ListSellersResponse{}
+	for _, sl := range sellers {
+		res.Sellers = append(res.Sellers, sellerToPB(sl))
+	}
+	return res, nil
+}
+
+func (s *Seller) GetSeller(ctx context.Context, req *pb.IDRequest) (*pb.Seller, error) {
+	sl, err := s.sellerService.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sellerToPB(sl), nil
+}
+
+func (s *Seller) CreateSeller(ctx context.Context, req *pb.Seller) (*pb.Seller, error) {
+	sl := sellerFromPB(req)
+	sl.ID = 0
+	if err := validateSeller(sl); err != nil {
+		return nil, invalid(err)
+	}
+
+	id, err := s.sellerService.Create(ctx, sl)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	sl.ID = id
+	return sellerToPB(sl), nil
+}
+
+// UpdateSeller applies the fields set on the seller as it is now, as PATCH does.
+func (s *Seller) UpdateSeller(ctx context.Context, req *pb.UpdateSellerRequest) (*pb.Seller, error) {
+	sl, err := s.sellerService.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if req.Cid != nil {
+		sl.CID = int(req.GetCid())
+	}
+	if req.CompanyName != nil {
+		sl.CompanyName = req.GetCompanyName()
+	}
+	if req.Address != nil {
+		sl.Address = req.GetAddress()
+	}
+	if req.Telephone != nil {
+		sl.Telephone = req.GetTelephone()
+	}
+	if req.LocalityId != nil {
+		sl.Locality_id = req.GetLocalityId()
+	}
+	if err := validateSeller(sl); err != nil {
+		return nil, invalid(err)
+	}
+
+	if err := s.sellerService.Update(ctx, sl); err != nil {
+		return nil, statusError(err)
+	}
+	return sellerToPB(sl), nil
+}
+
+func (s *Seller) DeleteSeller(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := s.sellerService.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Seller) RestoreSeller(ctx context.Context, req *pb.IDRequest) (*pb.Seller, error) {
+	sl, err := s.sellerService.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sellerToPB(sl), nil
+}
+
+func (s *Seller) HardDeleteSeller(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := s.sellerService.HardDelete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func validateSeller(sl domain.Seller) error {
+	if err := validator.New().Struct(&sl); err != nil {
+		return err
+	}
+	if sl.CID < 0 {
+		return ErrInvalidCID
+	}
+	return nil
+}
+
+func sellerToPB(sl domain.Seller) *pb.Seller {
+	return &pb.Seller{
+		Id:          int64(sl.ID),
+		Cid:         int64(sl.CID),
+		CompanyName: sl.CompanyName,
+		Address:     sl.Address,
+		Telephone:   sl.Telephone,
+		LocalityId:  sl.Locality_id,
+	}
+}
+
+func sellerFromPB(sl *pb.Seller) domain.Seller {
+	return domain.Seller{
+		ID:          int(sl.GetId()),
+		CID:         int(sl.GetCid()),
+		CompanyName: sl.GetCompanyName(),
+		Address:     sl.GetAddress(),
+		Telephone:   sl.GetTelephone(),
+		Locality_id: sl.GetLocalityId(),
+	}
+}
@@ -0,0 +1,256 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ______________________________________________________
+// tools
+
+var anything = mock.Anything
+
+var sellerFixture = domain.Seller{ID: 1, CID: 10, CompanyName: "Frio SA", Address: "Calle 1", Telephone: "1234", Locality_id: "6700"}
+
+type serviceMockSeller struct {
+	mock.Mock
+}
+
+func (r *serviceMockSeller) GetAll(ctx context.Context) ([]domain.Seller, error) {
+	args := r.Mock.Called(ctx)
+	return args.Get(0).([]domain.Seller), args.Error(1)
+}
+func (r *serviceMockSeller) GetAllWithDeleted(ctx context.Context) ([]domain.Seller, error) {
+	args := r.Mock.Called(ctx)
+	return args.Get(0).([]domain.Seller), args.Error(1)
+}
+func (r *serviceMockSeller) GetByID(ctx context.Context, id int) (domain.Seller, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.Seller), args.Error(1)
+}
+func (r *serviceMockSeller) Create(ctx context.Context, s domain.Seller) (int, error) {
+	args := r.Mock.Called(ctx, s)
+	return args.Get(0).(int), args.Error(1)
+}
+func (r *serviceMockSeller) Update(ctx context.Context, s domain.Seller) error {
+	args := r.Mock.Called(ctx, s)
+	return args.Error(0)
+}
+func (r *serviceMockSeller) Delete(ctx context.Context, id int) error {
+	args := r.Mock.Called(ctx, id)
+	return args.Error(0)
+}
+func (r *serviceMockSeller) Restore(ctx context.Context, id int) (domain.Seller, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.Seller), args.Error(1)
+}
+func (r *serviceMockSeller) HardDelete(ctx context.Context, id int) error {
+	args := r.Mock.Called(ctx, id)
+	return args.Error(0)
+}
+func (r *serviceMockSeller) GetReport(ctx context.Context, id int) (domain.SellerReport, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.SellerReport), args.Error(1)
+}
+func (r *serviceMockSeller) GetReports(ctx context.Context, q domain.SellerReportQuery) (domain.SellerReportPage, error) {
+	args := r.Mock.Called(ctx, q)
+	return args.Get(0).(domain.SellerReportPage), args.Error(1)
+}
+
+// sellerServiceWith returns a mock set up by expect, checked once the test ends.
+func sellerServiceWith(t *testing.T, expect func(m *serviceMockSeller)) seller.Service {
+	m := &serviceMockSeller{}
+	expect(m)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func sellerClient(t *testing.T, expect func(m *serviceMockSeller)) pb.SellerServiceClient {
+	conn, _ := dial(t, func(s *grpc.Server) {
+		pb.RegisterSellerServiceServer(s, NewSeller(sellerServiceWith(t, expect)))
+	})
+	return pb.NewSellerServiceClient(conn)
+}
+
+// ______________________________________________________
+// tests
+
+func Test_Seller_List(t *testing.T) {
+	t.Run("not deleted", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("GetAll", anything).Return([]domain.Seller{sellerFixture}, nil)
+		})
+
+		// act
+		res, err := client.ListSellers(context.Background(), &pb.ListSellersRequest{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, res.Sellers, 1)
+		assert.Equal(t, "Frio SA", res.Sellers[0].CompanyName)
+	})
+
+	t.Run("with deleted", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("GetAllWithDeleted", anything).Return([]domain.Seller{sellerFixture, {ID: 2}}, nil)
+		})
+
+		// act
+		res, err := client.ListSellers(context.Background(), &pb.ListSellersRequest{IncludeDeleted: true})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, res.Sellers, 2)
+	})
+}
+
+func Test_Seller_Get(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("GetByID", anything, 9).Return(domain.Seller{}, seller.ErrNotFound)
+		})
+
+		// act
+		_, err := client.GetSeller(context.Background(), &pb.IDRequest{Id: 9})
+
+		// assert
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func Test_Seller_Create(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// arrange
+		toCreate := sellerFixture
+		toCreate.ID = 0
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("Create", anything, toCreate).Return(1, nil)
+		})
+
+		// act
+		res, err := client.CreateSeller(context.Background(), sellerToPB(toCreate))
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.Id)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {})
+
+		// act
+		_, err := client.CreateSeller(context.Background(), &pb.Seller{CompanyName: "Frio SA"})
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("negative cid", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {})
+		req := sellerToPB(sellerFixture)
+		req.Cid = -1
+
+		// act
+		_, err := client.CreateSeller(context.Background(), req)
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, ErrInvalidCID.Error(), status.Convert(err).Message())
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("Create", anything, anything).Return(0, seller.ErrConflict)
+		})
+
+		// act
+		_, err := client.CreateSeller(context.Background(), sellerToPB(sellerFixture))
+
+		// assert
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
+
+func Test_Seller_Update(t *testing.T) {
+	ifMatch := func(version string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), IfMatchMetadata, version)
+	}
+
+	t.Run("only the fields set change", func(t *testing.T) {
+		// arrange
+		updated := sellerFixture
+		updated.CompanyName = "Frio SRL"
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("GetByID", anything, 1).Return(sellerFixture, nil)
+			m.On("Update", mock.MatchedBy(func(ctx context.Context) bool {
+				version, ok := etag.Expected(ctx)
+				return ok && version == 2
+			}), updated).Return(nil)
+		})
+		name := "Frio SRL"
+
+		// act
+		res, err := client.UpdateSeller(ifMatch(`"2"`), &pb.UpdateSellerRequest{Id: 1, CompanyName: &name})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, sellerToPB(updated).String(), res.String())
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("GetByID", anything, 1).Return(sellerFixture, nil)
+			m.On("Update", anything, sellerFixture).Return(etag.ErrPreconditionFailed)
+		})
+
+		// act
+		_, err := client.UpdateSeller(ifMatch(`"1"`), &pb.UpdateSellerRequest{Id: 1})
+
+		// assert
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("without if-match", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {})
+
+		// act
+		_, err := client.UpdateSeller(context.Background(), &pb.UpdateSellerRequest{Id: 1})
+
+		// assert
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func Test_Seller_HardDelete(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// arrange
+		client := sellerClient(t, func(m *serviceMockSeller) {
+			m.On("HardDelete", anything, 1).Return(nil)
+		})
+
+		// act
+		_, err := client.HardDeleteSeller(context.Background(), &pb.IDRequest{Id: 1})
+
+		// assert
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+// Package grpcserver serves over gRPC the operations the REST handlers expose on sellers, products,
+// sections, warehouses, employees, buyers and orders, backed by the same services.
+package grpcserver
+
+import (
+	"database/sql"
+	"os"
+
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/cmd/api/middleware"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/buyer"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/employee"
+	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/purchaseorder"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const DefaultPort = "9090"
+
+// NewServer registers every service on a new gRPC server, along with reflection so that tools such as
+// grpcurl can list and call them. As on the REST API, If-Match is required on writes of versioned
+// resources only when the IF_MATCH_REQUIRED environment variable is "true". Writes drop the entries of
+// reports read from the tables they touch, as they do on the REST API. Soft deleted rows are listed,
+// restored and hard deleted only with one of the API keys of ADMIN_API_KEYS in x-api-key.
+func NewServer(db *sql.DB, reports cache.Cache) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		Actor(),
+		Admin(middleware.ParseKeys(os.Getenv("ADMIN_API_KEYS"))),
+		Version(db, os.Getenv("IF_MATCH_REQUIRED") == "true"),
+		Invalidation(reports),
+	))
+
+	pb.RegisterSellerServiceServer(server, NewSeller(seller.NewService(seller.NewRepository(db))))
+	pb.RegisterProductServiceServer(server, NewProduct(product.NewService(product.NewRepository(db))))
+	pb.RegisterSectionServiceServer(server, NewSection(section.NewService(section.NewRepository(db))))
+	pb.RegisterWarehouseServiceServer(server, NewWarehouse(warehouse.NewService(warehouse.NewRepository(db))))
+	pb.RegisterEmployeeServiceServer(server, NewEmployee(employee.NewService(employee.NewRepository(db))))
+	pb.RegisterBuyerServiceServer(server, NewBuyer(buyer.NewAuditedService(db, buyer.NewService(buyer.NewRepository(db)))))
+	pb.RegisterOrderServiceServer(server, NewOrder(
+		purchaseorder.NewService(purchaseorder.NewRepository(db)),
+		inboundorder.NewService(inboundorder.NewRepository(db)),
+	))
+
+	reflection.Register(server)
+	return server
+}
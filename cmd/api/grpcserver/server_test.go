@@ -0,0 +1,72 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ______________________________________________________
+// tools
+
+// dial serves register on an in memory listener behind the interceptors of NewServer and returns a client
+// connection to it, If-Match being required.
+func dial(t *testing.T, register func(*grpc.Server)) (*grpc.ClientConn, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(Actor(), Version(db, true)))
+	register(server)
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		db.Close()
+	})
+	return conn, mock
+}
+
+// ______________________________________________________
+// tests
+
+func Test_NewServer(t *testing.T) {
+	// arrange
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// act
+	server := NewServer(db, cache.NewMemory(0))
+
+	// assert
+	var names []string
+	for name := range server.GetServiceInfo() {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"bootcamp.v1.SellerService",
+		"bootcamp.v1.ProductService",
+		"bootcamp.v1.SectionService",
+		"bootcamp.v1.WarehouseService",
+		"bootcamp.v1.EmployeeService",
+		"bootcamp.v1.BuyerService",
+		"bootcamp.v1.OrderService",
+		reflectionpb.ServerReflection_ServiceDesc.ServiceName,
+		"grpc.reflection.v1.ServerReflection",
+	}, names)
+}
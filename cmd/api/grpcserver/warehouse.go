@@ -0,0 +1,150 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/api/proto/v1"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Warehouse struct {
+	pb.UnimplementedWarehouseServiceServer
+	s warehouse.Service
+}
+
+func NewWarehouse(s warehouse.Service) *Warehouse {
+	return &Warehouse{s: s}
+}
+
+func (w *Warehouse) ListWarehouses(ctx context.Context, req *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+	getAll := w.s.GetAll
+	if req.GetIncludeDeleted() {
+		getAll = w.s.GetAllWithDeleted
+	}
+	warehouses, err := getAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	res := &pb.ListWarehousesResponse{}
+	for _, wareH := range warehouses {
+		res.Warehouses = append(res.Warehouses, warehouseToPB(wareH))
+	}
+	return res, nil
+}
+
+func (w *Warehouse) GetWarehouse(ctx context.Context, req *pb.IDRequest) (*pb.Warehouse, error) {
+	wareH, err := w.s.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return warehouseToPB(wareH), nil
+}
+
+func (w *Warehouse) CreateWarehouse(ctx context.Context, req *pb.Warehouse) (*pb.Warehouse, error) {
+	wareH := warehouseFromPB(req)
+	wareH.ID = 0
+	if err := validator.New().Struct(wareH); err != nil {
+		return nil, invalid(err)
+	}
+
+	wareH, err := w.s.Create(ctx, wareH)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return warehouseToPB(wareH), nil
+}
+
+// UpdateWarehouse applies the fields set on the warehouse as it is now, as PATCH does.
+func (w *Warehouse) UpdateWarehouse(ctx context.Context, req *pb.UpdateWarehouseRequest) (*pb.Warehouse, error) {
+	wareH, err := w.s.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if req.Address != nil {
+		wareH.Address = req.GetAddress()
+	}
+	if req.Telephone != nil {
+		wareH.Telephone = req.GetTelephone()
+	}
+	if req.WarehouseCode != nil {
+		wareH.WarehouseCode = req.GetWarehouseCode()
+	}
+	if req.MinimumCapacity != nil {
+		wareH.MinimumCapacity = int(req.GetMinimumCapacity())
+	}
+	if req.MinimumTemperature != nil {
+		wareH.MinimumTemperature = int(req.GetMinimumTemperature())
+	}
+	if req.LocalityId != nil {
+		wareH.LocalityID = req.GetLocalityId()
+	}
+	if req.Latitude != nil {
+		wareH.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		wareH.Longitude = req.Longitude
+	}
+	// locality is only required on create, as in the REST handler
+	if err := validator.New().StructExcept(wareH, "LocalityID"); err != nil {
+		return nil, invalid(err)
+	}
+
+	wareH, err = w.s.Update(ctx, wareH)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return warehouseToPB(wareH), nil
+}
+
+func (w *Warehouse) DeleteWarehouse(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := w.s.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (w *Warehouse) RestoreWarehouse(ctx context.Context, req *pb.IDRequest) (*pb.Warehouse, error) {
+	wareH, err := w.s.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return warehouseToPB(wareH), nil
+}
+
+func (w *Warehouse) HardDeleteWarehouse(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	if err := w.s.HardDelete(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func warehouseToPB(w domain.Warehouse) *pb.Warehouse {
+	return &pb.Warehouse{
+		Id:                 int64(w.ID),
+		Address:            w.Address,
+		Telephone:          w.Telephone,
+		WarehouseCode:      w.WarehouseCode,
+		MinimumCapacity:    int32(w.MinimumCapacity),
+		MinimumTemperature: int32(w.MinimumTemperature),
+		LocalityId:         w.LocalityID,
+		Latitude:           w.Latitude,
+		Longitude:          w.Longitude,
+	}
+}
+
+func warehouseFromPB(w *pb.Warehouse) domain.Warehouse {
+	return domain.Warehouse{
+		ID:                 int(w.GetId()),
+		Address:            w.GetAddress(),
+		Telephone:          w.GetTelephone(),
+		WarehouseCode:      w.GetWarehouseCode(),
+		MinimumCapacity:    int(w.GetMinimumCapacity()),
+		MinimumTemperature: int(w.GetMinimumTemperature()),
+		LocalityID:         w.GetLocalityId(),
+		Latitude:           w.Latitude,
+		Longitude:          w.Longitude,
+	}
+}
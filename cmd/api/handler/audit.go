@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+var (
+	ErrInvalidTime  = errors.New("from and to must be RFC 3339 times, e.g. 2023-03-01T00:00:00Z")
+	ErrInvalidLimit = errors.New("limit must be a positive integer")
+)
+
+type Audit struct {
+	auditService audit.Service
+}
+
+func NewAudit(auditService audit.Service) *Audit {
+	return &Audit{auditService: auditService}
+}
+
+// @Summary		Audit trail
+// @Tags			Audit
+// @Description	Returns who created, updated or deleted what and when, newest first, with the changed columns before and after
+// @Produce		json
+// @Param			resource	query		string	false	"table name, e.g. sellers"
+// @Param			resource_id	query		string	false	"id of the changed row"
+// @Param			actor		query		string	false	"who made the change"
+// @Param			from		query		string	false	"RFC 3339 time, inclusive"
+// @Param			to			query		string	false	"RFC 3339 time, inclusive"
+// @Param			limit		query		int		false	"at most 1000, 100 by default"
+// @Success		200			{object}	web.response{data=[]domain.AuditEntry}
+// @Failure		400			{object}	web.errorResponse
+// @Failure		500			{object}	web.errorResponse
+// @Router			/api/v1/audit [get]
+func (a *Audit) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		filter := domain.AuditFilter{
+			Resource:   ctx.Query("resource"),
+			ResourceID: ctx.Query("resource_id"),
+			Actor:      ctx.Query("actor"),
+		}
+		var err error
+
+		if v := ctx.Query("from"); v != "" {
+			if filter.From, err = time.Parse(time.RFC3339, v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidTime.Error())
+				return
+			}
+		}
+		if v := ctx.Query("to"); v != "" {
+			if filter.To, err = time.Parse(time.RFC3339, v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidTime.Error())
+				return
+			}
+		}
+		if v := ctx.Query("limit"); v != "" {
+			if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit < 1 {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidLimit.Error())
+				return
+			}
+		}
+
+		entries, err := a.auditService.GetAll(ctx, filter)
+		if err != nil {
+			switch err {
+			case audit.ErrTimeRange:
+				web.Error(ctx, http.StatusBadRequest, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, entries)
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockAudit struct {
+	mock.Mock
+}
+
+func (s *serviceMockAudit) GetAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+func CreateServerAudit(service audit.Service) *gin.Engine {
+	handler := NewAudit(service)
+
+	server := gin.Default()
+	server.GET("/api/v1/audit", handler.GetAll())
+
+	return server
+}
+
+func Test_Audit(t *testing.T) {
+	t.Run("filters by resource, actor and time range", func(t *testing.T) {
+		// arrange
+		createdAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+		filter := domain.AuditFilter{
+			Resource: "sellers",
+			Actor:    "alice",
+			From:     time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC),
+			Limit:    10,
+		}
+		service := &serviceMockAudit{}
+		service.On("GetAll", mock.Anything, filter).Return([]domain.AuditEntry{
+			{ID: 1, Actor: "alice", CreatedAt: createdAt, Resource: "sellers", ResourceID: "1", Action: audit.ActionUpdate, Before: []byte(`{"address":"a"}`), After: []byte(`{"address":"b"}`)},
+		}, nil)
+		server := CreateServerAudit(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/audit?resource=sellers&actor=alice&from=2023-03-01T00:00:00Z&to=2023-03-02T00:00:00Z&limit=10", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"actor":"alice","created_at":"2023-03-01T10:00:00Z","resource":"sellers","resource_id":"1","action":"update","before":{"address":"a"},"after":{"address":"b"}}]}`, res.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		// arrange
+		service := &serviceMockAudit{}
+		server := CreateServerAudit(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/audit?from=yesterday", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		service.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		// arrange
+		service := &serviceMockAudit{}
+		server := CreateServerAudit(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/audit?limit=0", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		service.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reversed time range", func(t *testing.T) {
+		// arrange
+		service := &serviceMockAudit{}
+		service.On("GetAll", mock.Anything, mock.Anything).Return([]domain.AuditEntry(nil), audit.ErrTimeRange)
+		server := CreateServerAudit(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/audit?from=2023-03-02T00:00:00Z&to=2023-03-01T00:00:00Z", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		service := &serviceMockAudit{}
+		service.On("GetAll", mock.Anything, domain.AuditFilter{}).Return([]domain.AuditEntry(nil), audit.ErrIntern)
+		server := CreateServerAudit(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/audit", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
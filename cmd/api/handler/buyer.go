@@ -262,9 +262,9 @@ func (b *Buyer) Delete() gin.HandlerFunc {
 // @Summary		Purchase orders by buyer and all
 // @Tags			Buyers
 // @Description	get report by id or all buyers
-// @Produce		json
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param			id	query	int	false	"Buyer ID"
-// @Produce		json
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
 // @Success		200	{object}	web.response{data=[]domain.Buyer}
 // @Failure		400	{object}	web.errorResponse
 // @Failure		404	{object}	web.errorResponse
@@ -272,6 +272,11 @@ func (b *Buyer) Delete() gin.HandlerFunc {
 // @Router			/api/v1/buyers/reportPurchaseOrders [get]
 func (b *Buyer) GetReport() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
 		//get and corroborate query id
 
 		var id int
@@ -302,6 +307,6 @@ func (b *Buyer) GetReport() gin.HandlerFunc {
 			}
 		}
 		//when the request is successful, the backend will return the report
-		web.Success(ctx, http.StatusOK, report)
+		writeReport(ctx, format, "buyers_report_purchase_orders", report)
 	}
 }
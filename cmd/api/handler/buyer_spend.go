@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/spend"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+var ErrInvalidTop = errors.New("top must be a positive integer")
+
+type BuyerSpend struct {
+	spendService spend.Service
+}
+
+func NewBuyerSpend(spendService spend.Service) *BuyerSpend {
+	return &BuyerSpend{spendService: spendService}
+}
+
+// @Summary		Buyer spend
+// @Tags			Buyers
+// @Description	Returns what every buyer spent on purchase orders at the sale price of their product records: the total,
+// @Description	the average order value, the first and last order dates and the products they spent the most on
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			id		query		int		false	"buyer id"
+// @Param			from	query		string	false	"first order date, YYYY-MM-DD"
+// @Param			to		query		string	false	"last order date, YYYY-MM-DD"
+// @Param			top		query		int		false	"products listed per buyer, at most 20, 3 by default"
+// @Param			format	query		string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200		{object}	web.response{data=[]domain.BuyerSpend}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/buyers/reportSpend [get]
+func (b *BuyerSpend) GetSpend() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		var filter domain.BuyerSpendFilter
+		var err error
+
+		if v := ctx.Query("id"); v != "" {
+			if filter.BuyerID, err = strconv.Atoi(v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+				return
+			}
+		}
+		if filter.From, filter.To, err = dateRange(ctx); err != nil {
+			web.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		if v := ctx.Query("top"); v != "" {
+			if filter.TopProducts, err = strconv.Atoi(v); err != nil || filter.TopProducts < 1 {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidTop.Error())
+				return
+			}
+		}
+
+		buyers, err := b.spendService.GetSpend(ctx, filter)
+		if err != nil {
+			switch err {
+			case spend.ErrDateRange:
+				web.Error(ctx, http.StatusBadRequest, err.Error())
+			case spend.ErrBuyerNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		writeReport(ctx, format, "buyers_report_spend", buyers)
+	}
+}
+
+// @Summary		Buyer cohorts
+// @Tags			Buyers
+// @Description	Groups the buyers by the month of their first order ever and totals the orders and spend of each group in the period
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			from	query		string	false	"first order date, YYYY-MM-DD"
+// @Param			to		query		string	false	"last order date, YYYY-MM-DD"
+// @Param			format	query		string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200		{object}	web.response{data=[]domain.BuyerCohort}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/buyers/reportCohorts [get]
+func (b *BuyerSpend) GetCohorts() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		var filter domain.BuyerSpendFilter
+		var err error
+		if filter.From, filter.To, err = dateRange(ctx); err != nil {
+			web.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cohorts, err := b.spendService.GetCohorts(ctx, filter)
+		if err != nil {
+			switch err {
+			case spend.ErrDateRange:
+				web.Error(ctx, http.StatusBadRequest, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		writeReport(ctx, format, "buyers_report_cohorts", cohorts)
+	}
+}
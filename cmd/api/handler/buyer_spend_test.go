@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/spend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockSpend struct {
+	mock.Mock
+}
+
+func (s *serviceMockSpend) GetSpend(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerSpend, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.BuyerSpend), args.Error(1)
+}
+func (s *serviceMockSpend) GetCohorts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerCohort, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.BuyerCohort), args.Error(1)
+}
+
+func CreateServerBuyerSpend(service spend.Service) *gin.Engine {
+	handler := NewBuyerSpend(service)
+
+	server := gin.Default()
+	server.GET("/api/v1/buyers/reportSpend", handler.GetSpend())
+	server.GET("/api/v1/buyers/reportCohorts", handler.GetCohorts())
+
+	return server
+}
+
+func Test_BuyerSpend_GetSpend(t *testing.T) {
+	t.Run("OK with filters", func(t *testing.T) {
+		// arrange
+		buyers := []domain.BuyerSpend{{ID: 1, FirstName: "Ana", PurchaseOrderCount: 2, TotalSpend: 80, AverageOrderValue: 40, FirstOrderDate: "2023-01-05", LastOrderDate: "2023-02-01",
+			TopProducts: []domain.BuyerProductSpend{{ProductID: 7, Description: "peas", PurchaseOrderCount: 2, TotalSpend: 80}}}}
+		service := &serviceMockSpend{}
+		service.On("GetSpend", mock.Anything, domain.BuyerSpendFilter{BuyerID: 1, From: "2023-01-01", To: "2023-03-31", TopProducts: 5}).Return(buyers, nil)
+		server := CreateServerBuyerSpend(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/buyers/reportSpend?id=1&from=2023-01-01&to=2023-03-31&top=5", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data []domain.BuyerSpend `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, buyers, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name   string
+		query  string
+		status int
+		err    error
+	}{
+		{"invalid id", "?id=abc", http.StatusBadRequest, ErrInvalidId},
+		{"invalid date", "?from=2023-02-30", http.StatusBadRequest, ErrInvalidDate},
+		{"invalid top", "?top=0", http.StatusBadRequest, ErrInvalidTop},
+		{"from after to", "?from=2023-02-01&to=2023-01-01", http.StatusBadRequest, spend.ErrDateRange},
+		{"buyer not found", "?id=9", http.StatusNotFound, spend.ErrBuyerNotFound},
+		{"internal error", "?from=2023-01-01", http.StatusInternalServerError, spend.ErrInternal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			service := &serviceMockSpend{}
+			service.On("GetSpend", mock.Anything, mock.Anything).Return([]domain.BuyerSpend{}, c.err)
+			server := CreateServerBuyerSpend(service)
+			req, res := NewRequestLocality(http.MethodGet, "/api/v1/buyers/reportSpend"+c.query, "")
+
+			// act
+			server.ServeHTTP(res, req)
+
+			// assert
+			var body errorResponse
+			assert.Equal(t, c.status, res.Code)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, c.err.Error(), body.Message)
+		})
+	}
+}
+
+func Test_BuyerSpend_GetCohorts(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		cohorts := []domain.BuyerCohort{{Cohort: "2023-01", Buyers: 2, ActiveBuyers: 1, PurchaseOrderCount: 3, TotalSpend: 90, AverageSpendPerBuyer: 45}}
+		service := &serviceMockSpend{}
+		service.On("GetCohorts", mock.Anything, domain.BuyerSpendFilter{From: "2023-01-01"}).Return(cohorts, nil)
+		server := CreateServerBuyerSpend(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/buyers/reportCohorts?from=2023-01-01", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data []domain.BuyerCohort `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, cohorts, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		// arrange
+		service := &serviceMockSpend{}
+		service.On("GetCohorts", mock.Anything, domain.BuyerSpendFilter{From: "2023-02-01", To: "2023-01-01"}).Return([]domain.BuyerCohort{}, spend.ErrDateRange)
+		server := CreateServerBuyerSpend(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/buyers/reportCohorts?from=2023-02-01&to=2023-01-01", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -43,14 +43,20 @@ func (ca *Carry) GetAll() gin.HandlerFunc {
 // @summary		count carries by locality
 // @tags			Carry
 // @Description	Returns a list of all carries by locality
-// @Produce		json
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param			id	query		string	false	"locality Id"
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
 // @Success		200	{object}	web.response{data=[]domain.CarrieLocality}
 // @Failure		500	{object}	web.errorResponse
 // @Router			/api/v1/localities/reportCarries [get]
 func (ca *Carry) GetAllByLocality() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
+		format, ok := reportFormat(c)
+		if !ok {
+			return
+		}
+
 		id, ok := c.GetQuery("id")
 
 		if ok {
@@ -61,7 +67,7 @@ func (ca *Carry) GetAllByLocality() gin.HandlerFunc {
 				web.Error(c, 404, err.Error())
 				return
 			}
-			web.Success(c, 200, found)
+			writeReport(c, format, "localities_report_carries", found)
 			return
 
 		}
@@ -73,7 +79,7 @@ func (ca *Carry) GetAllByLocality() gin.HandlerFunc {
 			return
 
 		}
-		web.Success(c, 200, carryG)
+		writeReport(c, format, "localities_report_carries", carryG)
 	}
 }
 
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/country"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Country struct {
+	countryService country.Service
+}
+
+func NewCountry(countryService country.Service) *Country {
+	return &Country{countryService: countryService}
+}
+
+// @Summary		List countries
+// @Tags			Countries
+// @Description	Returns a list of all countries
+// @Produce		json
+// @Success		200	{object}	web.response{data=[]domain.Country}
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/countries [get]
+func (co *Country) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		countries, err := co.countryService.GetAll(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, countries)
+	}
+}
+
+// @Summary		Country by id
+// @Tags			Countries
+// @Description	Get country by id
+// @Produce		json
+// @Param			id	path		int	true	"country id"
+// @Success		200	{object}	web.response{data=domain.Country}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/countries/{id} [get]
+func (co *Country) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := co.countryService.GetByID(ctx, id)
+		if err != nil {
+			switch err {
+			case country.ErrNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
+// @Summary		Create country
+// @Tags			Countries
+// @Description	Create country
+// @Accept			json
+// @Produce		json
+// @Param			request	body		domain.Country	true	"Country parameters"
+// @Success		201		{object}	web.response{data=domain.Country}
+// @Failure		422		{object}	web.errorResponse
+// @Failure		409		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/countries [post]
+func (co *Country) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request domain.Country
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+
+		// If the JSON object does not contain the necessary fields, a 422 code will be returned.
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		created, err := co.countryService.Create(ctx, request)
+		if err != nil {
+			switch err {
+			case country.ErrDuplicated:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusCreated, created)
+	}
+}
+
+// @Summary		Update country
+// @Tags			Countries
+// @Description	Update country
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int				true	"country id"
+// @Param			request	body		domain.Country	true	"Country parameters"
+// @Success		200		{object}	web.response{data=domain.Country}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/countries/{id} [patch]
+func (co *Country) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		countryDB, err := co.countryService.GetByID(ctx, id)
+		if err != nil {
+			web.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		// decode and update fetched country with fields decoded from request body
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&countryDB); err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrBadRequest.Error())
+			return
+		}
+
+		// new id should not be specified in request body, i.e. it should not change
+		if countryDB.Id != id {
+			web.Error(ctx, http.StatusBadRequest, "cannot update country id")
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&countryDB); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		updated, err := co.countryService.Update(ctx, countryDB)
+		if err != nil {
+			switch err {
+			case country.ErrDuplicated:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, updated)
+	}
+}
+
+// @Summary		Delete country
+// @Tags			Countries
+// @Description	Delete country. Countries referenced by provinces cannot be deleted.
+// @Param			id	path		int	true	"country id"
+// @Success		204	{object}	web.response
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/countries/{id} [delete]
+func (co *Country) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		err = co.countryService.Delete(ctx, id)
+		if err != nil {
+			switch err {
+			case country.ErrNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			case country.ErrHasProvinces:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
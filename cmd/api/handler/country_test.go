@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/country"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockCountry struct {
+	mock.Mock
+}
+
+func (s *serviceMockCountry) GetAll(ctx context.Context) ([]domain.Country, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.Country), args.Error(1)
+}
+func (s *serviceMockCountry) GetByID(ctx context.Context, id int) (domain.Country, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Country), args.Error(1)
+}
+func (s *serviceMockCountry) Create(ctx context.Context, c domain.Country) (domain.Country, error) {
+	args := s.Called(ctx, c)
+	return args.Get(0).(domain.Country), args.Error(1)
+}
+func (s *serviceMockCountry) Update(ctx context.Context, c domain.Country) (domain.Country, error) {
+	args := s.Called(ctx, c)
+	return args.Get(0).(domain.Country), args.Error(1)
+}
+func (s *serviceMockCountry) Delete(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+
+func CreateServerCountry(service country.Service) *gin.Engine {
+	handler := NewCountry(service)
+
+	server := gin.Default()
+	routes := server.Group("/api/v1/countries")
+	{
+		routes.GET("", handler.GetAll())
+		routes.GET("/:id", handler.Get())
+		routes.POST("", handler.Create())
+		routes.PATCH("/:id", handler.Update())
+		routes.DELETE("/:id", handler.Delete())
+	}
+
+	return server
+}
+
+func Test_Country(t *testing.T) {
+	t.Run("get all ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		service.On("GetAll", mock.Anything).Return([]domain.Country{{Id: 1, Country_name: "Argentina"}}, nil)
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/countries", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"country_name":"Argentina"}]}`, res.Body.String())
+	})
+
+	t.Run("get by id not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		service.On("GetByID", mock.Anything, 9).Return(domain.Country{}, country.ErrNotFound)
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/countries/9", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("create ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		service.On("Create", mock.Anything, domain.Country{Country_name: "Argentina"}).Return(domain.Country{Id: 1, Country_name: "Argentina"}, nil)
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/countries", `{"country_name":"Argentina"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"country_name":"Argentina"}}`, res.Body.String())
+	})
+
+	t.Run("create without name", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/countries", `{}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		service.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("create duplicated", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		service.On("Create", mock.Anything, domain.Country{Country_name: "Argentina"}).Return(domain.Country{}, country.ErrDuplicated)
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/countries", `{"country_name":"Argentina"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("update cannot change id", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		service.On("GetByID", mock.Anything, 1).Return(domain.Country{Id: 1, Country_name: "Argentina"}, nil)
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodPatch, "/api/v1/countries/1", `{"id":2}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		service.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("delete referenced by provinces", func(t *testing.T) {
+		// arrange
+		service := &serviceMockCountry{}
+		service.On("Delete", mock.Anything, 1).Return(country.ErrHasProvinces)
+		server := CreateServerCountry(service)
+		req, res := NewRequestLocality(http.MethodDelete, "/api/v1/countries/1", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+}
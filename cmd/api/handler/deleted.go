@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+// includeDeleted reads ?include_deleted=, which lets a listing return soft deleted rows too.
+// On a value that is not a boolean it answers 400 and returns false.
+func includeDeleted(c *gin.Context) (include bool, ok bool) {
+	value := c.Query("include_deleted")
+	if value == "" {
+		return false, true
+	}
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, "include_deleted must be a boolean")
+		return false, false
+	}
+	return include, true
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/employee"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
 )
 
@@ -17,6 +19,7 @@ var (
 	ErrNotFound       = errors.New("Employee not found.")
 	ErrNotWareHouse   = errors.New("WareHouse not found.")
 	ErrInternalServer = errors.New("Internal server error.")
+	ErrInvalidFilter  = errors.New("warehouse_id must be a positive integer and by_warehouse a boolean")
 )
 
 type Employee struct {
@@ -58,14 +61,26 @@ func (e *Employee) Get() gin.HandlerFunc {
 
 // @summary		List employees
 // @tags			Employees
-// @Description	get employees
+// @Description	get employees, soft deleted ones only with include_deleted=true
 // @Produce		json
-// @Success		200	{object}	web.response{data=[]domain.Employee}
-// @Failure		500	{object}	web.errorResponse
+// @Param			include_deleted	query		bool	false	"Include soft deleted employees"
+// @Success		200				{object}	web.response{data=[]domain.Employee}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Failure		403				{object}	web.errorResponse
 // @Router			/api/v1/employees [get]
 func (e *Employee) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		employees, err := e.employeeService.GetAll(c)
+		include, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		getAll := e.employeeService.GetAll
+		if include {
+			getAll = e.employeeService.GetAllWithDeleted
+		}
+		employees, err := getAll(c)
 		if err != nil {
 			web.Error(c, http.StatusInternalServerError, employee.ErrDatabase.Error())
 			return
@@ -197,6 +212,9 @@ func (e *Employee) Update() gin.HandlerFunc {
 			case employee.ErrWarehouseNotfound:
 				web.Error(c, http.StatusBadRequest, ErrNotWareHouse.Error())
 				return
+			case etag.ErrPreconditionFailed:
+				web.Error(c, http.StatusPreconditionFailed, err.Error())
+				return
 			default:
 				web.Error(c, http.StatusInternalServerError, ErrInternalServer.Error())
 				return
@@ -210,7 +228,7 @@ func (e *Employee) Update() gin.HandlerFunc {
 
 // @summary		Delete employee
 // @tags			Employees
-// @Description	delete employee by id
+// @Description	soft delete employee by id, it can be restored later
 // @Param			id	path	int	true	"Employee Id"
 // @Success		204
 // @Failure		400	{object}	web.errorResponse
@@ -226,6 +244,89 @@ func (e *Employee) Delete() gin.HandlerFunc {
 		}
 
 		err = e.employeeService.Delete(c, id)
+		if errors.Is(err, employee.ErrNotFound) {
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, etag.ErrPreconditionFailed) {
+			web.Error(c, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, employee.ErrDatabase.Error())
+			return
+		}
+
+		web.Success(c, http.StatusNoContent, "")
+	}
+}
+
+// @summary		Restore employee
+// @tags			Employees
+// @Description	restore a soft deleted employee by id
+// @Produce		json
+// @Param			id	path		int	true	"Employee Id"
+// @Success		200	{object}	web.response{data=domain.Employee}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/employees/{id}/restore [post]
+func (e *Employee) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		employeeDB, err := e.employeeService.Restore(c, id)
+		if errors.Is(err, employee.ErrNotFound) {
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, softdelete.ErrNotDeleted) {
+			web.Error(c, http.StatusConflict, "Employee is not deleted.")
+			return
+		}
+
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, employee.ErrDatabase.Error())
+			return
+		}
+
+		web.Success(c, http.StatusOK, employeeDB)
+	}
+}
+
+// @summary		Hard delete employee
+// @tags			Employees
+// @Description	remove employee by id for good, refused while inbound orders or transfers reference it
+// @Param			id	path	int	true	"Employee Id"
+// @Success		204
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/employees/{id}/hard [delete]
+func (e *Employee) HardDelete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		err = e.employeeService.HardDelete(c, id)
+		if errors.Is(err, softdelete.ErrHasDependents) {
+			web.Error(c, http.StatusConflict, "cannot delete employee, "+err.Error())
+			return
+		}
+
 		if errors.Is(err, employee.ErrNotFound) {
 			web.Error(c, http.StatusNotFound, err.Error())
 			return
@@ -243,16 +344,49 @@ func (e *Employee) Delete() gin.HandlerFunc {
 // @summary		Employee with inbound orders count
 // @tags			Employees
 // @Description	get employee with inbound orders count
-// @Produce		json
+// @Description	Any of from, to, group_by, by_warehouse or warehouse_id turns the report into a list of
+// @Description	productivity rows that also total the units received
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param			id	query		int	false	"Employee Id"
+// @Param			from	query	string	false	"first order date, YYYY-MM-DD"
+// @Param			to	query	string	false	"last order date, YYYY-MM-DD"
+// @Param			group_by	query	string	false	"day, week or month"
+// @Param			by_warehouse	query	bool	false	"split the totals by the warehouse the orders were received at"
+// @Param			warehouse_id	query	int	false	"only orders received at this warehouse"
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
 // @Success		200	{object}	web.response{data=[]domain.EmployeeWithInboundOrders}
 // @Success		200	{object}	web.response{data=domain.EmployeeWithInboundOrders}
+// @Success		200	{object}	web.response{data=[]domain.EmployeeProductivity}
 // @Failure		400	{object}	web.errorResponse
 // @Failure		404	{object}	web.errorResponse
 // @Failure		500	{object}	web.errorResponse
 // @Router			/api/v1/employees/reportInboundOrders [get]
 func (e *Employee) GetAllWithInboundOrders() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		format, ok := reportFormat(c)
+		if !ok {
+			return
+		}
+
+		if filter, ok, err := productivityFilter(c); err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		} else if ok {
+			employees, err := e.employeeService.GetProductivity(c, filter)
+			if err != nil {
+				switch err {
+				case employee.ErrDateRange, employee.ErrGroupBy:
+					web.Error(c, http.StatusBadRequest, err.Error())
+				default:
+					web.Error(c, http.StatusInternalServerError, ErrInternalServer.Error())
+				}
+				return
+			}
+
+			writeReport(c, format, "employees_report_inbound_orders", employees)
+			return
+		}
+
 		idQuery := c.Query("id")
 		if idQuery == "" {
 			employees, err := e.employeeService.GetAllInoundOrders(c)
@@ -261,7 +395,7 @@ func (e *Employee) GetAllWithInboundOrders() gin.HandlerFunc {
 				return
 			}
 
-			web.Success(c, http.StatusOK, employees)
+			writeReport(c, format, "employees_report_inbound_orders", employees)
 			return
 		}
 
@@ -277,6 +411,98 @@ func (e *Employee) GetAllWithInboundOrders() gin.HandlerFunc {
 			return
 		}
 
-		web.Success(c, http.StatusOK, employeeDB)
+		writeReport(c, format, "employees_report_inbound_orders", employeeDB)
+	}
+}
+
+// productivityFilter reads the productivity filters of the inbound orders report, returning false when
+// none was given.
+func productivityFilter(c *gin.Context) (domain.EmployeeProductivityFilter, bool, error) {
+	f := domain.EmployeeProductivityFilter{GroupBy: c.Query("group_by")}
+	given := f.GroupBy != ""
+	var err error
+
+	if f.From, f.To, err = dateRange(c); err != nil {
+		return f, false, err
+	}
+	given = given || f.From != "" || f.To != ""
+
+	if v := c.Query("by_warehouse"); v != "" {
+		if f.ByWarehouse, err = strconv.ParseBool(v); err != nil {
+			return f, false, ErrInvalidFilter
+		}
+		given = true
+	}
+	if v := c.Query("warehouse_id"); v != "" {
+		if f.WarehouseID, err = strconv.Atoi(v); err != nil || f.WarehouseID < 1 {
+			return f, false, ErrInvalidFilter
+		}
+		given = true
+	}
+	if !given {
+		return f, false, nil
+	}
+
+	if v := c.Query("id"); v != "" {
+		if f.EmployeeID, err = strconv.Atoi(v); err != nil {
+			return f, false, ErrInvalidId
+		}
+	}
+	return f, true, nil
+}
+
+// @summary		Employee leaderboard
+// @tags			Employees
+// @Description	Ranks the employees who received orders at a warehouse by units received, then by orders.
+// @Description	Employees tied on both share a rank.
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			warehouse_id	query	int	true	"Warehouse Id"
+// @Param			from	query	string	false	"first order date, YYYY-MM-DD"
+// @Param			to	query	string	false	"last order date, YYYY-MM-DD"
+// @Param			limit	query	int	false	"at most 100, 10 by default"
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200	{object}	web.response{data=[]domain.EmployeeRanking}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/employees/reportLeaderboard [get]
+func (e *Employee) Leaderboard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, ok := reportFormat(c)
+		if !ok {
+			return
+		}
+
+		filter := domain.EmployeeLeaderboardFilter{}
+		var err error
+		if filter.WarehouseID, err = strconv.Atoi(c.Query("warehouse_id")); err != nil || filter.WarehouseID < 1 {
+			web.Error(c, http.StatusBadRequest, ErrInvalidFilter.Error())
+			return
+		}
+		if filter.From, filter.To, err = dateRange(c); err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if v := c.Query("limit"); v != "" {
+			if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit < 1 {
+				web.Error(c, http.StatusBadRequest, ErrInvalidLimit.Error())
+				return
+			}
+		}
+
+		ranking, err := e.employeeService.GetLeaderboard(c, filter)
+		if err != nil {
+			switch err {
+			case employee.ErrDateRange:
+				web.Error(c, http.StatusBadRequest, err.Error())
+			case employee.ErrWarehouseNotfound:
+				web.Error(c, http.StatusNotFound, ErrNotWareHouse.Error())
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer.Error())
+			}
+			return
+		}
+
+		writeReport(c, format, "employees_report_leaderboard", ranking)
 	}
 }
@@ -39,7 +39,7 @@ func createServerEmployeeFunctional(db *sql.DB) *gin.Engine {
 }
 
 func Test_Functional_Employee_GetAll(t *testing.T) {
-	query := "SELECT * FROM employees"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE deleted_at IS NULL"
 
 	type response struct {
 		Data []domain.Employee `json:"data"`
@@ -120,7 +120,7 @@ func Test_Functional_Employee_GetAll(t *testing.T) {
 }
 
 func Test_Functional_Employee_Get(t *testing.T) {
-	query := "SELECT * FROM employees WHERE id=?;"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND deleted_at IS NULL;"
 
 	type response struct {
 		Data domain.Employee `json:"data"`
@@ -394,8 +394,8 @@ func Test_Functional_Employee_Create(t *testing.T) {
 }
 
 func Test_Functional_Employee_Update(t *testing.T) {
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
-	queryGet := "SELECT * FROM employees WHERE id=?;"
+	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?, version=version+1 WHERE id=?"
+	queryGet := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND deleted_at IS NULL;"
 
 	type response struct {
 		Data domain.Employee `json:"data"`
@@ -622,7 +622,7 @@ func Test_Functional_Employee_Update(t *testing.T) {
 }
 
 func Test_Functional_Employee_Delete(t *testing.T) {
-	query := "DELETE FROM employees WHERE id=?"
+	query := "UPDATE employees SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
 
 	t.Run("Delete OK 204", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -721,7 +721,7 @@ func Test_Functional_Employee_Delete(t *testing.T) {
 }
 
 func Test_Functional_Employee_GetAllWithInboundOrders_WithoutID(t *testing.T) {
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.deleted_at IS NULL GROUP BY e.id;"
 
 	type response struct {
 		Data []domain.EmployeeWithInboundOrders `json:"data"`
@@ -804,7 +804,7 @@ func Test_Functional_Employee_GetAllWithInboundOrders_WithoutID(t *testing.T) {
 }
 
 func Test_Functional_Employee_GetAllWithInboundOrders_WithID(t *testing.T) {
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? AND e.deleted_at IS NULL GROUP BY e.id;"
 
 	type response struct {
 		Data domain.EmployeeWithInboundOrders `json:"data"`
@@ -55,6 +55,21 @@ func (sm *serviceEmployeeMock) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (sm *serviceEmployeeMock) GetAllWithDeleted(ctx context.Context) ([]domain.Employee, error) {
+	args := sm.Called(ctx)
+	return args.Get(0).([]domain.Employee), args.Error(1)
+}
+
+func (sm *serviceEmployeeMock) Restore(ctx context.Context, id int) (domain.Employee, error) {
+	args := sm.Called(ctx, id)
+	return args.Get(0).(domain.Employee), args.Error(1)
+}
+
+func (sm *serviceEmployeeMock) HardDelete(ctx context.Context, id int) error {
+	args := sm.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (sm *serviceEmployeeMock) GetAllInoundOrders(ctx context.Context) ([]domain.EmployeeWithInboundOrders, error) {
 	args := sm.Called(ctx)
 	return args.Get(0).([]domain.EmployeeWithInboundOrders), args.Error(1)
@@ -65,6 +80,16 @@ func (sm *serviceEmployeeMock) GetWithInboundOrder(ctx context.Context, id int)
 	return args.Get(0).(domain.EmployeeWithInboundOrders), args.Error(1)
 }
 
+func (sm *serviceEmployeeMock) GetProductivity(ctx context.Context, f domain.EmployeeProductivityFilter) ([]domain.EmployeeProductivity, error) {
+	args := sm.Called(ctx, f)
+	return args.Get(0).([]domain.EmployeeProductivity), args.Error(1)
+}
+
+func (sm *serviceEmployeeMock) GetLeaderboard(ctx context.Context, f domain.EmployeeLeaderboardFilter) ([]domain.EmployeeRanking, error) {
+	args := sm.Called(ctx, f)
+	return args.Get(0).([]domain.EmployeeRanking), args.Error(1)
+}
+
 func createServerEmployeeUnit(service *serviceEmployeeMock) *gin.Engine {
 	handler := NewEmployee(service)
 
@@ -78,6 +103,7 @@ func createServerEmployeeUnit(service *serviceEmployeeMock) *gin.Engine {
 		rEmp.PATCH("/:id", handler.Update())
 		rEmp.DELETE("/:id", handler.Delete())
 		rEmp.GET("/reportInboundOrders", handler.GetAllWithInboundOrders())
+		rEmp.GET("/reportLeaderboard", handler.Leaderboard())
 	}
 
 	return eng
@@ -786,3 +812,154 @@ func Test_Employee_GetAllWithInboundOrders_WithID(t *testing.T) {
 	})
 
 }
+
+func Test_Employee_GetAllWithInboundOrders_Productivity(t *testing.T) {
+	type response struct {
+		Data []domain.EmployeeProductivity `json:"data"`
+	}
+
+	employees := []domain.EmployeeProductivity{
+		{
+			EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 1, CardNumberID: "A12", FirstName: "Juan", LastName: "Perez", WarehouseID: 1, InboundOrdersCount: 3},
+			Period:                    "2023-03",
+			OrderWarehouseID:          2,
+			UnitsReceived:             450,
+		},
+	}
+
+	t.Run("GetAllWithInboundOrders Productivity OK", func(t *testing.T) {
+		service := NewServiceEmployeeMock()
+		filter := domain.EmployeeProductivityFilter{EmployeeID: 1, From: "2023-03-01", To: "2023-03-31", GroupBy: "month", ByWarehouse: true}
+		service.On("GetProductivity", mock.Anything, filter).Return(employees, nil)
+		server := createServerEmployeeUnit(service)
+
+		req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportInboundOrders?id=1&from=2023-03-01&to=2023-03-31&group_by=month&by_warehouse=true", "")
+		server.ServeHTTP(resp, req)
+
+		var result response
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, response{Data: employees}, result)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	cases := []struct {
+		name  string
+		query string
+		err   error
+	}{
+		{"invalid date", "?from=01/03/2023", ErrInvalidDate},
+		{"invalid by_warehouse", "?by_warehouse=maybe", ErrInvalidFilter},
+		{"invalid warehouse_id", "?warehouse_id=0", ErrInvalidFilter},
+		{"invalid id", "?group_by=day&id=abc", ErrInvalidId},
+	}
+	for _, c := range cases {
+		t.Run("GetAllWithInboundOrders Productivity Error "+c.name, func(t *testing.T) {
+			service := NewServiceEmployeeMock()
+			server := createServerEmployeeUnit(service)
+
+			req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportInboundOrders"+c.query, "")
+			server.ServeHTTP(resp, req)
+
+			var result errorResponse
+			err := json.NewDecoder(resp.Body).Decode(&result)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Equal(t, c.err.Error(), result.Message)
+			assert.True(t, service.AssertExpectations(t))
+		})
+	}
+
+	t.Run("GetAllWithInboundOrders Productivity Error group_by", func(t *testing.T) {
+		service := NewServiceEmployeeMock()
+		service.On("GetProductivity", mock.Anything, domain.EmployeeProductivityFilter{GroupBy: "year"}).Return([]domain.EmployeeProductivity{}, employee.ErrGroupBy)
+		server := createServerEmployeeUnit(service)
+
+		req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportInboundOrders?group_by=year", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("GetAllWithInboundOrders Productivity Error", func(t *testing.T) {
+		service := NewServiceEmployeeMock()
+		service.On("GetProductivity", mock.Anything, domain.EmployeeProductivityFilter{WarehouseID: 2}).Return([]domain.EmployeeProductivity{}, employee.ErrDatabase)
+		server := createServerEmployeeUnit(service)
+
+		req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportInboundOrders?warehouse_id=2", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
+
+func Test_Employee_Leaderboard(t *testing.T) {
+	type response struct {
+		Data []domain.EmployeeRanking `json:"data"`
+	}
+
+	ranking := []domain.EmployeeRanking{
+		{Rank: 1, EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 2, CardNumberID: "A13", FirstName: "Jose", LastName: "Gomez", WarehouseID: 1, InboundOrdersCount: 4}, UnitsReceived: 600},
+		{Rank: 2, EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 1, CardNumberID: "A12", FirstName: "Juan", LastName: "Perez", WarehouseID: 1, InboundOrdersCount: 3}, UnitsReceived: 450},
+	}
+
+	t.Run("Leaderboard OK", func(t *testing.T) {
+		service := NewServiceEmployeeMock()
+		service.On("GetLeaderboard", mock.Anything, domain.EmployeeLeaderboardFilter{WarehouseID: 1, From: "2023-03-01", Limit: 5}).Return(ranking, nil)
+		server := createServerEmployeeUnit(service)
+
+		req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportLeaderboard?warehouse_id=1&from=2023-03-01&limit=5", "")
+		server.ServeHTTP(resp, req)
+
+		var result response
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, response{Data: ranking}, result)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	cases := []struct {
+		name  string
+		query string
+		err   error
+	}{
+		{"missing warehouse_id", "", ErrInvalidFilter},
+		{"invalid date", "?warehouse_id=1&to=2023-13-01", ErrInvalidDate},
+		{"invalid limit", "?warehouse_id=1&limit=-1", ErrInvalidLimit},
+	}
+	for _, c := range cases {
+		t.Run("Leaderboard Error "+c.name, func(t *testing.T) {
+			service := NewServiceEmployeeMock()
+			server := createServerEmployeeUnit(service)
+
+			req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportLeaderboard"+c.query, "")
+			server.ServeHTTP(resp, req)
+
+			var result errorResponse
+			err := json.NewDecoder(resp.Body).Decode(&result)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Equal(t, c.err.Error(), result.Message)
+		})
+	}
+
+	t.Run("Leaderboard Error warehouse not found", func(t *testing.T) {
+		service := NewServiceEmployeeMock()
+		service.On("GetLeaderboard", mock.Anything, domain.EmployeeLeaderboardFilter{WarehouseID: 9}).Return([]domain.EmployeeRanking{}, employee.ErrWarehouseNotfound)
+		server := createServerEmployeeUnit(service)
+
+		req, resp := createRequestEmployeeUnit(http.MethodGet, "/api/v1/employees/reportLeaderboard?warehouse_id=9", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/forecast"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+var ErrInvalidForecast = errors.New("history, horizon and window must be positive integers and alpha a positive number")
+
+type Forecast struct {
+	forecastService forecast.Service
+}
+
+func NewForecast(forecastService forecast.Service) *Forecast {
+	return &Forecast{forecastService: forecastService}
+}
+
+// @Summary		Product demand forecast
+// @Tags			Products
+// @Description	Returns the daily purchase orders of a product up to yesterday and the moving-average and exponential-smoothing
+// @Description	forecasts of the days from today
+// @Produce		json
+// @Param			id		path		int		true	"product id"
+// @Param			history	query		int		false	"days of orders the forecast is built from, 90 by default and at most 365"
+// @Param			horizon	query		int		false	"days forecast, 14 by default and at most 90"
+// @Param			window	query		int		false	"days the moving average spans, 7 by default"
+// @Param			alpha	query		number	false	"smoothing factor in (0, 1], 0.3 by default"
+// @Success		200		{object}	web.response{data=domain.ProductForecast}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/products/{id}/forecast [get]
+func (f *Forecast) GetForecast() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		var params domain.ForecastParams
+		days := map[string]*int{"history": &params.History, "horizon": &params.Horizon, "window": &params.Window}
+		for name, value := range days {
+			if v := ctx.Query(name); v != "" {
+				if *value, err = strconv.Atoi(v); err != nil || *value <= 0 {
+					web.Error(ctx, http.StatusBadRequest, ErrInvalidForecast.Error())
+					return
+				}
+			}
+		}
+		if v := ctx.Query("alpha"); v != "" {
+			if params.Alpha, err = strconv.ParseFloat(v, 64); err != nil || params.Alpha <= 0 {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidForecast.Error())
+				return
+			}
+		}
+
+		result, err := f.forecastService.GetForecast(ctx, id, params)
+		if err != nil {
+			switch err {
+			case forecast.ErrHistory, forecast.ErrHorizon, forecast.ErrWindow, forecast.ErrAlpha:
+				web.Error(ctx, http.StatusBadRequest, err.Error())
+			case forecast.ErrProductNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
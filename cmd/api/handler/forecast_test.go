@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/forecast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockForecast struct {
+	mock.Mock
+}
+
+func (s *serviceMockForecast) GetForecast(ctx context.Context, productID int, p domain.ForecastParams) (domain.ProductForecast, error) {
+	args := s.Called(ctx, productID, p)
+	return args.Get(0).(domain.ProductForecast), args.Error(1)
+}
+func (s *serviceMockForecast) Generate(ctx context.Context) (int, error) {
+	args := s.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func CreateServerForecast(service forecast.Service) *gin.Engine {
+	handler := NewForecast(service)
+
+	server := gin.Default()
+	server.GET("/api/v1/products/:id/forecast", handler.GetForecast())
+
+	return server
+}
+
+func Test_Forecast_GetForecast(t *testing.T) {
+	t.Run("OK with params", func(t *testing.T) {
+		// arrange
+		result := domain.ProductForecast{
+			ProductID: 1,
+			Window:    3,
+			Alpha:     0.5,
+			History:   []domain.DemandDay{{Date: "2023-03-31", Units: 2}},
+			Forecast:  []domain.ForecastDay{{Date: "2023-04-01", MovingAverage: 2, ExponentialSmoothing: 2}},
+		}
+		service := &serviceMockForecast{}
+		service.On("GetForecast", mock.Anything, 1, domain.ForecastParams{History: 1, Horizon: 1, Window: 3, Alpha: 0.5}).Return(result, nil)
+		server := CreateServerForecast(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/products/1/forecast?history=1&horizon=1&window=3&alpha=0.5", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data domain.ProductForecast `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, result, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name   string
+		url    string
+		status int
+		err    error
+	}{
+		{"invalid id", "/api/v1/products/abc/forecast", http.StatusBadRequest, ErrInvalidId},
+		{"invalid horizon", "/api/v1/products/1/forecast?horizon=0", http.StatusBadRequest, ErrInvalidForecast},
+		{"invalid alpha", "/api/v1/products/1/forecast?alpha=high", http.StatusBadRequest, ErrInvalidForecast},
+		{"window longer than history", "/api/v1/products/1/forecast?history=3&window=5", http.StatusBadRequest, forecast.ErrWindow},
+		{"product not found", "/api/v1/products/9/forecast", http.StatusNotFound, forecast.ErrProductNotFound},
+		{"internal error", "/api/v1/products/1/forecast", http.StatusInternalServerError, forecast.ErrInternal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			service := &serviceMockForecast{}
+			service.On("GetForecast", mock.Anything, mock.Anything, mock.Anything).Return(domain.ProductForecast{}, c.err)
+			server := CreateServerForecast(service)
+			req, res := NewRequestLocality(http.MethodGet, c.url, "")
+
+			// act
+			server.ServeHTTP(res, req)
+
+			// assert
+			var body errorResponse
+			assert.Equal(t, c.status, res.Code)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, c.err.Error(), body.Message)
+		})
+	}
+}
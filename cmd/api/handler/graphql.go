@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/gql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type GraphQL struct {
+	graphqlService gql.Service
+}
+
+func NewGraphQL(graphqlService gql.Service) *GraphQL {
+	return &GraphQL{graphqlService: graphqlService}
+}
+
+// @Summary		GraphQL
+// @Tags			GraphQL
+// @Description	Runs a read only GraphQL query over sellers, products, product batches, sections and warehouses. The
+// @Description	answer follows the GraphQL spec rather than the usual envelope: data and errors at the top level.
+// @Description	Queries nested deeper than 8 fields, or weighing more than 10000 fields with every list counted as 10
+// @Description	items, are refused before they run.
+// @Accept			json
+// @Produce		json
+// @Param			request	body	gql.Request	true	"query, operationName and variables"
+// @Success		200
+// @Failure		400
+// @Failure		422	{object}	web.errorResponse
+// @Router			/api/v1/graphql [post]
+func (g *GraphQL) Query() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request gql.Request
+		if err := ctx.ShouldBindJSON(&request); err != nil || request.Query == "" {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+
+		result, err := g.graphqlService.Execute(ctx, request)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, result)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, result)
+	}
+}
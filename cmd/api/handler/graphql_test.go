@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/gql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockGraphQL struct {
+	mock.Mock
+}
+
+func (s *serviceMockGraphQL) Execute(ctx context.Context, req gql.Request) (*graphql.Result, error) {
+	args := s.Called(ctx, req)
+	return args.Get(0).(*graphql.Result), args.Error(1)
+}
+
+func CreateServerGraphQL(service gql.Service) *gin.Engine {
+	handler := NewGraphQL(service)
+
+	server := gin.Default()
+	server.POST("/api/v1/graphql", handler.Query())
+
+	return server
+}
+
+func Test_Query_GraphQL(t *testing.T) {
+	t.Run("answers the result unwrapped", func(t *testing.T) {
+		// arrange
+		service := &serviceMockGraphQL{}
+		request := gql.Request{Query: "{ sellers { id } }"}
+		service.On("Execute", mock.Anything, request).Return(&graphql.Result{Data: map[string]interface{}{"sellers": []interface{}{map[string]interface{}{"id": 1}}}}, nil)
+		server := CreateServerGraphQL(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/graphql", `{"query":"{ sellers { id } }"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"sellers":[{"id":1}]}}`, res.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("refused query", func(t *testing.T) {
+		// arrange
+		service := &serviceMockGraphQL{}
+		service.On("Execute", mock.Anything, mock.Anything).Return(&graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError("query is too deep: depth 9 over 8")}}, gql.ErrInvalidQuery)
+		server := CreateServerGraphQL(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/graphql", `{"query":"{ sellers { id } }"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Contains(t, res.Body.String(), "query is too deep")
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		// arrange
+		service := &serviceMockGraphQL{}
+		server := CreateServerGraphQL(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/graphql", `{"variables":{}}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		service.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/importer"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Import struct {
+	importService importer.Service
+}
+
+func NewImport(importService importer.Service) *Import {
+	return &Import{importService: importService}
+}
+
+// @Summary		Bulk CSV import
+// @Tags			Import
+// @Description	Imports sellers, products, localities or warehouses from a CSV file whose header uses the JSON field names.
+// @Description	Rows are validated like the JSON endpoints and rejected rows are reported by line number.
+// @Description	all_or_nothing (default) stores nothing unless every row is accepted; best_effort stores the valid rows, committing every chunk_size rows.
+// @Description	Answers 201 when every row was imported, 200 when only some were and 422 when none were.
+// @Description	all_or_nothing files are limited to 10000 rows and larger ones are answered with 413.
+// @Accept			multipart/form-data
+// @Produce		json
+// @Param			resource	path		string	true	"sellers, products, localities or warehouses"
+// @Param			file		formData	file	true	"CSV file"
+// @Param			mode		query		string	false	"all_or_nothing or best_effort"
+// @Param			chunk_size	query		int		false	"rows per transaction in best_effort mode"
+// @Success		201			{object}	web.response{data=domain.ImportResult}
+// @Success		200			{object}	web.response{data=domain.ImportResult}
+// @Failure		400			{object}	web.errorResponse
+// @Failure		413			{object}	web.errorResponse
+// @Failure		422			{object}	web.response{data=domain.ImportResult}
+// @Failure		500			{object}	web.errorResponse
+// @Router			/api/v1/{resource}/import [post]
+func (i *Import) Import(resource string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		opt := importer.Options{Mode: ctx.Query("mode")}
+		if v := ctx.Query("chunk_size"); v != "" {
+			size, err := strconv.Atoi(v)
+			if err != nil || size <= 0 {
+				web.Error(ctx, http.StatusBadRequest, "chunk_size must be a positive integer")
+				return
+			}
+			opt.ChunkSize = size
+		}
+
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, "a csv file is required in the file field")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, importer.ErrInvalidFile.Error())
+			return
+		}
+		defer file.Close()
+
+		result, err := i.importService.Import(ctx, resource, file, opt)
+		if err != nil {
+			switch {
+			case errors.Is(err, importer.ErrInvalidMode), errors.Is(err, importer.ErrInvalidFile), errors.Is(err, importer.ErrUnknownColumn):
+				web.Error(ctx, http.StatusBadRequest, err.Error())
+			case errors.Is(err, importer.ErrTooManyRows):
+				web.Error(ctx, http.StatusRequestEntityTooLarge, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		switch {
+		case result.Failed == 0:
+			web.Success(ctx, http.StatusCreated, result)
+		case result.Imported == 0:
+			web.Success(ctx, http.StatusUnprocessableEntity, result)
+		default:
+			web.Success(ctx, http.StatusOK, result)
+		}
+	}
+}
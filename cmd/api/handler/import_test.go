@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockImport struct {
+	mock.Mock
+}
+
+func (s *serviceMockImport) Import(ctx context.Context, name string, file io.Reader, opt importer.Options) (domain.ImportResult, error) {
+	content, _ := io.ReadAll(file)
+	args := s.Called(ctx, name, string(content), opt)
+	return args.Get(0).(domain.ImportResult), args.Error(1)
+}
+
+func CreateServerImport(service importer.Service) *gin.Engine {
+	handler := NewImport(service)
+
+	server := gin.Default()
+	server.POST("/api/v1/sellers/import", handler.Import("sellers"))
+
+	return server
+}
+
+func NewRequestImport(url, field, content string) (*http.Request, *httptest.ResponseRecorder) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, "sellers.csv")
+	part.Write([]byte(content))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req, httptest.NewRecorder()
+}
+
+func Test_Import(t *testing.T) {
+	const file = "cid,company_name,address,telephone,locality_id\n1,Meli,Street 1,123,6700\n"
+
+	t.Run("all rows imported", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		service.On("Import", mock.Anything, "sellers", file, importer.Options{Mode: "best_effort", ChunkSize: 100}).
+			Return(domain.ImportResult{Resource: "sellers", Mode: "best_effort", Total: 1, Imported: 1, Errors: []domain.ImportRowError{}}, nil)
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import?mode=best_effort&chunk_size=100", "file", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.JSONEq(t, `{"data":{"resource":"sellers","mode":"best_effort","total":1,"imported":1,"failed":0,"errors":[]}}`, res.Body.String())
+	})
+
+	t.Run("nothing imported", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		service.On("Import", mock.Anything, "sellers", file, importer.Options{}).
+			Return(domain.ImportResult{Resource: "sellers", Mode: "all_or_nothing", Total: 1, Failed: 1, Errors: []domain.ImportRowError{{Row: 2, Errors: []string{"cid already exists"}}}}, nil)
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import", "file", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.JSONEq(t, `{"data":{"resource":"sellers","mode":"all_or_nothing","total":1,"imported":0,"failed":1,"errors":[{"row":2,"errors":["cid already exists"]}]}}`, res.Body.String())
+	})
+
+	t.Run("partially imported", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		service.On("Import", mock.Anything, "sellers", file, importer.Options{Mode: "best_effort"}).
+			Return(domain.ImportResult{Total: 2, Imported: 1, Failed: 1}, nil)
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import?mode=best_effort", "file", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import", "other", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		service.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		service.On("Import", mock.Anything, "sellers", file, importer.Options{}).Return(domain.ImportResult{}, importer.ErrUnknownColumn)
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import", "file", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		service.On("Import", mock.Anything, "sellers", file, importer.Options{}).Return(domain.ImportResult{}, importer.ErrTooManyRows)
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import", "file", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		// arrange
+		service := &serviceMockImport{}
+		server := CreateServerImport(service)
+		req, res := NewRequestImport("/api/v1/sellers/import?chunk_size=0", "file", file)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,9 +57,16 @@ func Test_Functional_InboundOrder_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM inbound_orders WHERE id=?")).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"id", "order_number"}).AddRow(1, "order1"))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.InboundOrderReceived, "inbound_orders", "1", `{"id":1,"order_number":"order1"}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		server := createServerInboundOrderFunctional(db)
 
@@ -171,9 +179,11 @@ func Test_Functional_InboundOrder_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "employees"})
+		mock.ExpectRollback()
 
 		server := createServerInboundOrderFunctional(db)
 
@@ -204,9 +214,11 @@ func Test_Functional_InboundOrder_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "products_batches"})
+		mock.ExpectRollback()
 
 		server := createServerInboundOrderFunctional(db)
 
@@ -237,9 +249,11 @@ func Test_Functional_InboundOrder_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "warehouses"})
+		mock.ExpectRollback()
 
 		server := createServerInboundOrderFunctional(db)
 
@@ -270,9 +284,11 @@ func Test_Functional_InboundOrder_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Order number exists"})
+		mock.ExpectRollback()
 
 		server := createServerInboundOrderFunctional(db)
 
@@ -303,9 +319,11 @@ func Test_Functional_InboundOrder_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
+		mock.ExpectRollback()
 
 		server := createServerInboundOrderFunctional(db)
 
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inventory"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Inventory struct {
+	inventoryService inventory.Service
+}
+
+func NewInventory(inventoryService inventory.Service) *Inventory {
+	return &Inventory{inventoryService: inventoryService}
+}
+
+// @Summary		Inventory summary
+// @Tags			Inventory
+// @Description	Returns the available and expired stock of every product, optionally filtered by seller and product type
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			seller_id		query		int	false	"seller id"
+// @Param			product_type_id	query		int	false	"product type id"
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200				{object}	web.response{data=[]domain.InventorySummary}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Router			/api/v1/inventory [get]
+func (i *Inventory) GetSummary() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		var filter domain.InventoryFilter
+		var err error
+
+		if v := ctx.Query("seller_id"); v != "" {
+			if filter.SellerID, err = strconv.Atoi(v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+				return
+			}
+		}
+		if v := ctx.Query("product_type_id"); v != "" {
+			if filter.ProductTypeID, err = strconv.Atoi(v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+				return
+			}
+		}
+
+		summary, err := i.inventoryService.GetSummary(ctx, filter)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		writeReport(ctx, format, "inventory", summary)
+	}
+}
+
+// @Summary		Product inventory
+// @Tags			Inventory
+// @Description	Returns the stock of a product broken down by warehouse, section and batch, splitting expired and available quantities
+// @Produce		json
+// @Param			id	path		int	true	"product id"
+// @Success		200	{object}	web.response{data=domain.ProductInventory}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/products/{id}/inventory [get]
+func (i *Inventory) GetByProduct() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := i.inventoryService.GetByProduct(ctx, id)
+		if err != nil {
+			switch err {
+			case inventory.ErrProductNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockInventory struct {
+	mock.Mock
+}
+
+func (s *serviceMockInventory) GetSummary(ctx context.Context, f domain.InventoryFilter) ([]domain.InventorySummary, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.InventorySummary), args.Error(1)
+}
+func (s *serviceMockInventory) GetByProduct(ctx context.Context, productID int) (domain.ProductInventory, error) {
+	args := s.Called(ctx, productID)
+	return args.Get(0).(domain.ProductInventory), args.Error(1)
+}
+
+func CreateServerInventory(service inventory.Service) *gin.Engine {
+	handler := NewInventory(service)
+
+	server := gin.Default()
+	server.GET("/api/v1/inventory", handler.GetSummary())
+	server.GET("/api/v1/products/:id/inventory", handler.GetByProduct())
+
+	return server
+}
+
+func Test_InventorySummary(t *testing.T) {
+	t.Run("filters by seller and product type", func(t *testing.T) {
+		// arrange
+		service := &serviceMockInventory{}
+		service.On("GetSummary", mock.Anything, domain.InventoryFilter{SellerID: 3, ProductTypeID: 2}).
+			Return([]domain.InventorySummary{{ProductID: 1, Description: "yogurt", SellerID: 3, ProductTypeID: 2, TotalQuantity: 15, AvailableQuantity: 10, ExpiredQuantity: 5, WarehousesCount: 1}}, nil)
+		server := CreateServerInventory(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/inventory?seller_id=3&product_type_id=2", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":[{"product_id":1,"description":"yogurt","seller_id":3,"product_type_id":2,"total_quantity":15,"available_quantity":10,"expired_quantity":5,"warehouses_count":1}]}`, res.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("invalid seller id", func(t *testing.T) {
+		// arrange
+		service := &serviceMockInventory{}
+		server := CreateServerInventory(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/inventory?seller_id=abc", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		service.AssertNotCalled(t, "GetSummary", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_ProductInventory(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockInventory{}
+		service.On("GetByProduct", mock.Anything, 1).Return(domain.ProductInventory{
+			InventorySummary: domain.InventorySummary{ProductID: 1, Description: "yogurt", TotalQuantity: 10, AvailableQuantity: 10, WarehousesCount: 1},
+			Warehouses: []domain.InventoryWarehouse{
+				{WarehouseID: 1, WarehouseCode: "W1", AvailableQuantity: 10, Sections: []domain.InventorySection{
+					{SectionID: 2, SectionNumber: 20, AvailableQuantity: 10, Batches: []domain.InventoryBatch{
+						{ID: 3, BatchNumber: 300, DueDate: "2099-01-01", CurrentQuantity: 10},
+					}},
+				}},
+			},
+		}, nil)
+		server := CreateServerInventory(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/products/1/inventory", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"product_id":1,"description":"yogurt","seller_id":0,"product_type_id":0,"total_quantity":10,"available_quantity":10,"expired_quantity":0,"warehouses_count":1,
+			"warehouses":[{"warehouse_id":1,"warehouse_code":"W1","available_quantity":10,"expired_quantity":0,"sections":[
+				{"section_id":2,"section_number":20,"available_quantity":10,"expired_quantity":0,"batches":[
+					{"id":3,"batch_number":300,"due_date":"2099-01-01","current_quantity":10,"expired":false}]}]}]}}`, res.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockInventory{}
+		service.On("GetByProduct", mock.Anything, 9).Return(domain.ProductInventory{}, inventory.ErrProductNotFound)
+		server := CreateServerInventory(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/products/9/inventory", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		// arrange
+		service := &serviceMockInventory{}
+		server := CreateServerInventory(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/products/abc/inventory", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/locality"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
 )
 
@@ -18,6 +21,51 @@ func NewLocality(localityService locality.Service) *Locality {
 	return &Locality{localityService: localityService}
 }
 
+// @Summary		List localities
+// @Tags			Localities
+// @Description	Returns a list of all localities
+// @Produce		json
+// @Success		200	{object}	web.response{data=[]domain.Locality}
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/localities [get]
+func (l *Locality) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		result, err := l.localityService.GetAll(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
+// @Summary		Locality by id
+// @Tags			Localities
+// @Description	Get locality by id
+// @Produce		json
+// @Param			id	path		string	true	"locality id"
+// @Success		200	{object}	web.response{data=domain.Locality}
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/localities/{id} [get]
+func (l *Locality) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		result, err := l.localityService.GetByID(ctx, ctx.Param("id"))
+		if err != nil {
+			switch err {
+			case locality.ErrLocalityNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
 // @Summary		Create locality
 // @Tags			Localities
 // @Description	Create locality
@@ -56,6 +104,9 @@ func (l *Locality) Create() gin.HandlerFunc {
 			case locality.ErrDuplicated:
 				web.Error(ctx, http.StatusConflict, err.Error())
 				return
+			case locality.ErrProvinceNotFound:
+				web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+				return
 			default:
 				web.Error(ctx, http.StatusInternalServerError, "internal error")
 				return
@@ -68,11 +119,105 @@ func (l *Locality) Create() gin.HandlerFunc {
 	}
 }
 
+// @Summary		Update locality
+// @Tags			Localities
+// @Description	Update the name or province of a locality
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string			true	"locality id"
+// @Param			request	body		domain.Locality	true	"Locality parameters"
+// @Success		200		{object}	web.response{data=domain.Locality}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/localities/{id} [patch]
+func (l *Locality) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+
+		localityDB, err := l.localityService.GetByID(ctx, id)
+		if err != nil {
+			switch err {
+			case locality.ErrLocalityNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		// decode and update fetched locality with fields decoded from request body
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&localityDB); err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrBadRequest.Error())
+			return
+		}
+
+		// new id should not be specified in request body, i.e. it should not change
+		if localityDB.Id != id {
+			web.Error(ctx, http.StatusBadRequest, "cannot update locality id")
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&localityDB); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		updated, err := l.localityService.Update(ctx, localityDB)
+		if err != nil {
+			switch err {
+			case locality.ErrProvinceNotFound:
+				web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, updated)
+	}
+}
+
+// @Summary		Delete locality
+// @Tags			Localities
+// @Description	Delete locality. Localities referenced by sellers, carries, warehouses or buyers cannot be deleted.
+// @Param			id	path		string	true	"locality id"
+// @Success		204	{object}	web.response
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/localities/{id} [delete]
+func (l *Locality) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := l.localityService.Delete(ctx, ctx.Param("id"))
+		if err != nil {
+			switch err {
+			case locality.ErrLocalityNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			case locality.ErrLocalityInUse:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
+
 // @Summary		ReportSellers
 // @Tags			Localities
 // @Description	Returns a list of all reports
 // @Param			id	query	string	false	"locality Id"
-// @Produce		json
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
 // @Success		200	{object}	web.response{domain.QuantitySellerByLocality}
 // @Success		200	{object}	web.response{data=[]domain.QuantitySellerByLocality}
 // @Failure		404	{object}	web.errorResponse
@@ -80,6 +225,11 @@ func (l *Locality) Create() gin.HandlerFunc {
 // @Router			/api/v1/localities/reportSellers [get]
 func (l *Locality) GetQuantitySellerByLocality() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
 		id, ok := ctx.GetQuery("id")
 
 		if !ok {
@@ -89,7 +239,7 @@ func (l *Locality) GetQuantitySellerByLocality() gin.HandlerFunc {
 				return
 			}
 
-			web.Success(ctx, http.StatusOK, result)
+			writeReport(ctx, format, "localities_report_sellers", result)
 			return
 		}
 		result, err := l.localityService.GetSellerByLocality(ctx, id)
@@ -103,6 +253,127 @@ func (l *Locality) GetQuantitySellerByLocality() gin.HandlerFunc {
 			return
 		}
 
+		writeReport(ctx, format, "localities_report_sellers", result)
+	}
+}
+
+// @Summary		ReportWarehouses
+// @Tags			Localities
+// @Description	Returns the number of warehouses of every locality, or of one if id is given
+// @Param			id	query	string	false	"locality Id"
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200	{object}	web.response{data=[]domain.QuantityWarehouseByLocality}
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/localities/reportWarehouses [get]
+func (l *Locality) GetQuantityWarehouseByLocality() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		id, ok := ctx.GetQuery("id")
+
+		if !ok {
+			result, err := l.localityService.GetWarehouseAll(ctx)
+			if err != nil {
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			writeReport(ctx, format, "localities_report_warehouses", result)
+			return
+		}
+		result, err := l.localityService.GetWarehouseByLocality(ctx, id)
+		if err != nil {
+			switch err {
+			case locality.ErrLocalityNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		writeReport(ctx, format, "localities_report_warehouses", result)
+	}
+}
+
+// @Summary		Report
+// @Tags			Localities
+// @Description	Returns the number of sellers, carries, warehouses and buyers of every locality, or of one if id is given
+// @Param			id	query	string	false	"locality Id"
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200	{object}	web.response{data=[]domain.LocalityReport}
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/localities/report [get]
+func (l *Locality) GetReport() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		id, ok := ctx.GetQuery("id")
+
+		if !ok {
+			result, err := l.localityService.GetReportAll(ctx)
+			if err != nil {
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			writeReport(ctx, format, "localities_report", result)
+			return
+		}
+		result, err := l.localityService.GetReportByLocality(ctx, id)
+		if err != nil {
+			switch err {
+			case locality.ErrLocalityNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		writeReport(ctx, format, "localities_report", result)
+	}
+}
+
+// @Summary		Localities by province
+// @Tags			Provinces
+// @Description	Returns the localities of a province
+// @Produce		json
+// @Param			id	path		int	true	"province id"
+// @Success		200	{object}	web.response{data=[]domain.Locality}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/provinces/{id}/localities [get]
+func (l *Locality) GetByProvince() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := l.localityService.GetByProvince(ctx, id)
+		if err != nil {
+			switch err {
+			case locality.ErrProvinceNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
 		web.Success(ctx, http.StatusOK, result)
 	}
 }
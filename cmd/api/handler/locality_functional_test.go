@@ -52,8 +52,8 @@ func Test_Functional_Create_Locality(t *testing.T) {
 	type responseStruct struct {
 		Data domain.Locality `json:"data"`
 	}
-	localityToCreate := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_name: "Buenos Aires", Country_name: "Argentina"}
-	localityCreated := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_name: "Buenos Aires", Country_name: "Argentina"}
+	localityToCreate := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1}
+	localityCreated := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1}
 	data := responseStruct{
 		Data: localityCreated,
 	}
@@ -66,9 +66,9 @@ func Test_Functional_Create_Locality(t *testing.T) {
 
 		server := CreateServerLocalityFunctional(db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta(locality.QueryInsert)).ExpectExec().WithArgs(localityToCreate.Id, localityToCreate.Locality_name, localityToCreate.Province_name, localityToCreate.Country_name).WillReturnResult(sqlmock.NewResult(6701, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(locality.QueryInsert)).ExpectExec().WithArgs(localityToCreate.Id, localityToCreate.Locality_name, localityToCreate.Province_id, localityToCreate.Latitude, localityToCreate.Longitude).WillReturnResult(sqlmock.NewResult(6701, 1))
 
-		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -95,7 +95,7 @@ func Test_Functional_Create_Locality(t *testing.T) {
 			Message: "error bad request",
 		}
 
-		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -122,7 +122,7 @@ func Test_Functional_Create_Locality(t *testing.T) {
 			Message: "Key: 'Locality.Id' Error:Field validation for 'Id' failed on the 'required' tag",
 		}
 
-		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -145,14 +145,14 @@ func Test_Functional_Create_Locality(t *testing.T) {
 
 		server := CreateServerLocalityFunctional(db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta(locality.QueryInsert)).ExpectExec().WithArgs(localityToCreate.Id, localityToCreate.Locality_name, localityToCreate.Province_name, localityToCreate.Country_name).WillReturnError(locality.ErrIntern)
+		mock.ExpectPrepare(regexp.QuoteMeta(locality.QueryInsert)).ExpectExec().WithArgs(localityToCreate.Id, localityToCreate.Locality_name, localityToCreate.Province_id, localityToCreate.Latitude, localityToCreate.Longitude).WillReturnError(locality.ErrIntern)
 
 		errResp := errorResponseLocality{
 			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(http.StatusInternalServerError)), " ", "_"),
 			Message: locality.ErrIntern.Error(),
 		}
 
-		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -175,14 +175,14 @@ func Test_Functional_Create_Locality(t *testing.T) {
 
 		server := CreateServerLocalityFunctional(db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta(locality.QueryInsert)).ExpectExec().WithArgs(localityToCreate.Id, localityToCreate.Locality_name, localityToCreate.Province_name, localityToCreate.Country_name).WillReturnError(&mysql.MySQLError{Number: 1062})
+		mock.ExpectPrepare(regexp.QuoteMeta(locality.QueryInsert)).ExpectExec().WithArgs(localityToCreate.Id, localityToCreate.Locality_name, localityToCreate.Province_id, localityToCreate.Latitude, localityToCreate.Longitude).WillReturnError(&mysql.MySQLError{Number: 1062})
 
 		errResp := errorResponseLocality{
 			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(http.StatusConflict)), " ", "_"),
 			Message: locality.ErrDuplicated.Error(),
 		}
 
-		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -212,7 +212,7 @@ func Test_Functional_Create_Locality(t *testing.T) {
 			Message: "internal error",
 		}
 
-		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocalityFunctional(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -32,6 +32,38 @@ func NewServiceMockLocality() *serviceMockLocality {
 }
 
 // All methods simply return the struct's initially defined members
+func (r *serviceMockLocality) GetAll(ctx context.Context) ([]domain.Locality, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Locality), args.Error(1)
+}
+func (r *serviceMockLocality) GetByID(ctx context.Context, id string) (domain.Locality, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.Locality), args.Error(1)
+}
+func (r *serviceMockLocality) Update(ctx context.Context, l domain.Locality) (domain.Locality, error) {
+	args := r.Called(ctx, l)
+	return args.Get(0).(domain.Locality), args.Error(1)
+}
+func (r *serviceMockLocality) Delete(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+func (r *serviceMockLocality) GetWarehouseAll(ctx context.Context) ([]domain.QuantityWarehouseByLocality, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.QuantityWarehouseByLocality), args.Error(1)
+}
+func (r *serviceMockLocality) GetWarehouseByLocality(ctx context.Context, id string) (domain.QuantityWarehouseByLocality, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.QuantityWarehouseByLocality), args.Error(1)
+}
+func (r *serviceMockLocality) GetReportAll(ctx context.Context) ([]domain.LocalityReport, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.LocalityReport), args.Error(1)
+}
+func (r *serviceMockLocality) GetReportByLocality(ctx context.Context, id string) (domain.LocalityReport, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.LocalityReport), args.Error(1)
+}
 func (r *serviceMockLocality) Create(ctx context.Context, l domain.Locality) error {
 	args := r.Called(ctx, l)
 	return args.Error(0)
@@ -44,6 +76,10 @@ func (r *serviceMockLocality) GetSellerByLocality(ctx context.Context, id string
 	args := r.Called(ctx, id)
 	return args.Get(0).(domain.QuantitySellerByLocality), args.Error(1)
 }
+func (r *serviceMockLocality) GetByProvince(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	args := r.Called(ctx, provinceID)
+	return args.Get(0).([]domain.Locality), args.Error(1)
+}
 
 func CreateServerLocality(service locality.Service) *gin.Engine {
 	// instances
@@ -55,9 +91,16 @@ func CreateServerLocality(service locality.Service) *gin.Engine {
 	// -> routes
 	routes := server.Group("/api/v1/localities")
 	{
+		routes.GET("", handler.GetAll())
+		routes.GET("/:id", handler.Get())
 		routes.POST("", handler.Create())
+		routes.PATCH("/:id", handler.Update())
+		routes.DELETE("/:id", handler.Delete())
+		routes.GET("/report", handler.GetReport())
 		routes.GET("/reportSellers", handler.GetQuantitySellerByLocality())
+		routes.GET("/reportWarehouses", handler.GetQuantityWarehouseByLocality())
 	}
+	server.GET("/api/v1/provinces/:id/localities", handler.GetByProvince())
 
 	return server
 }
@@ -77,8 +120,8 @@ func Test_Create_Locality(t *testing.T) {
 	type responseStruct struct {
 		Data domain.Locality `json:"data"`
 	}
-	localityToCreate := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_name: "Buenos Aires", Country_name: "Argentina"}
-	localityCreated := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_name: "Buenos Aires", Country_name: "Argentina"}
+	localityToCreate := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1}
+	localityCreated := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1}
 	data := responseStruct{
 		Data: localityCreated,
 	}
@@ -90,7 +133,7 @@ func Test_Create_Locality(t *testing.T) {
 
 		service.On("Create", mock.Anything, localityToCreate).Return(nil)
 
-		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -114,7 +157,7 @@ func Test_Create_Locality(t *testing.T) {
 			Message: "error bad request",
 		}
 
-		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -138,7 +181,7 @@ func Test_Create_Locality(t *testing.T) {
 			Message: "Key: 'Locality.Id' Error:Field validation for 'Id' failed on the 'required' tag",
 		}
 
-		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -164,7 +207,7 @@ func Test_Create_Locality(t *testing.T) {
 
 		service.On("Create", mock.Anything, localityToCreate).Return(locality.ErrIntern)
 
-		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -190,7 +233,7 @@ func Test_Create_Locality(t *testing.T) {
 
 		service.On("Create", mock.Anything, localityToCreate).Return(locality.ErrDuplicated)
 
-		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -205,6 +248,31 @@ func Test_Create_Locality(t *testing.T) {
 		assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
 	})
 
+	t.Run("province not found error creating", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		errResp := errorResponseLocality{
+			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(http.StatusUnprocessableEntity)), " ", "_"),
+			Message: locality.ErrProvinceNotFound.Error(),
+		}
+
+		service.On("Create", mock.Anything, localityToCreate).Return(locality.ErrProvinceNotFound)
+
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
+
+		// act
+		server.ServeHTTP(response, request)
+		var localityResult errorResponseLocality
+		err := json.Unmarshal(response.Body.Bytes(), &localityResult)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+		assert.Equal(t, errResp, localityResult)
+	})
+
 	t.Run("default error creating", func(t *testing.T) {
 		// arrange
 		service := NewServiceMockLocality()
@@ -216,7 +284,7 @@ func Test_Create_Locality(t *testing.T) {
 
 		service.On("Create", mock.Anything, localityToCreate).Return(locality.ErrLocalityNotFound)
 
-		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_name": "Buenos Aires", "country_name": "Argentina"}`)
+		request, response := NewRequestLocality(http.MethodPost, "/api/v1/localities", `{"id": "6701", "locality_name": "Villa Crespo", "province_id": 1}`)
 
 		// act
 		server.ServeHTTP(response, request)
@@ -369,3 +437,321 @@ func Test_GetQuantitySellerByLocality(t *testing.T) {
 		assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
 	})
 }
+
+func Test_GetLocalitiesByProvince(t *testing.T) {
+	// arrange
+	type responseStruct struct {
+		Data []domain.Locality `json:"data"`
+	}
+
+	t.Run("OK localities of a province", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		resultExpected := []domain.Locality{
+			{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1},
+			{Id: "6702", Locality_name: "Nuñez", Province_id: 1},
+		}
+		service.On("GetByProvince", mock.Anything, 1).Return(resultExpected, nil)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/provinces/1/localities", "")
+
+		// act
+		server.ServeHTTP(response, request)
+		var result responseStruct
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+		assert.Equal(t, responseStruct{Data: resultExpected}, result)
+	})
+
+	t.Run("invalid province id", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/provinces/abc/localities", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("province not found", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		errResp := errorResponseLocality{
+			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(http.StatusNotFound)), " ", "_"),
+			Message: locality.ErrProvinceNotFound.Error(),
+		}
+		service.On("GetByProvince", mock.Anything, 9).Return([]domain.Locality{}, locality.ErrProvinceNotFound)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/provinces/9/localities", "")
+
+		// act
+		server.ServeHTTP(response, request)
+		var result errorResponseLocality
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+		assert.Equal(t, errResp, result)
+	})
+}
+
+func Test_GetLocality(t *testing.T) {
+	t.Run("OK get all", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		expected := []domain.Locality{{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1}}
+		service.On("GetAll", mock.Anything).Return(expected, nil)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":"6701","locality_name":"Villa Crespo","province_id":1}]}`, response.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		service.On("GetByID", mock.Anything, "9999").Return(domain.Locality{}, locality.ErrLocalityNotFound)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/9999", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
+
+func Test_UpdateLocality(t *testing.T) {
+	localityDB := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1}
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		updated := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 2}
+		service.On("GetByID", mock.Anything, "6701").Return(localityDB, nil)
+		service.On("Update", mock.Anything, updated).Return(updated, nil)
+
+		request, response := NewRequestLocality(http.MethodPatch, "/api/v1/localities/6701", `{"province_id": 2}`)
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":"6701","locality_name":"Villa Crespo","province_id":2}}`, response.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("cannot change id", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		service.On("GetByID", mock.Anything, "6701").Return(localityDB, nil)
+
+		request, response := NewRequestLocality(http.MethodPatch, "/api/v1/localities/6701", `{"id": "6702"}`)
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		service.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("province not found error", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		updated := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 9}
+		service.On("GetByID", mock.Anything, "6701").Return(localityDB, nil)
+		service.On("Update", mock.Anything, updated).Return(domain.Locality{}, locality.ErrProvinceNotFound)
+
+		request, response := NewRequestLocality(http.MethodPatch, "/api/v1/localities/6701", `{"province_id": 9}`)
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
+
+func Test_DeleteLocality(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		service.On("Delete", mock.Anything, "6701").Return(nil)
+
+		request, response := NewRequestLocality(http.MethodDelete, "/api/v1/localities/6701", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("referenced locality error", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		errResp := errorResponseLocality{
+			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(http.StatusConflict)), " ", "_"),
+			Message: locality.ErrLocalityInUse.Error(),
+		}
+		service.On("Delete", mock.Anything, "6701").Return(locality.ErrLocalityInUse)
+
+		request, response := NewRequestLocality(http.MethodDelete, "/api/v1/localities/6701", "")
+
+		// act
+		server.ServeHTTP(response, request)
+		var result errorResponseLocality
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Equal(t, errResp, result)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
+
+func Test_GetLocalityReport(t *testing.T) {
+	t.Run("OK report of all localities", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		expected := []domain.LocalityReport{
+			{Locality_id: "6701", Locality_name: "Villa Crespo", Sellers_count: 2, Carries_count: 1, Warehouses_count: 1, Buyers_count: 0},
+		}
+		service.On("GetReportAll", mock.Anything).Return(expected, nil)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/report", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"locality_id":"6701","locality_name":"Villa Crespo","sellers_count":2,"carries_count":1,"warehouses_count":1,"buyers_count":0}]}`, response.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("not found error by locality", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		service.On("GetReportByLocality", mock.Anything, "9999").Return(domain.LocalityReport{}, locality.ErrLocalityNotFound)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/report?id=9999", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("OK report as csv from the accept header", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		service.On("GetReportAll", mock.Anything).Return([]domain.LocalityReport{
+			{Locality_id: "6701", Locality_name: "Villa Crespo", Sellers_count: 2, Carries_count: 1, Warehouses_count: 1, Buyers_count: 0},
+		}, nil)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/report", "")
+		request.Header.Set("Accept", "text/csv")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", response.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="localities_report.csv"`, response.Header().Get("Content-Disposition"))
+		assert.Equal(t, "locality_id,locality_name,sellers_count,carries_count,warehouses_count,buyers_count\n6701,Villa Crespo,2,1,1,0\n", response.Body.String())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/report?format=pdf", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		service.AssertNotCalled(t, "GetReportAll", mock.Anything)
+	})
+}
+
+func Test_GetQuantityWarehouseByLocality(t *testing.T) {
+	t.Run("OK report the number of warehouses of all locations", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		expected := []domain.QuantityWarehouseByLocality{
+			{Locality_id: "6701", Locality_name: "Villa Crespo", Warehouses_count: 2},
+			{Locality_id: "6702", Locality_name: "Nuñez", Warehouses_count: 0},
+		}
+		service.On("GetWarehouseAll", mock.Anything).Return(expected, nil)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/reportWarehouses", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"locality_id":"6701","locality_name":"Villa Crespo","warehouses_count":2},{"locality_id":"6702","locality_name":"Nuñez","warehouses_count":0}]}`, response.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("not found error when obtaining the number of warehouses in a location", func(t *testing.T) {
+		// arrange
+		service := NewServiceMockLocality()
+		server := CreateServerLocality(service)
+		service.On("GetWarehouseByLocality", mock.Anything, "9999").Return(domain.QuantityWarehouseByLocality{}, locality.ErrLocalityNotFound)
+
+		request, response := NewRequestLocality(http.MethodGet, "/api/v1/localities/reportWarehouses?id=9999", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/margin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Margin struct {
+	marginService margin.Service
+}
+
+func NewMargin(marginService margin.Service) *Margin {
+	return &Margin{marginService: marginService}
+}
+
+// @Summary		Gross margin
+// @Tags			Products
+// @Description	Returns the revenue, cost and gross margin of the purchase orders per product, product type or seller, each
+// @Description	order priced with the product record in effect at its date. below_cost flags products whose latest record
+// @Description	sells under the purchase price
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			group_by		query		string	false	"product (default), product_type or seller"
+// @Param			seller_id		query		int		false	"seller id"
+// @Param			product_type_id	query		int		false	"product type id"
+// @Param			from			query		string	false	"first order date, YYYY-MM-DD"
+// @Param			to				query		string	false	"last order date, YYYY-MM-DD"
+// @Param			format			query		string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200				{object}	web.response{data=[]domain.Margin}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Router			/api/v1/products/reportMargins [get]
+func (m *Margin) GetMargins() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		filter := domain.MarginFilter{GroupBy: ctx.Query("group_by")}
+		var err error
+
+		if v := ctx.Query("seller_id"); v != "" {
+			if filter.SellerID, err = strconv.Atoi(v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+				return
+			}
+		}
+		if v := ctx.Query("product_type_id"); v != "" {
+			if filter.ProductTypeID, err = strconv.Atoi(v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+				return
+			}
+		}
+		if filter.From, filter.To, err = dateRange(ctx); err != nil {
+			web.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		margins, err := m.marginService.GetMargins(ctx, filter)
+		if err != nil {
+			switch err {
+			case margin.ErrGroupBy, margin.ErrDateRange:
+				web.Error(ctx, http.StatusBadRequest, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		writeReport(ctx, format, "products_report_margins", margins)
+	}
+}
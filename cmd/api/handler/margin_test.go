@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/margin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockMargin struct {
+	mock.Mock
+}
+
+func (s *serviceMockMargin) GetMargins(ctx context.Context, f domain.MarginFilter) ([]domain.Margin, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.Margin), args.Error(1)
+}
+
+func CreateServerMargin(service margin.Service) *gin.Engine {
+	handler := NewMargin(service)
+
+	server := gin.Default()
+	server.GET("/api/v1/products/reportMargins", handler.GetMargins())
+
+	return server
+}
+
+func Test_Margin_GetMargins(t *testing.T) {
+	t.Run("OK with filters", func(t *testing.T) {
+		// arrange
+		margins := []domain.Margin{{ID: 9, UnitsSold: 4, Revenue: 35, Cost: 26, GrossMargin: 9, MarginPercent: 25.71, BelowCost: true, ProductsBelowCost: 1}}
+		service := &serviceMockMargin{}
+		service.On("GetMargins", mock.Anything, domain.MarginFilter{GroupBy: "seller", SellerID: 9, ProductTypeID: 2, From: "2023-01-01", To: "2023-03-31"}).Return(margins, nil)
+		server := CreateServerMargin(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/products/reportMargins?group_by=seller&seller_id=9&product_type_id=2&from=2023-01-01&to=2023-03-31", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data []domain.Margin `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, margins, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name   string
+		query  string
+		status int
+		err    error
+	}{
+		{"invalid seller id", "?seller_id=abc", http.StatusBadRequest, ErrInvalidId},
+		{"invalid product type id", "?product_type_id=abc", http.StatusBadRequest, ErrInvalidId},
+		{"invalid date", "?to=2023-13-01", http.StatusBadRequest, ErrInvalidDate},
+		{"invalid group by", "?group_by=warehouse", http.StatusBadRequest, margin.ErrGroupBy},
+		{"from after to", "?from=2023-02-01&to=2023-01-01", http.StatusBadRequest, margin.ErrDateRange},
+		{"internal error", "", http.StatusInternalServerError, margin.ErrInternal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			service := &serviceMockMargin{}
+			service.On("GetMargins", mock.Anything, mock.Anything).Return([]domain.Margin{}, c.err)
+			server := CreateServerMargin(service)
+			req, res := NewRequestLocality(http.MethodGet, "/api/v1/products/reportMargins"+c.query, "")
+
+			// act
+			server.ServeHTTP(res, req)
+
+			// assert
+			var body errorResponse
+			assert.Equal(t, c.status, res.Code)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, c.err.Error(), body.Message)
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/go-playground/validator"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
 )
 
@@ -32,15 +34,26 @@ var (
 
 // @summary		List products
 // @tags			Products
-// @Description	Returns a list of all products
+// @Description	Returns a list of all products, soft deleted ones only with include_deleted=true
 // @Produce		json
-// @Success		200	{object}	web.response{data=[]domain.Product}
-// @Failure		500	{object}	web.errorResponse
+// @Param			include_deleted	query		bool	false	"include soft deleted products"
+// @Success		200				{object}	web.response{data=[]domain.Product}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Failure		403				{object}	web.errorResponse
 // @Router			/api/v1/products [get]
 func (p *Product) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		include, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
 		// get and return all products
-		products, err := p.productService.GetAll(c)
+		getAll := p.productService.GetAll
+		if include {
+			getAll = p.productService.GetAllWithDeleted
+		}
+		products, err := getAll(c)
 		if err != nil {
 			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
 			return
@@ -162,6 +175,9 @@ func (p *Product) Update() gin.HandlerFunc {
 			// this error occurs when a new product code is provided but is already in use
 			web.Error(c, http.StatusBadRequest, err.Error())
 			return
+		case etag.ErrPreconditionFailed:
+			web.Error(c, http.StatusPreconditionFailed, err.Error())
+			return
 		case product.ErrDatabase:
 			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
 			return
@@ -172,7 +188,7 @@ func (p *Product) Update() gin.HandlerFunc {
 
 // @summary		Delete product
 // @tags			Products
-// @Description	Deletes the product specified by URL id parameter.
+// @Description	Soft deletes the product specified by URL id parameter, it can be restored later.
 // @Param			id	path	int	true	"Product ID"
 // @Produce		json
 // @Success		204	{object}	web.response
@@ -195,6 +211,9 @@ func (p *Product) Delete() gin.HandlerFunc {
 		case product.ErrNotFound:
 			web.Error(c, http.StatusNotFound, err.Error())
 			return
+		case etag.ErrPreconditionFailed:
+			web.Error(c, http.StatusPreconditionFailed, err.Error())
+			return
 		case product.ErrDatabase:
 			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
 			return
@@ -203,6 +222,81 @@ func (p *Product) Delete() gin.HandlerFunc {
 	}
 }
 
+// @summary		Restore product
+// @tags			Products
+// @Description	Restores a soft deleted product specified by its ID passed as a URL parameter
+// @Param			id	path	int	true	"Product ID"
+// @Produce		json
+// @Success		200	{object}	web.response{data=domain.Product}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/products/{id}/restore [post]
+func (p *Product) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// get id param from URL, must be integer
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+		// only a soft deleted product can be restored
+		prod, err := p.productService.Restore(c, id)
+		switch err {
+		case nil:
+		case product.ErrNotFound:
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		case softdelete.ErrNotDeleted:
+			web.Error(c, http.StatusConflict, "product is not deleted")
+			return
+		default:
+			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
+			return
+		}
+		web.Success(c, http.StatusOK, prod)
+	}
+}
+
+// @summary		Hard delete product
+// @tags			Products
+// @Description	Removes a product for good, refused while batches or records reference it
+// @Param			id	path	int	true	"Product ID"
+// @Success		204	{object}	web.response
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/products/{id}/hard [delete]
+func (p *Product) HardDelete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// get id param from URL, must be integer
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+		// the conflict message names the rows still referencing the product
+		err = p.productService.HardDelete(c, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, softdelete.ErrHasDependents):
+			web.Error(c, http.StatusConflict, "cannot delete product, "+err.Error())
+			return
+		case err == product.ErrNotFound:
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		default:
+			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
+			return
+		}
+		web.Success(c, http.StatusNoContent, gin.H{})
+	}
+}
+
 // @summary		Create product type
 // @tags			Products
 // @Description	Given a name, creates a product type with that name
@@ -210,6 +304,7 @@ func (p *Product) Delete() gin.HandlerFunc {
 // @Produce		json
 // @Param			request	body		domain.ProductTypeRequest	true	"Product type"
 // @Success		201		{object}	web.response{data=domain.ProductType}
+// @Failure		409		{object}	web.errorResponse
 // @Failure		422		{object}	web.errorResponse
 // @Failure		500		{object}	web.errorResponse
 // @Router			/api/v1/products/type [post]
@@ -228,8 +323,13 @@ func (p *Product) CreateType() gin.HandlerFunc {
 			return
 		}
 
-		id, err := p.productService.CreateType(context.Background(), req.Name)
+		id, err := p.productService.CreateType(c, req.Name)
 		if err != nil {
+			// return 409 if a product type with that name already exists
+			if err == product.ErrTypeName {
+				web.Error(c, http.StatusConflict, err.Error())
+				return
+			}
 			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
 			return
 		}
@@ -242,7 +342,8 @@ func (p *Product) CreateType() gin.HandlerFunc {
 // @tags			Products
 // @Description	Given a product id as a query, it will return the amount of product records for that given product. If given no id, it will return the amount of product records for all products.
 // @Param			id	query	int	false	"Product ID"
-// @Produce		json
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
 // @Success		200	{object}	web.response{data=[]domain.Report}
 // @Failure		400	{object}	web.errorResponse
 // @Failure		404	{object}	web.errorResponse
@@ -250,20 +351,25 @@ func (p *Product) CreateType() gin.HandlerFunc {
 // @Router			/api/v1/products/reportRecords [get]
 func (p *Product) GetReport() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// csv or xlsx may be requested instead of json
+		format, ok := reportFormat(c)
+		if !ok {
+			return
+		}
 		// get query parameter "id"; if it exists, ok will be true, otherwise ok will be false
 		idQuery, ok := c.GetQuery("id")
 		// dispatch appropriate handler function
 		if ok {
-			p.getOneReport(c, idQuery)
+			p.getOneReport(c, format, idQuery)
 		} else {
-			p.getAllReports(c)
+			p.getAllReports(c, format)
 		}
 	}
 }
 
 // returns the number of product records associated with a single product_id passed by query
 // as well as the product's description and ID
-func (p *Product) getOneReport(c *gin.Context, idQuery string) {
+func (p *Product) getOneReport(c *gin.Context, format, idQuery string) {
 	// converts id parameter from string to int, returns with error status 400 on failure
 	id, err := strconv.Atoi(idQuery)
 	if err != nil {
@@ -286,11 +392,11 @@ func (p *Product) getOneReport(c *gin.Context, idQuery string) {
 		return
 	}
 
-	web.Success(c, http.StatusOK, report)
+	writeReport(c, format, "products_report_records", report)
 }
 
 // returns the count of records and the product description and ID for every product in the database
-func (p *Product) getAllReports(c *gin.Context) {
+func (p *Product) getAllReports(c *gin.Context, format string) {
 	// fetches the report, returns with status error 500 on failure
 	report, err := p.productService.GetAllReports(context.Background())
 	if err != nil {
@@ -298,5 +404,5 @@ func (p *Product) getAllReports(c *gin.Context) {
 		return
 	}
 
-	web.Success(c, http.StatusOK, report)
+	writeReport(c, format, "products_report_records", report)
 }
@@ -89,6 +89,9 @@ func (s *ProductBatches) Create() gin.HandlerFunc {
 			case product_batches.ErrSectionNotFound:
 				web.Error(ctx, http.StatusConflict, err.Error())
 				return
+			case product_batches.ErrCapacityExceeded:
+				web.Error(ctx, http.StatusConflict, err.Error())
+				return
 			default:
 				web.Error(ctx, http.StatusInternalServerError, err.Error())
 				return
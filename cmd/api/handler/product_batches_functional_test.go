@@ -11,6 +11,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_batches"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,8 +47,19 @@ func Test_Create_Product_Batches_Functional(t *testing.T) {
 		defer db.Close()
 		server := createServerProductBatches(db)
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT current_capacity, maximum_capacity FROM sections WHERE id=? FOR UPDATE;")).WithArgs(3).
+			WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(0, 100))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE sections SET current_capacity=current_capacity+?, version=version+1 WHERE id=?;")).WithArgs(50, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"id", "batch_number"}).AddRow(1, 10))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.ProductBatchCreated, "products_batches", "1", `{"batch_number":10,"id":1}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		// act
 		request, response := createRequestProductBatches(http.MethodPost, "/api/v1/productBatches/", `{"batch_number": 10, "current_quantity": 50, "current_temperature": 15, "due_date": "2023-02-01", "initial_quantity": 50, "manufacturing_date": "2023-01-01", "manufacturing_hour": "13:01:06", "minumum_temperature": 5, "product_id": 1, "section_id": 3}`)
@@ -160,8 +172,10 @@ func Test_Create_Product_Batches_Functional(t *testing.T) {
 		defer db.Close()
 		server := createServerProductBatches(db)
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1062})
+		mock.ExpectRollback()
 
 		// act
 		request, response := createRequestProductBatches(http.MethodPost, "/api/v1/productBatches/", `{"batch_number": 1, "current_quantity": 50, "current_temperature": 15, "due_date": "2023-02-01", "initial_quantity": 50, "manufacturing_date": "2023-01-01", "manufacturing_hour": "13:01:06", "minumum_temperature": 5, "product_id": 1, "section_id": 3}`)
@@ -179,8 +193,10 @@ func Test_Create_Product_Batches_Functional(t *testing.T) {
 		defer db.Close()
 		server := createServerProductBatches(db)
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1452, Message: "`products`"})
+		mock.ExpectRollback()
 
 		// act
 		request, response := createRequestProductBatches(http.MethodPost, "/api/v1/productBatches/", `{"batch_number": 4, "current_quantity": 50, "current_temperature": 15, "due_date": "2023-02-01", "initial_quantity": 50, "manufacturing_date": "2023-01-01", "manufacturing_hour": "13:01:06", "minumum_temperature": 5, "product_id": 999, "section_id": 3}`)
@@ -198,8 +214,10 @@ func Test_Create_Product_Batches_Functional(t *testing.T) {
 		defer db.Close()
 		server := createServerProductBatches(db)
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1452, Message: "`sections`"})
+		mock.ExpectRollback()
 
 		// act
 		request, response := createRequestProductBatches(http.MethodPost, "/api/v1/productBatches/", `{"batch_number": 5, "current_quantity": 50, "current_temperature": 15, "due_date": "2023-02-01", "initial_quantity": 50, "manufacturing_date": "2023-01-01", "manufacturing_hour": "13:01:06", "minumum_temperature": 5, "product_id": 1, "section_id": 999}`)
@@ -217,8 +235,10 @@ func Test_Create_Product_Batches_Functional(t *testing.T) {
 		defer db.Close()
 		server := createServerProductBatches(db)
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{})
+		mock.ExpectRollback()
 
 		// act
 		request, response := createRequestProductBatches(http.MethodPost, "/api/v1/productBatches/", `{"batch_number": 20, "current_quantity": 50, "current_temperature": 15, "due_date": "2023-02-01", "initial_quantity": 50, "manufacturing_date": "2023-01-01", "manufacturing_hour": "13:01:06", "minumum_temperature": 5, "product_id": 1, "section_id": 3}`)
@@ -30,6 +30,11 @@ func (r *serviceProductBatchesTest) Create(ctx context.Context, productBatches d
 	return args.Get(0).(domain.ProductBatches), args.Error(1)
 }
 
+func (r *serviceProductBatchesTest) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error) {
+	args := r.Called(ctx, productIDs)
+	return args.Get(0).([]domain.ProductBatches), args.Error(1)
+}
+
 func createServerProductBatchesUnit(service *serviceProductBatchesTest) *gin.Engine {
 	handler := NewProductBatches(service)
 	eng := gin.Default()
@@ -209,6 +214,21 @@ func Test_Create_Product_Batches_Unit(t *testing.T) {
 		assert.Equal(t, response.Header().Get("Content-Type"), "application/json; charset=utf-8")
 	})
 
+	t.Run("Validate Section capacity exceeded", func(t *testing.T) {
+		// arrange
+		service := NewServiceTestProductBatches()
+		service.On("Create", mock.Anything, productBatch).Return(domain.ProductBatches{}, product_batches.ErrCapacityExceeded)
+		server := createServerProductBatchesUnit(service)
+
+		// act
+		request, response := createRequestProductBatchesUnit(http.MethodPost, "/api/v1/productBatches/", `{"batch_number": 1234, "current_quantity": 10, "current_temperature": 10, "due_date": "2023-02-01", "initial_quantity": 5, "manufacturing_date": "2023-01-01", "manufacturing_hour": "13:01:06", "minumum_temperature": 5, "product_id": 1, "section_id": 1}`)
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Equal(t, response.Header().Get("Content-Type"), "application/json; charset=utf-8")
+	})
+
 	t.Run("Validate Default error", func(t *testing.T) {
 		// arrange
 		service := NewServiceTestProductBatches()
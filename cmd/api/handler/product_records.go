@@ -60,7 +60,7 @@ func (pr *ProductRecords) Create() gin.HandlerFunc {
 		}
 
 		// attempts to create the product in the database, returns with error status 500 if it fails
-		productRecord, err := pr.productRecordsService.Create(context.Background(), productRecordToCreate)
+		productRecord, err := pr.productRecordsService.Create(c, productRecordToCreate)
 		if err != nil {
 			web.Error(c, http.StatusInternalServerError, ErrInternal.Error())
 			return
@@ -36,6 +36,9 @@ func (s stubProductService) GetAll(ctx context.Context) ([]domain.Product, error
 func (s stubProductService) GetByID(ctx context.Context, id int) (domain.Product, error) {
 	return s.Product, s.Err
 }
+func (s stubProductService) GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error) {
+	return s.Products, s.Err
+}
 func (s stubProductService) Create(ctx context.Context, p domain.Product) (domain.Product, error) {
 	return s.Product, s.Err
 }
@@ -45,6 +48,15 @@ func (s stubProductService) Update(ctx context.Context, p domain.Product) (domai
 func (s stubProductService) Delete(ctx context.Context, id int) error {
 	return s.Err
 }
+func (s stubProductService) GetAllWithDeleted(ctx context.Context) ([]domain.Product, error) {
+	return s.Products, s.Err
+}
+func (s stubProductService) Restore(ctx context.Context, id int) (domain.Product, error) {
+	return s.Product, s.Err
+}
+func (s stubProductService) HardDelete(ctx context.Context, id int) error {
+	return s.Err
+}
 func (s stubProductService) ValidateProductID(ctx context.Context, pid int) bool {
 	return s.Valid
 }
@@ -81,7 +93,7 @@ func createTestProductHandler(stub stubProductService) Product {
 func createTestGinContextAndRecorder(method string) (*httptest.ResponseRecorder, *gin.Context) {
 	rr := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(rr)
-	c.Request = &http.Request{Method: method, Header: make(http.Header)}
+	c.Request = &http.Request{Method: method, Header: make(http.Header), URL: &url.URL{}}
 	return rr, c
 }
 func mockRequestBody(c *gin.Context, contents interface{}) {
@@ -689,6 +701,32 @@ func TestProductCreateType_InternalServerError(t *testing.T) {
 	assert.Equal(t, expectedRes, res)
 }
 
+func TestProductCreateType_Conflict(t *testing.T) {
+	// Arrange
+	expectedRes := errorResponse{
+		Code:    "conflict",
+		Message: product.ErrTypeName.Error(),
+	}
+
+	rr, c := createTestGinContextAndRecorder("POST")
+	mockRequestBody(c, domain.ProductTypeRequest{Name: "pepe"})
+
+	handler := createTestProductHandler(stubProductService{
+		Err: product.ErrTypeName,
+	})
+
+	// Act
+	handler.CreateType()(c)
+
+	var res errorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &res)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, expectedRes, res)
+}
+
 func TestProductGetAllReports_Ok(t *testing.T) {
 	// Arrange
 	expected := []domain.Report{
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_type"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type ProductType struct {
+	productTypeService product_type.Service
+}
+
+func NewProductType(productTypeService product_type.Service) *ProductType {
+	return &ProductType{productTypeService: productTypeService}
+}
+
+// @Summary		List product types
+// @Tags			ProductTypes
+// @Description	Returns a list of all product types
+// @Produce		json
+// @Success		200	{object}	web.response{data=[]domain.ProductType}
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/productTypes [get]
+func (pt *ProductType) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		types, err := pt.productTypeService.GetAll(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, types)
+	}
+}
+
+// @Summary		Product type by id
+// @Tags			ProductTypes
+// @Description	Get product type by id
+// @Produce		json
+// @Param			id	path		int	true	"product type id"
+// @Success		200	{object}	web.response{data=domain.ProductType}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/productTypes/{id} [get]
+func (pt *ProductType) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := pt.productTypeService.GetByID(ctx, id)
+		if err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
+// @Summary		Create product type
+// @Tags			ProductTypes
+// @Description	Create product type. Names must be unique.
+// @Accept			json
+// @Produce		json
+// @Param			request	body		domain.ProductTypeRequest	true	"Product type parameters"
+// @Success		201		{object}	web.response{data=domain.ProductType}
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/productTypes [post]
+func (pt *ProductType) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request domain.ProductTypeRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		created, err := pt.productTypeService.Create(ctx, domain.ProductType{Name: request.Name})
+		if err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusCreated, created)
+	}
+}
+
+// @Summary		Update product type
+// @Tags			ProductTypes
+// @Description	Rename product type
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"product type id"
+// @Param			request	body		domain.ProductTypeRequest	true	"Product type parameters"
+// @Success		200		{object}	web.response{data=domain.ProductType}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/productTypes/{id} [patch]
+func (pt *ProductType) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		if _, err := pt.productTypeService.GetByID(ctx, id); err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		var request domain.ProductTypeRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrBadRequest.Error())
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		updated, err := pt.productTypeService.Update(ctx, domain.ProductType{ID: id, Name: request.Name})
+		if err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, updated)
+	}
+}
+
+// @Summary		Delete product type
+// @Tags			ProductTypes
+// @Description	Delete product type. Types referenced by products or sections cannot be deleted.
+// @Param			id	path		int	true	"product type id"
+// @Success		204	{object}	web.response
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/productTypes/{id} [delete]
+func (pt *ProductType) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		if err := pt.productTypeService.Delete(ctx, id); err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
+
+// @Summary		Products by product type
+// @Tags			ProductTypes
+// @Description	Returns the products of a product type
+// @Produce		json
+// @Param			id	path		int	true	"product type id"
+// @Success		200	{object}	web.response{data=[]domain.Product}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/productTypes/{id}/products [get]
+func (pt *ProductType) GetProducts() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		products, err := pt.productTypeService.GetProducts(ctx, id)
+		if err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, products)
+	}
+}
+
+// @Summary		Sections by product type
+// @Tags			ProductTypes
+// @Description	Returns the sections that store a product type
+// @Produce		json
+// @Param			id	path		int	true	"product type id"
+// @Success		200	{object}	web.response{data=[]domain.Section}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/productTypes/{id}/sections [get]
+func (pt *ProductType) GetSections() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		sections, err := pt.productTypeService.GetSections(ctx, id)
+		if err != nil {
+			pt.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, sections)
+	}
+}
+
+// maps product type errors to their http status
+func (pt *ProductType) writeError(ctx *gin.Context, err error) {
+	switch err {
+	case product_type.ErrNotFound:
+		web.Error(ctx, http.StatusNotFound, err.Error())
+	case product_type.ErrDuplicated, product_type.ErrHasProducts, product_type.ErrHasSections, product_type.ErrInUse:
+		web.Error(ctx, http.StatusConflict, err.Error())
+	case etag.ErrPreconditionFailed:
+		web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+	default:
+		web.Error(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
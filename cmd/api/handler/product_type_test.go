@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockProductType struct {
+	mock.Mock
+}
+
+func (s *serviceMockProductType) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.ProductType), args.Error(1)
+}
+func (s *serviceMockProductType) GetByID(ctx context.Context, id int) (domain.ProductType, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.ProductType), args.Error(1)
+}
+func (s *serviceMockProductType) Create(ctx context.Context, pt domain.ProductType) (domain.ProductType, error) {
+	args := s.Called(ctx, pt)
+	return args.Get(0).(domain.ProductType), args.Error(1)
+}
+func (s *serviceMockProductType) Update(ctx context.Context, pt domain.ProductType) (domain.ProductType, error) {
+	args := s.Called(ctx, pt)
+	return args.Get(0).(domain.ProductType), args.Error(1)
+}
+func (s *serviceMockProductType) Delete(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+func (s *serviceMockProductType) GetProducts(ctx context.Context, id int) ([]domain.Product, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+func (s *serviceMockProductType) GetSections(ctx context.Context, id int) ([]domain.Section, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+
+func CreateServerProductType(service product_type.Service) *gin.Engine {
+	handler := NewProductType(service)
+
+	server := gin.Default()
+	routes := server.Group("/api/v1/productTypes")
+	{
+		routes.GET("", handler.GetAll())
+		routes.GET("/:id", handler.Get())
+		routes.POST("", handler.Create())
+		routes.PATCH("/:id", handler.Update())
+		routes.DELETE("/:id", handler.Delete())
+		routes.GET("/:id/products", handler.GetProducts())
+		routes.GET("/:id/sections", handler.GetSections())
+	}
+
+	return server
+}
+
+func Test_ProductType(t *testing.T) {
+	t.Run("create ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("Create", mock.Anything, domain.ProductType{Name: "frozen"}).Return(domain.ProductType{ID: 1, Name: "frozen"}, nil)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/productTypes", `{"name":"frozen"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"name":"frozen"}}`, res.Body.String())
+	})
+
+	t.Run("create duplicated", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("Create", mock.Anything, domain.ProductType{Name: "frozen"}).Return(domain.ProductType{}, product_type.ErrDuplicated)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/productTypes", `{"name":"frozen"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("create without name", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/productTypes", `{}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+
+	t.Run("update ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("GetByID", mock.Anything, 1).Return(domain.ProductType{ID: 1, Name: "frozen"}, nil)
+		service.On("Update", mock.Anything, domain.ProductType{ID: 1, Name: "chilled"}).Return(domain.ProductType{ID: 1, Name: "chilled"}, nil)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodPatch, "/api/v1/productTypes/1", `{"name":"chilled"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"name":"chilled"}}`, res.Body.String())
+	})
+
+	t.Run("update not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("GetByID", mock.Anything, 9).Return(domain.ProductType{}, product_type.ErrNotFound)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodPatch, "/api/v1/productTypes/9", `{"name":"chilled"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("delete referenced", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("Delete", mock.Anything, 1).Return(product_type.ErrHasSections)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodDelete, "/api/v1/productTypes/1", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"product type is referenced by sections"}`, res.Body.String())
+	})
+
+	t.Run("delete ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("Delete", mock.Anything, 1).Return(nil)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodDelete, "/api/v1/productTypes/1", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("sections ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("GetSections", mock.Anything, 1).Return([]domain.Section{{ID: 2, SectionNumber: 20, ProductTypeID: 1}}, nil)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/productTypes/1/sections", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":[{"section_id":2,"section_number":20,"current_temperature":0,"minimum_temperature":0,"current_capacity":0,"minimum_capacity":0,"maximum_capacity":0,"warehouse_id":0,"product_type_id":1}]}`, res.Body.String())
+	})
+
+	t.Run("products not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProductType{}
+		service.On("GetProducts", mock.Anything, 9).Return([]domain.Product(nil), product_type.ErrNotFound)
+		server := CreateServerProductType(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/productTypes/9/products", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/province"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Province struct {
+	provinceService province.Service
+}
+
+func NewProvince(provinceService province.Service) *Province {
+	return &Province{provinceService: provinceService}
+}
+
+// @Summary		List provinces
+// @Tags			Provinces
+// @Description	Returns a list of all provinces
+// @Produce		json
+// @Success		200	{object}	web.response{data=[]domain.Province}
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/provinces [get]
+func (p *Province) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		provinces, err := p.provinceService.GetAll(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, provinces)
+	}
+}
+
+// @Summary		Province by id
+// @Tags			Provinces
+// @Description	Get province by id
+// @Produce		json
+// @Param			id	path		int	true	"province id"
+// @Success		200	{object}	web.response{data=domain.Province}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/provinces/{id} [get]
+func (p *Province) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := p.provinceService.GetByID(ctx, id)
+		if err != nil {
+			switch err {
+			case province.ErrNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
+// @Summary		Provinces by country
+// @Tags			Countries
+// @Description	Returns the provinces of a country
+// @Produce		json
+// @Param			id	path		int	true	"country id"
+// @Success		200	{object}	web.response{data=[]domain.Province}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/countries/{id}/provinces [get]
+func (p *Province) GetByCountry() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		provinces, err := p.provinceService.GetByCountry(ctx, id)
+		if err != nil {
+			switch err {
+			case province.ErrCountryNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, provinces)
+	}
+}
+
+// @Summary		Create province
+// @Tags			Provinces
+// @Description	Create province
+// @Accept			json
+// @Produce		json
+// @Param			request	body		domain.Province	true	"Province parameters"
+// @Success		201		{object}	web.response{data=domain.Province}
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/provinces [post]
+func (p *Province) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request domain.Province
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+
+		// If the JSON object does not contain the necessary fields, a 422 code will be returned.
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		created, err := p.provinceService.Create(ctx, request)
+		if err != nil {
+			switch err {
+			case province.ErrDuplicated, province.ErrCountryNotFound:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusCreated, created)
+	}
+}
+
+// @Summary		Update province
+// @Tags			Provinces
+// @Description	Update province
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int				true	"province id"
+// @Param			request	body		domain.Province	true	"Province parameters"
+// @Success		200		{object}	web.response{data=domain.Province}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/provinces/{id} [patch]
+func (p *Province) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		provinceDB, err := p.provinceService.GetByID(ctx, id)
+		if err != nil {
+			web.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+
+		// decode and update fetched province with fields decoded from request body
+		if err := json.NewDecoder(ctx.Request.Body).Decode(&provinceDB); err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrBadRequest.Error())
+			return
+		}
+
+		// new id should not be specified in request body, i.e. it should not change
+		if provinceDB.Id != id {
+			web.Error(ctx, http.StatusBadRequest, "cannot update province id")
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&provinceDB); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		updated, err := p.provinceService.Update(ctx, provinceDB)
+		if err != nil {
+			switch err {
+			case province.ErrDuplicated, province.ErrCountryNotFound:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, updated)
+	}
+}
+
+// @Summary		Delete province
+// @Tags			Provinces
+// @Description	Delete province. Provinces referenced by localities cannot be deleted.
+// @Param			id	path		int	true	"province id"
+// @Success		204	{object}	web.response
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/provinces/{id} [delete]
+func (p *Province) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		err = p.provinceService.Delete(ctx, id)
+		if err != nil {
+			switch err {
+			case province.ErrNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			case province.ErrHasLocalities:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/province"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockProvince struct {
+	mock.Mock
+}
+
+func (s *serviceMockProvince) GetAll(ctx context.Context) ([]domain.Province, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.Province), args.Error(1)
+}
+func (s *serviceMockProvince) GetByID(ctx context.Context, id int) (domain.Province, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Province), args.Error(1)
+}
+func (s *serviceMockProvince) GetByCountry(ctx context.Context, countryID int) ([]domain.Province, error) {
+	args := s.Called(ctx, countryID)
+	return args.Get(0).([]domain.Province), args.Error(1)
+}
+func (s *serviceMockProvince) Create(ctx context.Context, p domain.Province) (domain.Province, error) {
+	args := s.Called(ctx, p)
+	return args.Get(0).(domain.Province), args.Error(1)
+}
+func (s *serviceMockProvince) Update(ctx context.Context, p domain.Province) (domain.Province, error) {
+	args := s.Called(ctx, p)
+	return args.Get(0).(domain.Province), args.Error(1)
+}
+func (s *serviceMockProvince) Delete(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+
+func CreateServerProvince(service province.Service) *gin.Engine {
+	handler := NewProvince(service)
+
+	server := gin.Default()
+	routes := server.Group("/api/v1/provinces")
+	{
+		routes.GET("", handler.GetAll())
+		routes.GET("/:id", handler.Get())
+		routes.POST("", handler.Create())
+		routes.PATCH("/:id", handler.Update())
+		routes.DELETE("/:id", handler.Delete())
+	}
+	server.GET("/api/v1/countries/:id/provinces", handler.GetByCountry())
+
+	return server
+}
+
+func Test_Province(t *testing.T) {
+	t.Run("get by country ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProvince{}
+		service.On("GetByCountry", mock.Anything, 1).Return([]domain.Province{{Id: 1, Province_name: "Buenos Aires", Country_id: 1}}, nil)
+		server := CreateServerProvince(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/countries/1/provinces", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"province_name":"Buenos Aires","country_id":1}]}`, res.Body.String())
+	})
+
+	t.Run("get by country not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProvince{}
+		service.On("GetByCountry", mock.Anything, 9).Return([]domain.Province(nil), province.ErrCountryNotFound)
+		server := CreateServerProvince(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/countries/9/provinces", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("create with unknown country", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProvince{}
+		toCreate := domain.Province{Province_name: "Buenos Aires", Country_id: 9}
+		service.On("Create", mock.Anything, toCreate).Return(domain.Province{}, province.ErrCountryNotFound)
+		server := CreateServerProvince(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/provinces", `{"province_name":"Buenos Aires","country_id":9}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("delete referenced by localities", func(t *testing.T) {
+		// arrange
+		service := &serviceMockProvince{}
+		service.On("Delete", mock.Anything, 1).Return(province.ErrHasLocalities)
+		server := CreateServerProvince(service)
+		req, res := NewRequestLocality(http.MethodDelete, "/api/v1/provinces/1", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/replenishment"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+var ErrInvalidDays = errors.New("days and cover_days must be positive integers")
+
+type Replenishment struct {
+	replenishmentService replenishment.Service
+}
+
+func NewReplenishment(replenishmentService replenishment.Service) *Replenishment {
+	return &Replenishment{replenishmentService: replenishmentService}
+}
+
+// @Summary		List reorder points
+// @Tags			Warehouses
+// @Description	Returns the reorder points of the products of a warehouse
+// @Produce		json
+// @Param			id	path		int	true	"warehouse id"
+// @Success		200	{object}	web.response{data=[]domain.ReorderPoint}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/warehouses/{id}/reorderPoints [get]
+func (rp *Replenishment) GetReorderPoints() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		points, err := rp.replenishmentService.GetReorderPoints(ctx, id)
+		if err != nil {
+			rp.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, points)
+	}
+}
+
+// @Summary		Set reorder point
+// @Tags			Warehouses
+// @Description	Sets the reorder point and safety stock of a product in a warehouse, replacing the previous ones
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int					true	"warehouse id"
+// @Param			product_id	path		int					true	"product id"
+// @Param			request		body		domain.ReorderPoint	true	"reorder_point and safety_stock, at most the reorder point"
+// @Success		200			{object}	web.response{data=domain.ReorderPoint}
+// @Failure		400			{object}	web.errorResponse
+// @Failure		404			{object}	web.errorResponse
+// @Failure		422			{object}	web.errorResponse
+// @Failure		500			{object}	web.errorResponse
+// @Router			/api/v1/warehouses/{id}/reorderPoints/{product_id} [put]
+func (rp *Replenishment) SaveReorderPoint() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		warehouseID, errWarehouse := strconv.Atoi(ctx.Param("id"))
+		productID, errProduct := strconv.Atoi(ctx.Param("product_id"))
+		if errWarehouse != nil || errProduct != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		var request domain.ReorderPoint
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		request.ID, request.WarehouseID, request.ProductID = 0, warehouseID, productID
+
+		saved, err := rp.replenishmentService.SaveReorderPoint(ctx, request)
+		if err != nil {
+			rp.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, saved)
+	}
+}
+
+// @Summary		Delete reorder point
+// @Tags			Warehouses
+// @Description	Removes the reorder point of a product in a warehouse
+// @Param			id			path		int	true	"warehouse id"
+// @Param			product_id	path		int	true	"product id"
+// @Success		204
+// @Failure		400			{object}	web.errorResponse
+// @Failure		404			{object}	web.errorResponse
+// @Failure		500			{object}	web.errorResponse
+// @Router			/api/v1/warehouses/{id}/reorderPoints/{product_id} [delete]
+func (rp *Replenishment) DeleteReorderPoint() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		warehouseID, errWarehouse := strconv.Atoi(ctx.Param("id"))
+		productID, errProduct := strconv.Atoi(ctx.Param("product_id"))
+		if errWarehouse != nil || errProduct != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		if err := rp.replenishmentService.DeleteReorderPoint(ctx, warehouseID, productID); err != nil {
+			rp.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
+
+// @Summary		Replenishment suggestions
+// @Tags			Warehouses
+// @Description	Returns the products whose unexpired stock in a warehouse is below their reorder point, with the seller
+// @Description	supplying them and how much to order to cover the recent consumption on top of the safety stock
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			warehouse_id	query		int		false	"warehouse id, every warehouse when omitted"
+// @Param			days			query		int		false	"days the consumption is averaged over, 30 by default"
+// @Param			cover_days		query		int		false	"days of consumption an order covers, 14 by default"
+// @Param			format			query		string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200				{object}	web.response{data=[]domain.ReplenishmentSuggestion}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		404				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Router			/api/v1/warehouses/reportReplenishment [get]
+func (rp *Replenishment) GetSuggestions() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
+		var filter domain.ReplenishmentFilter
+		var err error
+
+		if v := ctx.Query("warehouse_id"); v != "" {
+			if filter.WarehouseID, err = strconv.Atoi(v); err != nil {
+				web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+				return
+			}
+		}
+		days := map[string]*int{"days": &filter.Days, "cover_days": &filter.CoverDays}
+		for name, value := range days {
+			if v := ctx.Query(name); v != "" {
+				if *value, err = strconv.Atoi(v); err != nil || *value <= 0 {
+					web.Error(ctx, http.StatusBadRequest, ErrInvalidDays.Error())
+					return
+				}
+			}
+		}
+
+		suggestions, err := rp.replenishmentService.GetSuggestions(ctx, filter)
+		if err != nil {
+			rp.writeError(ctx, err)
+			return
+		}
+
+		writeReport(ctx, format, "warehouses_report_replenishment", suggestions)
+	}
+}
+
+func (rp *Replenishment) writeError(ctx *gin.Context, err error) {
+	switch err {
+	case replenishment.ErrDays:
+		web.Error(ctx, http.StatusBadRequest, err.Error())
+	case replenishment.ErrSafetyStock:
+		web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+	case replenishment.ErrNotFound, replenishment.ErrWarehouseNotFound, replenishment.ErrProductNotFound:
+		web.Error(ctx, http.StatusNotFound, err.Error())
+	default:
+		web.Error(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
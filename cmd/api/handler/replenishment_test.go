@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/replenishment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockReplenishment struct {
+	mock.Mock
+}
+
+func (s *serviceMockReplenishment) GetReorderPoints(ctx context.Context, warehouseID int) ([]domain.ReorderPoint, error) {
+	args := s.Called(ctx, warehouseID)
+	return args.Get(0).([]domain.ReorderPoint), args.Error(1)
+}
+func (s *serviceMockReplenishment) SaveReorderPoint(ctx context.Context, rp domain.ReorderPoint) (domain.ReorderPoint, error) {
+	args := s.Called(ctx, rp)
+	return args.Get(0).(domain.ReorderPoint), args.Error(1)
+}
+func (s *serviceMockReplenishment) DeleteReorderPoint(ctx context.Context, warehouseID, productID int) error {
+	args := s.Called(ctx, warehouseID, productID)
+	return args.Error(0)
+}
+func (s *serviceMockReplenishment) GetSuggestions(ctx context.Context, f domain.ReplenishmentFilter) ([]domain.ReplenishmentSuggestion, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.ReplenishmentSuggestion), args.Error(1)
+}
+
+func CreateServerReplenishment(service replenishment.Service) *gin.Engine {
+	handler := NewReplenishment(service)
+
+	server := gin.Default()
+	server.GET("/api/v1/warehouses/:id/reorderPoints", handler.GetReorderPoints())
+	server.PUT("/api/v1/warehouses/:id/reorderPoints/:product_id", handler.SaveReorderPoint())
+	server.DELETE("/api/v1/warehouses/:id/reorderPoints/:product_id", handler.DeleteReorderPoint())
+	server.GET("/api/v1/warehouses/reportReplenishment", handler.GetSuggestions())
+
+	return server
+}
+
+func Test_Replenishment_GetReorderPoints(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		points := []domain.ReorderPoint{{ID: 1, WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}}
+		service := &serviceMockReplenishment{}
+		service.On("GetReorderPoints", mock.Anything, 2).Return(points, nil)
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/warehouses/2/reorderPoints", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data []domain.ReorderPoint `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, points, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("warehouse not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockReplenishment{}
+		service.On("GetReorderPoints", mock.Anything, 9).Return([]domain.ReorderPoint(nil), replenishment.ErrWarehouseNotFound)
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/warehouses/9/reorderPoints", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body errorResponse
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, replenishment.ErrWarehouseNotFound.Error(), body.Message)
+	})
+}
+
+func Test_Replenishment_SaveReorderPoint(t *testing.T) {
+	t.Run("OK takes the ids from the path", func(t *testing.T) {
+		// arrange
+		saved := domain.ReorderPoint{ID: 7, WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}
+		service := &serviceMockReplenishment{}
+		service.On("SaveReorderPoint", mock.Anything, domain.ReorderPoint{WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}).Return(saved, nil)
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodPut, "/api/v1/warehouses/2/reorderPoints/5", `{"id":3,"product_id":9,"reorder_point":40,"safety_stock":10}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data domain.ReorderPoint `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, saved, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name   string
+		url    string
+		body   string
+		status int
+		err    error
+	}{
+		{"invalid product id", "/api/v1/warehouses/2/reorderPoints/abc", `{"reorder_point":40}`, http.StatusBadRequest, ErrInvalidId},
+		{"safety stock over the reorder point", "/api/v1/warehouses/2/reorderPoints/5", `{"reorder_point":5,"safety_stock":10}`, http.StatusUnprocessableEntity, replenishment.ErrSafetyStock},
+		{"product not found", "/api/v1/warehouses/2/reorderPoints/9", `{"reorder_point":40}`, http.StatusNotFound, replenishment.ErrProductNotFound},
+		{"internal error", "/api/v1/warehouses/2/reorderPoints/5", `{"reorder_point":40}`, http.StatusInternalServerError, replenishment.ErrInternal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			service := &serviceMockReplenishment{}
+			service.On("SaveReorderPoint", mock.Anything, mock.Anything).Return(domain.ReorderPoint{}, c.err)
+			server := CreateServerReplenishment(service)
+			req, res := NewRequestLocality(http.MethodPut, c.url, c.body)
+
+			// act
+			server.ServeHTTP(res, req)
+
+			// assert
+			var body errorResponse
+			assert.Equal(t, c.status, res.Code)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, c.err.Error(), body.Message)
+		})
+	}
+
+	t.Run("negative reorder point", func(t *testing.T) {
+		// arrange
+		service := &serviceMockReplenishment{}
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodPut, "/api/v1/warehouses/2/reorderPoints/5", `{"reorder_point":-1}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		service.AssertExpectations(t)
+	})
+}
+
+func Test_Replenishment_DeleteReorderPoint(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		service := &serviceMockReplenishment{}
+		service.On("DeleteReorderPoint", mock.Anything, 2, 5).Return(nil)
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodDelete, "/api/v1/warehouses/2/reorderPoints/5", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockReplenishment{}
+		service.On("DeleteReorderPoint", mock.Anything, 2, 9).Return(replenishment.ErrNotFound)
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodDelete, "/api/v1/warehouses/2/reorderPoints/9", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body errorResponse
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, replenishment.ErrNotFound.Error(), body.Message)
+	})
+}
+
+func Test_Replenishment_GetSuggestions(t *testing.T) {
+	t.Run("OK with filters", func(t *testing.T) {
+		// arrange
+		suggestions := []domain.ReplenishmentSuggestion{{WarehouseID: 2, ProductID: 5, Description: "peas", SellerID: 4, SellerCompanyName: "Acme", Stock: 12, ReorderPoint: 40, SafetyStock: 10, DailyConsumption: 2, SuggestedQuantity: 28}}
+		service := &serviceMockReplenishment{}
+		service.On("GetSuggestions", mock.Anything, domain.ReplenishmentFilter{WarehouseID: 2, Days: 30, CoverDays: 14}).Return(suggestions, nil)
+		server := CreateServerReplenishment(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/warehouses/reportReplenishment?warehouse_id=2&days=30&cover_days=14", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		var body struct {
+			Data []domain.ReplenishmentSuggestion `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, suggestions, body.Data)
+		service.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name   string
+		query  string
+		status int
+		err    error
+	}{
+		{"invalid warehouse id", "?warehouse_id=abc", http.StatusBadRequest, ErrInvalidId},
+		{"invalid days", "?days=0", http.StatusBadRequest, ErrInvalidDays},
+		{"days too long", "?cover_days=400", http.StatusBadRequest, replenishment.ErrDays},
+		{"warehouse not found", "?warehouse_id=9", http.StatusNotFound, replenishment.ErrWarehouseNotFound},
+		{"internal error", "", http.StatusInternalServerError, replenishment.ErrInternal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			service := &serviceMockReplenishment{}
+			service.On("GetSuggestions", mock.Anything, mock.Anything).Return([]domain.ReplenishmentSuggestion{}, c.err)
+			server := CreateServerReplenishment(service)
+			req, res := NewRequestLocality(http.MethodGet, "/api/v1/warehouses/reportReplenishment"+c.query, "")
+
+			// act
+			server.ServeHTTP(res, req)
+
+			// assert
+			var body errorResponse
+			assert.Equal(t, c.status, res.Code)
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, c.err.Error(), body.Message)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/export"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+var ErrInvalidDate = errors.New("from and to must be dates, e.g. 2023-03-01")
+
+// reportFormat returns the export format asked for with ?format= or the Accept header, "" meaning JSON.
+// On an unsupported format it answers 400 and returns false.
+func reportFormat(c *gin.Context) (string, bool) {
+	format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return format, true
+}
+
+// writeReport answers a report in the requested format: JSON by default, or a CSV or xlsx attachment
+// named after the report whose columns are the JSON field names. Attachments are streamed, flushed
+// every export.FlushRows rows, and never stored in the report cache.
+func writeReport(c *gin.Context, format, name string, data interface{}) {
+	if format == "" {
+		web.Success(c, http.StatusOK, data)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.`+format+`"`)
+	c.Status(http.StatusOK)
+	// the status is already sent, so a failure can only cut the body short
+	if err := export.Write(c.Writer, format, data); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// dateRange reads the from and to dates of a report, "" when left out.
+func dateRange(c *gin.Context) (from, to string, err error) {
+	for _, d := range []struct {
+		value string
+		dst   *string
+	}{{c.Query("from"), &from}, {c.Query("to"), &to}} {
+		if d.value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", d.value)
+		if err != nil {
+			return "", "", ErrInvalidDate
+		}
+		*d.dst = date.Format("2006-01-02")
+	}
+	return from, to, nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/go-playground/validator"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
 )
 
@@ -31,17 +33,28 @@ func NewSection(s section.Service) *Section {
 
 // @Summary		List sections
 // @Tags			Sections
-// @Description	Get All Sections
+// @Description	Get All Sections, soft deleted ones only with include_deleted=true
 // @Produce		json
-// @Success		200	{object}	web.response
-// @Failure		500	{object}	web.errorResponse
+// @Param			include_deleted	query		bool	false	"include soft deleted sections"
+// @Success		200				{object}	web.response
+// @Failure		400				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Failure		403				{object}	web.errorResponse
 // @Router			/api/v1/sections [get]
 func (s *Section) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// Request
+		include, ok := includeDeleted(ctx)
+		if !ok {
+			return
+		}
 
 		// Process
-		sections, err := s.s.GetAll(ctx)
+		getAll := s.s.GetAll
+		if include {
+			getAll = s.s.GetAllWithDeleted
+		}
+		sections, err := getAll(ctx)
 		if err != nil {
 			web.Error(ctx, http.StatusInternalServerError, err.Error())
 			return
@@ -94,8 +107,9 @@ func (s *Section) Get() gin.HandlerFunc {
 // @Summary		Report Products
 // @Tags			Sections
 // @Description	Get the quantity of products of each section or the quantity of products for a determined section
-// @Produce		json
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param			id	query		int	false	"Section id"
+// @Param			format	query	string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
 // @Success		200	{object}	web.response{data=[]domain.SectionReportProducts}
 // @Success		200	{object}	web.response{data=domain.SectionReportProducts}
 // @Failure		400	{object}	web.errorResponse
@@ -104,6 +118,11 @@ func (s *Section) Get() gin.HandlerFunc {
 // @Router			/api/v1/sections/reportProducts [get]
 func (s *Section) GetReportProducts() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		format, ok := reportFormat(ctx)
+		if !ok {
+			return
+		}
+
 		// Request
 		var id int
 		var err error
@@ -132,7 +151,7 @@ func (s *Section) GetReportProducts() gin.HandlerFunc {
 
 		// Response
 		// When the request is successful, the backend will return the report list
-		web.Success(ctx, http.StatusOK, report)
+		writeReport(ctx, format, "sections_report_products", report)
 	}
 }
 
@@ -261,6 +280,9 @@ func (s *Section) Update() gin.HandlerFunc {
 			case section.ErrProductTypeNotFound:
 				web.Error(ctx, http.StatusConflict, err.Error())
 				return
+			case etag.ErrPreconditionFailed:
+				web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+				return
 			default:
 				web.Error(ctx, http.StatusInternalServerError, err.Error())
 				return
@@ -277,7 +299,7 @@ func (s *Section) Update() gin.HandlerFunc {
 
 // @Summary		Delete section
 // @Tags			Sections
-// @Description	Delete section
+// @Description	Soft delete section, it can be restored later
 // @Param			id	path		int	true	"section id"
 // @Success		204	{object}	web.response
 // @Failure		404	{object}	web.errorResponse
@@ -296,6 +318,10 @@ func (s *Section) Delete() gin.HandlerFunc {
 		// Process
 		// When the section does not exist a 404 code will be returned
 		err = s.s.Delete(ctx, id)
+		if err == etag.ErrPreconditionFailed {
+			web.Error(ctx, http.StatusPreconditionFailed, err.Error())
+			return
+		}
 		if err != nil {
 			web.Error(ctx, http.StatusNotFound, err.Error())
 			return
@@ -306,3 +332,86 @@ func (s *Section) Delete() gin.HandlerFunc {
 		web.Success(ctx, http.StatusNoContent, nil)
 	}
 }
+
+// @Summary		Restore section
+// @Tags			Sections
+// @Description	Restore a soft deleted section
+// @Produce		json
+// @Param			id	path		int	true	"section id"
+// @Success		200	{object}	web.response{data=domain.Section}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/sections/{id}/restore [post]
+func (s *Section) Restore() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// Request
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Process
+		// Only a soft deleted section can be restored
+		sectionDB, err := s.s.Restore(ctx, id)
+		switch err {
+		case nil:
+		case section.ErrSectionNotFound:
+			web.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		case softdelete.ErrNotDeleted:
+			web.Error(ctx, http.StatusConflict, "error: section is not deleted")
+			return
+		default:
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// Response
+		web.Success(ctx, http.StatusOK, sectionDB)
+	}
+}
+
+// @Summary		Hard delete section
+// @Tags			Sections
+// @Description	Remove section for good, refused while product batches or transfers reference it
+// @Param			id	path		int	true	"section id"
+// @Success		204	{object}	web.response
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/sections/{id}/hard [delete]
+func (s *Section) HardDelete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// Request
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Process
+		// The conflict message names the rows still referencing the section
+		err = s.s.HardDelete(ctx, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, softdelete.ErrHasDependents):
+			web.Error(ctx, http.StatusConflict, "error: cannot delete section, "+err.Error())
+			return
+		case err == section.ErrSectionNotFound:
+			web.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		default:
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// Response
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
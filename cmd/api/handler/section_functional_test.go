@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
 	"github.com/stretchr/testify/assert"
 )
@@ -55,7 +56,7 @@ func Test_GetAll_Section(t *testing.T) {
 		rows.AddRow(d.ID, d.SectionNumber, d.CurrentTemperature, d.MinimumTemperature, d.CurrentCapacity, d.MinimumCapacity, d.MaximumCapacity, d.WarehouseID, d.ProductTypeID)
 	}
 
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE deleted_at IS NULL;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -105,7 +106,7 @@ func Test_Get_Section(t *testing.T) {
 	row := mock.NewRows([]string{"id", "section_number", " current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity", "warehouse_id", "id_product_type"})
 	row.AddRow(expected.ID, expected.SectionNumber, expected.CurrentTemperature, expected.MinimumTemperature, expected.CurrentCapacity, expected.MinimumCapacity, expected.MaximumCapacity, expected.WarehouseID, expected.ProductTypeID)
 
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=?;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=? AND deleted_at IS NULL;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -198,7 +199,7 @@ func Test_GetReportProducts_Section(t *testing.T) {
 		rows.AddRow(d.ID, d.SectionNumber, d.ProductCount)
 	}
 
-	query := "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s LEFT JOIN products_batches as pb ON s.id = pb.section_id GROUP BY s.id, s.section_number;"
+	query := "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s LEFT JOIN products_batches as pb ON s.id = pb.section_id WHERE s.deleted_at IS NULL GROUP BY s.id, s.section_number;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -417,7 +418,7 @@ func Test_Create_Section(t *testing.T) {
 
 func Test_Update_Section(t *testing.T) {
 
-	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=? WHERE id=?;"
+	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=?, version=version+1 WHERE id=?;"
 
 	/*
 		t.Run("Ok", func(t *testing.T) {
@@ -600,7 +601,7 @@ func Test_Update_Section(t *testing.T) {
 
 func Test_Delete_Section(t *testing.T) {
 
-	query := "DELETE FROM sections WHERE id=?;"
+	query := "UPDATE sections SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -609,8 +610,16 @@ func Test_Delete_Section(t *testing.T) {
 		defer db.Close()
 		server := createServerSection(db)
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		// the delete is announced as a section update
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM sections WHERE id=?")).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(1, "2023-05-01 10:00:00"))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.SectionUpdated, "sections", "1", `{"deleted_at":"2023-05-01 10:00:00","id":1}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		// act
 		request, response := createRequestSection(http.MethodDelete, "/api/v1/sections/1", "")
@@ -619,6 +628,7 @@ func Test_Delete_Section(t *testing.T) {
 		// assert
 		assert.Equal(t, http.StatusNoContent, response.Code)
 		assert.Equal(t, response.Header().Get("Content-Type"), "application/json; charset=utf-8")
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("Validate ID type", func(t *testing.T) {
@@ -36,6 +36,11 @@ func (r *serviceSectionTest) GetByID(ctx context.Context, id int) (domain.Sectio
 	return args.Get(0).(domain.Section), args.Error(1)
 }
 
+func (r *serviceSectionTest) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+
 func (r *serviceSectionTest) GetReportProducts(ctx context.Context, id int) ([]domain.SectionReportProducts, error) {
 	args := r.Called(ctx, id)
 	return args.Get(0).([]domain.SectionReportProducts), args.Error(1)
@@ -56,6 +61,21 @@ func (r *serviceSectionTest) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (r *serviceSectionTest) GetAllWithDeleted(ctx context.Context) ([]domain.Section, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+
+func (r *serviceSectionTest) Restore(ctx context.Context, id int) (domain.Section, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.Section), args.Error(1)
+}
+
+func (r *serviceSectionTest) HardDelete(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
 func createServerSectionUnit(service *serviceSectionTest) *gin.Engine {
 	handler := NewSection(service)
 	eng := gin.Default()
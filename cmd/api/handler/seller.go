@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -9,9 +10,13 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
 )
 
+var ErrInvalidPage = errors.New("page and page_size must be positive integers")
+
 type Seller struct {
 	sellerService seller.Service
 }
@@ -24,15 +29,27 @@ func NewSeller(s seller.Service) *Seller {
 
 // @summary		List sellers
 // @tags			Sellers
-// @Description	Returns a list of all sellers
+// @Description	Returns a list of all sellers, soft deleted ones only with include_deleted=true
 // @Produce		json
-// @Success		200	{object}	web.response{data=[]domain.Seller}
-// @Failure		500	{object}	web.errorResponse
+// @Param			include_deleted	query		bool	false	"include soft deleted sellers"
+// @Success		200				{object}	web.response{data=[]domain.Seller}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Failure		403				{object}	web.errorResponse
 // @Router			/api/v1/sellers [get]
 func (s *Seller) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Request
+		include, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
 		// Process
-		sellers, err := s.sellerService.GetAll(c)
+		getAll := s.sellerService.GetAll
+		if include {
+			getAll = s.sellerService.GetAllWithDeleted
+		}
+		sellers, err := getAll(c)
 		if err != nil {
 			web.Error(c, http.StatusInternalServerError, err.Error())
 			return
@@ -187,6 +204,9 @@ func (s *Seller) Update() gin.HandlerFunc {
 		case seller.ErrConflict:
 			web.Error(c, http.StatusConflict, err.Error())
 			return
+		case etag.ErrPreconditionFailed:
+			web.Error(c, http.StatusPreconditionFailed, err.Error())
+			return
 		case seller.ErrIntern:
 			web.Error(c, http.StatusInternalServerError, err.Error())
 			return
@@ -200,7 +220,7 @@ func (s *Seller) Update() gin.HandlerFunc {
 
 // @Summary		Delete seller
 // @Tags			Sellers
-// @Description	Delete seller
+// @Description	Soft delete seller, it can be restored later
 // @Param			id	path		int	true	"seller id"
 // @Success		204	{object}	web.response
 // @Failure		404	{object}	web.errorResponse
@@ -223,6 +243,9 @@ func (s *Seller) Delete() gin.HandlerFunc {
 		case seller.ErrNotFound:
 			web.Error(c, http.StatusNotFound, err.Error())
 			return
+		case etag.ErrPreconditionFailed:
+			web.Error(c, http.StatusPreconditionFailed, err.Error())
+			return
 		case seller.ErrIntern:
 			web.Error(c, http.StatusInternalServerError, err.Error())
 			return
@@ -233,3 +256,168 @@ func (s *Seller) Delete() gin.HandlerFunc {
 		web.Success(c, http.StatusNoContent, gin.H{})
 	}
 }
+
+// @Summary		Restore seller
+// @Tags			Sellers
+// @Description	Restore a soft deleted seller
+// @Produce		json
+// @Param			id	path		int	true	"seller id"
+// @Success		200	{object}	web.response{data=domain.Seller}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/sellers/{id}/restore [post]
+func (s *Seller) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		// A seller that is not soft deleted cannot be restored, a 409 code will be returned
+		sel, err := s.sellerService.Restore(c, id)
+		switch err {
+		case nil:
+		case seller.ErrNotFound:
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		case softdelete.ErrNotDeleted:
+			web.Error(c, http.StatusConflict, "seller is not deleted")
+			return
+		default:
+			web.Error(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		web.Success(c, http.StatusOK, sel)
+	}
+}
+
+// @Summary		Hard delete seller
+// @Tags			Sellers
+// @Description	Remove seller for good, refused while products reference it
+// @Param			id	path		int	true	"seller id"
+// @Success		204	{object}	web.response
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/sellers/{id}/hard [delete]
+func (s *Seller) HardDelete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		// When rows still reference the seller a 409 code names them
+		err = s.sellerService.HardDelete(c, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, softdelete.ErrHasDependents):
+			web.Error(c, http.StatusConflict, "cannot delete seller, "+err.Error())
+			return
+		case errors.Is(err, seller.ErrNotFound):
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		default:
+			web.Error(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		web.Success(c, http.StatusNoContent, gin.H{})
+	}
+}
+
+// @Summary		Seller report
+// @Tags			Sellers
+// @Description	Returns the product count, units in stock, expired batches, units sold and revenue of a seller
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			id		path		int		true	"seller id"
+// @Param			format	query		string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200		{object}	web.response{data=domain.SellerReport}
+// @Failure		400		{object}	web.errorResponse
+// @Failure		404		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/sellers/{id}/report [get]
+func (s *Seller) GetReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, ok := reportFormat(c)
+		if !ok {
+			return
+		}
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		report, err := s.sellerService.GetReport(c, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, seller.ErrNotFound):
+			web.Error(c, http.StatusNotFound, err.Error())
+			return
+		default:
+			web.Error(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeReport(c, format, "seller_report", report)
+	}
+}
+
+// @Summary		Seller reports
+// @Tags			Sellers
+// @Description	Returns a page of seller reports. CSV and xlsx exports hold the sellers of the page only
+// @Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			sort		query		string	false	"field to sort by, descending when prefixed with -, e.g. -revenue; id by default"
+// @Param			page		query		int		false	"page number, 1 by default"
+// @Param			page_size	query		int		false	"sellers per page, at most 100, 20 by default"
+// @Param			format		query		string	false	"json (default), csv or xlsx; the Accept header is used when omitted"
+// @Success		200			{object}	web.response{data=domain.SellerReportPage}
+// @Failure		400			{object}	web.errorResponse
+// @Failure		500			{object}	web.errorResponse
+// @Router			/api/v1/sellers/report [get]
+func (s *Seller) GetReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, ok := reportFormat(c)
+		if !ok {
+			return
+		}
+
+		query := domain.SellerReportQuery{Sort: c.Query("sort")}
+		if len(query.Sort) > 0 && query.Sort[0] == '-' {
+			query.Sort, query.Descending = query.Sort[1:], true
+		}
+		var err error
+		if v := c.Query("page"); v != "" {
+			if query.Page, err = strconv.Atoi(v); err != nil || query.Page < 1 {
+				web.Error(c, http.StatusBadRequest, ErrInvalidPage.Error())
+				return
+			}
+		}
+		if v := c.Query("page_size"); v != "" {
+			if query.PageSize, err = strconv.Atoi(v); err != nil || query.PageSize < 1 {
+				web.Error(c, http.StatusBadRequest, ErrInvalidPage.Error())
+				return
+			}
+		}
+
+		page, err := s.sellerService.GetReports(c, query)
+		switch {
+		case err == nil:
+		case errors.Is(err, seller.ErrSort):
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		default:
+			web.Error(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if format != "" {
+			writeReport(c, format, "sellers_report", page.Sellers)
+			return
+		}
+		writeReport(c, format, "sellers_report", page)
+	}
+}
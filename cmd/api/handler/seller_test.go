@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -52,10 +53,30 @@ func (r *serviceMockSeller) Delete(ctx context.Context, id int) error {
 	args := r.Mock.Called(ctx, id)
 	return args.Error(0)
 }
+func (r *serviceMockSeller) GetAllWithDeleted(ctx context.Context) ([]domain.Seller, error) {
+	args := r.Mock.Called(ctx)
+	return args.Get(0).([]domain.Seller), args.Error(1)
+}
+func (r *serviceMockSeller) Restore(ctx context.Context, id int) (domain.Seller, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.Seller), args.Error(1)
+}
+func (r *serviceMockSeller) HardDelete(ctx context.Context, id int) error {
+	args := r.Mock.Called(ctx, id)
+	return args.Error(0)
+}
 func (r *serviceMockSeller) Exists(ctx context.Context, cid int) bool {
 	args := r.Mock.Called(ctx, cid)
 	return args.Get(0).(bool)
 }
+func (r *serviceMockSeller) GetReport(ctx context.Context, id int) (domain.SellerReport, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.SellerReport), args.Error(1)
+}
+func (r *serviceMockSeller) GetReports(ctx context.Context, q domain.SellerReportQuery) (domain.SellerReportPage, error) {
+	args := r.Mock.Called(ctx, q)
+	return args.Get(0).(domain.SellerReportPage), args.Error(1)
+}
 
 // ______________________________________________________
 // tools
@@ -71,7 +92,9 @@ func CreateServerSeller(service seller.Service) *gin.Engine {
 	{
 		routes.GET("/", handler.GetAll())
 		routes.POST("/", handler.Create())
+		routes.GET("/report", handler.GetReports())
 		routes.GET("/:id", handler.Get())
+		routes.GET("/:id/report", handler.GetReport())
 		routes.PATCH("/:id", handler.Update())
 		routes.DELETE("/:id", handler.Delete())
 	}
@@ -652,6 +675,24 @@ func Test_Update_Seller(t *testing.T) {
 		assert.True(t, service.AssertExpectations(t))
 		assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
 	})
+
+	t.Run("stale version", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+
+		service.On("GetByID", mock.Anything, 1).Return(sellerDB, nil)
+		service.On("Update", mock.Anything, sellerUpdated).Return(etag.ErrPreconditionFailed)
+
+		request, response := NewRequestSeller(http.MethodPatch, "/api/v1/sellers/1", `{"company_name": "Mercado Libre"}`)
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
 }
 
 func Test_Delete_Seller(t *testing.T) {
@@ -721,4 +762,135 @@ func Test_Delete_Seller(t *testing.T) {
 		assert.True(t, service.AssertExpectations(t))
 		assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
 	})
+
+	t.Run("stale version", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+
+		service.On("Delete", mock.Anything, 1).Return(etag.ErrPreconditionFailed)
+
+		request, response := NewRequestSeller(http.MethodDelete, "/api/v1/sellers/1", "")
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
+
+func Test_GetReport_Seller(t *testing.T) {
+	report := domain.SellerReport{ID: 1, CID: 1, CompanyName: "Mercado Libre", ProductCount: 3, UnitsInStock: 120, ExpiredBatches: 1, UnitsSold: 4, Revenue: 80.5}
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+		service.On("GetReport", mock.Anything, 1).Return(report, nil)
+		request, response := NewRequestSeller(http.MethodGet, "/api/v1/sellers/1/report", "")
+
+		// act
+		server.ServeHTTP(response, request)
+		var result struct {
+			Data domain.SellerReport `json:"data"`
+		}
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, report, result.Data)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+		service.On("GetReport", mock.Anything, 9).Return(domain.SellerReport{}, seller.ErrNotFound)
+		request, response := NewRequestSeller(http.MethodGet, "/api/v1/sellers/9/report", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+}
+
+func Test_GetReports_Seller(t *testing.T) {
+	page := domain.SellerReportPage{
+		Sellers:  []domain.SellerReport{{ID: 2, CID: 2, CompanyName: "Coto", UnitsSold: 9, Revenue: 300}},
+		Page:     2,
+		PageSize: 1,
+		Total:    3,
+	}
+
+	t.Run("OK sorted and paged", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+		service.On("GetReports", mock.Anything, domain.SellerReportQuery{Sort: "revenue", Descending: true, Page: 2, PageSize: 1}).Return(page, nil)
+		request, response := NewRequestSeller(http.MethodGet, "/api/v1/sellers/report?sort=-revenue&page=2&page_size=1", "")
+
+		// act
+		server.ServeHTTP(response, request)
+		var result struct {
+			Data domain.SellerReportPage `json:"data"`
+		}
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, page, result.Data)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("CSV holds the sellers of the page", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+		service.On("GetReports", mock.Anything, domain.SellerReportQuery{}).Return(page, nil)
+		request, response := NewRequestSeller(http.MethodGet, "/api/v1/sellers/report?format=csv", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "id,cid,company_name,product_count,units_in_stock,expired_batches,units_sold,revenue\n2,2,Coto,0,0,0,9,300\n", response.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("Invalid page", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+		request, response := NewRequestSeller(http.MethodGet, "/api/v1/sellers/report?page=0", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("Invalid sort", func(t *testing.T) {
+		// arrange
+		service := NewserviceMockSeller()
+		server := CreateServerSeller(service)
+		service.On("GetReports", mock.Anything, domain.SellerReportQuery{Sort: "telephone"}).Return(domain.SellerReportPage{}, seller.ErrSort)
+		request, response := NewRequestSeller(http.MethodGet, "/api/v1/sellers/report?sort=telephone", "")
+
+		// act
+		server.ServeHTTP(response, request)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
 }
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/transfer"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Transfer struct {
+	transferService transfer.Service
+}
+
+func NewTransfer(transferService transfer.Service) *Transfer {
+	return &Transfer{transferService: transferService}
+}
+
+// @Summary		List transfers
+// @Tags			Transfers
+// @Description	Returns every recorded stock transfer
+// @Produce		json
+// @Success		200	{object}	web.response{data=[]domain.Transfer}
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/transfers [get]
+func (tr *Transfer) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		transfers, err := tr.transferService.GetAll(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, transfers)
+	}
+}
+
+// @Summary		Transfer by id
+// @Tags			Transfers
+// @Description	Get transfer document by id
+// @Produce		json
+// @Param			id	path		int	true	"transfer id"
+// @Success		200	{object}	web.response{data=domain.Transfer}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/transfers/{id} [get]
+func (tr *Transfer) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := tr.transferService.GetByID(ctx, id)
+		if err != nil {
+			switch err {
+			case transfer.ErrTransferNotFound:
+				web.Error(ctx, http.StatusNotFound, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
+// @Summary		Transfer stock
+// @Tags			Transfers
+// @Description	Moves all or part of a product batch to another section, possibly in another warehouse. A partial move splits the batch. The destination must store the product type and have enough capacity.
+// @Accept			json
+// @Produce		json
+// @Param			request	body		domain.TransferRequest	true	"Transfer parameters"
+// @Success		201		{object}	web.response{data=domain.Transfer}
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/transfers [post]
+func (tr *Transfer) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request domain.TransferRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		created, err := tr.transferService.Create(ctx, request)
+		if err != nil {
+			switch err {
+			case transfer.ErrInvalidQuantity:
+				web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			case transfer.ErrBatchNotFound, transfer.ErrSectionNotFound, transfer.ErrEmployeeNotFound,
+				transfer.ErrSameSection, transfer.ErrInsufficientQuantity, transfer.ErrProductTypeMismatch, transfer.ErrCapacityExceeded:
+				web.Error(ctx, http.StatusConflict, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusCreated, created)
+	}
+}
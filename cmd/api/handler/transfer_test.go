@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/transfer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockTransfer struct {
+	mock.Mock
+}
+
+func (s *serviceMockTransfer) GetAll(ctx context.Context) ([]domain.Transfer, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.Transfer), args.Error(1)
+}
+func (s *serviceMockTransfer) GetByID(ctx context.Context, id int) (domain.Transfer, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Transfer), args.Error(1)
+}
+func (s *serviceMockTransfer) Create(ctx context.Context, req domain.TransferRequest) (domain.Transfer, error) {
+	args := s.Called(ctx, req)
+	return args.Get(0).(domain.Transfer), args.Error(1)
+}
+
+func CreateServerTransfer(service transfer.Service) *gin.Engine {
+	handler := NewTransfer(service)
+
+	server := gin.Default()
+	routes := server.Group("/api/v1/transfers")
+	{
+		routes.GET("", handler.GetAll())
+		routes.GET("/:id", handler.Get())
+		routes.POST("", handler.Create())
+	}
+
+	return server
+}
+
+func Test_Transfer(t *testing.T) {
+	t.Run("create ok", func(t *testing.T) {
+		// arrange
+		service := &serviceMockTransfer{}
+		service.On("Create", mock.Anything, domain.TransferRequest{ProductBatchID: 7, DestinationSectionID: 3, Quantity: 30, EmployeeID: 5}).
+			Return(domain.Transfer{ID: 1, TransferDate: "2023-05-01 10:30:00", ProductBatchID: 7, DestinationBatchID: 12, OriginSectionID: 1, DestinationSectionID: 3, Quantity: 30, EmployeeID: 5}, nil)
+		server := CreateServerTransfer(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/transfers", `{"product_batch_id":7,"destination_section_id":3,"quantity":30,"employee_id":5}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"transfer_date":"2023-05-01 10:30:00","product_batch_id":7,"destination_batch_id":12,"origin_section_id":1,"destination_section_id":3,"quantity":30,"employee_id":5}}`, res.Body.String())
+	})
+
+	t.Run("create missing employee", func(t *testing.T) {
+		// arrange
+		service := &serviceMockTransfer{}
+		server := CreateServerTransfer(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/transfers", `{"product_batch_id":7,"destination_section_id":3}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create without quantity", func(t *testing.T) {
+		// arrange
+		service := &serviceMockTransfer{}
+		server := CreateServerTransfer(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/transfers", `{"product_batch_id":7,"destination_section_id":3,"quantity":0,"employee_id":5}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create rule violation", func(t *testing.T) {
+		// arrange
+		service := &serviceMockTransfer{}
+		service.On("Create", mock.Anything, mock.Anything).Return(domain.Transfer{}, transfer.ErrProductTypeMismatch)
+		server := CreateServerTransfer(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/transfers", `{"product_batch_id":7,"destination_section_id":3,"quantity":10,"employee_id":5}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"`+transfer.ErrProductTypeMismatch.Error()+`"}`, res.Body.String())
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		// arrange
+		service := &serviceMockTransfer{}
+		service.On("GetByID", mock.Anything, 9).Return(domain.Transfer{}, transfer.ErrTransferNotFound)
+		server := CreateServerTransfer(service)
+		req, res := NewRequestLocality(http.MethodGet, "/api/v1/transfers/9", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
 )
 
@@ -23,15 +26,48 @@ func NewWarehouse(s warehouse.Service) *Warehouse {
 
 // @summary		list warehouse
 // @tags			Warehouse
-// @Description	Returns a list of all warehouse
+// @Description	Returns a list of all warehouse, optionally filtered by location
 // @Produce		json
-// @Success		200	{object}	web.response{data=[]domain.Warehouse}
-// @Failure		500	{object}	web.errorResponse
+// @Param			locality_id	query		string	false	"Locality Id"
+// @Param			province_id	query		int		false	"Province Id"
+// @Param			country_id	query		int		false	"Country Id"
+// @Param			include_deleted	query		bool	false	"Include soft deleted warehouses, unfiltered list only"
+// @Success		200			{object}	web.response{data=[]domain.Warehouse}
+// @Failure		400			{object}	web.errorResponse
+// @Failure		500			{object}	web.errorResponse
+// @Failure		403			{object}	web.errorResponse
 // @Router			/api/v1/warehouses/ [get]
 func (w *Warehouse) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// build the location filter from the query string
+		var err error
+		filter := domain.WarehouseFilter{LocalityID: c.Query("locality_id")}
+		if param, ok := c.GetQuery("province_id"); ok {
+			if filter.ProvinceID, err = strconv.Atoi(param); err != nil {
+				web.Error(c, 400, ErrInvalidId.Error())
+				return
+			}
+		}
+		if param, ok := c.GetQuery("country_id"); ok {
+			if filter.CountryID, err = strconv.Atoi(param); err != nil {
+				web.Error(c, 400, ErrInvalidId.Error())
+				return
+			}
+		}
+		include, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
 		//get and return all warehouse
-		wareH, err := w.s.GetAll(c)
+		var wareH []domain.Warehouse
+		if filter == (domain.WarehouseFilter{}) && include {
+			wareH, err = w.s.GetAllWithDeleted(c)
+		} else if filter == (domain.WarehouseFilter{}) {
+			wareH, err = w.s.GetAll(c)
+		} else {
+			wareH, err = w.s.GetAllByFilter(c, filter)
+		}
 		if err != nil {
 			web.Error(c, 500, err.Error())
 			return
@@ -110,7 +146,7 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 
 		ware, err := w.s.Create(c, wareHRequest)
 
-		if err == warehouse.ErrExist {
+		if err == warehouse.ErrExist || err == warehouse.ErrLocalityNotFound {
 			web.Error(c, 409, err.Error())
 			return
 
@@ -137,6 +173,7 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 // @Success		200		{object}	web.response{data=domain.Warehouse}
 // @Failure		400		{object}	web.errorResponse
 // @Failure		404		{object}	web.errorResponse
+// @Failure		409		{object}	web.errorResponse
 // @Router			/api/v1/warehouses/{id} [patch]
 func (w *Warehouse) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -167,9 +204,10 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 			return
 		}
 
-		//validate empty
+		//validate empty, locality is only required on create so
+		//warehouses stored before they had one can still be updated
 		validate := validator.New()
-		err = validate.Struct(wareH)
+		err = validate.StructExcept(wareH, "LocalityID")
 		if err != nil {
 			validateErr := err.(validator.ValidationErrors)
 			msgFields := ""
@@ -192,11 +230,24 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 			WarehouseCode:      wareH.WarehouseCode,
 			MinimumCapacity:    wareH.MinimumCapacity,
 			MinimumTemperature: wareH.MinimumTemperature,
+			LocalityID:         wareH.LocalityID,
+			Latitude:           wareH.Latitude,
+			Longitude:          wareH.Longitude,
 		}
 
 		//update data in the BD
 		wareHUpdate, er := w.s.Update(c, wareHBD)
 
+		if er == warehouse.ErrLocalityNotFound {
+			web.Error(c, 409, er.Error())
+			return
+		}
+
+		if er == etag.ErrPreconditionFailed {
+			web.Error(c, 412, er.Error())
+			return
+		}
+
 		if er != nil {
 			web.Error(c, 500, er.Error())
 			return
@@ -209,7 +260,7 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 
 // @summary		Delete warehouse
 // @tags			Warehouse
-// @Description	delete warehouse by id
+// @Description	soft delete warehouse by id, it can be restored later
 // @Param			id	path	int	true	"Warehouse Id"
 // @Success		204
 // @Failure		400	{object}	web.errorResponse
@@ -227,10 +278,149 @@ func (w *Warehouse) Delete() gin.HandlerFunc {
 		}
 		// delete product in BD
 		err = w.s.Delete(c, id)
+		if err == etag.ErrPreconditionFailed {
+			web.Error(c, 412, err.Error())
+			return
+		}
+		if err != nil {
+			web.Error(c, 404, err.Error())
+			return
+		}
+		web.Success(c, 204, nil)
+	}
+}
+
+// @summary		Restore warehouse
+// @tags			Warehouse
+// @Description	restore a soft deleted warehouse by id
+// @Produce		json
+// @Param			id	path		int	true	"Warehouse Id"
+// @Success		200	{object}	web.response{data=domain.Warehouse}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/warehouses/{id}/restore [post]
+func (w *Warehouse) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// get id param from URL
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, 400, ErrBadRequest.Error())
+			return
+		}
+		// only a soft deleted warehouse can be restored
+		wareH, err := w.s.Restore(c, id)
+		switch err {
+		case nil:
+		case warehouse.ErrNotFound:
+			web.Error(c, 404, err.Error())
+			return
+		case softdelete.ErrNotDeleted:
+			web.Error(c, 409, "warehouse is not deleted")
+			return
+		default:
+			web.Error(c, 500, err.Error())
+			return
+		}
+		web.Success(c, 200, wareH)
+	}
+}
+
+// @summary		Hard delete warehouse
+// @tags			Warehouse
+// @Description	remove warehouse by id for good, refused while employees, sections or inbound orders reference it
+// @Param			id	path	int	true	"Warehouse Id"
+// @Success		204
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		409	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Failure		403	{object}	web.errorResponse
+// @Router			/api/v1/warehouses/{id}/hard [delete]
+func (w *Warehouse) HardDelete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// get id param from URL
+		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
+			web.Error(c, 400, ErrBadRequest.Error())
+			return
+		}
+		// the conflict message names the rows still referencing the warehouse
+		err = w.s.HardDelete(c, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, softdelete.ErrHasDependents):
+			web.Error(c, 409, "cannot delete warehouse, "+err.Error())
+			return
+		case err == warehouse.ErrNotFound:
 			web.Error(c, 404, err.Error())
 			return
+		default:
+			web.Error(c, 500, err.Error())
+			return
 		}
 		web.Success(c, 204, nil)
 	}
 }
+
+// @summary		Nearest warehouses
+// @tags			Warehouse
+// @Description	Returns warehouses ordered by great-circle distance to a locality or to a coordinate.
+// @Description	Warehouses without coordinates are left out.
+// @Produce		json
+// @Param			locality_id		query		string	false	"Locality Id"
+// @Param			latitude		query		number	false	"Latitude, required when locality_id is not given"
+// @Param			longitude		query		number	false	"Longitude, required when locality_id is not given"
+// @Param			max_distance	query		number	false	"Maximum distance in kilometers"
+// @Success		200				{object}	web.response{data=[]domain.WarehouseDistance}
+// @Failure		400				{object}	web.errorResponse
+// @Failure		404				{object}	web.errorResponse
+// @Failure		409				{object}	web.errorResponse
+// @Failure		500				{object}	web.errorResponse
+// @Router			/api/v1/warehouses/nearest [get]
+func (w *Warehouse) GetNearest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// optional maximum distance, zero means no limit
+		maxDistance := 0.0
+		if param, ok := c.GetQuery("max_distance"); ok {
+			value, err := strconv.ParseFloat(param, 64)
+			if err != nil || value <= 0 {
+				web.Error(c, 400, "max_distance must be a positive number")
+				return
+			}
+			maxDistance = value
+		}
+
+		// search from a locality when given, from a coordinate otherwise
+		if localityID, ok := c.GetQuery("locality_id"); ok {
+			nearest, err := w.s.GetNearestToLocality(c, localityID, maxDistance)
+			switch err {
+			case nil:
+				web.Success(c, 200, nearest)
+			case warehouse.ErrLocalityNotFound:
+				web.Error(c, 404, err.Error())
+			case warehouse.ErrNoCoordinates:
+				web.Error(c, 409, err.Error())
+			default:
+				web.Error(c, 500, err.Error())
+			}
+			return
+		}
+
+		lat, errLat := strconv.ParseFloat(c.Query("latitude"), 64)
+		long, errLong := strconv.ParseFloat(c.Query("longitude"), 64)
+		if errLat != nil || errLong != nil || lat < -90 || lat > 90 || long < -180 || long > 180 {
+			web.Error(c, 400, "locality_id or valid latitude and longitude are required")
+			return
+		}
+
+		nearest, err := w.s.GetNearest(c, lat, long, maxDistance)
+		if err != nil {
+			web.Error(c, 500, err.Error())
+			return
+		}
+		web.Success(c, 200, nearest)
+	}
+}
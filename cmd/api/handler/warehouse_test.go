@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -37,6 +39,16 @@ func (s *serviceWarehouseTest) GetAll(ctx context.Context) ([]domain.Warehouse,
 	return args.Get(0).([]domain.Warehouse), args.Error(1)
 }
 
+func (s *serviceWarehouseTest) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	args := s.Called(ctx, ids)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+
+func (s *serviceWarehouseTest) GetAllByFilter(ctx context.Context, f domain.WarehouseFilter) ([]domain.Warehouse, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+
 func (s *serviceWarehouseTest) Get(ctx context.Context, id int) (domain.Warehouse, error) {
 	args := s.Called(ctx, id)
 	return args.Get(0).(domain.Warehouse), args.Error(1)
@@ -57,6 +69,31 @@ func (s *serviceWarehouseTest) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (s *serviceWarehouseTest) GetAllWithDeleted(ctx context.Context) ([]domain.Warehouse, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+
+func (s *serviceWarehouseTest) Restore(ctx context.Context, id int) (domain.Warehouse, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Warehouse), args.Error(1)
+}
+
+func (s *serviceWarehouseTest) HardDelete(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (s *serviceWarehouseTest) GetNearest(ctx context.Context, lat, long, maxDistanceKm float64) ([]domain.WarehouseDistance, error) {
+	args := s.Called(ctx, lat, long, maxDistanceKm)
+	return args.Get(0).([]domain.WarehouseDistance), args.Error(1)
+}
+
+func (s *serviceWarehouseTest) GetNearestToLocality(ctx context.Context, localityID string, maxDistanceKm float64) ([]domain.WarehouseDistance, error) {
+	args := s.Called(ctx, localityID, maxDistanceKm)
+	return args.Get(0).([]domain.WarehouseDistance), args.Error(1)
+}
+
 func CreateServerWarehouses(service *serviceWarehouseTest) *gin.Engine {
 	handler := NewWarehouse(service)
 
@@ -66,10 +103,13 @@ func CreateServerWarehouses(service *serviceWarehouseTest) *gin.Engine {
 
 	{
 		rWareH.GET("", handler.GetAll())
+		rWareH.GET("/nearest", handler.GetNearest())
 		rWareH.GET("/:id", handler.Get())
 		rWareH.POST("", handler.Create())
 		rWareH.PATCH("/:id", handler.Update())
 		rWareH.DELETE("/:id", handler.Delete())
+		rWareH.POST("/:id/restore", handler.Restore())
+		rWareH.DELETE("/:id/hard", handler.HardDelete())
 	}
 	return eng
 }
@@ -167,6 +207,7 @@ func TestGetWHandler(t *testing.T) {
 		WarehouseCode:      "ABC009",
 		MinimumCapacity:    10,
 		MinimumTemperature: 15,
+		LocalityID:         "6700",
 	}
 
 	data := response{
@@ -256,6 +297,7 @@ func TestCreateWHandler(t *testing.T) {
 		WarehouseCode:      "ABC009",
 		MinimumCapacity:    10,
 		MinimumTemperature: 15,
+		LocalityID:         "6700",
 	}
 
 	wareHReq := domain.Warehouse{
@@ -265,6 +307,7 @@ func TestCreateWHandler(t *testing.T) {
 		WarehouseCode:      "ABC009",
 		MinimumCapacity:    10,
 		MinimumTemperature: 15,
+		LocalityID:         "6700",
 	}
 
 	data := response{
@@ -278,7 +321,7 @@ func TestCreateWHandler(t *testing.T) {
 		service.On("Create", mock.Anything, wareHReq).Return(wareHResp, nil)
 		server := CreateServerWarehouses(service)
 
-		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15 }`)
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15, "locality_id": "6700" }`)
 
 		server.ServeHTTP(resp, req)
 
@@ -321,7 +364,7 @@ func TestCreateWHandler(t *testing.T) {
 		service.On("Create", mock.Anything, wareHReq).Return(domain.Warehouse{}, warehouse.ErrExist)
 		server := CreateServerWarehouses(service)
 
-		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15 }`)
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15, "locality_id": "6700" }`)
 		server.ServeHTTP(resp, req)
 
 		errResp := errorResponseWarehouse{
@@ -365,7 +408,7 @@ func TestCreateWHandler(t *testing.T) {
 		service.On("Create", mock.Anything, wareHReq).Return(domain.Warehouse{}, warehouse.ErrBD)
 		server := CreateServerWarehouses(service)
 
-		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15 }`)
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15, "locality_id": "6700" }`)
 		server.ServeHTTP(resp, req)
 
 		errResp := errorResponseWarehouse{
@@ -397,6 +440,7 @@ func TestUpdateWHandler(t *testing.T) {
 		WarehouseCode:      "ABC009",
 		MinimumCapacity:    10,
 		MinimumTemperature: 15,
+		LocalityID:         "6700",
 	}
 
 	wareHNuevo := domain.Warehouse{
@@ -406,6 +450,7 @@ func TestUpdateWHandler(t *testing.T) {
 		WarehouseCode:      "ABC009",
 		MinimumCapacity:    10,
 		MinimumTemperature: 15,
+		LocalityID:         "6700",
 	}
 
 	data := response{
@@ -432,6 +477,25 @@ func TestUpdateWHandler(t *testing.T) {
 		assert.True(t, service.AssertExpectations(t))
 
 	})
+	// locality_id solo es obligatorio al crear, un warehouse sin localidad se puede actualizar
+	t.Run("update_without_locality", func(t *testing.T) {
+		withoutLocality := wareHActual
+		withoutLocality.LocalityID = ""
+		updated := wareHNuevo
+		updated.LocalityID = ""
+
+		service := NewServiceWarehouseTest()
+		service.On("Get", mock.Anything, 9).Return(withoutLocality, nil)
+		service.On("Update", mock.Anything, updated).Return(updated, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodPatch, "/api/v1/warehouses/9", `{"address": "Calle 14 # 1-23"}`)
+
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
 	// Si el warehouse que se desea actualizar no existe se devolverá un código 404
 	t.Run("update_non_existent", func(t *testing.T) {
 		service := NewServiceWarehouseTest()
@@ -459,7 +523,7 @@ func TestUpdateWHandler(t *testing.T) {
 		service := NewServiceWarehouseTest()
 		server := CreateServerWarehouses(service)
 
-		req, resp := createRequestWarehouse(http.MethodPatch, "/api/v1/warehouses/abc", `{"telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15 }`)
+		req, resp := createRequestWarehouse(http.MethodPatch, "/api/v1/warehouses/abc", `{"telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15, "locality_id": "6700" }`)
 		server.ServeHTTP(resp, req)
 
 		errResp := errorResponseWarehouse{
@@ -589,3 +653,302 @@ func TestDeleteWHandler(t *testing.T) {
 
 	})
 }
+
+func TestGetAllIncludeDeletedWHandler(t *testing.T) {
+	//Con include_deleted=true se listan tambien los warehouses eliminados
+	t.Run("include_deleted", func(t *testing.T) {
+		data := []domain.Warehouse{{ID: 1, WarehouseCode: "ABC"}, {ID: 2, WarehouseCode: "DEF"}}
+		service := NewServiceWarehouseTest()
+		service.On("GetAllWithDeleted", mock.Anything).Return(data, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses?include_deleted=true", "")
+		server.ServeHTTP(resp, req)
+
+		var result struct {
+			Data []domain.Warehouse `json:"data"`
+		}
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, data, result.Data)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("include_deleted_invalid_400", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses?include_deleted=maybe", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+func TestRestoreWHandler(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		data := domain.Warehouse{ID: 9, WarehouseCode: "ABC"}
+		service := NewServiceWarehouseTest()
+		service.On("Restore", mock.Anything, 9).Return(data, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses/9/restore", "")
+		server.ServeHTTP(resp, req)
+
+		var result struct {
+			Data domain.Warehouse `json:"data"`
+		}
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, data, result.Data)
+	})
+
+	//Un warehouse que no esta eliminado no se puede restaurar
+	t.Run("restore_not_deleted_409", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("Restore", mock.Anything, 9).Return(domain.Warehouse{}, softdelete.ErrNotDeleted)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses/9/restore", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("restore_non_existent_404", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("Restore", mock.Anything, 9).Return(domain.Warehouse{}, warehouse.ErrNotFound)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses/9/restore", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestHardDeleteWHandler(t *testing.T) {
+	t.Run("hard_delete_ok", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("HardDelete", mock.Anything, 9).Return(nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodDelete, "/api/v1/warehouses/9/hard", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	//Cuando hay dependientes se devuelve 409 indicando cuales
+	t.Run("hard_delete_dependents_409", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		blocked := fmt.Errorf("%w: 2 employees, 1 sections", softdelete.ErrHasDependents)
+		service.On("HardDelete", mock.Anything, 9).Return(blocked)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodDelete, "/api/v1/warehouses/9/hard", "")
+		server.ServeHTTP(resp, req)
+
+		errResp := errorResponseWarehouse{
+			Code:    "conflict",
+			Message: "cannot delete warehouse, blocked by dependents: 2 employees, 1 sections",
+		}
+
+		var result errorResponseWarehouse
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, errResp, result)
+	})
+}
+
+func TestNearestWHandler(t *testing.T) {
+
+	type response struct {
+		Data []domain.WarehouseDistance `json:"data"`
+	}
+
+	lat, long := -34.6037, -58.3816
+	nearest := []domain.WarehouseDistance{
+		{Warehouse: domain.Warehouse{ID: 1, Address: "call3 40 # 3 -23", Telephone: "2345678", WarehouseCode: "ABC007", MinimumCapacity: 10, MinimumTemperature: 15, Latitude: &lat, Longitude: &long}, DistanceKm: 1.5},
+	}
+
+	t.Run("by_locality", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("GetNearestToLocality", mock.Anything, "6700", 50.0).Return(nearest, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses/nearest?locality_id=6700&max_distance=50", "")
+		server.ServeHTTP(resp, req)
+
+		var result response
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, response{Data: nearest}, result)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("by_coordinates", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("GetNearest", mock.Anything, lat, long, 0.0).Return(nearest, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses/nearest?latitude=-34.6037&longitude=-58.3816", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("missing_origin", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses/nearest?latitude=-34.6037", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("invalid_max_distance", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses/nearest?locality_id=6700&max_distance=-1", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("locality_non_existent", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("GetNearestToLocality", mock.Anything, "9999", 0.0).Return([]domain.WarehouseDistance(nil), warehouse.ErrLocalityNotFound)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses/nearest?locality_id=9999", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("locality_without_coordinates", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("GetNearestToLocality", mock.Anything, "6700", 0.0).Return([]domain.WarehouseDistance(nil), warehouse.ErrNoCoordinates)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses/nearest?locality_id=6700", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+}
+
+func TestGetAllByLocationWHandler(t *testing.T) {
+
+	type response struct {
+		Data []domain.Warehouse `json:"data"`
+	}
+
+	warehousess := []domain.Warehouse{
+		{ID: 1, Address: "call3 40 # 3 -23", Telephone: "2345678", WarehouseCode: "ABC007", MinimumCapacity: 10, MinimumTemperature: 15, LocalityID: "6700"},
+	}
+
+	//si se envia un filtro de ubicacion se listan solo los warehouses de esa ubicacion
+	t.Run("find_by_province_and_country", func(t *testing.T) {
+
+		service := NewServiceWarehouseTest()
+		service.On("GetAllByFilter", mock.Anything, domain.WarehouseFilter{ProvinceID: 1, CountryID: 2}).Return(warehousess, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses?province_id=1&country_id=2", "")
+		server.ServeHTTP(resp, req)
+
+		var result response
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, response{Data: warehousess}, result)
+		assert.True(t, service.AssertExpectations(t))
+		service.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+
+	t.Run("find_by_locality", func(t *testing.T) {
+
+		service := NewServiceWarehouseTest()
+		service.On("GetAllByFilter", mock.Anything, domain.WarehouseFilter{LocalityID: "6700"}).Return(warehousess, nil)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses?locality_id=6700", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("invalid_province_id", func(t *testing.T) {
+
+		service := NewServiceWarehouseTest()
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodGet, "/api/v1/warehouses?province_id=abc", "")
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+func TestCreateWHandlerLocality(t *testing.T) {
+
+	wareHReq := domain.Warehouse{
+		Address:            "calle 14 # 32 -23",
+		Telephone:          "2224678",
+		WarehouseCode:      "ABC009",
+		MinimumCapacity:    10,
+		MinimumTemperature: 15,
+		LocalityID:         "9999",
+	}
+
+	//si la localidad no existe retorna un 409
+	t.Run("create_locality_non_existent", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		service.On("Create", mock.Anything, wareHReq).Return(domain.Warehouse{}, warehouse.ErrLocalityNotFound)
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15, "locality_id": "9999" }`)
+		server.ServeHTTP(resp, req)
+
+		errResp := errorResponseWarehouse{
+			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(http.StatusConflict)), " ", "_"),
+			Message: warehouse.ErrLocalityNotFound.Error(),
+		}
+
+		var result errorResponseWarehouse
+		err := json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, errResp, result)
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	//sin locality_id el warehouse es invalido y retorna un 400
+	t.Run("create_without_locality", func(t *testing.T) {
+		service := NewServiceWarehouseTest()
+		server := CreateServerWarehouses(service)
+
+		req, resp := createRequestWarehouse(http.MethodPost, "/api/v1/warehouses", `{"address": "calle 14 # 32 -23", "telephone": "2224678", "warehouse_code": "ABC009", "minimum_capacity": 10, "minimum_temperature": 15 }`)
+		server.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+type Webhook struct {
+	webhookService webhook.Service
+}
+
+func NewWebhook(webhookService webhook.Service) *Webhook {
+	return &Webhook{webhookService: webhookService}
+}
+
+// @Summary		Register webhook
+// @Tags			Webhooks
+// @Description	Subscribes an http or https URL resolving to public addresses to domain events. Every delivery is posted with an X-Webhook-Signature header, "sha256=" followed by the hex HMAC-SHA256 under the secret of the X-Webhook-Timestamp header, a dot and the body.
+// @Accept			json
+// @Produce		json
+// @Param			request	body		domain.Webhook	true	"url, event_types and a secret of at least 16 characters"
+// @Success		201		{object}	web.response{data=domain.Webhook}
+// @Failure		422		{object}	web.errorResponse
+// @Failure		500		{object}	web.errorResponse
+// @Router			/api/v1/webhooks [post]
+func (wh *Webhook) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request domain.Webhook
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, "error bad request")
+			return
+		}
+
+		validator := validator.New()
+		if err := validator.Struct(&request); err != nil {
+			web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		created, err := wh.webhookService.Create(ctx, request)
+		if err != nil {
+			switch {
+			case errors.Is(err, webhook.ErrUnknownEventType), errors.Is(err, webhook.ErrForbiddenURL):
+				web.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+			default:
+				web.Error(ctx, http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		web.Success(ctx, http.StatusCreated, created)
+	}
+}
+
+// @Summary		List webhooks
+// @Tags			Webhooks
+// @Description	Returns every webhook, without its secret
+// @Produce		json
+// @Success		200	{object}	web.response{data=[]domain.Webhook}
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/webhooks [get]
+func (wh *Webhook) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		webhooks, err := wh.webhookService.GetAll(ctx)
+		if err != nil {
+			web.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, webhooks)
+	}
+}
+
+// @Summary		Webhook by id
+// @Tags			Webhooks
+// @Produce		json
+// @Param			id	path		int	true	"webhook id"
+// @Success		200	{object}	web.response{data=domain.Webhook}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/webhooks/{id} [get]
+func (wh *Webhook) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		result, err := wh.webhookService.GetByID(ctx, id)
+		if err != nil {
+			wh.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, result)
+	}
+}
+
+// @Summary		Delete webhook
+// @Tags			Webhooks
+// @Description	Unsubscribes the webhook and drops its delivery log
+// @Param			id	path	int	true	"webhook id"
+// @Success		204
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/webhooks/{id} [delete]
+func (wh *Webhook) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		if err := wh.webhookService.Delete(ctx, id); err != nil {
+			wh.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusNoContent, nil)
+	}
+}
+
+// @Summary		Webhook delivery log
+// @Tags			Webhooks
+// @Description	Returns the latest 100 deliveries of the webhook, newest first
+// @Produce		json
+// @Param			id	path		int	true	"webhook id"
+// @Success		200	{object}	web.response{data=[]domain.WebhookDelivery}
+// @Failure		400	{object}	web.errorResponse
+// @Failure		404	{object}	web.errorResponse
+// @Failure		500	{object}	web.errorResponse
+// @Router			/api/v1/webhooks/{id}/deliveries [get]
+func (wh *Webhook) GetDeliveries() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		deliveries, err := wh.webhookService.GetDeliveries(ctx, id)
+		if err != nil {
+			wh.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, deliveries)
+	}
+}
+
+// @Summary		Redeliver
+// @Tags			Webhooks
+// @Description	Posts the delivery again right away, whatever its status, and returns its outcome
+// @Produce		json
+// @Param			id			path		int	true	"webhook id"
+// @Param			delivery_id	path		int	true	"delivery id"
+// @Success		200			{object}	web.response{data=domain.WebhookDelivery}
+// @Failure		400			{object}	web.errorResponse
+// @Failure		404			{object}	web.errorResponse
+// @Failure		500			{object}	web.errorResponse
+// @Router			/api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (wh *Webhook) Redeliver() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+		deliveryID, err := strconv.Atoi(ctx.Param("delivery_id"))
+		if err != nil {
+			web.Error(ctx, http.StatusBadRequest, ErrInvalidId.Error())
+			return
+		}
+
+		delivery, err := wh.webhookService.Redeliver(ctx, id, deliveryID)
+		if err != nil {
+			wh.writeError(ctx, err)
+			return
+		}
+
+		web.Success(ctx, http.StatusOK, delivery)
+	}
+}
+
+func (wh *Webhook) writeError(ctx *gin.Context, err error) {
+	switch err {
+	case webhook.ErrNotFound, webhook.ErrDeliveryNotFound:
+		web.Error(ctx, http.StatusNotFound, err.Error())
+	default:
+		web.Error(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ______________________________________________________
+// tools
+type serviceMockWebhook struct {
+	mock.Mock
+}
+
+func (s *serviceMockWebhook) Create(ctx context.Context, w domain.Webhook) (domain.Webhook, error) {
+	args := s.Called(ctx, w)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (s *serviceMockWebhook) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (s *serviceMockWebhook) GetByID(ctx context.Context, id int) (domain.Webhook, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (s *serviceMockWebhook) Delete(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (s *serviceMockWebhook) GetDeliveries(ctx context.Context, webhookID int) ([]domain.WebhookDelivery, error) {
+	args := s.Called(ctx, webhookID)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (s *serviceMockWebhook) Redeliver(ctx context.Context, webhookID, deliveryID int) (domain.WebhookDelivery, error) {
+	args := s.Called(ctx, webhookID, deliveryID)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (s *serviceMockWebhook) DeliverDue(ctx context.Context) (int, error) {
+	args := s.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func CreateServerWebhook(service webhook.Service) *gin.Engine {
+	handler := NewWebhook(service)
+
+	server := gin.Default()
+	wh := server.Group("/api/v1/webhooks")
+	wh.POST("", handler.Create())
+	wh.GET("/:id/deliveries", handler.GetDeliveries())
+	wh.POST("/:id/deliveries/:delivery_id/redeliver", handler.Redeliver())
+
+	return server
+}
+
+func Test_Create_Webhook(t *testing.T) {
+	t.Run("registers the webhook", func(t *testing.T) {
+		// arrange
+		createdAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+		request := domain.Webhook{URL: "https://partner.example/hook", EventTypes: []string{outbox.PurchaseOrderCreated}, Secret: "0123456789abcdef"}
+		service := &serviceMockWebhook{}
+		service.On("Create", mock.Anything, request).Return(domain.Webhook{ID: 1, URL: request.URL, EventTypes: request.EventTypes, CreatedAt: createdAt}, nil)
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks", `{"url":"https://partner.example/hook","event_types":["PurchaseOrderCreated"],"secret":"0123456789abcdef"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"url":"https://partner.example/hook","event_types":["PurchaseOrderCreated"],"created_at":"2023-03-01T10:00:00Z"}}`, res.Body.String())
+		assert.True(t, service.AssertExpectations(t))
+	})
+
+	t.Run("short secret", func(t *testing.T) {
+		// arrange
+		service := &serviceMockWebhook{}
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks", `{"url":"https://partner.example/hook","event_types":["PurchaseOrderCreated"],"secret":"123"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		// arrange
+		service := &serviceMockWebhook{}
+		service.On("Create", mock.Anything, mock.Anything).Return(domain.Webhook{}, fmt.Errorf("%w: SellerCreated", webhook.ErrUnknownEventType))
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks", `{"url":"https://partner.example/hook","event_types":["SellerCreated"],"secret":"0123456789abcdef"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+
+	t.Run("forbidden url", func(t *testing.T) {
+		// arrange
+		service := &serviceMockWebhook{}
+		service.On("Create", mock.Anything, mock.Anything).Return(domain.Webhook{}, webhook.ErrForbiddenURL)
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks", `{"url":"http://169.254.169.254/latest","event_types":["PurchaseOrderCreated"],"secret":"0123456789abcdef"}`)
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+}
+
+func Test_Redeliver_Webhook(t *testing.T) {
+	t.Run("returns the outcome", func(t *testing.T) {
+		// arrange
+		service := &serviceMockWebhook{}
+		service.On("Redeliver", mock.Anything, 1, 3).Return(domain.WebhookDelivery{ID: 3, WebhookID: 1, Status: webhook.StatusDelivered, Attempts: 2, ResponseStatus: 200}, nil)
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks/1/deliveries/3/redeliver", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"status":"delivered"`)
+	})
+
+	t.Run("unknown delivery", func(t *testing.T) {
+		// arrange
+		service := &serviceMockWebhook{}
+		service.On("Redeliver", mock.Anything, 1, 3).Return(domain.WebhookDelivery{}, webhook.ErrDeliveryNotFound)
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks/1/deliveries/3/redeliver", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("invalid delivery id", func(t *testing.T) {
+		// arrange
+		service := &serviceMockWebhook{}
+		server := CreateServerWebhook(service)
+		req, res := NewRequestLocality(http.MethodPost, "/api/v1/webhooks/1/deliveries/x/redeliver", "")
+
+		// act
+		server.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func Test_GetDeliveries_Webhook(t *testing.T) {
+	// arrange
+	service := &serviceMockWebhook{}
+	service.On("GetDeliveries", mock.Anything, 2).Return([]domain.WebhookDelivery{}, webhook.ErrNotFound)
+	server := CreateServerWebhook(service)
+	req, res := NewRequestLocality(http.MethodGet, "/api/v1/webhooks/2/deliveries", "")
+
+	// act
+	server.ServeHTTP(res, req)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, res.Code)
+}
@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
+	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/cmd/api/grpcserver"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/cmd/api/routes"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/docs"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/forecast"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/database"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/ratelimit"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -31,14 +41,61 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// OUTBOX_SINK is "stdout" or "file:<path>"
+	sink, err := outbox.NewSink(os.Getenv("OUTBOX_SINK"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	webhooks := webhook.NewRepository(db)
+	go outbox.NewDispatcher(outbox.NewRepository(db), outbox.DefaultInterval, sink, webhook.NewSink(webhooks)).Run(context.Background())
+	go webhook.NewWorker(webhook.NewService(webhooks, nil), webhook.DefaultInterval).Run(context.Background())
+	go forecast.NewJob(forecast.NewService(forecast.NewRepository(db)), forecast.DefaultInterval).Run(context.Background())
+
+	// the writes made over gRPC drop the reports cached by the REST API too
+	reports := cache.NewMemory(cache.DefaultMaxEntries)
+
+	// the gRPC API listens on GRPC_PORT, next to the REST one
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = grpcserver.DefaultPort
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		if err := grpcserver.NewServer(db, reports).Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// RATE_LIMITS is a JSON policy, see ratelimit.ParsePolicy
+	policy, err := ratelimit.ParsePolicy(os.Getenv("RATE_LIMITS"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemory(), policy)
+
 	eng := gin.Default()
 
+	// TRUSTED_PROXIES is a comma separated list of the addresses or CIDRs of the proxies whose
+	// X-Forwarded-For is believed. None by default: the client IP the rate limits key on is the peer's.
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	if err := eng.SetTrustedProxies(proxies); err != nil {
+		log.Fatal(err)
+	}
+
 	eng.GET("/ping", func(c *gin.Context) { c.JSON(200, "pong") })
 
 	docs.SwaggerInfo.Host = "test--bootcamp-go-w7-s4-8-3.furyapps.io"
 	eng.GET("docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	router := routes.NewRouter(eng, db)
+	router := routes.NewRouter(eng, db, reports, limiter)
 	router.MapRoutes()
 
 	if err := eng.Run(); err != nil {
@@ -0,0 +1,25 @@
+// Package middleware holds the gin middlewares shared by every route.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+)
+
+const (
+	ActorHeader  = "X-Actor"
+	DefaultActor = "anonymous"
+)
+
+// Actor stores who performs the request, taken from the X-Actor header, so that the changes it makes
+// reach the audit trail under their name. Handlers pass the gin context down, where audit.Actor finds it.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := c.GetHeader(ActorHeader)
+		if actor == "" {
+			actor = DefaultActor
+		}
+		c.Set(audit.ActorKey, actor)
+		c.Next()
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Actor(t *testing.T) {
+	server := gin.New()
+	server.Use(Actor())
+	server.GET("/", func(c *gin.Context) {
+		actor, _ := audit.Actor(c)
+		c.String(http.StatusOK, actor)
+	})
+
+	t.Run("from header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(ActorHeader, "alice")
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, "alice", res.Body.String())
+	})
+
+	t.Run("anonymous without header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, DefaultActor, res.Body.String())
+	})
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+var ErrAdminOnly = errors.New("an admin api key is required")
+
+// Admin answers 403 to the requests guarded picks out unless they are made with one of keys in
+// X-Api-Key. A nil guarded picks out every request. Without keys no request is an admin's.
+func Admin(keys []string, guarded func(c *gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if guarded != nil && !guarded(c) {
+			c.Next()
+			return
+		}
+		if !IsAdminKey(keys, c.GetHeader(APIKeyHeader)) {
+			web.Error(c, http.StatusForbidden, ErrAdminOnly.Error())
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// IncludingDeleted picks out the listings asked for their soft deleted rows too.
+func IncludingDeleted(c *gin.Context) bool {
+	include, _ := strconv.ParseBool(c.Query("include_deleted"))
+	return include
+}
+
+// IsAdminKey reports whether key is one of keys, comparing in constant time.
+func IsAdminKey(keys []string, key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseKeys returns the keys of a comma separated list, such as the ADMIN_API_KEYS environment variable.
+func ParseKeys(list string) []string {
+	var keys []string
+	for _, key := range strings.Split(list, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerAdmin() *gin.Engine {
+	keys := []string{"admin-key"}
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	server := gin.New()
+	server.GET("/sellers", Admin(keys, IncludingDeleted), ok)
+	server.POST("/sellers/:id/restore", Admin(keys, nil), ok)
+	server.DELETE("/sellers/:id/hard", Admin(keys, nil), ok)
+	return server
+}
+
+func serve(server *gin.Engine, method, url, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, nil)
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, req)
+	return res
+}
+
+func Test_Admin(t *testing.T) {
+	server := createServerAdmin()
+
+	t.Run("listing without deleted rows is open", func(t *testing.T) {
+		res := serve(server, http.MethodGet, "/sellers", "")
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("include_deleted is forbidden without an admin key", func(t *testing.T) {
+		res := serve(server, http.MethodGet, "/sellers?include_deleted=true", "other-key")
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("restore is forbidden without an admin key", func(t *testing.T) {
+		res := serve(server, http.MethodPost, "/sellers/1/restore", "")
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("hard delete is forbidden without an admin key", func(t *testing.T) {
+		res := serve(server, http.MethodDelete, "/sellers/1/hard", "other-key")
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("admin key is let through", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(server, http.MethodGet, "/sellers?include_deleted=true", "admin-key").Code)
+		assert.Equal(t, http.StatusOK, serve(server, http.MethodPost, "/sellers/1/restore", "admin-key").Code)
+		assert.Equal(t, http.StatusOK, serve(server, http.MethodDelete, "/sellers/1/hard", "admin-key").Code)
+	})
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/export"
+)
+
+const (
+	CacheHeader = "X-Cache"
+	CacheHit    = "HIT"
+	CacheMiss   = "MISS"
+)
+
+// cachedResponse is what Cache stores of a response.
+type cachedResponse struct {
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// Invalidation lets the writes of the request drop the entries of c read from the tables they touch.
+func Invalidation(c cache.Cache) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(cache.Key, c)
+		ctx.Next()
+	}
+}
+
+// Cache answers a GET from c for ttl once it has been answered with 200, keyed by its URL. The entry is
+// tagged with the tables the response is read from, so that a write to any of them drops it. Responses
+// say whether they come from the cache in X-Cache, how long ago they were computed in Age and for how
+// long they are served in Cache-Control. A cache that fails is bypassed rather than failing the request.
+// Only JSON is cached: CSV and xlsx exports are streamed to the client as they are written instead of
+// being held whole in memory.
+func Cache(c cache.Cache, ttl time.Duration, tables ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet {
+			ctx.Next()
+			return
+		}
+		if format, err := export.Negotiate(ctx.Query("format"), ctx.GetHeader("Accept")); err != nil || format != "" {
+			ctx.Next()
+			return
+		}
+
+		key := ctx.Request.URL.RequestURI()
+		if entry, ok, err := c.Get(ctx, key); err == nil && ok {
+			var stored cachedResponse
+			if err := json.Unmarshal(entry.Value, &stored); err == nil {
+				age := entry.Age(time.Now())
+				ctx.Header(CacheHeader, CacheHit)
+				ctx.Header("Age", strconv.Itoa(int(age.Seconds())))
+				ctx.Header("Cache-Control", maxAge(entry.TTL-age))
+				ctx.Data(http.StatusOK, stored.ContentType, stored.Body)
+				ctx.Abort()
+				return
+			}
+		}
+
+		ctx.Header(CacheHeader, CacheMiss)
+		storedAt := time.Now()
+		recorder := &cacheRecorder{bodyRecorder: bodyRecorder{ResponseWriter: ctx.Writer}, ttl: ttl}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		if recorder.Status() != http.StatusOK {
+			return
+		}
+		value, err := json.Marshal(cachedResponse{
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			return
+		}
+		_ = c.Set(ctx, key, cache.Entry{Value: value, StoredAt: storedAt, TTL: ttl}, tables...)
+	}
+}
+
+// cacheRecorder keeps a copy of the response and tells clients to cache it too when it is a 200, the only
+// status stored.
+type cacheRecorder struct {
+	bodyRecorder
+	ttl time.Duration
+}
+
+func (w *cacheRecorder) WriteHeader(code int) {
+	if code == http.StatusOK {
+		w.Header().Set("Cache-Control", maxAge(w.ttl))
+	}
+	w.bodyRecorder.WriteHeader(code)
+}
+
+func maxAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return "max-age=" + strconv.Itoa(int(d.Seconds()))
+}
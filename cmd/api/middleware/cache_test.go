@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerCache(c cache.Cache, status *int) (*gin.Engine, *int) {
+	calls := 0
+	server := gin.New()
+	server.Use(Invalidation(c))
+	server.GET("/report", Cache(c, time.Minute, "sections"), func(ctx *gin.Context) {
+		calls++
+		if ctx.GetHeader("Accept") == "text/csv" {
+			ctx.Header("Content-Disposition", `attachment; filename="report.csv"`)
+			ctx.Data(*status, "text/csv", []byte("id\n1\n"))
+			return
+		}
+		ctx.JSON(*status, gin.H{"data": calls})
+	})
+	server.POST("/sections", func(ctx *gin.Context) {
+		cache.Invalidate(ctx, "sections")
+		ctx.Status(http.StatusCreated)
+	})
+	return server, &calls
+}
+
+func get(server *gin.Engine, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/report", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, req)
+	return res
+}
+
+func Test_Cache(t *testing.T) {
+	t.Run("second read is served from the cache", func(t *testing.T) {
+		// arrange
+		status := http.StatusOK
+		server, calls := createServerCache(cache.NewMemory(0), &status)
+
+		// act
+		first := get(server, "")
+		second := get(server, "")
+
+		// assert
+		assert.Equal(t, CacheMiss, first.Header().Get(CacheHeader))
+		assert.Equal(t, "max-age=60", first.Header().Get("Cache-Control"))
+		assert.Equal(t, CacheHit, second.Header().Get(CacheHeader))
+		assert.Equal(t, "0", second.Header().Get("Age"))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("exports are streamed, not cached", func(t *testing.T) {
+		// arrange
+		status := http.StatusOK
+		server, calls := createServerCache(cache.NewMemory(0), &status)
+		get(server, "")
+
+		// act
+		csv := get(server, "text/csv")
+		csvAgain := get(server, "text/csv")
+
+		// assert
+		assert.Empty(t, csv.Header().Get(CacheHeader))
+		assert.Empty(t, csvAgain.Header().Get(CacheHeader))
+		assert.Empty(t, csvAgain.Header().Get("Cache-Control"))
+		assert.Equal(t, `attachment; filename="report.csv"`, csvAgain.Header().Get("Content-Disposition"))
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		// arrange
+		status := http.StatusInternalServerError
+		server, calls := createServerCache(cache.NewMemory(0), &status)
+
+		// act
+		first := get(server, "")
+		second := get(server, "")
+
+		// assert
+		assert.Empty(t, first.Header().Get("Cache-Control"))
+		assert.Equal(t, CacheMiss, second.Header().Get(CacheHeader))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("a write to the table drops it", func(t *testing.T) {
+		// arrange
+		status := http.StatusOK
+		server, calls := createServerCache(cache.NewMemory(0), &status)
+		get(server, "")
+
+		// act
+		server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/sections", nil))
+		res := get(server, "")
+
+		// assert
+		assert.Equal(t, CacheMiss, res.Header().Get(CacheHeader))
+		assert.Equal(t, 2, *calls)
+	})
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/idempotency"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Idempotency makes a POST safe to retry when it carries an Idempotency-Key header. Keys belong to the
+// caller, told apart by X-Api-Key or else by actor, so that callers picking the same key do not see each
+// other's responses. The first request under a key is processed and its response stored; a retry with
+// the same body gets that response back without reaching the handler, while a retry with a different
+// body is refused with 422 and one arriving before the first has been answered with 409. Server errors
+// are not stored, so that they can be retried, and neither is a response that cannot be: its key is
+// released rather than left in progress until it expires. Requests without the header pass through
+// untouched.
+func Idempotency(service idempotency.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope, route := idempotencyScope(c), c.FullPath()
+		sum := sha256.Sum256(body)
+		stored, replay, err := service.Begin(c, scope, key, route, hex.EncodeToString(sum[:]))
+		if err != nil {
+			switch {
+			case errors.Is(err, idempotency.ErrKeyReused):
+				web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, idempotency.ErrInProgress):
+				web.Error(c, http.StatusConflict, err.Error())
+			default:
+				web.Error(c, http.StatusInternalServerError, err.Error())
+			}
+			c.Abort()
+			return
+		}
+		if replay {
+			c.Data(stored.Status, "application/json; charset=utf-8", stored.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if status := recorder.Status(); status < http.StatusInternalServerError {
+			err := service.Complete(c, scope, key, route, status, recorder.body.Bytes())
+			if err == nil {
+				return
+			}
+			log.Printf("idempotency: store the response of key %q on %s: %v", key, route, err)
+		}
+		if err := service.Release(c, scope, key, route); err != nil {
+			log.Printf("idempotency: release key %q on %s: %v", key, route, err)
+		}
+	}
+}
+
+// idempotencyScope is the caller the keys of the request belong to: its API key, hashed so that it is not
+// stored, or else its actor.
+func idempotencyScope(c *gin.Context) string {
+	if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+	actor, _ := audit.Actor(c)
+	return "actor:" + actor
+}
+
+// bodyRecorder keeps a copy of the response body written through it.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/idempotency"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryKeys is an idempotency.Repository kept in memory.
+type memoryKeys map[string]domain.IdempotencyKey
+
+func (m memoryKeys) Get(ctx context.Context, scope, key, route string) (domain.IdempotencyKey, error) {
+	k, ok := m[scope+route+key]
+	if !ok {
+		return domain.IdempotencyKey{}, idempotency.ErrNotFound
+	}
+	return k, nil
+}
+
+func (m memoryKeys) Reserve(ctx context.Context, k domain.IdempotencyKey, now time.Time) error {
+	if stored, ok := m[k.Scope+k.Route+k.Key]; ok && stored.ExpiresAt.After(now) {
+		return idempotency.ErrKeyTaken
+	}
+	m[k.Scope+k.Route+k.Key] = k
+	return nil
+}
+
+func (m memoryKeys) Complete(ctx context.Context, scope, key, route string, status int, body []byte) error {
+	k := m[scope+route+key]
+	k.Status, k.Body = status, body
+	m[scope+route+key] = k
+	return nil
+}
+
+func (m memoryKeys) Delete(ctx context.Context, scope, key, route string) error {
+	delete(m, scope+route+key)
+	return nil
+}
+
+// unstorableKeys is a memoryKeys whose responses cannot be stored.
+type unstorableKeys struct {
+	memoryKeys
+}
+
+func (m unstorableKeys) Complete(ctx context.Context, scope, key, route string, status int, body []byte) error {
+	return errors.New("db down")
+}
+
+// createServerIdempotency answers POST /orders with the number of times the handler ran and the body it
+// got, failing with 500 while fail is set.
+func createServerIdempotency(repo idempotency.Repository) (*gin.Engine, *int, *bool) {
+	calls, fail := 0, false
+	server := gin.New()
+	server.Use(Actor())
+	server.POST("/orders", Idempotency(idempotency.NewService(repo, time.Hour)), func(c *gin.Context) {
+		calls++
+		if fail {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "boom"})
+			return
+		}
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusCreated, gin.H{"calls": calls, "body": string(body)})
+	})
+	return server, &calls, &fail
+}
+
+func postOrder(server *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	return postOrderAs(server, "", "", key, body)
+}
+
+// postOrderAs posts an order made with apiKey by actor, each left out when empty.
+func postOrderAs(server *gin.Engine, apiKey, actor, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	if actor != "" {
+		req.Header.Set(ActorHeader, actor)
+	}
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, req)
+	return res
+}
+
+func Test_Idempotency(t *testing.T) {
+	t.Run("replays the original response", func(t *testing.T) {
+		server, calls, _ := createServerIdempotency(memoryKeys{})
+
+		first := postOrder(server, "k1", `{"id":1}`)
+		retry := postOrder(server, "k1", `{"id":1}`)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.JSONEq(t, `{"calls":1,"body":"{\"id\":1}"}`, retry.Body.String())
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("refuses a reused key with another payload", func(t *testing.T) {
+		server, calls, _ := createServerIdempotency(memoryKeys{})
+
+		postOrder(server, "k1", `{"id":1}`)
+		res := postOrder(server, "k1", `{"id":2}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("retries after a server error", func(t *testing.T) {
+		server, calls, fail := createServerIdempotency(memoryKeys{})
+
+		*fail = true
+		first := postOrder(server, "k1", `{"id":1}`)
+		*fail = false
+		retry := postOrder(server, "k1", `{"id":1}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("releases the key when the response cannot be stored", func(t *testing.T) {
+		server, calls, _ := createServerIdempotency(unstorableKeys{memoryKeys{}})
+
+		first := postOrder(server, "k1", `{"id":1}`)
+		retry := postOrder(server, "k1", `{"id":1}`)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("keeps the keys of different API keys apart", func(t *testing.T) {
+		server, calls, _ := createServerIdempotency(memoryKeys{})
+
+		first := postOrderAs(server, "key-a", "", "k1", `{"id":1}`)
+		other := postOrderAs(server, "key-b", "", "k1", `{"id":2}`)
+		retry := postOrderAs(server, "key-a", "", "k1", `{"id":1}`)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, other.Code)
+		assert.JSONEq(t, `{"calls":2,"body":"{\"id\":2}"}`, other.Body.String())
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("keeps the keys of different actors apart", func(t *testing.T) {
+		server, calls, _ := createServerIdempotency(memoryKeys{})
+
+		first := postOrderAs(server, "", "alice", "k1", `{"id":1}`)
+		other := postOrderAs(server, "", "bob", "k1", `{"id":1}`)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, other.Code)
+		assert.NotEqual(t, first.Body.String(), other.Body.String())
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("passes requests without a key through", func(t *testing.T) {
+		server, calls, _ := createServerIdempotency(memoryKeys{})
+
+		postOrder(server, "", `{"id":1}`)
+		postOrder(server, "", `{"id":1}`)
+
+		assert.Equal(t, 2, *calls)
+	})
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/ratelimit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+const APIKeyHeader = "X-Api-Key"
+
+// RateLimit takes a token from the bucket of the client for the class of the route, keyed by its
+// X-Api-Key or else its IP, and refuses the request with 429 and Retry-After once the bucket is empty.
+// The IP is the one ClientIP gives, so X-Forwarded-For only counts from the trusted proxies of the engine.
+// Every response carries the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of the
+// bucket. A store that fails lets the request through rather than failing it.
+func RateLimit(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := limiter.Allow(c, c.GetHeader(APIKeyHeader), c.ClientIP(), rateClass(c))
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			web.Error(c, http.StatusTooManyRequests, "rate limit exceeded, retry later")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// reportRoutes are the reports whose last path segment does not start with "report", as route suffixes.
+var reportRoutes = []string{"/inventory", "/products/:id/inventory", "/products/:id/forecast"}
+
+// rateClass tells reports, whose last path segment starts with "report" or listed in reportRoutes, apart
+// from the other reads and from writes.
+func rateClass(c *gin.Context) ratelimit.Class {
+	switch {
+	case c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead:
+		return ratelimit.Write
+	case strings.HasPrefix(path.Base(c.FullPath()), "report"):
+		return ratelimit.Report
+	}
+	for _, route := range reportRoutes {
+		if strings.HasSuffix(c.FullPath(), route) {
+			return ratelimit.Report
+		}
+	}
+	return ratelimit.Read
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerRateLimit() *gin.Engine {
+	policy := ratelimit.Policy{Default: ratelimit.Limits{
+		Read:   &ratelimit.Limit{Rate: 1, Burst: 2},
+		Write:  &ratelimit.Limit{Rate: 1, Burst: 1},
+		Report: &ratelimit.Limit{Rate: 1, Burst: 1},
+	}}
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	// like main without TRUSTED_PROXIES
+	server := gin.New()
+	_ = server.SetTrustedProxies(nil)
+	server.Use(RateLimit(ratelimit.NewLimiter(ratelimit.NewMemory(), policy)))
+	server.GET("/sections", ok)
+	server.GET("/sections/reportProducts", ok)
+	server.GET("/api/v1/inventory", ok)
+	server.GET("/api/v1/products/:id/inventory", ok)
+	server.GET("/api/v1/products/:id/forecast", ok)
+	server.POST("/productRecords", ok)
+	return server
+}
+
+func send(server *gin.Engine, method, path, ip string) *httptest.ResponseRecorder {
+	return sendForwarded(server, method, path, ip, "")
+}
+
+// sendForwarded sends a request from ip claiming, in X-Forwarded-For, to come from forwardedFor.
+func sendForwarded(server *gin.Engine, method, path, ip, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, req)
+	return res
+}
+
+func Test_RateLimit(t *testing.T) {
+	t.Run("headers on allowed requests", func(t *testing.T) {
+		// arrange
+		server := createServerRateLimit()
+
+		// act
+		res := send(server, http.MethodGet, "/sections", "10.0.0.1")
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "2", res.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", res.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", res.Header().Get("RateLimit-Reset"))
+	})
+
+	t.Run("429 with Retry-After once the bucket is empty", func(t *testing.T) {
+		// arrange
+		server := createServerRateLimit()
+		send(server, http.MethodPost, "/productRecords", "10.0.0.1")
+
+		// act
+		res := send(server, http.MethodPost, "/productRecords", "10.0.0.1")
+
+		// assert
+		assert.Equal(t, http.StatusTooManyRequests, res.Code)
+		assert.Equal(t, "1", res.Header().Get("Retry-After"))
+		assert.Equal(t, "0", res.Header().Get("RateLimit-Remaining"))
+	})
+
+	t.Run("classes and clients are limited apart", func(t *testing.T) {
+		// arrange
+		server := createServerRateLimit()
+		send(server, http.MethodPost, "/productRecords", "10.0.0.1")
+
+		// act
+		read := send(server, http.MethodGet, "/sections", "10.0.0.1")
+		report := send(server, http.MethodGet, "/sections/reportProducts", "10.0.0.1")
+		otherClient := send(server, http.MethodPost, "/productRecords", "10.0.0.2")
+
+		// assert
+		assert.Equal(t, http.StatusOK, read.Code)
+		assert.Equal(t, http.StatusOK, report.Code)
+		assert.Equal(t, "1", report.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, http.StatusOK, otherClient.Code)
+	})
+
+	t.Run("spoofed X-Forwarded-For shares the bucket of the peer", func(t *testing.T) {
+		// arrange
+		server := createServerRateLimit()
+		sendForwarded(server, http.MethodPost, "/productRecords", "10.0.0.1", "203.0.113.1")
+
+		// act
+		res := sendForwarded(server, http.MethodPost, "/productRecords", "10.0.0.1", "203.0.113.2")
+
+		// assert
+		assert.Equal(t, http.StatusTooManyRequests, res.Code)
+	})
+
+	t.Run("inventory and forecast are reports", func(t *testing.T) {
+		for _, path := range []string{"/api/v1/inventory", "/api/v1/products/1/inventory", "/api/v1/products/1/forecast"} {
+			// arrange
+			server := createServerRateLimit()
+
+			// act
+			res := send(server, http.MethodGet, path, "10.0.0.1")
+
+			// assert
+			assert.Equal(t, "1", res.Header().Get("RateLimit-Limit"), path)
+		}
+	})
+}
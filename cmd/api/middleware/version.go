@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/web"
+)
+
+const IfMatchHeader = "If-Match"
+
+// Version serves the optimistic concurrency of the row of table named by the :id parameter. On GET it
+// answers the version as an ETag, read before the handler reads the row so that a concurrent write can
+// only make it older than the body. On PATCH and DELETE it passes the If-Match version down to the write,
+// which then fails with etag.ErrPreconditionFailed once the row has moved on; "If-Match: *" skips the
+// check. A write without If-Match is refused with 428 when required is set.
+func Version(db *sql.DB, table string, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet:
+			if version, err := etag.Version(c, db, table, c.Param("id")); err == nil {
+				c.Header("ETag", etag.Format(version))
+			}
+		case http.MethodPatch, http.MethodDelete:
+			ifMatch := c.GetHeader(IfMatchHeader)
+			if ifMatch == "" && required {
+				web.Error(c, http.StatusPreconditionRequired, etag.ErrPreconditionRequired.Error())
+				c.Abort()
+				return
+			}
+			if version, ok := etag.Parse(ifMatch); ok {
+				c.Set(etag.VersionKey, version)
+			}
+		}
+		c.Next()
+	}
+}
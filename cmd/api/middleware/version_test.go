@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/stretchr/testify/assert"
+)
+
+func createServerVersion(t *testing.T, required bool) (*gin.Engine, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// answers the version the write would expect, -1 for none
+	answer := func(c *gin.Context) {
+		version, ok := etag.Expected(c)
+		if !ok {
+			version = -1
+		}
+		c.String(http.StatusOK, strconv.Itoa(version))
+	}
+
+	server := gin.New()
+	version := Version(db, "sellers", required)
+	server.GET("/sellers/:id", version, answer)
+	server.PATCH("/sellers/:id", version, answer)
+	server.DELETE("/sellers/:id", version, answer)
+	return server, mock
+}
+
+func Test_Version(t *testing.T) {
+	t.Run("GET answers the ETag", func(t *testing.T) {
+		server, mock := createServerVersion(t, true)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM sellers WHERE id=?")).WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+		req := httptest.NewRequest(http.MethodGet, "/sellers/1", nil)
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `"3"`, res.Header().Get("ETag"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("PATCH passes the If-Match version down", func(t *testing.T) {
+		server, _ := createServerVersion(t, true)
+		req := httptest.NewRequest(http.MethodPatch, "/sellers/1", nil)
+		req.Header.Set(IfMatchHeader, `"3"`)
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "3", res.Body.String())
+	})
+
+	t.Run("DELETE without If-Match when required", func(t *testing.T) {
+		server, _ := createServerVersion(t, true)
+		req := httptest.NewRequest(http.MethodDelete, "/sellers/1", nil)
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, res.Code)
+	})
+
+	t.Run("PATCH without If-Match when optional", func(t *testing.T) {
+		server, _ := createServerVersion(t, false)
+		req := httptest.NewRequest(http.MethodPatch, "/sellers/1", nil)
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "-1", res.Body.String())
+	})
+
+	t.Run("If-Match * skips the check", func(t *testing.T) {
+		server, _ := createServerVersion(t, true)
+		req := httptest.NewRequest(http.MethodPatch, "/sellers/1", nil)
+		req.Header.Set(IfMatchHeader, "*")
+		res := httptest.NewRecorder()
+
+		server.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "-1", res.Body.String())
+	})
+}
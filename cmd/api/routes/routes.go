@@ -2,21 +2,40 @@ package routes
 
 import (
 	"database/sql"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/cmd/api/handler"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/cmd/api/middleware"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/buyer"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/carry"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/country"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/employee"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/forecast"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/gql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/idempotency"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/importer"
 	inboundorder "github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inbound_order"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/inventory"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/locality"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/margin"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_batches"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_records"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_type"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/province"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/purchaseorder"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/replenishment"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/spend"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/transfer"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/webhook"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/ratelimit"
 )
 
 type Router interface {
@@ -27,10 +46,41 @@ type router struct {
 	eng *gin.Engine
 	rg  *gin.RouterGroup
 	db  *sql.DB
+	// ifMatchRequired refuses PATCH and DELETE of versioned resources sent without If-Match
+	ifMatchRequired bool
+	// idempotencyTTL is how long the responses given under an Idempotency-Key are replayed
+	idempotencyTTL time.Duration
+	// reports caches the reports, dropped by the writes to the tables they are read from
+	reports        cache.Cache
+	reportCacheTTL time.Duration
+	// limiter bounds the requests of each client
+	limiter *ratelimit.Limiter
+	// adminKeys are the API keys allowed to see, restore and hard delete soft deleted rows
+	adminKeys []string
 }
 
-func NewRouter(eng *gin.Engine, db *sql.DB) Router {
-	return &router{eng: eng, db: db}
+// NewRouter maps the API on eng. If-Match is checked whenever it is sent, and required on writes of
+// versioned resources only when the IF_MATCH_REQUIRED environment variable is "true". Idempotency
+// keys live for IDEMPOTENCY_TTL, a duration such as "12h", or idempotency.DefaultTTL when it is unset
+// or invalid. Reports are served from reports for REPORT_CACHE_TTL, or cache.DefaultTTL when it is
+// unset or invalid. Every request goes through limiter. Listing soft deleted rows, restoring and hard
+// deleting them take one of the comma separated API keys of ADMIN_API_KEYS.
+func NewRouter(eng *gin.Engine, db *sql.DB, reports cache.Cache, limiter *ratelimit.Limiter) Router {
+	ttl, _ := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	reportTTL, err := time.ParseDuration(os.Getenv("REPORT_CACHE_TTL"))
+	if err != nil || reportTTL <= 0 {
+		reportTTL = cache.DefaultTTL
+	}
+	return &router{
+		eng:             eng,
+		db:              db,
+		ifMatchRequired: os.Getenv("IF_MATCH_REQUIRED") == "true",
+		idempotencyTTL:  ttl,
+		reports:         reports,
+		reportCacheTTL:  reportTTL,
+		limiter:         limiter,
+		adminKeys:       middleware.ParseKeys(os.Getenv("ADMIN_API_KEYS")),
+	}
 }
 
 func (r *router) MapRoutes() {
@@ -43,29 +93,76 @@ func (r *router) MapRoutes() {
 	r.buildWarehouseRoutes()
 	r.buildEmployeeRoutes()
 	r.buildBuyerRoutes()
+	r.buildBuyerSpendRoutes()
 	r.buildCarriesRoutes()
 	r.buildPurchasOrderRoutes()
 	r.buildInoundOrderRoutes()
 	r.builLocalityRoutes()
 	r.buildProductRecordRoutes()
+	r.buildCountryRoutes()
+	r.buildProvinceRoutes()
+	r.buildInventoryRoutes()
+	r.buildMarginRoutes()
+	r.buildForecastRoutes()
+	r.buildReplenishmentRoutes()
+	r.buildTransferRoutes()
+	r.buildProductTypeRoutes()
+	r.buildImportRoutes()
+	r.buildAuditRoutes()
+	r.buildWebhookRoutes()
+	r.buildGraphQLRoutes()
 }
 
 func (r *router) setGroup() {
 	r.rg = r.eng.Group("/api/v1")
+	r.rg.Use(middleware.RateLimit(r.limiter), middleware.Actor(), middleware.Invalidation(r.reports))
 }
 
+// version serves the ETag and If-Match of the rows of table on the routes of a single row.
+func (r *router) version(table string) gin.HandlerFunc {
+	return middleware.Version(r.db, table, r.ifMatchRequired)
+}
+
+// admin keeps the route to the admin API keys.
+func (r *router) admin() gin.HandlerFunc {
+	return middleware.Admin(r.adminKeys, nil)
+}
+
+// adminIfDeleted keeps a listing to the admin API keys when it is asked for soft deleted rows.
+func (r *router) adminIfDeleted() gin.HandlerFunc {
+	return middleware.Admin(r.adminKeys, middleware.IncludingDeleted)
+}
+
+// cachedReport serves a report from the report cache until a write to one of tables.
+func (r *router) cachedReport(tables ...string) gin.HandlerFunc {
+	return middleware.Cache(r.reports, r.reportCacheTTL, tables...)
+}
+
+// idempotent replays the response of a POST retried under the same Idempotency-Key.
+func (r *router) idempotent() gin.HandlerFunc {
+	return middleware.Idempotency(idempotency.NewService(idempotency.NewRepository(r.db), r.idempotencyTTL))
+}
+
+// sellerReportTables are the tables the seller reports are read from.
+var sellerReportTables = []string{"sellers", "products", "products_batches", "purchase_orders", "product_records"}
+
 func (r *router) buildSellerRoutes() {
 	// Example
 	repo := seller.NewRepository(r.db)
 	service := seller.NewService(repo)
 	handler := handler.NewSeller(service)
+	version := r.version("sellers")
 	sr := r.rg.Group("/sellers")
 	{
-		sr.GET("/", handler.GetAll())
+		sr.GET("/", r.adminIfDeleted(), handler.GetAll())
 		sr.POST("/", handler.Create())
-		sr.GET("/:id", handler.Get())
-		sr.PATCH("/:id", handler.Update())
-		sr.DELETE("/:id", handler.Delete())
+		sr.GET("/report", r.cachedReport(sellerReportTables...), handler.GetReports())
+		sr.GET("/:id", version, handler.Get())
+		sr.GET("/:id/report", r.cachedReport(sellerReportTables...), handler.GetReport())
+		sr.PATCH("/:id", version, handler.Update())
+		sr.DELETE("/:id", version, handler.Delete())
+		sr.POST("/:id/restore", r.admin(), handler.Restore())
+		sr.DELETE("/:id/hard", r.admin(), handler.HardDelete())
 	}
 }
 
@@ -73,15 +170,18 @@ func (r *router) buildProductRoutes() {
 	repo := product.NewRepository(r.db)
 	service := product.NewService(repo)
 	handler := handler.NewProduct(service)
+	version := r.version("products")
 
 	pr := r.rg.Group("/products")
 	{
-		pr.GET("/", handler.GetAll())
-		pr.GET("/:id", handler.Get())
+		pr.GET("/", r.adminIfDeleted(), handler.GetAll())
+		pr.GET("/:id", version, handler.Get())
 		pr.POST("/", handler.Create())
-		pr.PATCH("/:id", handler.Update())
-		pr.DELETE("/:id", handler.Delete())
-		pr.GET("/reportRecords", handler.GetReport())
+		pr.PATCH("/:id", version, handler.Update())
+		pr.DELETE("/:id", version, handler.Delete())
+		pr.POST("/:id/restore", r.admin(), handler.Restore())
+		pr.DELETE("/:id/hard", r.admin(), handler.HardDelete())
+		pr.GET("/reportRecords", r.cachedReport("products", "product_records"), handler.GetReport())
 		pr.POST("/type", handler.CreateType())
 	}
 }
@@ -90,15 +190,18 @@ func (r *router) buildSectionRoutes() {
 	repo := section.NewRepository(r.db)
 	service := section.NewService(repo)
 	handler := handler.NewSection(service)
+	version := r.version("sections")
 
 	sections := r.rg.Group("/sections")
 	{
-		sections.GET("/", handler.GetAll())
-		sections.GET("/:id", handler.Get())
-		sections.GET("/reportProducts", handler.GetReportProducts())
+		sections.GET("/", r.adminIfDeleted(), handler.GetAll())
+		sections.GET("/:id", version, handler.Get())
+		sections.GET("/reportProducts", r.cachedReport("sections", "products_batches"), handler.GetReportProducts())
 		sections.POST("/", handler.Create())
-		sections.PATCH("/:id", handler.Update())
-		sections.DELETE("/:id", handler.Delete())
+		sections.PATCH("/:id", version, handler.Update())
+		sections.DELETE("/:id", version, handler.Delete())
+		sections.POST("/:id/restore", r.admin(), handler.Restore())
+		sections.DELETE("/:id/hard", r.admin(), handler.HardDelete())
 	}
 }
 
@@ -109,7 +212,7 @@ func (r *router) buildProductBatchesRoutes() {
 
 	productBatches := r.rg.Group("/productBatches")
 	{
-		productBatches.POST("/", handler.Create())
+		productBatches.POST("/", r.idempotent(), handler.Create())
 	}
 }
 
@@ -118,15 +221,19 @@ func (r *router) buildWarehouseRoutes() {
 	repo := warehouse.NewRepository(r.db)
 	service := warehouse.NewService(repo)
 	handler := handler.NewWarehouse(service)
+	version := r.version("warehouses")
 
 	//r.eng.GET("/ping", func(c *gin.Context) { c.String(200, "pong") })
 	wareH := r.rg.Group("/warehouses")
 	{
-		wareH.GET("", handler.GetAll()) //http://localhost:8080/api/v1/warehouses
-		wareH.GET(":id", handler.Get()) //http://localhost:8080/api/v1/warehouses/2
+		wareH.GET("", r.adminIfDeleted(), handler.GetAll()) //http://localhost:8080/api/v1/warehouses
+		wareH.GET("/nearest", handler.GetNearest())         //http://localhost:8080/api/v1/warehouses/nearest?locality_id=6700&max_distance=50
+		wareH.GET(":id", version, handler.Get())            //http://localhost:8080/api/v1/warehouses/2
 		wareH.POST("", handler.Create())
-		wareH.PATCH(":id", handler.Update())
-		wareH.DELETE(":id", handler.Delete())
+		wareH.PATCH(":id", version, handler.Update())
+		wareH.DELETE(":id", version, handler.Delete())
+		wareH.POST(":id/restore", r.admin(), handler.Restore())
+		wareH.DELETE(":id/hard", r.admin(), handler.HardDelete())
 	}
 
 }
@@ -145,7 +252,7 @@ func (r *router) buildCarriesRoutes() {
 
 	}
 
-	r.rg.GET("/localities/reportCarries", handler.GetAllByLocality()) //http://localhost:8080/api/v1/localities/reportCarries?id=2001
+	r.rg.GET("/localities/reportCarries", r.cachedReport("localities", "carries"), handler.GetAllByLocality()) //http://localhost:8080/api/v1/localities/reportCarries?id=2001
 
 }
 
@@ -153,14 +260,18 @@ func (r *router) buildEmployeeRoutes() {
 	repo := employee.NewRepository(r.db)
 	service := employee.NewService(repo)
 	handler := handler.NewEmployee(service)
+	version := r.version("employees")
 
 	rEmp := r.rg.Group("/employees")
-	rEmp.GET("", handler.GetAll())
-	rEmp.GET("/:id", handler.Get())
+	rEmp.GET("", r.adminIfDeleted(), handler.GetAll())
+	rEmp.GET("/:id", version, handler.Get())
 	rEmp.POST("", handler.Create())
-	rEmp.PATCH("/:id", handler.Update())
-	rEmp.DELETE("/:id", handler.Delete())
-	rEmp.GET("/reportInboundOrders", handler.GetAllWithInboundOrders())
+	rEmp.PATCH("/:id", version, handler.Update())
+	rEmp.DELETE("/:id", version, handler.Delete())
+	rEmp.POST("/:id/restore", r.admin(), handler.Restore())
+	rEmp.DELETE("/:id/hard", r.admin(), handler.HardDelete())
+	rEmp.GET("/reportInboundOrders", r.cachedReport("employees", "inbound_orders", "products_batches"), handler.GetAllWithInboundOrders())
+	rEmp.GET("/reportLeaderboard", r.cachedReport("employees", "inbound_orders", "products_batches", "warehouses"), handler.Leaderboard())
 
 }
 
@@ -168,24 +279,33 @@ func (r *router) buildBuyerRoutes() {
 	// Example
 	//instances
 	repo := buyer.NewRepository(r.db)
-	service := buyer.NewService(repo)
+	service := buyer.NewAuditedService(r.db, buyer.NewService(repo))
 	handler := handler.NewBuyer(service)
 
 	//endpoints
 	r.rg.GET("/buyers", handler.GetAll())
 	r.rg.GET("/buyers/:id", handler.Get())
-	r.rg.GET("/buyers/reportPurchaseOrders", handler.GetReport())
+	r.rg.GET("/buyers/reportPurchaseOrders", r.cachedReport("buyers", "purchase_orders"), handler.GetReport())
 	r.rg.POST("/buyers", handler.Create())
 	r.rg.PATCH("/buyers/:id", handler.Update())
 	r.rg.DELETE("/buyers/:id", handler.Delete())
 }
 
+func (r *router) buildBuyerSpendRoutes() {
+	repo := spend.NewRepository(r.db)
+	service := spend.NewService(repo)
+	handler := handler.NewBuyerSpend(service)
+
+	r.rg.GET("/buyers/reportSpend", r.cachedReport("buyers", "purchase_orders", "product_records", "products"), handler.GetSpend())
+	r.rg.GET("/buyers/reportCohorts", r.cachedReport("purchase_orders", "product_records"), handler.GetCohorts())
+}
+
 func (r *router) buildPurchasOrderRoutes() {
 	repo := purchaseorder.NewRepository(r.db)
 	service := purchaseorder.NewService(repo)
 	handler := handler.NewPurchaseOrder(service)
 
-	r.rg.POST("/purchaseorders", handler.Create())
+	r.rg.POST("/purchaseorders", r.idempotent(), handler.Create())
 }
 
 func (r *router) buildInoundOrderRoutes() {
@@ -194,18 +314,27 @@ func (r *router) buildInoundOrderRoutes() {
 	handler := handler.NewInoudOrder(service)
 
 	rEmp := r.rg.Group("/inboundOrders")
-	rEmp.POST("", handler.Create())
+	rEmp.POST("", r.idempotent(), handler.Create())
 }
 
 func (r *router) builLocalityRoutes() {
 	repo := locality.NewRepository(r.db)
 	service := locality.NewService(repo)
 	handler := handler.NewLocality(service)
+	version := r.version("localities")
 	sr := r.rg.Group("/localities")
 
 	//endpoints
+	sr.GET("", handler.GetAll())
+	sr.GET("/:id", version, handler.Get())
 	sr.POST("", handler.Create())
-	sr.GET("/reportSellers", handler.GetQuantitySellerByLocality())
+	sr.PATCH("/:id", version, handler.Update())
+	sr.DELETE("/:id", version, handler.Delete())
+	sr.GET("/report", r.cachedReport("localities", "sellers", "carries", "warehouses", "buyers"), handler.GetReport())
+	sr.GET("/reportSellers", r.cachedReport("localities", "sellers"), handler.GetQuantitySellerByLocality())
+	sr.GET("/reportWarehouses", r.cachedReport("localities", "warehouses"), handler.GetQuantityWarehouseByLocality())
+
+	r.rg.GET("/provinces/:id/localities", handler.GetByProvince())
 }
 
 func (r *router) buildProductRecordRoutes() {
@@ -218,3 +347,156 @@ func (r *router) buildProductRecordRoutes() {
 		pr.POST("/", handler.Create())
 	}
 }
+
+func (r *router) buildCountryRoutes() {
+	repo := country.NewRepository(r.db)
+	service := country.NewService(repo)
+	handler := handler.NewCountry(service)
+	version := r.version("countries")
+
+	cr := r.rg.Group("/countries")
+	{
+		cr.GET("", handler.GetAll())
+		cr.GET("/:id", version, handler.Get())
+		cr.POST("", handler.Create())
+		cr.PATCH("/:id", version, handler.Update())
+		cr.DELETE("/:id", version, handler.Delete())
+	}
+}
+
+func (r *router) buildProvinceRoutes() {
+	repo := province.NewRepository(r.db)
+	service := province.NewService(repo)
+	handler := handler.NewProvince(service)
+	version := r.version("provinces")
+
+	pr := r.rg.Group("/provinces")
+	{
+		pr.GET("", handler.GetAll())
+		pr.GET("/:id", version, handler.Get())
+		pr.POST("", handler.Create())
+		pr.PATCH("/:id", version, handler.Update())
+		pr.DELETE("/:id", version, handler.Delete())
+	}
+
+	r.rg.GET("/countries/:id/provinces", handler.GetByCountry())
+}
+
+func (r *router) buildInventoryRoutes() {
+	repo := inventory.NewRepository(r.db)
+	service := inventory.NewService(repo)
+	handler := handler.NewInventory(service)
+
+	r.rg.GET("/inventory", handler.GetSummary())                //http://localhost:8080/api/v1/inventory?seller_id=1&product_type_id=2
+	r.rg.GET("/products/:id/inventory", handler.GetByProduct()) //http://localhost:8080/api/v1/products/1/inventory
+}
+
+func (r *router) buildMarginRoutes() {
+	repo := margin.NewRepository(r.db)
+	service := margin.NewService(repo)
+	handler := handler.NewMargin(service)
+
+	r.rg.GET("/products/reportMargins", r.cachedReport("products", "product_records", "purchase_orders"), handler.GetMargins()) //http://localhost:8080/api/v1/products/reportMargins?group_by=seller&from=2023-01-01
+}
+
+func (r *router) buildForecastRoutes() {
+	repo := forecast.NewRepository(r.db)
+	service := forecast.NewService(repo)
+	handler := handler.NewForecast(service)
+
+	r.rg.GET("/products/:id/forecast", handler.GetForecast()) //http://localhost:8080/api/v1/products/1/forecast?horizon=30&alpha=0.5
+}
+
+func (r *router) buildReplenishmentRoutes() {
+	repo := replenishment.NewRepository(r.db)
+	service := replenishment.NewService(repo)
+	handler := handler.NewReplenishment(service)
+
+	r.rg.GET("/warehouses/:id/reorderPoints", handler.GetReorderPoints())
+	r.rg.PUT("/warehouses/:id/reorderPoints/:product_id", handler.SaveReorderPoint())
+	r.rg.DELETE("/warehouses/:id/reorderPoints/:product_id", handler.DeleteReorderPoint())
+	r.rg.GET("/warehouses/reportReplenishment", r.cachedReport("reorder_points", "products_batches", "sections", "purchase_orders", "product_records", "products", "sellers", "warehouses"), handler.GetSuggestions()) //http://localhost:8080/api/v1/warehouses/reportReplenishment?warehouse_id=1&days=30&cover_days=14
+}
+
+func (r *router) buildTransferRoutes() {
+	repo := transfer.NewRepository(r.db)
+	service := transfer.NewService(repo)
+	handler := handler.NewTransfer(service)
+
+	tr := r.rg.Group("/transfers")
+	{
+		tr.GET("", handler.GetAll())
+		tr.GET("/:id", handler.Get())
+		tr.POST("", handler.Create())
+	}
+}
+
+func (r *router) buildProductTypeRoutes() {
+	repo := product_type.NewRepository(r.db)
+	service := product_type.NewService(repo)
+	handler := handler.NewProductType(service)
+	version := r.version("product_types")
+
+	pt := r.rg.Group("/productTypes")
+	{
+		pt.GET("", handler.GetAll())
+		pt.GET("/:id", version, handler.Get())
+		pt.POST("", handler.Create())
+		pt.PATCH("/:id", version, handler.Update())
+		pt.DELETE("/:id", version, handler.Delete())
+		pt.GET("/:id/products", handler.GetProducts())
+		pt.GET("/:id/sections", handler.GetSections())
+	}
+}
+
+func (r *router) buildImportRoutes() {
+	repo := importer.NewRepository(r.db)
+	service := importer.NewService(repo)
+	handler := handler.NewImport(service)
+
+	//http://localhost:8080/api/v1/sellers/import?mode=best_effort&chunk_size=200
+	for _, resource := range importer.Resources() {
+		r.rg.POST("/"+resource+"/import", handler.Import(resource))
+	}
+}
+
+func (r *router) buildAuditRoutes() {
+	repo := audit.NewRepository(r.db)
+	service := audit.NewService(repo)
+	handler := handler.NewAudit(service)
+
+	r.rg.GET("/audit", handler.GetAll())
+}
+
+func (r *router) buildWebhookRoutes() {
+	repo := webhook.NewRepository(r.db)
+	service := webhook.NewService(repo, nil)
+	handler := handler.NewWebhook(service)
+
+	wh := r.rg.Group("/webhooks")
+	{
+		wh.GET("", handler.GetAll())
+		wh.POST("", handler.Create())
+		wh.GET("/:id", handler.Get())
+		wh.DELETE("/:id", handler.Delete())
+		wh.GET("/:id/deliveries", handler.GetDeliveries())
+		wh.POST("/:id/deliveries/:delivery_id/redeliver", handler.Redeliver())
+	}
+}
+
+func (r *router) buildGraphQLRoutes() {
+	service, err := gql.NewService(gql.Services{
+		Sellers:    seller.NewService(seller.NewRepository(r.db)),
+		Products:   product.NewService(product.NewRepository(r.db)),
+		Batches:    product_batches.NewService(product_batches.NewRepository(r.db)),
+		Sections:   section.NewService(section.NewRepository(r.db)),
+		Warehouses: warehouse.NewService(warehouse.NewRepository(r.db)),
+	}, gql.DefaultLimits)
+	if err != nil {
+		// the schema is fixed, failing to build it is a bug
+		panic(err)
+	}
+	handler := handler.NewGraphQL(service)
+
+	r.rg.POST("/graphql", handler.Query())
+}
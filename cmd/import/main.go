@@ -0,0 +1,58 @@
+// Command import loads a CSV file into the database, the same way POST /api/v1/{resource}/import does.
+//
+//	go run ./cmd/import -resource sellers -file sellers.csv -mode best_effort -chunk 200
+//
+// It prints the import result as JSON and exits with status 1 when any row was rejected.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/importer"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/database"
+)
+
+func main() {
+	resource := flag.String("resource", "", "one of "+strings.Join(importer.Resources(), ", "))
+	path := flag.String("file", "", "path to the CSV file")
+	mode := flag.String("mode", importer.ModeAllOrNothing, "all_or_nothing or best_effort")
+	chunk := flag.Int("chunk", importer.DefaultChunkSize, "rows per transaction in best_effort mode")
+	flag.Parse()
+
+	if *resource == "" || *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	db, err := database.NewDatabaseConnection()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	service := importer.NewService(importer.NewRepository(db))
+	result, err := service.Import(context.Background(), *resource, file, importer.Options{Mode: *mode, ChunkSize: *chunk})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		log.Fatal(err)
+	}
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,8 +1,26 @@
+create table countries(
+    `id` int not null primary key auto_increment,
+    country_name varchar(50) not null unique,
+    version int not null default 1
+);
+
+create table provinces(
+    `id` int not null primary key auto_increment,
+    province_name varchar(50) not null,
+    country_id int not null,
+    version int not null default 1,
+    unique (country_id, province_name),
+    foreign key (country_id) references countries(id)
+);
+
 create table localities(
     `id` varchar(50) not null primary key,
     local_name text not null,
-    province_name text not null,
-    country_name text not null
+    province_id int not null,
+    latitude double null,
+    longitude double null,
+    version int not null default 1,
+    foreign key (province_id) references provinces(id)
 );
 
 create table sellers(
@@ -12,11 +30,14 @@ create table sellers(
     `address` text not null,
     telephone varchar(15) not null,
     locality_id varchar(50) not null,
+    deleted_at datetime null,
+    version int not null default 1,
     foreign key (locality_id ) references localities(id)
 );
 create table product_types(
     `id` int not null primary key auto_increment,
-    `name` varchar(50) not null
+    `name` varchar(50) not null unique,
+    version int not null default 1
 );
 
 create table products(
@@ -32,6 +53,8 @@ create table products(
     width float not null,
     id_product_type int not null,
     id_seller int not null,
+    deleted_at datetime null,
+    version int not null default 1,
     foreign key (id_seller) references sellers(id),
     foreign key (id_product_type) references product_types(id)
 );
@@ -41,7 +64,13 @@ create table warehouses(
     telephone text null,
     warehouse_code text null,
     minimum_capacity int null,
-    minimum_temperature int null
+    minimum_temperature int null,
+    locality_id varchar(50) null,
+    latitude double null,
+    longitude double null,
+    deleted_at datetime null,
+    version int not null default 1,
+    foreign key (locality_id) references localities(id)
 );
 create table employees(
     `id` int not null primary key auto_increment,
@@ -49,6 +78,8 @@ create table employees(
     first_name text not null,
     last_name text not null,
     warehouse_id int not null,
+    deleted_at datetime null,
+    version int not null default 1,
     foreign key (warehouse_id) references warehouses(id)
 );
 
@@ -62,6 +93,8 @@ create table sections(
     maximum_capacity int not null,
     warehouse_id int not null,
     id_product_type int not null,
+    deleted_at datetime null,
+    version int not null default 1,
     foreign key (warehouse_id) references warehouses(id),
     foreign key (id_product_type) references product_types(id)
 );
@@ -70,7 +103,9 @@ create table buyers(
     `id` int not null primary key auto_increment,
     card_number_id text not null,
     first_name text not null,
-    last_name text not null
+    last_name text not null,
+    locality_id varchar(50) null,
+    foreign key (locality_id) references localities(id)
 );
 
 /* tablas sprint 2 */
@@ -135,3 +170,106 @@ CREATE TABLE purchase_orders (
     FOREIGN KEY (`product_record_id`) references product_records(`id`),
     FOREIGN KEY (`order_status_id`) references inbound_orders (`id`)
 );
+
+create table transfers(
+    `id` int not null primary key auto_increment,
+    transfer_date datetime not null,
+    product_batch_id int not null,
+    destination_batch_id int not null,
+    origin_section_id int not null,
+    destination_section_id int not null,
+    quantity int not null,
+    employee_id int not null,
+    foreign key (product_batch_id) references products_batches(id),
+    foreign key (destination_batch_id) references products_batches(id),
+    foreign key (origin_section_id) references sections(id),
+    foreign key (destination_section_id) references sections(id),
+    foreign key (employee_id) references employees(id)
+);
+
+create table audit_log(
+    `id` int not null primary key auto_increment,
+    actor varchar(100) not null,
+    created_at datetime not null,
+    resource varchar(50) not null,
+    resource_id varchar(50) not null,
+    action varchar(20) not null,
+    before_data json null,
+    after_data json null,
+    index audit_log_resource (resource, resource_id),
+    index audit_log_created_at (created_at)
+);
+
+create table idempotency_keys(
+    scope varchar(255) not null default '',
+    idempotency_key varchar(255) not null,
+    route varchar(100) not null,
+    request_hash char(64) not null,
+    status int null,
+    body blob null,
+    expires_at datetime not null,
+    primary key (scope, idempotency_key, route),
+    index idempotency_keys_expires_at (expires_at)
+);
+
+create table outbox(
+    `id` int not null primary key auto_increment,
+    event_type varchar(100) not null,
+    resource varchar(50) not null,
+    resource_id varchar(50) not null,
+    payload json null,
+    created_at datetime not null,
+    published_at datetime null,
+    attempts int not null default 0,
+    last_error varchar(500) null,
+    parked_at datetime null,
+    index outbox_published_at (published_at)
+);
+
+create table webhooks(
+    `id` int not null primary key auto_increment,
+    url varchar(500) not null,
+    event_types varchar(500) not null,
+    secret varchar(255) not null,
+    created_at datetime not null
+);
+
+create table webhook_deliveries(
+    `id` int not null primary key auto_increment,
+    webhook_id int not null,
+    event_id int not null,
+    event_type varchar(100) not null,
+    payload json not null,
+    status varchar(20) not null,
+    attempts int not null default 0,
+    response_status int null,
+    last_error varchar(500) null,
+    next_attempt_at datetime not null,
+    delivered_at datetime null,
+    created_at datetime not null,
+    unique webhook_deliveries_event (webhook_id, event_id),
+    index webhook_deliveries_due (status, next_attempt_at),
+    foreign key (webhook_id) references webhooks(id) on delete cascade
+);
+
+create table product_forecasts(
+    `id` int not null primary key auto_increment,
+    product_id int not null,
+    forecast_date date not null,
+    moving_average float not null,
+    exponential_smoothing float not null,
+    generated_at datetime not null,
+    unique product_forecasts_day (product_id, forecast_date),
+    foreign key (product_id) references products(id)
+);
+
+create table reorder_points(
+    `id` int not null primary key auto_increment,
+    warehouse_id int not null,
+    product_id int not null,
+    reorder_point int not null,
+    safety_stock int not null,
+    unique reorder_points_product (warehouse_id, product_id),
+    foreign key (warehouse_id) references warehouses(id),
+    foreign key (product_id) references products(id)
+);
@@ -0,0 +1,242 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+)
+
+// Actions
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionDelete     = "delete"
+	ActionRestore    = "restore"
+	ActionHardDelete = "hard_delete"
+)
+
+// ActorKey is the context key holding who performs a request. It is a string so that the middleware can
+// set it on the gin context the handlers pass down as their context.
+const ActorKey = "audit.actor"
+
+// ErrRecord is a failure of the transaction around a change rather than of the change itself.
+var ErrRecord = errors.New("audit: cannot record change")
+
+var QueryInsert = "INSERT INTO audit_log (actor, created_at, resource, resource_id, action, before_data, after_data) VALUES (?, UTC_TIMESTAMP(), ?, ?, ?, ?, ?);"
+
+// Execer runs statements on the database or on the transaction an audit entry shares with its change.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Change names the row a mutation touches. Table is also the resource reported in the trail and ID is
+// nil for a create, whose id is the one the mutation returns.
+type Change struct {
+	Table  string
+	ID     interface{}
+	Action string
+}
+
+// WithActor returns a context whose changes are recorded as made by actor, for callers outside the API.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ActorKey, actor)
+}
+
+// Actor returns who performs the changes made with ctx, if anyone does.
+func Actor(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(ActorKey).(string)
+	return actor, ok && actor != ""
+}
+
+// Mutate runs fn, the statements of one change, and records it in the audit trail within the same
+// transaction, so that either both or neither are stored. An update or delete expecting a version, see
+// etag.Expected, first locks the row and fails with etag.ErrPreconditionFailed when it has moved on.
+// The event ctx emits, see outbox.WithEvent, is written to the outbox in the transaction too.
+// fn returns the id of a created row and the errors of its own contract, which Mutate passes through.
+// Without an actor, an expected version or an event in ctx fn runs straight on db.
+// Once the change is stored, the entries of the cache of ctx read from its table are dropped.
+func Mutate(ctx context.Context, db *sql.DB, change Change, fn func(ex Execer) (int, error)) (int, error) {
+	id, err := mutate(ctx, db, change, fn)
+	if err == nil {
+		// the change is stored even when this fails, the entries then live out their TTL
+		_ = cache.Invalidate(ctx, change.Table)
+	}
+	return id, err
+}
+
+func mutate(ctx context.Context, db *sql.DB, change Change, fn func(ex Execer) (int, error)) (int, error) {
+	_, audited := Actor(ctx)
+	_, conditional := etag.Expected(ctx)
+	_, emitting := outbox.Emitted(ctx)
+	if !audited && !conditional && !emitting {
+		return fn(db)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrRecord, err)
+	}
+	defer tx.Rollback()
+
+	if change.Action == ActionUpdate || change.Action == ActionDelete {
+		if err := etag.Check(ctx, tx, change.Table, change.ID); err != nil {
+			if errors.Is(err, etag.ErrPreconditionFailed) {
+				return 0, err
+			}
+			return 0, fmt.Errorf("%w: %v", ErrRecord, err)
+		}
+	}
+
+	var before map[string]interface{}
+	if audited && change.ID != nil {
+		if before, err = Snapshot(ctx, tx, change.Table, change.ID); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrRecord, err)
+		}
+	}
+
+	id, err := fn(tx)
+	if err != nil {
+		return id, err
+	}
+	if id != 0 {
+		change.ID = id
+	}
+
+	if audited || emitting {
+		after, err := Snapshot(ctx, tx, change.Table, change.ID)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrRecord, err)
+		}
+		if err := Record(ctx, tx, change, before, after); err != nil {
+			return 0, err
+		}
+		if err := outbox.Write(ctx, tx, change.Table, change.ID, after); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrRecord, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrRecord, err)
+	}
+	return id, nil
+}
+
+// Record writes the entry of a change whose before and after rows are already known, for code that runs
+// its own transaction. Only the columns that differ are kept and a change without any is not recorded.
+func Record(ctx context.Context, ex Execer, change Change, before, after map[string]interface{}) error {
+	actor, ok := Actor(ctx)
+	if !ok {
+		return nil
+	}
+
+	before, after = diff(before, after)
+	if before == nil && after == nil {
+		return nil
+	}
+	beforeData, err := marshal(before)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRecord, err)
+	}
+	afterData, err := marshal(after)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRecord, err)
+	}
+
+	if _, err := ex.ExecContext(ctx, QueryInsert, actor, change.Table, fmt.Sprint(change.ID), change.Action, beforeData, afterData); err != nil {
+		return fmt.Errorf("%w: %v", ErrRecord, err)
+	}
+	return nil
+}
+
+// RecordAfter reads the row change names, as left by the statements just run on ex, and records it against
+// before. It is the end of Mutate for code that runs its own transaction and reads nothing without an actor.
+func RecordAfter(ctx context.Context, ex Execer, change Change, before map[string]interface{}) error {
+	if _, ok := Actor(ctx); !ok {
+		return nil
+	}
+
+	after, err := Snapshot(ctx, ex, change.Table, change.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRecord, err)
+	}
+	return Record(ctx, ex, change, before, after)
+}
+
+// Snapshot reads the row of table with the given id as column name to value, or nil when there is none.
+func Snapshot(ctx context.Context, ex Execer, table string, id interface{}) (map[string]interface{}, error) {
+	rows, err := ex.QueryContext(ctx, "SELECT * FROM "+table+" WHERE id=?", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		// the driver hands text columns over as bytes
+		if b, ok := values[i].([]byte); ok {
+			row[column] = string(b)
+			continue
+		}
+		row[column] = values[i]
+	}
+	return row, nil
+}
+
+// diff keeps the columns whose value changed between before and after. A missing row stays nil.
+func diff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	if before == nil || after == nil {
+		return before, after
+	}
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for column, value := range after {
+		if !reflect.DeepEqual(before[column], value) {
+			changedBefore[column] = before[column]
+			changedAfter[column] = value
+		}
+	}
+	if len(changedAfter) == 0 {
+		return nil, nil
+	}
+	return changedBefore, changedAfter
+}
+
+func marshal(row map[string]interface{}) (interface{}, error) {
+	if row == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,269 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	querySnapshot = "SELECT * FROM sellers WHERE id=?"
+	queryUpdate   = "UPDATE sellers SET company_name=? WHERE id=?"
+	queryInsert   = "INSERT INTO sellers (company_name) VALUES (?)"
+)
+
+func update(ex Execer) (int, error) {
+	_, err := ex.Exec(queryUpdate, "b", 1)
+	return 0, err
+}
+
+func Test_Mutate(t *testing.T) {
+	t.Run("without actor runs on the database", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// act
+		_, err = Mutate(context.Background(), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update records the changed columns", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		columns := []string{"id", "cid", "company_name"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows(columns).AddRow(1, 10, []byte("a")))
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows(columns).AddRow(1, 10, []byte("b")))
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).
+			WithArgs("alice", "sellers", "1", ActionUpdate, `{"company_name":"a"}`, `{"company_name":"b"}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// act
+		_, err = Mutate(WithActor(context.Background(), "alice"), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("create records the new row under its id", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(queryInsert)).WithArgs("a").WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(7).WillReturnRows(mock.NewRows([]string{"id", "company_name"}).AddRow(7, "a"))
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).
+			WithArgs("alice", "sellers", "7", ActionCreate, nil, `{"company_name":"a","id":7}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// act
+		id, err := Mutate(WithActor(context.Background(), "alice"), db, Change{Table: "sellers", Action: ActionCreate}, func(ex Execer) (int, error) {
+			res, err := ex.Exec(queryInsert, "a")
+			if err != nil {
+				return 0, err
+			}
+			id, err := res.LastInsertId()
+			return int(id), err
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unchanged row is not recorded", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		columns := []string{"id", "company_name"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows(columns).AddRow(1, "b"))
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows(columns).AddRow(1, "b"))
+		mock.ExpectCommit()
+
+		// act
+		_, err = Mutate(WithActor(context.Background(), "alice"), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed change is rolled back with its own error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		errChange := errors.New("seller not found")
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		// act
+		_, err = Mutate(WithActor(context.Background(), "alice"), db, Change{Table: "sellers", ID: 1, Action: ActionDelete}, func(ex Execer) (int, error) {
+			return 0, errChange
+		})
+
+		// assert
+		assert.Equal(t, errChange, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed entry rolls back the change", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows([]string{"id", "company_name"}).AddRow(1, "a"))
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).WillReturnRows(mock.NewRows([]string{"id", "company_name"}).AddRow(1, "b"))
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WillReturnError(errors.New("table is full"))
+		mock.ExpectRollback()
+
+		// act
+		_, err = Mutate(WithActor(context.Background(), "alice"), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.ErrorIs(t, err, ErrRecord)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Mutate_Version(t *testing.T) {
+	queryCheck := regexp.QuoteMeta("SELECT version FROM sellers WHERE id=? FOR UPDATE")
+
+	t.Run("stale version is refused before the change", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryCheck).WithArgs(1).WillReturnRows(mock.NewRows([]string{"version"}).AddRow(4))
+		mock.ExpectRollback()
+
+		// act
+		_, err = Mutate(etag.WithVersion(context.Background(), 3), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.Equal(t, etag.ErrPreconditionFailed, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("current version applies without an actor", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectQuery(queryCheck).WithArgs(1).WillReturnRows(mock.NewRows([]string{"version"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// act
+		_, err = Mutate(etag.WithVersion(context.Background(), 3), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Mutate_Event(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(querySnapshot)).WithArgs(1).
+		WillReturnRows(mock.NewRows([]string{"id", "company_name"}).AddRow(1, []byte("b")))
+	mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+		WithArgs(outbox.SectionUpdated, "sellers", "1", `{"company_name":"b","id":1}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// act
+	_, err = Mutate(outbox.WithEvent(context.Background(), outbox.SectionUpdated), db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Mutate_Cache(t *testing.T) {
+	cached := func(c cache.Cache) bool {
+		_, ok, _ := c.Get(context.Background(), "report")
+		return ok
+	}
+	withReport := func() (*cache.Memory, context.Context) {
+		c := cache.NewMemory(0)
+		c.Set(context.Background(), "report", cache.Entry{Value: []byte("[]"), StoredAt: time.Now(), TTL: time.Minute}, "sellers")
+		return c, cache.WithCache(context.Background(), c)
+	}
+
+	t.Run("a stored change drops the entries of its table", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		c, ctx := withReport()
+
+		// act
+		_, err = Mutate(ctx, db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, cached(c))
+	})
+
+	t.Run("a failed change keeps them", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectExec(regexp.QuoteMeta(queryUpdate)).WithArgs("b", 1).WillReturnError(errors.New("deadlock"))
+		c, ctx := withReport()
+
+		// act
+		_, err = Mutate(ctx, db, Change{Table: "sellers", ID: 1, Action: ActionUpdate}, update)
+
+		// assert
+		assert.Error(t, err)
+		assert.True(t, cached(c))
+	})
+}
+
+func Test_Actor(t *testing.T) {
+	_, ok := Actor(context.Background())
+	assert.False(t, ok)
+
+	actor, ok := Actor(WithActor(context.Background(), "alice"))
+	assert.True(t, ok)
+	assert.Equal(t, "alice", actor)
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqltime"
+)
+
+var (
+	ErrIntern   = errors.New("an internal error")
+	QueryGetAll = "SELECT id, actor, " + sqltime.Column("created_at") + ", resource, resource_id, action, before_data, after_data FROM audit_log"
+)
+
+// Repository encapsulates the reading of the audit trail, which Mutate and Record write.
+type Repository interface {
+	GetAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// returns the entries matching filter, newest first
+func (r *repository) GetAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Resource != "" {
+		conditions = append(conditions, "resource=?")
+		args = append(args, filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		conditions = append(conditions, "resource_id=?")
+		args = append(args, filter.ResourceID)
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor=?")
+		args = append(args, filter.Actor)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at>=?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at<=?")
+		args = append(args, filter.To)
+	}
+
+	query := QueryGetAll
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	entries := []domain.AuditEntry{}
+	for rows.Next() {
+		e := domain.AuditEntry{}
+		var createdAt string
+		var before, after sql.NullString
+		if err := rows.Scan(&e.ID, &e.Actor, &createdAt, &e.Resource, &e.ResourceID, &e.Action, &before, &after); err != nil {
+			return nil, ErrIntern
+		}
+		var err error
+		if e.CreatedAt, err = sqltime.Parse(createdAt); err != nil {
+			return nil, ErrIntern
+		}
+		if before.Valid {
+			e.Before = []byte(before.String)
+		}
+		if after.Valid {
+			e.After = []byte(after.String)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrIntern
+	}
+
+	return entries, nil
+}
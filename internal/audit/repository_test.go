@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var auditColumns = []string{"id", "actor", "created_at", "resource", "resource_id", "action", "before_data", "after_data"}
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	createdAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Ok without filters", func(t *testing.T) {
+		// arrange
+		rows := mock.NewRows(auditColumns).
+			AddRow(2, "alice", "2023-03-01 10:00:00", "sellers", "1", ActionUpdate, `{"address":"a"}`, `{"address":"b"}`).
+			AddRow(1, "alice", "2023-03-01 10:00:00", "sellers", "1", ActionCreate, nil, `{"address":"a"}`)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll + " ORDER BY id DESC LIMIT ?")).WithArgs(100).WillReturnRows(rows)
+		rp := NewRepository(db)
+
+		// act
+		entries, err := rp.GetAll(context.Background(), domain.AuditFilter{Limit: 100})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.AuditEntry{
+			{ID: 2, Actor: "alice", CreatedAt: createdAt, Resource: "sellers", ResourceID: "1", Action: ActionUpdate, Before: []byte(`{"address":"a"}`), After: []byte(`{"address":"b"}`)},
+			{ID: 1, Actor: "alice", CreatedAt: createdAt, Resource: "sellers", ResourceID: "1", Action: ActionCreate, After: []byte(`{"address":"a"}`)},
+		}, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ok with every filter", func(t *testing.T) {
+		// arrange
+		from, to := createdAt.Add(-time.Hour), createdAt.Add(time.Hour)
+		query := QueryGetAll + " WHERE resource=? AND resource_id=? AND actor=? AND created_at>=? AND created_at<=? ORDER BY id DESC LIMIT ?"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("sellers", "1", "alice", from, to, 10).
+			WillReturnRows(mock.NewRows(auditColumns))
+		rp := NewRepository(db)
+
+		// act
+		entries, err := rp.GetAll(context.Background(), domain.AuditFilter{Resource: "sellers", ResourceID: "1", Actor: "alice", From: from, To: to, Limit: 10})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Internal Error", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll)).WillReturnError(errors.New("connection lost"))
+		rp := NewRepository(db)
+
+		// act
+		entries, err := rp.GetAll(context.Background(), domain.AuditFilter{Limit: 100})
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.Nil(t, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
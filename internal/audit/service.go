@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+var ErrTimeRange = errors.New("from must not be after to")
+
+type Service interface {
+	GetAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// returns the audit entries matching filter, at most MaxLimit of them
+func (s *service) GetAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, ErrTimeRange
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultLimit
+	}
+	if filter.Limit > MaxLimit {
+		filter.Limit = MaxLimit
+	}
+	return s.repo.GetAll(ctx, filter)
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := r.Called(ctx, filter)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+func Test_GetAll_Service(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults the limit", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx, domain.AuditFilter{Actor: "alice", Limit: DefaultLimit}).Return([]domain.AuditEntry{{ID: 1}}, nil)
+
+		// act
+		entries, err := NewService(repo).GetAll(ctx, domain.AuditFilter{Actor: "alice"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.AuditEntry{{ID: 1}}, entries)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("caps the limit", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx, domain.AuditFilter{Limit: MaxLimit}).Return([]domain.AuditEntry{}, nil)
+
+		// act
+		_, err := NewService(repo).GetAll(ctx, domain.AuditFilter{Limit: 5000})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a reversed time range", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		now := time.Now()
+
+		// act
+		_, err := NewService(repo).GetAll(ctx, domain.AuditFilter{From: now, To: now.Add(-time.Hour)})
+
+		// assert
+		assert.Equal(t, ErrTimeRange, err)
+		repo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,72 @@
+package buyer
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+)
+
+// auditedService records the writes of the buyer service in the audit trail and drops the cached reports
+// read from buyers, as audit.Mutate does for the repositories that run their statements through it. The
+// rows are read around the write, so an entry is written right after the change it records rather than
+// in its transaction.
+type auditedService struct {
+	Service
+	db *sql.DB
+}
+
+// NewAuditedService returns s with its creates, updates and deletes recorded in the audit trail.
+func NewAuditedService(db *sql.DB, s Service) Service {
+	return &auditedService{Service: s, db: db}
+}
+
+func (s *auditedService) Create(ctx context.Context, b domain.Buyer) (domain.Buyer, error) {
+	created, err := s.Service.Create(ctx, b)
+	if err != nil {
+		return domain.Buyer{}, err
+	}
+	s.record(ctx, audit.ActionCreate, created.ID, nil)
+	return created, nil
+}
+
+func (s *auditedService) Update(ctx context.Context, b domain.Buyer, id int) error {
+	before := s.snapshot(ctx, id)
+	if err := s.Service.Update(ctx, b, id); err != nil {
+		return err
+	}
+	s.record(ctx, audit.ActionUpdate, id, before)
+	return nil
+}
+
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	before := s.snapshot(ctx, id)
+	if err := s.Service.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.record(ctx, audit.ActionDelete, id, before)
+	return nil
+}
+
+// snapshot reads the buyer before a change, nil when there is no actor to record it for.
+func (s *auditedService) snapshot(ctx context.Context, id int) map[string]interface{} {
+	if _, ok := audit.Actor(ctx); !ok {
+		return nil
+	}
+	before, err := audit.Snapshot(ctx, s.db, "buyers", id)
+	if err != nil {
+		log.Printf("buyer: reading buyer %d before the change: %v", id, err)
+	}
+	return before
+}
+
+// record writes the audit entry of a change already committed, which a failure cannot undo, so it is logged.
+func (s *auditedService) record(ctx context.Context, action string, id int, before map[string]interface{}) {
+	_ = cache.Invalidate(ctx, "buyers")
+	if err := audit.RecordAfter(ctx, s.db, audit.Change{Table: "buyers", ID: id, Action: action}, before); err != nil {
+		log.Printf("buyer: recording the %s of buyer %d: %v", action, id, err)
+	}
+}
@@ -0,0 +1,112 @@
+package buyer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+// writesService answers the writes of a buyer service, the reads being left out.
+type writesService struct {
+	Service
+	err error
+}
+
+func (s *writesService) Create(ctx context.Context, b domain.Buyer) (domain.Buyer, error) {
+	b.ID = 7
+	return b, s.err
+}
+
+func (s *writesService) Update(ctx context.Context, b domain.Buyer, id int) error {
+	return s.err
+}
+
+func (s *writesService) Delete(ctx context.Context, id int) error {
+	return s.err
+}
+
+func Test_AuditedService(t *testing.T) {
+	snapshot := regexp.QuoteMeta("SELECT * FROM buyers WHERE id=?")
+	ctx := audit.WithActor(context.Background(), "alice")
+
+	t.Run("create is recorded", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(snapshot).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).AddRow(7, "Ana"))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("alice", "buyers", "7", audit.ActionCreate, nil, `{"first_name":"Ana","id":7}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		// act
+		created, err := NewAuditedService(db, &writesService{}).Create(ctx, domain.Buyer{FirstName: "Ana"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update is recorded against the row before it", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(snapshot).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).AddRow(7, "Ana"))
+		mock.ExpectQuery(snapshot).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).AddRow(7, "Ane"))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("alice", "buyers", "7", audit.ActionUpdate, `{"first_name":"Ana"}`, `{"first_name":"Ane"}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		// act
+		err = NewAuditedService(db, &writesService{}).Update(ctx, domain.Buyer{FirstName: "Ane"}, 7)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed write is not recorded", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(snapshot).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+		// act
+		err = NewAuditedService(db, &writesService{err: ErrNotFound}).Delete(ctx, 7)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_AuditedService_Invalidation(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	reports := cache.NewMemory(0)
+	ctx := cache.WithCache(context.Background(), reports)
+	entry := cache.Entry{Value: []byte(`{"data":[]}`), StoredAt: time.Now(), TTL: time.Minute}
+	assert.NoError(t, reports.Set(ctx, "/api/v1/buyers/reportPurchaseOrders", entry, "buyers", "purchase_orders"))
+
+	// act
+	_, err = NewAuditedService(db, &writesService{}).Create(ctx, domain.Buyer{FirstName: "Ana"})
+	_, hit, getErr := reports.Get(ctx, "/api/v1/buyers/reportPurchaseOrders")
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, getErr)
+	assert.False(t, hit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 )
 
@@ -102,22 +103,24 @@ func (r *repository) ExistsFK(ctx context.Context, localityCode string) bool {
 
 func (r *repository) Crear(ctx context.Context, c domain.Carrie) (int, error) {
 	query := "INSERT INTO carries (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return 0, err //devuelve valor por defecto
-	}
-
-	res, err := stmt.Exec(&c.Cid, &c.Company_name, &c.Address, &c.Telephone, &c.Locality_id)
-	if err != nil {
-		return 0, err
-	}
-
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
-
-	return int(id), nil
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "carries", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err //devuelve valor por defecto
+		}
+
+		res, err := stmt.Exec(&c.Cid, &c.Company_name, &c.Address, &c.Telephone, &c.Locality_id)
+		if err != nil {
+			return 0, err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+
+		return int(id), nil
+	})
 }
 
 /*func (r *repository) Update(ctx context.Context, c domain.Carrie) error {
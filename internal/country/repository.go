@@ -0,0 +1,172 @@
+package country
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+var (
+	ErrIntern       = errors.New("an internal error")
+	ErrDuplicated   = errors.New("duplicated country")
+	ErrNotFound     = errors.New("country not found")
+	ErrHasProvinces = errors.New("country has provinces")
+	QueryGetAll     = "SELECT id, country_name FROM countries"
+	QueryGetById    = "SELECT id, country_name FROM countries WHERE id=?;"
+	QueryInsert     = "INSERT INTO countries (country_name) VALUES (?)"
+	QueryUpdate     = "UPDATE countries SET country_name=?, version=version+1 WHERE id=?"
+	QueryDelete     = "DELETE FROM countries WHERE id=?"
+)
+
+// Repository encapsulates the storage of a Country.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Country, error)
+	Get(ctx context.Context, id int) (domain.Country, error)
+	Save(ctx context.Context, c domain.Country) (int, error)
+	Update(ctx context.Context, c domain.Country) error
+	Delete(ctx context.Context, id int) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Country, error) {
+	rows, err := r.db.Query(QueryGetAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var countries []domain.Country
+
+	for rows.Next() {
+		c := domain.Country{}
+		if err := rows.Scan(&c.Id, &c.Country_name); err != nil {
+			return nil, ErrIntern
+		}
+		countries = append(countries, c)
+	}
+
+	return countries, nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Country, error) {
+	row := r.db.QueryRow(QueryGetById, id)
+	c := domain.Country{}
+	err := row.Scan(&c.Id, &c.Country_name)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.Country{}, err
+	}
+
+	return c, nil
+}
+
+func (r *repository) Save(ctx context.Context, c domain.Country) (int, error) {
+	return r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryInsert)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(c.Country_name)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		return int(id), nil
+	})
+}
+
+func (r *repository) Update(ctx context.Context, c domain.Country) error {
+	_, err := r.mutate(ctx, audit.ActionUpdate, c.Id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryUpdate)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		_, err = stmt.Exec(c.Country_name, c.Id)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryDelete)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// mutate runs fn as a change of the country id recorded in the audit trail, a failure to record it being ErrIntern.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "countries", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrIntern
+	}
+	return newID, err
+}
+
+// translates MySQL constraint violations into package errors
+func mapDriverError(err error) error {
+	driverErr, ok := err.(*mysql.MySQLError)
+	if !ok {
+		return ErrIntern
+	}
+
+	switch driverErr.Number {
+	case 1062:
+		return ErrDuplicated
+	case 1451:
+		return ErrHasProvinces
+	default:
+		return ErrIntern
+	}
+}
@@ -0,0 +1,216 @@
+package country
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expected := []domain.Country{
+			{Id: 1, Country_name: "Argentina"},
+			{Id: 2, Country_name: "Colombia"},
+		}
+		rows := mock.NewRows([]string{"id", "country_name"})
+		for _, c := range expected {
+			rows.AddRow(c.Id, c.Country_name)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll)).WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		countries, err := rp.GetAll(context.Background())
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, countries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Internal Error", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll)).WillReturnError(ErrIntern)
+
+		rp := NewRepository(db)
+
+		// act
+		countries, err := rp.GetAll(context.Background())
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.Nil(t, countries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expected := domain.Country{Id: 1, Country_name: "Argentina"}
+		row := mock.NewRows([]string{"id", "country_name"}).AddRow(expected.Id, expected.Country_name)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetById)).WithArgs(1).WillReturnRows(row)
+
+		rp := NewRepository(db)
+
+		// act
+		c, err := rp.Get(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, c)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetById)).WithArgs(9).WillReturnRows(mock.NewRows([]string{"id", "country_name"}))
+
+		rp := NewRepository(db)
+
+		// act
+		c, err := rp.Get(context.Background(), 9)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.Equal(t, domain.Country{}, c)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	country := domain.Country{Country_name: "Argentina"}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs(country.Country_name).WillReturnResult(sqlmock.NewResult(3, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		id, err := rp.Save(context.Background(), country)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs(country.Country_name).WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		rp := NewRepository(db)
+
+		// act
+		id, err := rp.Save(context.Background(), country)
+
+		// assert
+		assert.Equal(t, ErrDuplicated, err)
+		assert.Equal(t, 0, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	country := domain.Country{Id: 1, Country_name: "Argentina"}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WithArgs(country.Country_name, country.Id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Update(context.Background(), country)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WithArgs(country.Country_name, country.Id).WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Update(context.Background(), country)
+
+		// assert
+		assert.Equal(t, ErrDuplicated, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 9)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Referenced by provinces", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(1).WillReturnError(&mysql.MySQLError{Number: 1451})
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 1)
+
+		// assert
+		assert.Equal(t, ErrHasProvinces, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
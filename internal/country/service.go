@@ -0,0 +1,66 @@
+package country
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.Country, error)
+	GetByID(ctx context.Context, id int) (domain.Country, error)
+	Create(ctx context.Context, c domain.Country) (domain.Country, error)
+	Update(ctx context.Context, c domain.Country) (domain.Country, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// returns all countries
+func (s *service) GetAll(ctx context.Context) ([]domain.Country, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// returns the country specified by id
+func (s *service) GetByID(ctx context.Context, id int) (domain.Country, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// adds one country and returns it with its database-defined id
+func (s *service) Create(ctx context.Context, c domain.Country) (domain.Country, error) {
+	// surrounding spaces would otherwise bypass the unique name constraint
+	c.Country_name = strings.TrimSpace(c.Country_name)
+
+	id, err := s.repo.Save(ctx, c)
+	if err != nil {
+		return domain.Country{}, err
+	}
+
+	c.Id = id
+	return c, nil
+}
+
+// renames the country specified by c.Id and returns it
+func (s *service) Update(ctx context.Context, c domain.Country) (domain.Country, error) {
+	c.Country_name = strings.TrimSpace(c.Country_name)
+
+	if err := s.repo.Update(ctx, c); err != nil {
+		return domain.Country{}, err
+	}
+
+	return c, nil
+}
+
+// deletes the country specified by id; fails while provinces reference it
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
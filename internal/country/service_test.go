@@ -0,0 +1,127 @@
+package country
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// define a mock repository struct that implements the Repository interface for testing purposes
+type RepositoryMock struct {
+	mock.Mock
+	Repository
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context) ([]domain.Country, error) {
+	args := r.Mock.Called(ctx)
+	return args.Get(0).([]domain.Country), args.Error(1)
+}
+func (r *RepositoryMock) Get(ctx context.Context, id int) (domain.Country, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.Country), args.Error(1)
+}
+func (r *RepositoryMock) Save(ctx context.Context, c domain.Country) (int, error) {
+	args := r.Mock.Called(ctx, c)
+	return args.Get(0).(int), args.Error(1)
+}
+func (r *RepositoryMock) Update(ctx context.Context, c domain.Country) error {
+	args := r.Mock.Called(ctx, c)
+	return args.Error(0)
+}
+func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
+	args := r.Mock.Called(ctx, id)
+	return args.Error(0)
+}
+
+func Test_Create_Country(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK trims the name", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, domain.Country{Country_name: "Argentina"}).Return(1, nil)
+
+		// act
+		created, err := service.Create(ctx, domain.Country{Country_name: "  Argentina "})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Country{Id: 1, Country_name: "Argentina"}, created)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, domain.Country{Country_name: "Argentina"}).Return(0, ErrDuplicated)
+
+		// act
+		created, err := service.Create(ctx, domain.Country{Country_name: "Argentina"})
+
+		// assert
+		assert.Equal(t, ErrDuplicated, err)
+		assert.Equal(t, domain.Country{}, created)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+}
+
+func Test_Update_Country(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Update", ctx, domain.Country{Id: 1, Country_name: "Chile"}).Return(nil)
+
+		// act
+		updated, err := service.Update(ctx, domain.Country{Id: 1, Country_name: "Chile "})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Country{Id: 1, Country_name: "Chile"}, updated)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Update", ctx, domain.Country{Id: 1, Country_name: "Chile"}).Return(ErrIntern)
+
+		// act
+		updated, err := service.Update(ctx, domain.Country{Id: 1, Country_name: "Chile"})
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.Equal(t, domain.Country{}, updated)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+}
+
+func Test_Delete_Country(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Referenced by provinces", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Delete", ctx, 1).Return(ErrHasProvinces)
+
+		// act
+		err := service.Delete(ctx, 1)
+
+		// assert
+		assert.Equal(t, ErrHasProvinces, err)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records one create, update or delete of a row. Before and After hold only the columns the
+// change touched: a create has no Before and a hard delete no After.
+type AuditEntry struct {
+	ID         int             `json:"id"`
+	Actor      string          `json:"actor"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Resource   string          `json:"resource"`
+	ResourceID string          `json:"resource_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+// AuditFilter narrows the audit trail; zero values match everything.
+type AuditFilter struct {
+	Resource   string
+	ResourceID string
+	Actor      string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
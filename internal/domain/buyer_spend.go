@@ -0,0 +1,46 @@
+package domain
+
+// BuyerSpendFilter narrows the buyer spend reports. Zero values mean no filter.
+type BuyerSpendFilter struct {
+	BuyerID int
+	// From and To bound the order dates, YYYY-MM-DD and inclusive
+	From string
+	To   string
+	// TopProducts is how many products the report lists per buyer
+	TopProducts int
+}
+
+// BuyerSpend totals what a buyer spent, every purchase order costing the sale price of its product record.
+// The order dates are empty for buyers without orders in the period.
+type BuyerSpend struct {
+	ID                 int                 `json:"id"`
+	CardNumberID       string              `json:"card_number_id"`
+	FirstName          string              `json:"first_name"`
+	LastName           string              `json:"last_name"`
+	PurchaseOrderCount int                 `json:"purchase_order_count"`
+	TotalSpend         float64             `json:"total_spend"`
+	AverageOrderValue  float64             `json:"average_order_value"`
+	FirstOrderDate     string              `json:"first_order_date"`
+	LastOrderDate      string              `json:"last_order_date"`
+	TopProducts        []BuyerProductSpend `json:"top_products"`
+}
+
+// BuyerProductSpend is what a buyer spent on a single product.
+type BuyerProductSpend struct {
+	BuyerID            int     `json:"-"`
+	ProductID          int     `json:"product_id"`
+	Description        string  `json:"description"`
+	PurchaseOrderCount int     `json:"purchase_order_count"`
+	TotalSpend         float64 `json:"total_spend"`
+}
+
+// BuyerCohort groups the buyers whose first order ever fell in the same month. Buyers counts all of them,
+// ActiveBuyers only those who ordered in the period the orders and spend are totalled over.
+type BuyerCohort struct {
+	Cohort               string  `json:"cohort"`
+	Buyers               int     `json:"buyers"`
+	ActiveBuyers         int     `json:"active_buyers"`
+	PurchaseOrderCount   int     `json:"purchase_order_count"`
+	TotalSpend           float64 `json:"total_spend"`
+	AverageSpendPerBuyer float64 `json:"average_spend_per_buyer"`
+}
@@ -0,0 +1,6 @@
+package domain
+
+type Country struct {
+	Id           int    `json:"id"`
+	Country_name string `json:"country_name" validate:"required"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+// EmployeeProductivityFilter narrows and groups the employee productivity report. Zero values mean no filter
+// and no grouping.
+type EmployeeProductivityFilter struct {
+	EmployeeID int
+	// WarehouseID keeps the orders received at this warehouse
+	WarehouseID int
+	// From and To bound the order dates, YYYY-MM-DD and inclusive
+	From string
+	To   string
+	// GroupBy splits the totals by day, week or month of the order date
+	GroupBy string
+	// ByWarehouse splits the totals by the warehouse the orders were received at
+	ByWarehouse bool
+}
+
+// Grouped reports whether the totals are split, in which case employees without orders are left out.
+func (f EmployeeProductivityFilter) Grouped() bool {
+	return f.GroupBy != "" || f.ByWarehouse
+}
+
+// EmployeeProductivity holds the inbound orders an employee received and the units they brought in,
+// for a single period and warehouse when the report is grouped.
+type EmployeeProductivity struct {
+	EmployeeWithInboundOrders
+	Period           string `json:"period,omitempty"`
+	OrderWarehouseID int    `json:"order_warehouse_id,omitempty"`
+	UnitsReceived    int    `json:"units_received"`
+}
+
+// EmployeeLeaderboardFilter selects the warehouse and the dates the leaderboard ranks.
+type EmployeeLeaderboardFilter struct {
+	WarehouseID int
+	From        string
+	To          string
+	Limit       int
+}
+
+// EmployeeRanking is an employee's place in the leaderboard of a warehouse, ranked by units received.
+type EmployeeRanking struct {
+	Rank int `json:"rank"`
+	EmployeeWithInboundOrders
+	UnitsReceived int `json:"units_received"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is a change other systems may react to, stored in the outbox in the same transaction as the change
+// and published afterwards. Payload holds the row the change left, keyed by column.
+type Event struct {
+	ID         int             `json:"id"`
+	Type       string          `json:"type"`
+	Resource   string          `json:"resource"`
+	ResourceID string          `json:"resource_id"`
+	Payload    json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Attempts   int             `json:"-"` // failed deliveries so far, bookkeeping of the outbox
+}
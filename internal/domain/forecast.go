@@ -0,0 +1,42 @@
+package domain
+
+// ForecastParams configures a demand forecast. Zero values take the defaults of the forecast service.
+type ForecastParams struct {
+	// History is how many days of purchase orders, up to yesterday, the forecast is built from
+	History int
+	// Horizon is how many days, from today, are forecast
+	Horizon int
+	// Window is how many of the latest days the moving average spans
+	Window int
+	// Alpha is the smoothing factor, in (0, 1]; higher values weigh the latest days more
+	Alpha float64
+}
+
+// DemandDay is the demand of a product on a single day, the purchase orders placed for it.
+type DemandDay struct {
+	Date  string `json:"date"`
+	Units int    `json:"units"`
+}
+
+// ProductDemand is a day of demand of a product, as read for every product at once.
+type ProductDemand struct {
+	ProductID int
+	DemandDay
+}
+
+// ForecastDay is the demand forecast for a day by each method.
+type ForecastDay struct {
+	Date                 string  `json:"date"`
+	MovingAverage        float64 `json:"moving_average"`
+	ExponentialSmoothing float64 `json:"exponential_smoothing"`
+}
+
+// ProductForecast is the daily demand history of a product, days without orders included, and the
+// forecast of the days that follow.
+type ProductForecast struct {
+	ProductID int           `json:"product_id"`
+	Window    int           `json:"window"`
+	Alpha     float64       `json:"alpha"`
+	History   []DemandDay   `json:"history"`
+	Forecast  []ForecastDay `json:"forecast"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// IdempotencyKey is a request made under an Idempotency-Key header. Scope is the caller the key belongs
+// to, so that two callers picking the same key do not get each other's responses. Status and Body hold
+// the response once the request has been answered; a zero Status means it is still being processed.
+type IdempotencyKey struct {
+	Scope       string
+	Key         string
+	Route       string
+	RequestHash string
+	Status      int
+	Body        []byte
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,17 @@
+package domain
+
+// ImportResult summarizes a bulk CSV import.
+type ImportResult struct {
+	Resource string           `json:"resource"`
+	Mode     string           `json:"mode"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ImportRowError lists why a row was rejected. Row is the line number in the file, the header being line 1.
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+// InventoryFilter narrows the global inventory summary. Zero values mean no filter.
+type InventoryFilter struct {
+	SellerID      int
+	ProductTypeID int
+}
+
+// InventorySummary holds the stock totals of a single product across every warehouse.
+type InventorySummary struct {
+	ProductID         int    `json:"product_id"`
+	Description       string `json:"description"`
+	SellerID          int    `json:"seller_id"`
+	ProductTypeID     int    `json:"product_type_id"`
+	TotalQuantity     int    `json:"total_quantity"`
+	AvailableQuantity int    `json:"available_quantity"`
+	ExpiredQuantity   int    `json:"expired_quantity"`
+	WarehousesCount   int    `json:"warehouses_count"`
+}
+
+// InventoryRecord is a single batch of a product together with the section and warehouse that store it.
+type InventoryRecord struct {
+	WarehouseID     int
+	WarehouseCode   string
+	SectionID       int
+	SectionNumber   int
+	BatchID         int
+	BatchNumber     int
+	DueDate         string
+	CurrentQuantity int
+	Expired         bool
+}
+
+type InventoryBatch struct {
+	ID              int    `json:"id"`
+	BatchNumber     int    `json:"batch_number"`
+	DueDate         string `json:"due_date"`
+	CurrentQuantity int    `json:"current_quantity"`
+	Expired         bool   `json:"expired"`
+}
+
+type InventorySection struct {
+	SectionID         int              `json:"section_id"`
+	SectionNumber     int              `json:"section_number"`
+	AvailableQuantity int              `json:"available_quantity"`
+	ExpiredQuantity   int              `json:"expired_quantity"`
+	Batches           []InventoryBatch `json:"batches"`
+}
+
+type InventoryWarehouse struct {
+	WarehouseID       int                `json:"warehouse_id"`
+	WarehouseCode     string             `json:"warehouse_code"`
+	AvailableQuantity int                `json:"available_quantity"`
+	ExpiredQuantity   int                `json:"expired_quantity"`
+	Sections          []InventorySection `json:"sections"`
+}
+
+// ProductInventory breaks the stock of a product down by warehouse, section and batch.
+type ProductInventory struct {
+	InventorySummary
+	Warehouses []InventoryWarehouse `json:"warehouses"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+type Locality struct {
+	Id            string   `json:"id" validate:"required"`
+	Locality_name string   `json:"locality_name" validate:"required"`
+	Province_id   int      `json:"province_id" validate:"required"`
+	Latitude      *float64 `json:"latitude,omitempty" validate:"omitempty,latitude"`
+	Longitude     *float64 `json:"longitude,omitempty" validate:"omitempty,longitude"`
+}
+
+type QuantitySellerByLocality struct {
+	Locality_id   string `json:"locality_id"`
+	Locality_name string `json:"locality_name"`
+	Sellers_count int    `json:"sellers_count"`
+}
+
+type QuantityWarehouseByLocality struct {
+	Locality_id      string `json:"locality_id"`
+	Locality_name    string `json:"locality_name"`
+	Warehouses_count int    `json:"warehouses_count"`
+}
+
+type LocalityReport struct {
+	Locality_id      string `json:"locality_id"`
+	Locality_name    string `json:"locality_name"`
+	Sellers_count    int    `json:"sellers_count"`
+	Carries_count    int    `json:"carries_count"`
+	Warehouses_count int    `json:"warehouses_count"`
+	Buyers_count     int    `json:"buyers_count"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+// MarginFilter narrows the margin report. Zero values mean no filter.
+type MarginFilter struct {
+	// GroupBy is product, product_type or seller
+	GroupBy       string
+	SellerID      int
+	ProductTypeID int
+	// From and To bound the purchase order dates, YYYY-MM-DD and inclusive
+	From string
+	To   string
+}
+
+// ProductMargin is what the orders of a product brought in and cost, each priced with the product record
+// in effect at the order date, along with the price the product is sold at now.
+type ProductMargin struct {
+	ProductID     int
+	Description   string
+	ProductTypeID int
+	SellerID      int
+	UnitsSold     int
+	Revenue       float64
+	Cost          float64
+	// HasPrice is false for products without product records, whose current prices are zero
+	HasPrice             bool
+	CurrentPurchasePrice float64
+	CurrentSalePrice     float64
+}
+
+// Margin is the gross margin of a product, product type or seller. ProductsBelowCost counts the products
+// whose current sale price is under their purchase price.
+type Margin struct {
+	ID                int     `json:"id"`
+	Description       string  `json:"description,omitempty"`
+	UnitsSold         int     `json:"units_sold"`
+	Revenue           float64 `json:"revenue"`
+	Cost              float64 `json:"cost"`
+	GrossMargin       float64 `json:"gross_margin"`
+	MarginPercent     float64 `json:"margin_percent"`
+	BelowCost         bool    `json:"below_cost"`
+	ProductsBelowCost int     `json:"products_below_cost"`
+}
@@ -0,0 +1,7 @@
+package domain
+
+type Province struct {
+	Id            int    `json:"id"`
+	Province_name string `json:"province_name" validate:"required"`
+	Country_id    int    `json:"country_id" validate:"required"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+// ReorderPoint is the stock of a product under which a warehouse should replenish it. SafetyStock is the
+// stock kept on top of what the consumption is expected to take.
+type ReorderPoint struct {
+	ID           int `json:"id"`
+	WarehouseID  int `json:"warehouse_id"`
+	ProductID    int `json:"product_id"`
+	ReorderPoint int `json:"reorder_point" validate:"min=0"`
+	SafetyStock  int `json:"safety_stock" validate:"min=0"`
+}
+
+// ReplenishmentFilter narrows the replenishment report. Zero values take the defaults of the service.
+type ReplenishmentFilter struct {
+	WarehouseID int
+	// Days is how many days back the consumption is averaged over
+	Days int
+	// CoverDays is how many days of consumption a suggested order should cover
+	CoverDays int
+}
+
+// ReorderStock is the stock of a product with a reorder point in a warehouse, along with its consumption,
+// the share of the purchase orders placed for it over the days of the report that falls to the warehouse.
+type ReorderStock struct {
+	ReorderPoint
+	Description       string
+	SellerID          int
+	SellerCompanyName string
+	Stock             int
+	Consumed          int
+}
+
+// ReplenishmentSuggestion is a product to replenish in a warehouse and how much of it to order from its
+// seller.
+type ReplenishmentSuggestion struct {
+	WarehouseID       int     `json:"warehouse_id"`
+	ProductID         int     `json:"product_id"`
+	Description       string  `json:"description"`
+	SellerID          int     `json:"seller_id"`
+	SellerCompanyName string  `json:"seller_company_name"`
+	Stock             int     `json:"stock"`
+	ReorderPoint      int     `json:"reorder_point"`
+	SafetyStock       int     `json:"safety_stock"`
+	DailyConsumption  float64 `json:"daily_consumption"`
+	SuggestedQuantity int     `json:"suggested_quantity"`
+}
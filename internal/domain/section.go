@@ -18,3 +18,9 @@ type SectionReportProducts struct {
 	SectionNumber int `json:"section_number"`
 	ProductCount  int `json:"product_count"`
 }
+
+// Fits reports whether units more product units can be stored in the section. Capacities count
+// product units: a batch takes as much of its section as its current quantity.
+func (s Section) Fits(units int) bool {
+	return s.CurrentCapacity+units <= s.MaximumCapacity
+}
@@ -0,0 +1,31 @@
+package domain
+
+// SellerReport sums up the catalog and the sales of a seller. Units sold count one per purchase order and
+// revenue adds up the sale price of the product records ordered.
+type SellerReport struct {
+	ID             int     `json:"id"`
+	CID            int     `json:"cid"`
+	CompanyName    string  `json:"company_name"`
+	ProductCount   int     `json:"product_count"`
+	UnitsInStock   int     `json:"units_in_stock"`
+	ExpiredBatches int     `json:"expired_batches"`
+	UnitsSold      int     `json:"units_sold"`
+	Revenue        float64 `json:"revenue"`
+}
+
+// SellerReportQuery sorts and pages the list of seller reports.
+type SellerReportQuery struct {
+	// Sort is the json name of the field to sort by
+	Sort       string
+	Descending bool
+	Page       int
+	PageSize   int
+}
+
+// SellerReportPage is a page of seller reports along with the number of sellers in all pages.
+type SellerReportPage struct {
+	Sellers  []SellerReport `json:"sellers"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Total    int            `json:"total"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+// Transfer is the document recorded when stock moves from one section to another.
+type Transfer struct {
+	ID                   int    `json:"id"`
+	TransferDate         string `json:"transfer_date"`
+	ProductBatchID       int    `json:"product_batch_id"`
+	DestinationBatchID   int    `json:"destination_batch_id"`
+	OriginSectionID      int    `json:"origin_section_id"`
+	DestinationSectionID int    `json:"destination_section_id"`
+	Quantity             int    `json:"quantity"`
+	EmployeeID           int    `json:"employee_id"`
+}
+
+// TransferRequest moves quantity units of a batch to another section.
+// Moving all of them moves the batch itself, fewer split it.
+type TransferRequest struct {
+	ProductBatchID       int `json:"product_batch_id" validate:"required"`
+	DestinationSectionID int `json:"destination_section_id" validate:"required"`
+	Quantity             int `json:"quantity" validate:"required,min=1"`
+	EmployeeID           int `json:"employee_id" validate:"required"`
+}
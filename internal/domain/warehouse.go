@@ -1,19 +1,38 @@
 package domain
 
 type Warehouse struct {
-	ID                 int    `json:"id"`
-	Address            string `json:"address" validate:"required"`
-	Telephone          string `json:"telephone" validate:"required"`
-	WarehouseCode      string `json:"warehouse_code" validate:"required"`
-	MinimumCapacity    int    `json:"minimum_capacity" validate:"required"`
-	MinimumTemperature int    `json:"minimum_temperature" validate:"required"`
+	ID                 int      `json:"id"`
+	Address            string   `json:"address" validate:"required"`
+	Telephone          string   `json:"telephone" validate:"required"`
+	WarehouseCode      string   `json:"warehouse_code" validate:"required"`
+	MinimumCapacity    int      `json:"minimum_capacity" validate:"required"`
+	MinimumTemperature int      `json:"minimum_temperature" validate:"required"`
+	LocalityID         string   `json:"locality_id" validate:"required"`
+	Latitude           *float64 `json:"latitude,omitempty" validate:"omitempty,latitude"`
+	Longitude          *float64 `json:"longitude,omitempty" validate:"omitempty,longitude"`
+}
+
+// warehouse along with its great-circle distance to a given point
+type WarehouseDistance struct {
+	Warehouse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// location criteria to list warehouses, zero values are ignored
+type WarehouseFilter struct {
+	LocalityID string
+	ProvinceID int
+	CountryID  int
 }
 
 // create struct for validate field empty
 type WarehouseRequest struct {
-	Address            string `json:"address" validate:"required"`
-	Telephone          string `json:"telephone" validate:"required"`
-	WarehouseCode      string `json:"warehouse_code" validate:"required"`
-	MinimumCapacity    int    `json:"minimum_capacity" validate:"required"`
-	MinimumTemperature int    `json:"minimum_temperature" validate:"required"`
+	Address            string   `json:"address" validate:"required"`
+	Telephone          string   `json:"telephone" validate:"required"`
+	WarehouseCode      string   `json:"warehouse_code" validate:"required"`
+	MinimumCapacity    int      `json:"minimum_capacity" validate:"required"`
+	MinimumTemperature int      `json:"minimum_temperature" validate:"required"`
+	LocalityID         string   `json:"locality_id"`
+	Latitude           *float64 `json:"latitude,omitempty" validate:"omitempty,latitude"`
+	Longitude          *float64 `json:"longitude,omitempty" validate:"omitempty,longitude"`
 }
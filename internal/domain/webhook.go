@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Webhook is a partner endpoint subscribed to some event types. The secret signs every delivery and is
+// never returned once registered.
+type Webhook struct {
+	ID         int       `json:"id"`
+	URL        string    `json:"url" validate:"required,url"`
+	EventTypes []string  `json:"event_types" validate:"required,min=1"`
+	Secret     string    `json:"secret,omitempty" validate:"required,min=16"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one event to be posted to one webhook, tracked until it is delivered or given up on.
+// ResponseStatus and LastError describe the last attempt.
+type WebhookDelivery struct {
+	ID             int        `json:"id"`
+	WebhookID      int        `json:"webhook_id"`
+	EventID        int        `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
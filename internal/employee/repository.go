@@ -4,25 +4,62 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 var (
 	ErrWarehouseNotfound = errors.New("Warehouse Not found")
 )
 
+// Queries of the productivity report and the leaderboard, units received being the initial quantity of
+// the batches the orders brought in.
+var (
+	QueryProductivity = "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, %s, %s, COUNT(i.id), COALESCE(SUM(pb.initial_quantity),0) " +
+		"FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id%s " +
+		"LEFT JOIN products_batches pb ON pb.id = i.product_batch_id"
+	QueryLeaderboard = "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) AS orders, COALESCE(SUM(pb.initial_quantity),0) AS units " +
+		"FROM inbound_orders i INNER JOIN employees e ON e.id = i.employee_id " +
+		"INNER JOIN products_batches pb ON pb.id = i.product_batch_id " +
+		"WHERE i.warehouse_id = ? AND e.deleted_at IS NULL"
+	QueryLeaderboardGroup = " GROUP BY e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id ORDER BY units DESC, orders DESC, e.id LIMIT ?;"
+	QueryExistsWarehouse  = "SELECT id FROM warehouses WHERE id=?;"
+)
+
+// periodFormats are the DATE_FORMAT layouts of the periods the productivity report groups by, weeks being ISO weeks.
+var periodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%x-W%v",
+	"month": "%Y-%m",
+}
+
+// Dependents are the rows whose foreign keys block removing an employee for good.
+var Dependents = []softdelete.Reference{
+	{Name: "inbound orders", Table: "inbound_orders", Column: "employee_id"},
+	{Name: "transfers", Table: "transfers", Column: "employee_id"},
+}
+
 // Repository encapsulates the storage of a employee.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Employee, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Employee, error)
 	Get(ctx context.Context, id int) (domain.Employee, error)
 	Exists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, e domain.Employee) (int, error)
 	Update(ctx context.Context, e domain.Employee) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	HardDelete(ctx context.Context, id int) error
 	GetAllInoundOrders(ctx context.Context) ([]domain.EmployeeWithInboundOrders, error)
 	GetWithInboundOrder(ctx context.Context, id int) (domain.EmployeeWithInboundOrders, error)
+	GetProductivity(ctx context.Context, f domain.EmployeeProductivityFilter) ([]domain.EmployeeProductivity, error)
+	GetLeaderboard(ctx context.Context, f domain.EmployeeLeaderboardFilter) ([]domain.EmployeeRanking, error)
+	ExistsWarehouse(ctx context.Context, id int) bool
 }
 
 type repository struct {
@@ -36,7 +73,16 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	query := "SELECT * FROM employees"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE deleted_at IS NULL"
+	return r.getAll(query)
+}
+
+func (r *repository) GetAllWithDeleted(ctx context.Context) ([]domain.Employee, error) {
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees"
+	return r.getAll(query)
+}
+
+func (r *repository) getAll(query string) ([]domain.Employee, error) {
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -56,7 +102,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
-	query := "SELECT * FROM employees WHERE id=?;"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND deleted_at IS NULL;"
 	row := r.db.QueryRow(query, id)
 	e := domain.Employee{}
 	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
@@ -76,80 +122,149 @@ func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
 
 func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
 	query := "INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return 0, err
-	}
-
-	res, err := stmt.Exec(&e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
-	if err != nil {
-		switch err.(*mysql.MySQLError).Number {
-		case 1452:
-			return 0, ErrWarehouseNotfound
-		default:
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "employees", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
 			return 0, err
 		}
-	}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
+		res, err := stmt.Exec(&e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+		if err != nil {
+			switch err.(*mysql.MySQLError).Number {
+			case 1452:
+				return 0, ErrWarehouseNotfound
+			default:
+				return 0, err
+			}
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
 
-	return int(id), nil
+		return int(id), nil
+	})
 }
 
 func (r *repository) Update(ctx context.Context, e domain.Employee) error {
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return err
-	}
+	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?, version=version+1 WHERE id=?"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "employees", ID: e.ID, Action: audit.ActionUpdate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
 
-	res, err := stmt.Exec(&e.FirstName, &e.LastName, &e.WarehouseID, &e.ID)
-	if err != nil {
-		switch err.(*mysql.MySQLError).Number {
-		case 1452:
-			return ErrWarehouseNotfound
-		default:
-			return err
+		res, err := stmt.Exec(&e.FirstName, &e.LastName, &e.WarehouseID, &e.ID)
+		if err != nil {
+			switch err.(*mysql.MySQLError).Number {
+			case 1452:
+				return 0, ErrWarehouseNotfound
+			default:
+				return 0, err
+			}
 		}
-	}
 
-	_, err = res.RowsAffected()
-	if err != nil {
-		return err
-	}
+		_, err = res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
 
-	return nil
+		return 0, nil
+	})
+	return err
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM employees WHERE id=?"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return err
-	}
+	query := "UPDATE employees SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "employees", ID: id, Action: audit.ActionDelete}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
 
-	res, err := stmt.Exec(id)
-	if err != nil {
-		return err
-	}
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, err
+		}
 
-	affect, err := res.RowsAffected()
-	if err != nil {
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE employees SET deleted_at=NULL, version=version+1 WHERE id=? AND deleted_at IS NOT NULL"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "employees", ID: id, Action: audit.ActionRestore}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) HardDelete(ctx context.Context, id int) error {
+	if err := softdelete.Dependents(ctx, r.db, id, Dependents...); err != nil {
 		return err
 	}
 
-	if affect < 1 {
-		return ErrNotFound
-	}
+	query := "DELETE FROM employees WHERE id=?"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "employees", ID: id, Action: audit.ActionHardDelete}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			if softdelete.IsReferenced(err) {
+				return 0, softdelete.ErrHasDependents
+			}
+			return 0, err
+		}
 
-	return nil
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
 }
 
 func (r *repository) GetAllInoundOrders(ctx context.Context) ([]domain.EmployeeWithInboundOrders, error) {
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.deleted_at IS NULL GROUP BY e.id;"
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -169,7 +284,7 @@ func (r *repository) GetAllInoundOrders(ctx context.Context) ([]domain.EmployeeW
 }
 
 func (r *repository) GetWithInboundOrder(ctx context.Context, id int) (domain.EmployeeWithInboundOrders, error) {
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? AND e.deleted_at IS NULL GROUP BY e.id;"
 	row := r.db.QueryRow(query, id)
 	e := domain.EmployeeWithInboundOrders{}
 	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.InboundOrdersCount)
@@ -179,3 +294,106 @@ func (r *repository) GetWithInboundOrder(ctx context.Context, id int) (domain.Em
 
 	return e, nil
 }
+
+// GetProductivity counts the inbound orders of every employee and the units they received. The date and
+// warehouse filters apply to the orders, so employees without matching orders still show up with zero
+// unless the totals are grouped. Soft deleted employees are left out.
+func (r *repository) GetProductivity(ctx context.Context, f domain.EmployeeProductivityFilter) ([]domain.EmployeeProductivity, error) {
+	period, orderWarehouse := "NULL", "NULL"
+	var on string
+	where := " WHERE e.deleted_at IS NULL"
+	var groups []string
+	var args []interface{}
+
+	if layout, ok := periodFormats[f.GroupBy]; ok {
+		period = "DATE_FORMAT(i.order_date, '" + layout + "')"
+		groups = append(groups, period)
+	}
+	if f.ByWarehouse {
+		orderWarehouse = "i.warehouse_id"
+		groups = append(groups, orderWarehouse)
+	}
+	if f.From != "" {
+		on += " AND i.order_date >= ?"
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		on += " AND i.order_date <= ?"
+		args = append(args, f.To)
+	}
+	if f.WarehouseID != 0 {
+		on += " AND i.warehouse_id = ?"
+		args = append(args, f.WarehouseID)
+	}
+	if f.EmployeeID != 0 {
+		where += " AND e.id = ?"
+		args = append(args, f.EmployeeID)
+	}
+
+	groupBy := strings.Join(append([]string{"e.id"}, groups...), ", ")
+	query := fmt.Sprintf(QueryProductivity, period, orderWarehouse, on) + where + " GROUP BY " + groupBy
+	if f.Grouped() {
+		query += " HAVING COUNT(i.id) > 0"
+	}
+	query += " ORDER BY " + groupBy + ";"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := []domain.EmployeeProductivity{}
+	for rows.Next() {
+		e := domain.EmployeeProductivity{}
+		var period sql.NullString
+		var orderWarehouse sql.NullInt64
+		if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &period, &orderWarehouse, &e.InboundOrdersCount, &e.UnitsReceived); err != nil {
+			return nil, err
+		}
+		e.Period = period.String
+		e.OrderWarehouseID = int(orderWarehouse.Int64)
+		employees = append(employees, e)
+	}
+
+	return employees, rows.Err()
+}
+
+// GetLeaderboard returns the employees who received orders at the warehouse, most units first.
+func (r *repository) GetLeaderboard(ctx context.Context, f domain.EmployeeLeaderboardFilter) ([]domain.EmployeeRanking, error) {
+	query := QueryLeaderboard
+	args := []interface{}{f.WarehouseID}
+	if f.From != "" {
+		query += " AND i.order_date >= ?"
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		query += " AND i.order_date <= ?"
+		args = append(args, f.To)
+	}
+	query += QueryLeaderboardGroup
+	args = append(args, f.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ranking := []domain.EmployeeRanking{}
+	for rows.Next() {
+		e := domain.EmployeeRanking{}
+		if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.InboundOrdersCount, &e.UnitsReceived); err != nil {
+			return nil, err
+		}
+		ranking = append(ranking, e)
+	}
+
+	return ranking, rows.Err()
+}
+
+func (r *repository) ExistsWarehouse(ctx context.Context, id int) bool {
+	row := r.db.QueryRowContext(ctx, QueryExistsWarehouse, id)
+	err := row.Scan(&id)
+	return err == nil
+}
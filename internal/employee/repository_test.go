@@ -17,7 +17,7 @@ import (
 func Test_Repository_GetAll(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT * FROM employees"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE deleted_at IS NULL"
 
 	data := []domain.Employee{
 		{
@@ -91,7 +91,7 @@ func Test_Repository_GetAll(t *testing.T) {
 func Test_Repository_Get(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT * FROM employees WHERE id=?;"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND deleted_at IS NULL;"
 
 	data := domain.Employee{
 		ID:           1,
@@ -278,7 +278,7 @@ func Test_Repository_Save(t *testing.T) {
 func Test_Repository_Update(t *testing.T) {
 	ctx := context.Background()
 
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
+	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?, version=version+1 WHERE id=?"
 
 	employee := domain.Employee{
 		ID:           1,
@@ -363,7 +363,7 @@ func Test_Repository_Update(t *testing.T) {
 func Test_Repository_Delete(t *testing.T) {
 	ctx := context.Background()
 
-	query := "DELETE FROM employees WHERE id=?"
+	query := "UPDATE employees SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
 
 	t.Run("Delete OK", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -437,10 +437,50 @@ func Test_Repository_Delete(t *testing.T) {
 	})
 }
 
+func Test_Repository_HardDelete(t *testing.T) {
+	ctx := context.Background()
+
+	query := "DELETE FROM employees WHERE id=?"
+	expectCounts := func(mock sqlmock.Sqlmock, counts ...int) {
+		for i, ref := range Dependents {
+			count := "SELECT COUNT(*) FROM " + ref.Table + " WHERE " + ref.Column + "=?"
+			mock.ExpectQuery(regexp.QuoteMeta(count)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(counts[i]))
+		}
+	}
+
+	t.Run("HardDelete OK", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectCounts(mock, 0, 0)
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).ExpectExec().
+			WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := NewRepository(db)
+		err = repo.HardDelete(ctx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("HardDelete Error Dependents", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectCounts(mock, 3, 1)
+
+		repo := NewRepository(db)
+		err = repo.HardDelete(ctx, 1)
+		assert.EqualError(t, err, "blocked by dependents: 3 inbound orders, 1 transfers")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func Test_Repository_GetAllInboundOrders(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.deleted_at IS NULL GROUP BY e.id;"
 
 	data := []domain.EmployeeWithInboundOrders{
 		{
@@ -520,7 +560,7 @@ func Test_Repository_GetAllInboundOrders(t *testing.T) {
 func Test_Repository_GetWithInoundOrders(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? AND e.deleted_at IS NULL GROUP BY e.id;"
 
 	data := domain.EmployeeWithInboundOrders{
 		ID:                 1,
@@ -564,3 +604,133 @@ func Test_Repository_GetWithInoundOrders(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func Test_Repository_GetProductivity(t *testing.T) {
+	ctx := context.Background()
+
+	columns := []string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "period", "order_warehouse_id", "COUNT(i.id)", "units"}
+
+	t.Run("GetProductivity OK all time", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, NULL, NULL, COUNT(i.id), COALESCE(SUM(pb.initial_quantity),0) " +
+			"FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id AND i.order_date >= ? " +
+			"LEFT JOIN products_batches pb ON pb.id = i.product_batch_id WHERE e.deleted_at IS NULL GROUP BY e.id ORDER BY e.id;"
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, "12", "Juan", "Perez", 1, nil, nil, 2, 300).
+			AddRow(2, "121", "Carlos", "Perez", 1, nil, nil, 0, 0)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("2023-03-01").WillReturnRows(rows)
+
+		repo := NewRepository(db)
+
+		employees, err := repo.GetProductivity(ctx, domain.EmployeeProductivityFilter{From: "2023-03-01"})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.EmployeeProductivity{
+			{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 1, CardNumberID: "12", FirstName: "Juan", LastName: "Perez", WarehouseID: 1, InboundOrdersCount: 2}, UnitsReceived: 300},
+			{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 2, CardNumberID: "121", FirstName: "Carlos", LastName: "Perez", WarehouseID: 1}},
+		}, employees)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetProductivity OK grouped by week and warehouse", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, DATE_FORMAT(i.order_date, '%x-W%v'), i.warehouse_id, COUNT(i.id), COALESCE(SUM(pb.initial_quantity),0) " +
+			"FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id AND i.order_date <= ? AND i.warehouse_id = ? " +
+			"LEFT JOIN products_batches pb ON pb.id = i.product_batch_id WHERE e.deleted_at IS NULL AND e.id = ? " +
+			"GROUP BY e.id, DATE_FORMAT(i.order_date, '%x-W%v'), i.warehouse_id HAVING COUNT(i.id) > 0 " +
+			"ORDER BY e.id, DATE_FORMAT(i.order_date, '%x-W%v'), i.warehouse_id;"
+		rows := sqlmock.NewRows(columns).AddRow(1, "12", "Juan", "Perez", 1, "2023-W09", 2, 1, 100)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("2023-03-31", 2, 1).WillReturnRows(rows)
+
+		repo := NewRepository(db)
+
+		employees, err := repo.GetProductivity(ctx, domain.EmployeeProductivityFilter{EmployeeID: 1, WarehouseID: 2, To: "2023-03-31", GroupBy: "week", ByWarehouse: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.EmployeeProductivity{
+			{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 1, CardNumberID: "12", FirstName: "Juan", LastName: "Perez", WarehouseID: 1, InboundOrdersCount: 1}, Period: "2023-W09", OrderWarehouseID: 2, UnitsReceived: 100},
+		}, employees)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetProductivity Error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT e.id").WillReturnError(errors.New("Error data base"))
+
+		repo := NewRepository(db)
+
+		employees, err := repo.GetProductivity(ctx, domain.EmployeeProductivityFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, employees)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Repository_GetLeaderboard(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GetLeaderboard OK", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "orders", "units"}).
+			AddRow(2, "121", "Carlos", "Perez", 1, 3, 450).
+			AddRow(1, "12", "Juan", "Perez", 1, 2, 300)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryLeaderboard+" AND i.order_date >= ? AND i.order_date <= ?"+QueryLeaderboardGroup)).
+			WithArgs(1, "2023-03-01", "2023-03-31", 10).WillReturnRows(rows)
+
+		repo := NewRepository(db)
+
+		ranking, err := repo.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, From: "2023-03-01", To: "2023-03-31", Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.EmployeeRanking{
+			{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 2, CardNumberID: "121", FirstName: "Carlos", LastName: "Perez", WarehouseID: 1, InboundOrdersCount: 3}, UnitsReceived: 450},
+			{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 1, CardNumberID: "12", FirstName: "Juan", LastName: "Perez", WarehouseID: 1, InboundOrdersCount: 2}, UnitsReceived: 300},
+		}, ranking)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetLeaderboard skips soft deleted employees", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) AS orders, COALESCE(SUM(pb.initial_quantity),0) AS units " +
+			"FROM inbound_orders i INNER JOIN employees e ON e.id = i.employee_id " +
+			"INNER JOIN products_batches pb ON pb.id = i.product_batch_id " +
+			"WHERE i.warehouse_id = ? AND e.deleted_at IS NULL " +
+			"GROUP BY e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id ORDER BY units DESC, orders DESC, e.id LIMIT ?;"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "orders", "units"}))
+
+		repo := NewRepository(db)
+
+		ranking, err := repo.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, Limit: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, ranking)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GetLeaderboard Error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryLeaderboard+QueryLeaderboardGroup)).WithArgs(1, 10).WillReturnError(errors.New("Error data base"))
+
+		repo := NewRepository(db)
+
+		ranking, err := repo.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, Limit: 10})
+		assert.Error(t, err)
+		assert.Nil(t, ranking)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 // Errors
@@ -12,16 +14,29 @@ var (
 	ErrNotFound     = errors.New("employee not found")
 	ErrDatabase     = errors.New("Database error.")
 	ErrExistsCardId = errors.New("Exists card number id.")
+	ErrDateRange    = errors.New("from must not be after to")
+	ErrGroupBy      = errors.New("group_by must be day, week or month")
+)
+
+// Leaderboard sizes
+const (
+	DefaultLeaderboardLimit = 10
+	MaxLeaderboardLimit     = 100
 )
 
 type Service interface {
 	GetAll(ctx context.Context) ([]domain.Employee, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Employee, error)
 	Get(ctx context.Context, id int) (domain.Employee, error)
 	Create(ctx context.Context, e domain.Employee) (domain.Employee, error)
 	Update(ctx context.Context, e domain.Employee) (domain.Employee, error)
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (domain.Employee, error)
+	HardDelete(ctx context.Context, id int) error
 	GetAllInoundOrders(ctx context.Context) ([]domain.EmployeeWithInboundOrders, error)
 	GetWithInboundOrder(ctx context.Context, id int) (domain.EmployeeWithInboundOrders, error)
+	GetProductivity(ctx context.Context, f domain.EmployeeProductivityFilter) ([]domain.EmployeeProductivity, error)
+	GetLeaderboard(ctx context.Context, f domain.EmployeeLeaderboardFilter) ([]domain.EmployeeRanking, error)
 }
 
 type service struct {
@@ -42,6 +57,14 @@ func (s *service) GetAll(ctx context.Context) ([]domain.Employee, error) {
 	return employees, nil
 }
 
+func (s *service) GetAllWithDeleted(ctx context.Context) ([]domain.Employee, error) {
+	employees, err := s.repository.GetAllWithDeleted(ctx)
+	if err != nil {
+		return employees, ErrDatabase
+	}
+	return employees, nil
+}
+
 func (s *service) Get(ctx context.Context, id int) (domain.Employee, error) {
 	employee, err := s.repository.Get(ctx, id)
 	if err != nil {
@@ -76,8 +99,38 @@ func (s *service) Update(ctx context.Context, e domain.Employee) (domain.Employe
 
 func (s *service) Delete(ctx context.Context, id int) error {
 	err := s.repository.Delete(ctx, id)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, etag.ErrPreconditionFailed) {
+		return err
+	}
+
+	if err != nil {
+		return ErrDatabase
+	}
+
+	return nil
+}
+
+func (s *service) Restore(ctx context.Context, id int) (domain.Employee, error) {
+	if _, err := s.repository.Get(ctx, id); err == nil {
+		return domain.Employee{}, softdelete.ErrNotDeleted
+	}
+
+	err := s.repository.Restore(ctx, id)
 	if errors.Is(err, ErrNotFound) {
-		return ErrNotFound
+		return domain.Employee{}, ErrNotFound
+	}
+
+	if err != nil {
+		return domain.Employee{}, ErrDatabase
+	}
+
+	return s.Get(ctx, id)
+}
+
+func (s *service) HardDelete(ctx context.Context, id int) error {
+	err := s.repository.HardDelete(ctx, id)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, softdelete.ErrHasDependents) {
+		return err
 	}
 
 	if err != nil {
@@ -103,3 +156,47 @@ func (s *service) GetWithInboundOrder(ctx context.Context, id int) (domain.Emplo
 
 	return employee, nil
 }
+
+func (s *service) GetProductivity(ctx context.Context, f domain.EmployeeProductivityFilter) ([]domain.EmployeeProductivity, error) {
+	if f.GroupBy != "" && periodFormats[f.GroupBy] == "" {
+		return nil, ErrGroupBy
+	}
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return nil, ErrDateRange
+	}
+
+	employees, err := s.repository.GetProductivity(ctx, f)
+	if err != nil {
+		return nil, ErrDatabase
+	}
+	return employees, nil
+}
+
+// GetLeaderboard ranks the employees of a warehouse by units received, then by orders. Employees tied on
+// both share a rank and the next one skips the places they took.
+func (s *service) GetLeaderboard(ctx context.Context, f domain.EmployeeLeaderboardFilter) ([]domain.EmployeeRanking, error) {
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return nil, ErrDateRange
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultLeaderboardLimit
+	}
+	if f.Limit > MaxLeaderboardLimit {
+		f.Limit = MaxLeaderboardLimit
+	}
+	if !s.repository.ExistsWarehouse(ctx, f.WarehouseID) {
+		return nil, ErrWarehouseNotfound
+	}
+
+	ranking, err := s.repository.GetLeaderboard(ctx, f)
+	if err != nil {
+		return nil, ErrDatabase
+	}
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+		if prev := i - 1; prev >= 0 && ranking[prev].UnitsReceived == ranking[i].UnitsReceived && ranking[prev].InboundOrdersCount == ranking[i].InboundOrdersCount {
+			ranking[i].Rank = ranking[prev].Rank
+		}
+	}
+	return ranking, nil
+}
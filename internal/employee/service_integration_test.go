@@ -17,7 +17,7 @@ import (
 func Test_Integration_Service_GetAll(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT * FROM employees"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE deleted_at IS NULL"
 
 	data := []domain.Employee{
 		{
@@ -75,7 +75,7 @@ func Test_Integration_Service_GetAll(t *testing.T) {
 func Test_Integration_Service_Get(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT * FROM employees WHERE id=?;"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id=? AND deleted_at IS NULL;"
 
 	data := domain.Employee{
 		ID:           1,
@@ -219,7 +219,7 @@ func Test_Integration_Service_Create(t *testing.T) {
 func Test_Integration_Service_Update(t *testing.T) {
 	ctx := context.Background()
 
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
+	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?, version=version+1 WHERE id=?"
 
 	data := domain.Employee{
 		ID:           1,
@@ -281,7 +281,7 @@ func Test_Integration_Service_Update(t *testing.T) {
 func Test_Integration_Service_Delete(t *testing.T) {
 	ctx := context.Background()
 
-	query := "DELETE FROM employees WHERE id=?"
+	query := "UPDATE employees SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
 
 	t.Run("Delete OK", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -334,7 +334,7 @@ func Test_Integration_Service_Delete(t *testing.T) {
 func Test_Integration_Service_GetAllInoundOrders(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.deleted_at IS NULL GROUP BY e.id;"
 
 	data := []domain.EmployeeWithInboundOrders{
 		{
@@ -394,7 +394,7 @@ func Test_Integration_Service_GetAllInoundOrders(t *testing.T) {
 func Test_Integration_Service_GetWithInoundOrders(t *testing.T) {
 	ctx := context.Background()
 
-	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? GROUP BY e.id;"
+	query := "SELECT e.id, e.card_number_id, e.first_name, e.last_name, e.warehouse_id, COUNT(i.id) FROM employees e LEFT JOIN inbound_orders i ON e.id = i.employee_id WHERE e.id=? AND e.deleted_at IS NULL GROUP BY e.id;"
 
 	data := domain.EmployeeWithInboundOrders{
 		ID:                 1,
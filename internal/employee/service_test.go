@@ -48,6 +48,21 @@ func (rm *repositoryMock) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (rm *repositoryMock) GetAllWithDeleted(ctx context.Context) ([]domain.Employee, error) {
+	args := rm.Called(ctx)
+	return args.Get(0).([]domain.Employee), args.Error(1)
+}
+
+func (rm *repositoryMock) Restore(ctx context.Context, id int) error {
+	args := rm.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (rm *repositoryMock) HardDelete(ctx context.Context, id int) error {
+	args := rm.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (rm *repositoryMock) GetAllInoundOrders(ctx context.Context) ([]domain.EmployeeWithInboundOrders, error) {
 	args := rm.Called(ctx)
 	return args.Get(0).([]domain.EmployeeWithInboundOrders), args.Error(1)
@@ -58,6 +73,21 @@ func (rm *repositoryMock) GetWithInboundOrder(ctx context.Context, id int) (doma
 	return args.Get(0).(domain.EmployeeWithInboundOrders), args.Error(1)
 }
 
+func (rm *repositoryMock) GetProductivity(ctx context.Context, f domain.EmployeeProductivityFilter) ([]domain.EmployeeProductivity, error) {
+	args := rm.Called(ctx, f)
+	return args.Get(0).([]domain.EmployeeProductivity), args.Error(1)
+}
+
+func (rm *repositoryMock) GetLeaderboard(ctx context.Context, f domain.EmployeeLeaderboardFilter) ([]domain.EmployeeRanking, error) {
+	args := rm.Called(ctx, f)
+	return args.Get(0).([]domain.EmployeeRanking), args.Error(1)
+}
+
+func (rm *repositoryMock) ExistsWarehouse(ctx context.Context, id int) bool {
+	args := rm.Called(ctx, id)
+	return args.Bool(0)
+}
+
 func Test_Service_Create(t *testing.T) {
 	ctx := context.Background()
 
@@ -388,3 +418,116 @@ func Test_Service_GetWithInoundOrders(t *testing.T) {
 		assert.True(t, repo.AssertExpectations(t))
 	})
 }
+
+func Test_Service_GetProductivity(t *testing.T) {
+	ctx := context.Background()
+
+	data := []domain.EmployeeProductivity{
+		{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: 1, InboundOrdersCount: 2}, Period: "2023-03", UnitsReceived: 150},
+	}
+
+	t.Run("GetProductivity OK", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		filter := domain.EmployeeProductivityFilter{From: "2023-03-01", To: "2023-03-31", GroupBy: "month"}
+		repo.On("GetProductivity", ctx, filter).Return(data, nil)
+
+		employees, err := service.GetProductivity(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, data, employees)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+
+	t.Run("GetProductivity Error group_by", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		_, err := service.GetProductivity(ctx, domain.EmployeeProductivityFilter{GroupBy: "year"})
+		assert.Equal(t, ErrGroupBy, err)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+
+	t.Run("GetProductivity Error date range", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		_, err := service.GetProductivity(ctx, domain.EmployeeProductivityFilter{From: "2023-04-01", To: "2023-03-01"})
+		assert.Equal(t, ErrDateRange, err)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+
+	t.Run("GetProductivity Error", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		repo.On("GetProductivity", ctx, domain.EmployeeProductivityFilter{ByWarehouse: true}).Return([]domain.EmployeeProductivity{}, errors.New("Error in DB"))
+
+		employees, err := service.GetProductivity(ctx, domain.EmployeeProductivityFilter{ByWarehouse: true})
+		assert.Equal(t, ErrDatabase, err)
+		assert.Nil(t, employees)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+}
+
+func Test_Service_GetLeaderboard(t *testing.T) {
+	ctx := context.Background()
+
+	ranked := func(id, orders, units int) domain.EmployeeRanking {
+		return domain.EmployeeRanking{EmployeeWithInboundOrders: domain.EmployeeWithInboundOrders{ID: id, InboundOrdersCount: orders}, UnitsReceived: units}
+	}
+
+	t.Run("GetLeaderboard OK ties share a rank", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		repo.On("ExistsWarehouse", ctx, 1).Return(true)
+		repo.On("GetLeaderboard", ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, Limit: DefaultLeaderboardLimit}).
+			Return([]domain.EmployeeRanking{ranked(3, 4, 500), ranked(1, 2, 300), ranked(2, 2, 300), ranked(4, 1, 300)}, nil)
+
+		ranking, err := service.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1})
+		assert.NoError(t, err)
+		var ranks []int
+		for _, r := range ranking {
+			ranks = append(ranks, r.Rank)
+		}
+		assert.Equal(t, []int{1, 2, 2, 4}, ranks)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+
+	t.Run("GetLeaderboard OK limit capped", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		repo.On("ExistsWarehouse", ctx, 1).Return(true)
+		repo.On("GetLeaderboard", ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, Limit: MaxLeaderboardLimit}).Return([]domain.EmployeeRanking{}, nil)
+
+		ranking, err := service.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, Limit: 5000})
+		assert.NoError(t, err)
+		assert.Empty(t, ranking)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+
+	t.Run("GetLeaderboard Error warehouse not found", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		repo.On("ExistsWarehouse", ctx, 9).Return(false)
+
+		_, err := service.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 9})
+		assert.Equal(t, ErrWarehouseNotfound, err)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+
+	t.Run("GetLeaderboard Error", func(t *testing.T) {
+		repo := NewRepositoryMock()
+		service := NewService(repo)
+
+		repo.On("ExistsWarehouse", ctx, 1).Return(true)
+		repo.On("GetLeaderboard", ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1, Limit: DefaultLeaderboardLimit}).Return([]domain.EmployeeRanking{}, errors.New("Error in DB"))
+
+		_, err := service.GetLeaderboard(ctx, domain.EmployeeLeaderboardFilter{WarehouseID: 1})
+		assert.Equal(t, ErrDatabase, err)
+		assert.True(t, repo.AssertExpectations(t))
+	})
+}
@@ -0,0 +1,53 @@
+package forecast
+
+import (
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+// DailySeries spreads the demand over every day from from to to, the days missing from it having no units.
+// The demand is expected to be within those days.
+func DailySeries(demand []domain.DemandDay, from, to time.Time) []domain.DemandDay {
+	units := make(map[string]int, len(demand))
+	for _, d := range demand {
+		units[d.Date] += d.Units
+	}
+	series := []domain.DemandDay{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		series = append(series, domain.DemandDay{Date: date, Units: units[date]})
+	}
+	return series
+}
+
+// MovingAverage forecasts the demand of the coming days as the average of the last window days of the
+// series, or of the whole series when it is shorter.
+func MovingAverage(series []domain.DemandDay, window int) float64 {
+	if window > len(series) {
+		window = len(series)
+	}
+	if window <= 0 {
+		return 0
+	}
+	total := 0
+	for _, d := range series[len(series)-window:] {
+		total += d.Units
+	}
+	return float64(total) / float64(window)
+}
+
+// ExponentialSmoothing forecasts the demand of the coming days with simple exponential smoothing, the level
+// starting at the first day and moving alpha of the way towards every following one.
+func ExponentialSmoothing(series []domain.DemandDay, alpha float64) float64 {
+	if len(series) == 0 {
+		return 0
+	}
+	level := float64(series[0].Units)
+	for _, d := range series[1:] {
+		level += alpha * (float64(d.Units) - level)
+	}
+	return level
+}
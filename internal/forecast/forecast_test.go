@@ -0,0 +1,60 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DailySeries(t *testing.T) {
+	// arrange
+	from := time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)
+	demand := []domain.DemandDay{{Date: "2023-02-28", Units: 3}, {Date: "2023-03-02", Units: 1}}
+
+	// act
+	series := DailySeries(demand, from, to)
+
+	// assert
+	assert.Equal(t, []domain.DemandDay{
+		{Date: "2023-02-27"},
+		{Date: "2023-02-28", Units: 3},
+		{Date: "2023-03-01"},
+		{Date: "2023-03-02", Units: 1},
+	}, series)
+}
+
+func Test_MovingAverage(t *testing.T) {
+	series := []domain.DemandDay{{Units: 4}, {Units: 0}, {Units: 2}, {Units: 7}}
+
+	t.Run("averages the last window days", func(t *testing.T) {
+		assert.Equal(t, 3.0, MovingAverage(series, 3))
+	})
+
+	t.Run("window longer than the series", func(t *testing.T) {
+		assert.Equal(t, 3.25, MovingAverage(series, 10))
+	})
+
+	t.Run("empty series", func(t *testing.T) {
+		assert.Equal(t, 0.0, MovingAverage(nil, 3))
+	})
+}
+
+func Test_ExponentialSmoothing(t *testing.T) {
+	t.Run("moves alpha of the way towards every day", func(t *testing.T) {
+		// 4, then 4 + 0.5 * (0 - 4) = 2, then 2 + 0.5 * (6 - 2) = 4
+		series := []domain.DemandDay{{Units: 4}, {Units: 0}, {Units: 6}}
+		assert.Equal(t, 4.0, ExponentialSmoothing(series, 0.5))
+	})
+
+	t.Run("alpha of one keeps the last day", func(t *testing.T) {
+		series := []domain.DemandDay{{Units: 4}, {Units: 9}}
+		assert.Equal(t, 9.0, ExponentialSmoothing(series, 1))
+	})
+
+	t.Run("empty series", func(t *testing.T) {
+		assert.Equal(t, 0.0, ExponentialSmoothing(nil, 0.3))
+	})
+}
@@ -0,0 +1,39 @@
+package forecast
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const DefaultInterval = 24 * time.Hour
+
+// Job stores the forecasts of every product every interval, for the reports to read.
+type Job struct {
+	service  Service
+	interval time.Duration
+}
+
+func NewJob(service Service, interval time.Duration) *Job {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Job{service: service, interval: interval}
+}
+
+// Run generates the forecasts right away and then every interval until ctx is done. It is meant to run
+// on its own goroutine.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := j.service.Generate(ctx); err != nil {
+			log.Printf("forecast: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
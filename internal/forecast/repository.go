@@ -0,0 +1,149 @@
+// Package forecast forecasts the daily demand of products, the purchase orders placed for them, from the
+// orders of the days before.
+package forecast
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqltime"
+)
+
+// Errors
+var (
+	ErrInternal        = errors.New("error: internal error")
+	ErrProductNotFound = errors.New("error: product id does not exists")
+	ErrHistory         = errors.New("error: history must be at most 365 days")
+	ErrHorizon         = errors.New("error: horizon must be at most 90 days")
+	ErrWindow          = errors.New("error: window must not be longer than history")
+	ErrAlpha           = errors.New("error: alpha must be greater than 0 and at most 1")
+)
+
+// Queries
+var (
+	QueryExistsProduct = "SELECT COUNT(*) FROM products WHERE id = ? AND deleted_at IS NULL;"
+	QueryProductIDs    = "SELECT id FROM products WHERE deleted_at IS NULL ORDER BY id;"
+	QueryDemand        = "SELECT pr.product_id, " + sqltime.Date("po.order_date") + ", COUNT(po.id) " +
+		"FROM purchase_orders AS po " +
+		"INNER JOIN product_records AS pr ON pr.id = po.product_record_id " +
+		"INNER JOIN products AS p ON p.id = pr.product_id " +
+		"WHERE p.deleted_at IS NULL AND po.order_date >= ? AND po.order_date <= ?"
+	QueryDemandGroup     = " GROUP BY pr.product_id, po.order_date ORDER BY pr.product_id, po.order_date;"
+	QueryDeleteForecasts = "DELETE FROM product_forecasts;"
+	QueryInsertForecast  = "INSERT INTO product_forecasts(product_id, forecast_date, moving_average, exponential_smoothing, generated_at) VALUES (?,?,?,?,?);"
+)
+
+type Repository interface {
+	ExistsProduct(ctx context.Context, id int) (bool, error)
+	GetProductIDs(ctx context.Context) ([]int, error)
+	GetDemand(ctx context.Context, productID int, from, to string) ([]domain.ProductDemand, error)
+	SaveForecasts(ctx context.Context, forecasts []domain.ProductForecast, generatedAt time.Time) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) ExistsProduct(ctx context.Context, id int) (bool, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, QueryExistsProduct, id).Scan(&count); err != nil {
+		return false, ErrInternal
+	}
+	return count > 0, nil
+}
+
+// GetProductIDs returns the ids of the products that are not deleted.
+func (r *repository) GetProductIDs(ctx context.Context) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, QueryProductIDs)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, ErrInternal
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return ids, nil
+}
+
+// GetDemand returns the orders placed per product and day from from to to, of productID alone unless it is
+// zero. Days without orders are left out.
+func (r *repository) GetDemand(ctx context.Context, productID int, from, to string) ([]domain.ProductDemand, error) {
+	query := QueryDemand
+	args := []interface{}{from, to}
+	if productID != 0 {
+		query += " AND p.id = ?"
+		args = append(args, productID)
+	}
+	query += QueryDemandGroup
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	var demand []domain.ProductDemand
+	for rows.Next() {
+		d := domain.ProductDemand{}
+		if err := rows.Scan(&d.ProductID, &d.Date, &d.Units); err != nil {
+			return nil, ErrInternal
+		}
+		demand = append(demand, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return demand, nil
+}
+
+// SaveForecasts replaces the stored forecasts with these, in a single transaction so reports never see a
+// run half written.
+func (r *repository) SaveForecasts(ctx context.Context, forecasts []domain.ProductForecast, generatedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrInternal
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, QueryDeleteForecasts); err != nil {
+		return ErrInternal
+	}
+	stmt, err := tx.PrepareContext(ctx, QueryInsertForecast)
+	if err != nil {
+		return ErrInternal
+	}
+	defer stmt.Close()
+	generated := generatedAt.Format("2006-01-02 15:04:05")
+	for _, f := range forecasts {
+		for _, day := range f.Forecast {
+			if _, err := stmt.ExecContext(ctx, f.ProductID, day.Date, day.MovingAverage, day.ExponentialSmoothing, generated); err != nil {
+				return ErrInternal
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Repository_ExistsProduct(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistsProduct)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistsProduct)).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		repo := NewRepository(db)
+
+		// act
+		found, errFound := repo.ExistsProduct(ctx, 1)
+		missing, errMissing := repo.ExistsProduct(ctx, 9)
+
+		// assert
+		assert.NoError(t, errFound)
+		assert.True(t, found)
+		assert.NoError(t, errMissing)
+		assert.False(t, missing)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistsProduct)).WithArgs(1).WillReturnError(errors.New("connection lost"))
+		repo := NewRepository(db)
+
+		// act
+		_, err = repo.ExistsProduct(ctx, 1)
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Repository_GetProductIDs(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(QueryProductIDs)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(3))
+	repo := NewRepository(db)
+
+	// act
+	ids, err := repo.GetProductIDs(context.Background())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Repository_GetDemand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK of a product", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"product_id", "order_date", "count"}).
+			AddRow(1, "2023-03-01", 2).
+			AddRow(1, "2023-03-04", 1)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryDemand+" AND p.id = ?"+QueryDemandGroup)).WithArgs("2023-03-01", "2023-03-31", 1).WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		demand, err := repo.GetDemand(ctx, 1, "2023-03-01", "2023-03-31")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ProductDemand{
+			{ProductID: 1, DemandDay: domain.DemandDay{Date: "2023-03-01", Units: 2}},
+			{ProductID: 1, DemandDay: domain.DemandDay{Date: "2023-03-04", Units: 1}},
+		}, demand)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryDemand+QueryDemandGroup)).WithArgs("2023-03-01", "2023-03-31").WillReturnError(errors.New("connection lost"))
+		repo := NewRepository(db)
+
+		// act
+		demand, err := repo.GetDemand(ctx, 0, "2023-03-01", "2023-03-31")
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, demand)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Repository_SaveForecasts(t *testing.T) {
+	ctx := context.Background()
+	generatedAt := time.Date(2023, 4, 1, 3, 0, 0, 0, time.UTC)
+	forecasts := []domain.ProductForecast{
+		{ProductID: 1, Forecast: []domain.ForecastDay{{Date: "2023-04-01", MovingAverage: 2, ExponentialSmoothing: 1.5}, {Date: "2023-04-02", MovingAverage: 2, ExponentialSmoothing: 1.5}}},
+		{ProductID: 3, Forecast: []domain.ForecastDay{{Date: "2023-04-01"}, {Date: "2023-04-02"}}},
+	}
+
+	t.Run("OK replaces the stored forecasts", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(QueryDeleteForecasts)).WillReturnResult(sqlmock.NewResult(0, 4))
+		insert := mock.ExpectPrepare(regexp.QuoteMeta(QueryInsertForecast))
+		insert.ExpectExec().WithArgs(1, "2023-04-01", 2.0, 1.5, "2023-04-01 03:00:00").WillReturnResult(sqlmock.NewResult(1, 1))
+		insert.ExpectExec().WithArgs(1, "2023-04-02", 2.0, 1.5, "2023-04-01 03:00:00").WillReturnResult(sqlmock.NewResult(2, 1))
+		insert.ExpectExec().WithArgs(3, "2023-04-01", 0.0, 0.0, "2023-04-01 03:00:00").WillReturnResult(sqlmock.NewResult(3, 1))
+		insert.ExpectExec().WithArgs(3, "2023-04-02", 0.0, 0.0, "2023-04-01 03:00:00").WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectCommit()
+		repo := NewRepository(db)
+
+		// act
+		err = repo.SaveForecasts(ctx, forecasts, generatedAt)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert fails and rolls back", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(QueryDeleteForecasts)).WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsertForecast)).ExpectExec().WillReturnError(errors.New("connection lost"))
+		mock.ExpectRollback()
+		repo := NewRepository(db)
+
+		// act
+		err = repo.SaveForecasts(ctx, forecasts, generatedAt)
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
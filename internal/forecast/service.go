@@ -0,0 +1,168 @@
+package forecast
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Forecast defaults and bounds
+const (
+	DefaultHistory = 90
+	MaxHistory     = 365
+	DefaultHorizon = 14
+	MaxHorizon     = 90
+	DefaultWindow  = 7
+	DefaultAlpha   = 0.3
+)
+
+type Service interface {
+	GetForecast(ctx context.Context, productID int, p domain.ForecastParams) (domain.ProductForecast, error)
+	Generate(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repository Repository
+	now        func() time.Time
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+		now:        time.Now,
+	}
+}
+
+// GetForecast returns the demand history of the product up to yesterday and its forecast from today.
+func (s *service) GetForecast(ctx context.Context, productID int, p domain.ForecastParams) (domain.ProductForecast, error) {
+	p, err := withDefaults(p)
+	if err != nil {
+		return domain.ProductForecast{}, err
+	}
+
+	exists, err := s.repository.ExistsProduct(ctx, productID)
+	if err != nil {
+		return domain.ProductForecast{}, err
+	}
+	if !exists {
+		return domain.ProductForecast{}, ErrProductNotFound
+	}
+
+	today := s.today()
+	from, to := historyDays(today, p)
+	demand, err := s.repository.GetDemand(ctx, productID, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return domain.ProductForecast{}, err
+	}
+	days := make([]domain.DemandDay, len(demand))
+	for i, d := range demand {
+		days[i] = d.DemandDay
+	}
+
+	return forecast(productID, DailySeries(days, from, to), today, p), nil
+}
+
+// Generate forecasts every product with the default parameters, stores the forecasts in place of the
+// previous ones and returns how many products were forecast.
+func (s *service) Generate(ctx context.Context) (int, error) {
+	p, _ := withDefaults(domain.ForecastParams{})
+	now := s.now()
+	today := s.today()
+	from, to := historyDays(today, p)
+
+	ids, err := s.repository.GetProductIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	demand, err := s.repository.GetDemand(ctx, 0, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return 0, err
+	}
+	byProduct := map[int][]domain.DemandDay{}
+	for _, d := range demand {
+		byProduct[d.ProductID] = append(byProduct[d.ProductID], d.DemandDay)
+	}
+
+	forecasts := make([]domain.ProductForecast, len(ids))
+	for i, id := range ids {
+		forecasts[i] = forecast(id, DailySeries(byProduct[id], from, to), today, p)
+	}
+	if err := s.repository.SaveForecasts(ctx, forecasts, now); err != nil {
+		return 0, err
+	}
+
+	return len(forecasts), nil
+}
+
+// today is the start of the current day, in UTC like the order dates are compared.
+func (s *service) today() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// withDefaults fills the parameters left at zero and checks them.
+func withDefaults(p domain.ForecastParams) (domain.ForecastParams, error) {
+	if p.History == 0 {
+		p.History = DefaultHistory
+	}
+	if p.Horizon == 0 {
+		p.Horizon = DefaultHorizon
+	}
+	if p.Window == 0 {
+		p.Window = DefaultWindow
+		if p.Window > p.History {
+			p.Window = p.History
+		}
+	}
+	if p.Alpha == 0 {
+		p.Alpha = DefaultAlpha
+	}
+
+	switch {
+	case p.History < 0 || p.History > MaxHistory:
+		return p, ErrHistory
+	case p.Horizon < 0 || p.Horizon > MaxHorizon:
+		return p, ErrHorizon
+	case p.Window < 0 || p.Window > p.History:
+		return p, ErrWindow
+	case p.Alpha < 0 || p.Alpha > 1:
+		return p, ErrAlpha
+	}
+	return p, nil
+}
+
+// historyDays returns the first and last day of the history, which ends yesterday, the last complete day.
+func historyDays(today time.Time, p domain.ForecastParams) (time.Time, time.Time) {
+	return today.AddDate(0, 0, -p.History), today.AddDate(0, 0, -1)
+}
+
+// forecast builds the forecast of the horizon from the series. Neither method models a trend or a season,
+// so every day of the horizon gets the same forecast.
+func forecast(productID int, series []domain.DemandDay, today time.Time, p domain.ForecastParams) domain.ProductForecast {
+	movingAverage := round(MovingAverage(series, p.Window))
+	smoothing := round(ExponentialSmoothing(series, p.Alpha))
+
+	days := make([]domain.ForecastDay, p.Horizon)
+	for i := range days {
+		days[i] = domain.ForecastDay{
+			Date:                 today.AddDate(0, 0, i).Format(dateLayout),
+			MovingAverage:        movingAverage,
+			ExponentialSmoothing: smoothing,
+		}
+	}
+
+	return domain.ProductForecast{
+		ProductID: productID,
+		Window:    p.Window,
+		Alpha:     p.Alpha,
+		History:   series,
+		Forecast:  days,
+	}
+}
+
+// round keeps two decimals of a forecast.
+func round(units float64) float64 {
+	return math.Round(units*100) / 100
+}
@@ -0,0 +1,177 @@
+package forecast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) ExistsProduct(ctx context.Context, id int) (bool, error) {
+	args := r.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+func (r *RepositoryMock) GetProductIDs(ctx context.Context) ([]int, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]int), args.Error(1)
+}
+func (r *RepositoryMock) GetDemand(ctx context.Context, productID int, from, to string) ([]domain.ProductDemand, error) {
+	args := r.Called(ctx, productID, from, to)
+	return args.Get(0).([]domain.ProductDemand), args.Error(1)
+}
+func (r *RepositoryMock) SaveForecasts(ctx context.Context, forecasts []domain.ProductForecast, generatedAt time.Time) error {
+	args := r.Called(ctx, forecasts, generatedAt)
+	return args.Error(0)
+}
+
+var now = time.Date(2023, 4, 1, 10, 30, 0, 0, time.UTC)
+
+func newTestService(repo Repository) *service {
+	return &service{repository: repo, now: func() time.Time { return now }}
+}
+
+func Test_GetForecast(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK fills the days without orders and forecasts the horizon", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsProduct", ctx, 1).Return(true, nil)
+		repoMock.On("GetDemand", ctx, 1, "2023-03-28", "2023-03-31").Return([]domain.ProductDemand{
+			{ProductID: 1, DemandDay: domain.DemandDay{Date: "2023-03-28", Units: 3}},
+			{ProductID: 1, DemandDay: domain.DemandDay{Date: "2023-03-31", Units: 2}},
+		}, nil)
+		service := newTestService(repoMock)
+
+		// act
+		result, err := service.GetForecast(ctx, 1, domain.ForecastParams{History: 4, Horizon: 2, Window: 3, Alpha: 0.5})
+
+		// assert
+		// smoothing: 3, 1.5, 0.75, 1.375
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ProductForecast{
+			ProductID: 1,
+			Window:    3,
+			Alpha:     0.5,
+			History:   []domain.DemandDay{{Date: "2023-03-28", Units: 3}, {Date: "2023-03-29"}, {Date: "2023-03-30"}, {Date: "2023-03-31", Units: 2}},
+			Forecast: []domain.ForecastDay{
+				{Date: "2023-04-01", MovingAverage: 0.67, ExponentialSmoothing: 1.38},
+				{Date: "2023-04-02", MovingAverage: 0.67, ExponentialSmoothing: 1.38},
+			},
+		}, result)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("OK defaults", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsProduct", ctx, 1).Return(true, nil)
+		repoMock.On("GetDemand", ctx, 1, "2023-01-01", "2023-03-31").Return([]domain.ProductDemand(nil), nil)
+		service := newTestService(repoMock)
+
+		// act
+		result, err := service.GetForecast(ctx, 1, domain.ForecastParams{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultWindow, result.Window)
+		assert.Equal(t, DefaultAlpha, result.Alpha)
+		assert.Len(t, result.History, DefaultHistory)
+		assert.Len(t, result.Forecast, DefaultHorizon)
+		repoMock.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name   string
+		params domain.ForecastParams
+		err    error
+	}{
+		{"history too long", domain.ForecastParams{History: MaxHistory + 1}, ErrHistory},
+		{"horizon too long", domain.ForecastParams{Horizon: MaxHorizon + 1}, ErrHorizon},
+		{"window longer than history", domain.ForecastParams{History: 5, Window: 6}, ErrWindow},
+		{"alpha over one", domain.ForecastParams{Alpha: 1.5}, ErrAlpha},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			repoMock := &RepositoryMock{}
+			service := newTestService(repoMock)
+
+			// act
+			_, err := service.GetForecast(ctx, 1, c.params)
+
+			// assert
+			assert.Equal(t, c.err, err)
+			repoMock.AssertExpectations(t)
+		})
+	}
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsProduct", ctx, 9).Return(false, nil)
+		service := newTestService(repoMock)
+
+		// act
+		_, err := service.GetForecast(ctx, 9, domain.ForecastParams{})
+
+		// assert
+		assert.Equal(t, ErrProductNotFound, err)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func Test_Generate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK stores a forecast for every product", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetProductIDs", ctx).Return([]int{1, 3}, nil)
+		repoMock.On("GetDemand", ctx, 0, "2023-01-01", "2023-03-31").Return([]domain.ProductDemand{
+			{ProductID: 1, DemandDay: domain.DemandDay{Date: "2023-03-31", Units: 7}},
+		}, nil)
+		var saved []domain.ProductForecast
+		repoMock.On("SaveForecasts", ctx, mock.Anything, now).Run(func(args mock.Arguments) {
+			saved = args.Get(1).([]domain.ProductForecast)
+		}).Return(nil)
+		service := newTestService(repoMock)
+
+		// act
+		count, err := service.Generate(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Len(t, saved, 2)
+		assert.Equal(t, 1, saved[0].ProductID)
+		assert.Equal(t, domain.ForecastDay{Date: "2023-04-01", MovingAverage: 1, ExponentialSmoothing: 2.1}, saved[0].Forecast[0])
+		assert.Equal(t, 3, saved[1].ProductID)
+		assert.Equal(t, domain.ForecastDay{Date: "2023-04-14"}, saved[1].Forecast[DefaultHorizon-1])
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("save error", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetProductIDs", ctx).Return([]int{1}, nil)
+		repoMock.On("GetDemand", ctx, 0, "2023-01-01", "2023-03-31").Return([]domain.ProductDemand(nil), nil)
+		repoMock.On("SaveForecasts", ctx, mock.Anything, now).Return(ErrInternal)
+		service := newTestService(repoMock)
+
+		// act
+		count, err := service.Generate(ctx)
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Equal(t, 0, count)
+		repoMock.AssertExpectations(t)
+	})
+}
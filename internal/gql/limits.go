@@ -0,0 +1,161 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	ErrTooDeep    = errors.New("query is too deep")
+	ErrTooComplex = errors.New("query is too complex")
+)
+
+const (
+	DefaultMaxDepth      = 8
+	DefaultMaxComplexity = 10000
+	// listFactor is how many items a list field is assumed to hold when weighing a query
+	listFactor = 10
+)
+
+// Limits bound the queries run, checked before anything is resolved.
+type Limits struct {
+	// MaxDepth is how deep fields may be nested, a root field being at depth 1
+	MaxDepth int
+	// MaxComplexity bounds the fields a query may resolve, every field costing 1 and the fields below a
+	// list costing listFactor times as much
+	MaxComplexity int
+}
+
+// DefaultLimits let a query walk seller -> products -> batches -> section -> warehouse with some room to spare.
+var DefaultLimits = Limits{MaxDepth: DefaultMaxDepth, MaxComplexity: DefaultMaxComplexity}
+
+// measure returns the depth and the complexity of the heaviest operation of doc. Introspection fields
+// are left out, their answers being static.
+func measure(schema graphql.Schema, doc *ast.Document) (depth, complexity int) {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	m := &measurer{schema: schema, fragments: fragments, visiting: map[string]bool{}}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		var root *graphql.Object
+		switch op.Operation {
+		case ast.OperationTypeMutation:
+			root = schema.MutationType()
+		case ast.OperationTypeSubscription:
+			root = schema.SubscriptionType()
+		default:
+			root = schema.QueryType()
+		}
+		d, c := m.selectionSet(root, op.SelectionSet)
+		if d > depth {
+			depth = d
+		}
+		if c > complexity {
+			complexity = c
+		}
+	}
+	return depth, complexity
+}
+
+type measurer struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	// visiting guards against fragment cycles, which the validation run afterwards reports
+	visiting map[string]bool
+}
+
+func (m *measurer) selectionSet(parent *graphql.Object, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, c = m.field(parent, s)
+		case *ast.InlineFragment:
+			d, c = m.selectionSet(m.condition(parent, s.TypeCondition), s.SelectionSet)
+		case *ast.FragmentSpread:
+			fragment := m.fragments[s.Name.Value]
+			if fragment == nil || m.visiting[s.Name.Value] {
+				continue
+			}
+			m.visiting[s.Name.Value] = true
+			d, c = m.selectionSet(m.condition(parent, fragment.TypeCondition), fragment.SelectionSet)
+			m.visiting[s.Name.Value] = false
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (m *measurer) field(parent *graphql.Object, field *ast.Field) (depth, complexity int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	var child *graphql.Object
+	factor := 1
+	if parent != nil {
+		if def, ok := parent.Fields()[field.Name.Value]; ok {
+			child, factor = unwrap(def.Type)
+		}
+	}
+
+	d, c := m.selectionSet(child, field.SelectionSet)
+	return d + 1, 1 + factor*c
+}
+
+// condition is the type a fragment applies to, parent when it names none.
+func (m *measurer) condition(parent *graphql.Object, named *ast.Named) *graphql.Object {
+	if named == nil || named.Name == nil {
+		return parent
+	}
+	object, _ := m.schema.Type(named.Name.Value).(*graphql.Object)
+	return object
+}
+
+// unwrap returns the object a field resolves to, nil for scalars, and the factor its selections weigh.
+func unwrap(t graphql.Type) (*graphql.Object, int) {
+	factor := 1
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			factor *= listFactor
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped, factor
+		default:
+			return nil, factor
+		}
+	}
+}
+
+// check refuses doc when it goes over the limits, a zero limit being no limit.
+func (l Limits) check(schema graphql.Schema, doc *ast.Document) error {
+	depth, complexity := measure(schema, doc)
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("%w: depth %d over %d", ErrTooDeep, depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return fmt.Errorf("%w: complexity %d over %d", ErrTooComplex, complexity, l.MaxComplexity)
+	}
+	return nil
+}
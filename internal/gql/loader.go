@@ -0,0 +1,141 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// batch collects the keys asked for while a level of the query resolves and loads them all with a
+// single call once the first of their values is needed. The executor resolves a level breadth first,
+// so the products of every seller in a list are read with one query rather than one per seller.
+type batch struct {
+	mu      sync.Mutex
+	load    func(ctx context.Context, keys []int) (map[int]interface{}, error)
+	pending []int
+	values  map[int]interface{}
+	errs    map[int]error
+}
+
+func newBatch(load func(ctx context.Context, keys []int) (map[int]interface{}, error)) *batch {
+	return &batch{load: load, values: map[int]interface{}{}, errs: map[int]error{}}
+}
+
+// Load queues key and returns the thunk the executor calls for its value, nil when there is none.
+func (b *batch) Load(ctx context.Context, key int) func() (interface{}, error) {
+	b.mu.Lock()
+	b.pending = append(b.pending, key)
+	b.mu.Unlock()
+
+	return func() (interface{}, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, loaded := b.values[key]; !loaded && b.errs[key] == nil {
+			b.flush(ctx)
+		}
+		return b.values[key], b.errs[key]
+	}
+}
+
+// flush loads the pending keys not loaded yet. Callers hold mu.
+func (b *batch) flush(ctx context.Context) {
+	var keys []int
+	seen := map[int]bool{}
+	for _, key := range b.pending {
+		if _, loaded := b.values[key]; loaded || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	b.pending = nil
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := b.load(ctx, keys)
+	for _, key := range keys {
+		if err != nil {
+			b.errs[key] = err
+			continue
+		}
+		b.values[key] = values[key]
+	}
+}
+
+// loaders are the batches of one request, so that nothing loaded is shared between requests.
+type loaders struct {
+	productsBySeller *batch
+	batchesByProduct *batch
+	sections         *batch
+	warehouses       *batch
+}
+
+func newLoaders(s Services) *loaders {
+	return &loaders{
+		productsBySeller: newBatch(func(ctx context.Context, sellerIDs []int) (map[int]interface{}, error) {
+			products, err := s.Products.GetBySellerIDs(ctx, sellerIDs)
+			if err != nil {
+				return nil, err
+			}
+			grouped := map[int][]domain.Product{}
+			for _, p := range products {
+				grouped[p.SellerID] = append(grouped[p.SellerID], p)
+			}
+			values := map[int]interface{}{}
+			for _, id := range sellerIDs {
+				values[id] = append([]domain.Product{}, grouped[id]...)
+			}
+			return values, nil
+		}),
+		batchesByProduct: newBatch(func(ctx context.Context, productIDs []int) (map[int]interface{}, error) {
+			batches, err := s.Batches.GetByProductIDs(ctx, productIDs)
+			if err != nil {
+				return nil, err
+			}
+			grouped := map[int][]domain.ProductBatches{}
+			for _, b := range batches {
+				grouped[b.ProductID] = append(grouped[b.ProductID], b)
+			}
+			values := map[int]interface{}{}
+			for _, id := range productIDs {
+				values[id] = append([]domain.ProductBatches{}, grouped[id]...)
+			}
+			return values, nil
+		}),
+		sections: newBatch(func(ctx context.Context, ids []int) (map[int]interface{}, error) {
+			sections, err := s.Sections.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			values := map[int]interface{}{}
+			for _, section := range sections {
+				values[section.ID] = section
+			}
+			return values, nil
+		}),
+		warehouses: newBatch(func(ctx context.Context, ids []int) (map[int]interface{}, error) {
+			warehouses, err := s.Warehouses.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			values := map[int]interface{}{}
+			for _, w := range warehouses {
+				values[w.ID] = w
+			}
+			return values, nil
+		}),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
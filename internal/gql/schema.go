@@ -0,0 +1,175 @@
+package gql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_batches"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+)
+
+// Services are what the resolvers read through, the same ones behind the REST endpoints.
+type Services struct {
+	Sellers    seller.Service
+	Products   product.Service
+	Batches    product_batches.Service
+	Sections   section.Service
+	Warehouses warehouse.Service
+}
+
+// newSchema builds the read only schema. Fields are named after the JSON of the REST endpoints and
+// resolved from the json tags of the domain types, except for the relationships
+// seller -> products -> batches -> section -> warehouse, which go through the request loaders.
+func newSchema(s Services) (graphql.Schema, error) {
+	warehouseType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Warehouse",
+		Fields: graphql.Fields{
+			"id":                  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"address":             &graphql.Field{Type: graphql.String},
+			"telephone":           &graphql.Field{Type: graphql.String},
+			"warehouse_code":      &graphql.Field{Type: graphql.String},
+			"minimum_capacity":    &graphql.Field{Type: graphql.Int},
+			"minimum_temperature": &graphql.Field{Type: graphql.Int},
+			"locality_id":         &graphql.Field{Type: graphql.String},
+			"latitude":            &graphql.Field{Type: graphql.Float},
+			"longitude":           &graphql.Field{Type: graphql.Float},
+		},
+	})
+
+	sectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Section",
+		Fields: graphql.Fields{
+			"section_id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"section_number":      &graphql.Field{Type: graphql.Int},
+			"current_temperature": &graphql.Field{Type: graphql.Int},
+			"minimum_temperature": &graphql.Field{Type: graphql.Int},
+			"current_capacity":    &graphql.Field{Type: graphql.Int},
+			"minimum_capacity":    &graphql.Field{Type: graphql.Int},
+			"maximum_capacity":    &graphql.Field{Type: graphql.Int},
+			"warehouse_id":        &graphql.Field{Type: graphql.Int},
+			"product_type_id":     &graphql.Field{Type: graphql.Int},
+			"warehouse": &graphql.Field{
+				Type: warehouseType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).warehouses.Load(p.Context, p.Source.(domain.Section).WarehouseID), nil
+				},
+			},
+		},
+	})
+
+	batchType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ProductBatch",
+		Fields: graphql.Fields{
+			"id":                  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"batch_number":        &graphql.Field{Type: graphql.Int},
+			"current_quantity":    &graphql.Field{Type: graphql.Int},
+			"current_temperature": &graphql.Field{Type: graphql.Int},
+			"due_date":            &graphql.Field{Type: graphql.String},
+			"initial_quantity":    &graphql.Field{Type: graphql.Int},
+			"manufacturing_date":  &graphql.Field{Type: graphql.String},
+			"manufacturing_hour":  &graphql.Field{Type: graphql.String},
+			"minumum_temperature": &graphql.Field{Type: graphql.Int},
+			"product_id":          &graphql.Field{Type: graphql.Int},
+			"section_id":          &graphql.Field{Type: graphql.Int},
+			"section": &graphql.Field{
+				Type: sectionType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).sections.Load(p.Context, p.Source.(domain.ProductBatches).SectionID), nil
+				},
+			},
+		},
+	})
+
+	productType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"id":                               &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"description":                      &graphql.Field{Type: graphql.String},
+			"expiration_rate":                  &graphql.Field{Type: graphql.Int},
+			"freezing_rate":                    &graphql.Field{Type: graphql.Int},
+			"height":                           &graphql.Field{Type: graphql.Float},
+			"length":                           &graphql.Field{Type: graphql.Float},
+			"netweight":                        &graphql.Field{Type: graphql.Float},
+			"product_code":                     &graphql.Field{Type: graphql.String},
+			"recommended_freezing_temperature": &graphql.Field{Type: graphql.Float},
+			"width":                            &graphql.Field{Type: graphql.Float},
+			"product_type_id":                  &graphql.Field{Type: graphql.Int},
+			"seller_id":                        &graphql.Field{Type: graphql.Int},
+			"batches": &graphql.Field{
+				Type: graphql.NewList(batchType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).batchesByProduct.Load(p.Context, p.Source.(domain.Product).ID), nil
+				},
+			},
+		},
+	})
+
+	sellerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Seller",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"cid":          &graphql.Field{Type: graphql.Int},
+			"company_name": &graphql.Field{Type: graphql.String},
+			"address":      &graphql.Field{Type: graphql.String},
+			"telephone":    &graphql.Field{Type: graphql.String},
+			"locality_id":  &graphql.Field{Type: graphql.String},
+			"products": &graphql.Field{
+				Type: graphql.NewList(productType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).productsBySeller.Load(p.Context, p.Source.(domain.Seller).ID), nil
+				},
+			},
+		},
+	})
+
+	idArgs := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"sellers": &graphql.Field{
+				Type: graphql.NewList(sellerType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.Sellers.GetAll(p.Context)
+				},
+			},
+			"seller": &graphql.Field{
+				Type: sellerType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.Sellers.GetByID(p.Context, p.Args["id"].(int))
+				},
+			},
+			"products": &graphql.Field{
+				Type: graphql.NewList(productType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.Products.GetAll(p.Context)
+				},
+			},
+			"product": &graphql.Field{
+				Type: productType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.Products.GetByID(p.Context, p.Args["id"].(int))
+				},
+			},
+			"sections": &graphql.Field{
+				Type: graphql.NewList(sectionType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.Sections.GetAll(p.Context)
+				},
+			},
+			"section": &graphql.Field{
+				Type: sectionType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.Sections.GetByID(p.Context, p.Args["id"].(int))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
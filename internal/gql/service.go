@@ -0,0 +1,71 @@
+// Package gql serves a read only GraphQL view of sellers, products, their batches, sections and
+// warehouses, resolved through the same services as the REST endpoints.
+package gql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// ErrInvalidQuery is returned along with the result of a query refused before it ran: one that does not
+// parse, does not validate against the schema or goes over the limits.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Request is a GraphQL request as posted by clients.
+type Request struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Service interface {
+	Execute(ctx context.Context, req Request) (*graphql.Result, error)
+}
+
+type service struct {
+	services Services
+	schema   graphql.Schema
+	limits   Limits
+}
+
+func NewService(services Services, limits Limits) (Service, error) {
+	schema, err := newSchema(services)
+	if err != nil {
+		return nil, err
+	}
+	return &service{services: services, schema: schema, limits: limits}, nil
+}
+
+// runs the query with loaders of its own, so the rows read for one request are never served to another
+func (s *service) Execute(ctx context.Context, req Request) (*graphql.Result, error) {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, ErrInvalidQuery
+	}
+
+	if err := s.limits.check(s.schema, doc); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, ErrInvalidQuery
+	}
+
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}, ErrInvalidQuery
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(s.services)),
+	}), nil
+}
+
+func parse(query string) (*ast.Document, error) {
+	return parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"})})
+}
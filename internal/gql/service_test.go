@@ -0,0 +1,322 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product_batches"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/section"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/warehouse"
+	"github.com/stretchr/testify/assert"
+)
+
+// The stubs embed the service they stand for, calling anything but the reads below panics.
+type stubSellers struct {
+	seller.Service
+	sellers []domain.Seller
+}
+
+func (s *stubSellers) GetAll(ctx context.Context) ([]domain.Seller, error) {
+	return s.sellers, nil
+}
+
+func (s *stubSellers) GetByID(ctx context.Context, id int) (domain.Seller, error) {
+	for _, sl := range s.sellers {
+		if sl.ID == id {
+			return sl, nil
+		}
+	}
+	return domain.Seller{}, errors.New("seller not found")
+}
+
+type stubProducts struct {
+	product.Service
+	products []domain.Product
+	asked    [][]int
+}
+
+func (s *stubProducts) GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error) {
+	s.asked = append(s.asked, sellerIDs)
+	var products []domain.Product
+	for _, p := range s.products {
+		for _, id := range sellerIDs {
+			if p.SellerID == id {
+				products = append(products, p)
+			}
+		}
+	}
+	return products, nil
+}
+
+type stubBatches struct {
+	product_batches.Service
+	batches []domain.ProductBatches
+	asked   [][]int
+	err     error
+}
+
+func (s *stubBatches) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error) {
+	s.asked = append(s.asked, productIDs)
+	var batches []domain.ProductBatches
+	for _, b := range s.batches {
+		for _, id := range productIDs {
+			if b.ProductID == id {
+				batches = append(batches, b)
+			}
+		}
+	}
+	return batches, s.err
+}
+
+type stubSections struct {
+	section.Service
+	sections []domain.Section
+	asked    [][]int
+}
+
+func (s *stubSections) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	s.asked = append(s.asked, ids)
+	var sections []domain.Section
+	for _, sc := range s.sections {
+		for _, id := range ids {
+			if sc.ID == id {
+				sections = append(sections, sc)
+			}
+		}
+	}
+	return sections, nil
+}
+
+type stubWarehouses struct {
+	warehouse.Service
+	warehouses []domain.Warehouse
+	asked      [][]int
+}
+
+func (s *stubWarehouses) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	s.asked = append(s.asked, ids)
+	var warehouses []domain.Warehouse
+	for _, w := range s.warehouses {
+		for _, id := range ids {
+			if w.ID == id {
+				warehouses = append(warehouses, w)
+			}
+		}
+	}
+	return warehouses, nil
+}
+
+type stubs struct {
+	sellers    *stubSellers
+	products   *stubProducts
+	batches    *stubBatches
+	sections   *stubSections
+	warehouses *stubWarehouses
+}
+
+func newStubs() stubs {
+	return stubs{
+		sellers: &stubSellers{sellers: []domain.Seller{{ID: 1, CompanyName: "Frio SA"}, {ID: 2, CompanyName: "Helados SRL"}, {ID: 3, CompanyName: "Vacio SA"}}},
+		products: &stubProducts{products: []domain.Product{
+			{ID: 10, Description: "peas", SellerID: 1},
+			{ID: 11, Description: "corn", SellerID: 1},
+			{ID: 20, Description: "ice cream", SellerID: 2},
+		}},
+		batches: &stubBatches{batches: []domain.ProductBatches{
+			{ID: 100, BatchNumber: 1, ProductID: 10, SectionID: 5},
+			{ID: 101, BatchNumber: 2, ProductID: 11, SectionID: 5},
+			{ID: 200, BatchNumber: 3, ProductID: 20, SectionID: 6},
+		}},
+		sections: &stubSections{sections: []domain.Section{
+			{ID: 5, SectionNumber: 50, WarehouseID: 7},
+			{ID: 6, SectionNumber: 60, WarehouseID: 7},
+		}},
+		warehouses: &stubWarehouses{warehouses: []domain.Warehouse{{ID: 7, WarehouseCode: "W7"}}},
+	}
+}
+
+func (s stubs) services() Services {
+	return Services{Sellers: s.sellers, Products: s.products, Batches: s.batches, Sections: s.sections, Warehouses: s.warehouses}
+}
+
+func newTestService(t *testing.T, s stubs, limits Limits) Service {
+	service, err := NewService(s.services(), limits)
+	assert.NoError(t, err)
+	return service
+}
+
+const nestedQuery = `{
+	sellers {
+		id
+		company_name
+		products {
+			id
+			batches {
+				batch_number
+				section { section_number warehouse { warehouse_code } }
+			}
+		}
+	}
+}`
+
+func Test_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resolves the relationships with one read per level", func(t *testing.T) {
+		// arrange
+		s := newStubs()
+		service := newTestService(t, s, DefaultLimits)
+
+		// act
+		result, err := service.Execute(ctx, Request{Query: nestedQuery})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		data, _ := json.Marshal(result.Data)
+		assert.JSONEq(t, `{"sellers":[
+			{"id":1,"company_name":"Frio SA","products":[
+				{"id":10,"batches":[{"batch_number":1,"section":{"section_number":50,"warehouse":{"warehouse_code":"W7"}}}]},
+				{"id":11,"batches":[{"batch_number":2,"section":{"section_number":50,"warehouse":{"warehouse_code":"W7"}}}]}]},
+			{"id":2,"company_name":"Helados SRL","products":[
+				{"id":20,"batches":[{"batch_number":3,"section":{"section_number":60,"warehouse":{"warehouse_code":"W7"}}}]}]},
+			{"id":3,"company_name":"Vacio SA","products":[]}]}`, string(data))
+		assert.Equal(t, [][]int{{1, 2, 3}}, s.products.asked)
+		assert.Equal(t, [][]int{{10, 11, 20}}, s.batches.asked)
+		assert.Equal(t, [][]int{{5, 6}}, s.sections.asked)
+		assert.Equal(t, [][]int{{7}}, s.warehouses.asked)
+	})
+
+	t.Run("single seller by id", func(t *testing.T) {
+		// arrange
+		service := newTestService(t, newStubs(), DefaultLimits)
+
+		// act
+		result, err := service.Execute(ctx, Request{
+			Query:     `query Seller($id: Int!) { seller(id: $id) { company_name products { description } } }`,
+			Variables: map[string]interface{}{"id": 2},
+		})
+
+		// assert
+		assert.NoError(t, err)
+		data, _ := json.Marshal(result.Data)
+		assert.JSONEq(t, `{"seller":{"company_name":"Helados SRL","products":[{"description":"ice cream"}]}}`, string(data))
+	})
+
+	t.Run("a failed read fails only its fields", func(t *testing.T) {
+		// arrange
+		s := newStubs()
+		s.batches.err = errors.New("error: internal error")
+		service := newTestService(t, s, DefaultLimits)
+
+		// act
+		result, err := service.Execute(ctx, Request{Query: `{ sellers { id products { id batches { id } } } }`})
+
+		// assert
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.Errors)
+		assert.Equal(t, "error: internal error", result.Errors[0].Message)
+		assert.Len(t, s.batches.asked, 1)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		// arrange
+		service := newTestService(t, newStubs(), DefaultLimits)
+
+		// act
+		result, err := service.Execute(ctx, Request{Query: `{ sellers { salary } }`})
+
+		// assert
+		assert.Equal(t, ErrInvalidQuery, err)
+		assert.NotEmpty(t, result.Errors)
+		assert.Nil(t, result.Data)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		// arrange
+		service := newTestService(t, newStubs(), DefaultLimits)
+
+		// act
+		result, err := service.Execute(ctx, Request{Query: `{ sellers { id }`})
+
+		// assert
+		assert.Equal(t, ErrInvalidQuery, err)
+		assert.NotEmpty(t, result.Errors)
+	})
+}
+
+func Test_Execute_Limits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("too deep", func(t *testing.T) {
+		// arrange
+		s := newStubs()
+		service := newTestService(t, s, Limits{MaxDepth: 5})
+
+		// act
+		result, err := service.Execute(ctx, Request{Query: nestedQuery})
+
+		// assert
+		assert.Equal(t, ErrInvalidQuery, err)
+		assert.Contains(t, result.Errors[0].Message, ErrTooDeep.Error())
+		assert.Empty(t, s.products.asked)
+	})
+
+	t.Run("too deep through a fragment", func(t *testing.T) {
+		// arrange
+		service := newTestService(t, newStubs(), Limits{MaxDepth: 3})
+
+		// act
+		_, err := service.Execute(ctx, Request{Query: `{ sellers { ...withProducts } } fragment withProducts on Seller { products { batches { id } } }`})
+
+		// assert
+		assert.Equal(t, ErrInvalidQuery, err)
+	})
+
+	t.Run("too complex", func(t *testing.T) {
+		// arrange
+		service := newTestService(t, newStubs(), Limits{MaxComplexity: 100})
+
+		// act
+		result, err := service.Execute(ctx, Request{Query: `{ sellers { id products { id description } } }`})
+
+		// assert
+		assert.Equal(t, ErrInvalidQuery, err)
+		assert.Contains(t, result.Errors[0].Message, ErrTooComplex.Error())
+	})
+}
+
+func Test_Measure(t *testing.T) {
+	schema, err := newSchema(newStubs().services())
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name       string
+		query      string
+		depth      int
+		complexity int
+	}{
+		{"scalar root", `{ seller(id: 1) { id } }`, 2, 2},
+		{"list", `{ sellers { id company_name } }`, 2, 21},
+		{"nested lists", `{ sellers { products { id } } }`, 3, 111},
+		{"inline fragment", `{ sellers { ... on Seller { id } } }`, 2, 11},
+		{"introspection", `{ __schema { types { name fields { name type { name ofType { name } } } } } }`, 0, 0},
+		{"fragment cycle", `{ sellers { ...a } } fragment a on Seller { id ...a }`, 2, 11},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			doc, err := parse(c.query)
+			assert.NoError(t, err)
+
+			depth, complexity := measure(schema, doc)
+
+			assert.Equal(t, c.depth, depth)
+			assert.Equal(t, c.complexity, complexity)
+		})
+	}
+}
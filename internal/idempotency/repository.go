@@ -0,0 +1,92 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqltime"
+)
+
+var (
+	ErrNotFound = errors.New("idempotency key not found")
+	ErrKeyTaken = errors.New("idempotency key already reserved")
+	ErrIntern   = errors.New("an internal error")
+
+	QueryGet      = "SELECT request_hash, status, body, " + sqltime.Column("expires_at") + " FROM idempotency_keys WHERE scope=? AND idempotency_key=? AND route=?;"
+	QueryPurge    = "DELETE FROM idempotency_keys WHERE expires_at<=?;"
+	QueryReserve  = "INSERT INTO idempotency_keys (scope, idempotency_key, route, request_hash, expires_at) VALUES (?, ?, ?, ?, ?);"
+	QueryComplete = "UPDATE idempotency_keys SET status=?, body=? WHERE scope=? AND idempotency_key=? AND route=?;"
+	QueryDelete   = "DELETE FROM idempotency_keys WHERE scope=? AND idempotency_key=? AND route=?;"
+)
+
+// Repository stores the idempotency keys and the responses given under them.
+type Repository interface {
+	Get(ctx context.Context, scope, key, route string) (domain.IdempotencyKey, error)
+	Reserve(ctx context.Context, k domain.IdempotencyKey, now time.Time) error
+	Complete(ctx context.Context, scope, key, route string, status int, body []byte) error
+	Delete(ctx context.Context, scope, key, route string) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// returns the key scope stored for route, expired or not
+func (r *repository) Get(ctx context.Context, scope, key, route string) (domain.IdempotencyKey, error) {
+	k := domain.IdempotencyKey{Scope: scope, Key: key, Route: route}
+	var status sql.NullInt64
+	var expiresAt string
+	err := r.db.QueryRowContext(ctx, QueryGet, scope, key, route).Scan(&k.RequestHash, &status, &k.Body, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyKey{}, ErrNotFound
+		}
+		return domain.IdempotencyKey{}, ErrIntern
+	}
+	if k.ExpiresAt, err = sqltime.Parse(expiresAt); err != nil {
+		return domain.IdempotencyKey{}, ErrIntern
+	}
+	k.Status = int(status.Int64)
+	return k, nil
+}
+
+// drops the keys expired at now, then stores k without a response. Fails with ErrKeyTaken when a live
+// key with the same scope, name and route exists.
+func (r *repository) Reserve(ctx context.Context, k domain.IdempotencyKey, now time.Time) error {
+	if _, err := r.db.ExecContext(ctx, QueryPurge, now); err != nil {
+		return ErrIntern
+	}
+	if _, err := r.db.ExecContext(ctx, QueryReserve, k.Scope, k.Key, k.Route, k.RequestHash, k.ExpiresAt); err != nil {
+		if driverErr, ok := err.(*mysql.MySQLError); ok && driverErr.Number == 1062 {
+			return ErrKeyTaken
+		}
+		return ErrIntern
+	}
+	return nil
+}
+
+// stores the response given to the request scope reserved under key
+func (r *repository) Complete(ctx context.Context, scope, key, route string, status int, body []byte) error {
+	if _, err := r.db.ExecContext(ctx, QueryComplete, status, body, scope, key, route); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
+
+// forgets key so that the request can be retried under it
+func (r *repository) Delete(ctx context.Context, scope, key, route string) error {
+	if _, err := r.db.ExecContext(ctx, QueryDelete, scope, key, route); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
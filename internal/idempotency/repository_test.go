@@ -0,0 +1,125 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var keyColumns = []string{"request_hash", "status", "body", "expires_at"}
+
+func Test_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	expiresAt := time.Date(2023, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("answered key", func(t *testing.T) {
+		// arrange
+		rows := mock.NewRows(keyColumns).AddRow("abc", 201, []byte(`{"data":{}}`), "2023-03-02 10:00:00")
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGet)).WithArgs("actor:alice", "k1", "/purchaseorders").WillReturnRows(rows)
+
+		// act
+		k, err := NewRepository(db).Get(context.Background(), "actor:alice", "k1", "/purchaseorders")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IdempotencyKey{Scope: "actor:alice", Key: "k1", Route: "/purchaseorders", RequestHash: "abc", Status: 201, Body: []byte(`{"data":{}}`), ExpiresAt: expiresAt}, k)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("key in progress", func(t *testing.T) {
+		// arrange
+		rows := mock.NewRows(keyColumns).AddRow("abc", nil, nil, "2023-03-02 10:00:00")
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGet)).WithArgs("actor:alice", "k1", "/purchaseorders").WillReturnRows(rows)
+
+		// act
+		k, err := NewRepository(db).Get(context.Background(), "actor:alice", "k1", "/purchaseorders")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, k.Status)
+		assert.Empty(t, k.Body)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGet)).WithArgs("actor:alice", "k1", "/purchaseorders").WillReturnRows(mock.NewRows(keyColumns))
+
+		// act
+		_, err := NewRepository(db).Get(context.Background(), "actor:alice", "k1", "/purchaseorders")
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Reserve(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	k := domain.IdempotencyKey{Scope: "actor:alice", Key: "k1", Route: "/purchaseorders", RequestHash: "abc", ExpiresAt: now.Add(DefaultTTL)}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectExec(regexp.QuoteMeta(QueryPurge)).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(QueryReserve)).WithArgs("actor:alice", "k1", "/purchaseorders", "abc", k.ExpiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// act
+		err := NewRepository(db).Reserve(context.Background(), k, now)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("key taken", func(t *testing.T) {
+		// arrange
+		mock.ExpectExec(regexp.QuoteMeta(QueryPurge)).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(QueryReserve)).WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		// act
+		err := NewRepository(db).Reserve(context.Background(), k, now)
+
+		// assert
+		assert.Equal(t, ErrKeyTaken, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		mock.ExpectExec(regexp.QuoteMeta(QueryPurge)).WillReturnError(errors.New("connection lost"))
+
+		// act
+		err := NewRepository(db).Reserve(context.Background(), k, now)
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Complete(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(QueryComplete)).WithArgs(201, []byte(`{}`), "actor:alice", "k1", "/purchaseorders").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	err = NewRepository(db).Complete(context.Background(), "actor:alice", "k1", "/purchaseorders", 201, []byte(`{}`))
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
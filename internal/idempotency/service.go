@@ -0,0 +1,79 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+const DefaultTTL = 24 * time.Hour
+
+var (
+	ErrKeyReused  = errors.New("idempotency key reused with a different request")
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+type Service interface {
+	Begin(ctx context.Context, scope, key, route, hash string) (domain.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, scope, key, route string, status int, body []byte) error
+	Release(ctx context.Context, scope, key, route string) error
+}
+
+type service struct {
+	repo Repository
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewService remembers keys for ttl, or DefaultTTL when ttl is not positive.
+func NewService(repo Repository, ttl time.Duration) Service {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &service{
+		repo: repo,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// reserves key of scope for the request hashing to hash. When key has already answered that request the stored
+// response is returned along with true, so that it can be replayed. Fails with ErrKeyReused when key was
+// used for a different request and with ErrInProgress while the first request is still being processed.
+func (s *service) Begin(ctx context.Context, scope, key, route, hash string) (domain.IdempotencyKey, bool, error) {
+	now := s.now()
+	stored, err := s.repo.Get(ctx, scope, key, route)
+	switch {
+	case err == nil && stored.ExpiresAt.After(now):
+		if stored.RequestHash != hash {
+			return domain.IdempotencyKey{}, false, ErrKeyReused
+		}
+		if stored.Status == 0 {
+			return domain.IdempotencyKey{}, false, ErrInProgress
+		}
+		return stored, true, nil
+	case err != nil && !errors.Is(err, ErrNotFound):
+		return domain.IdempotencyKey{}, false, err
+	}
+
+	k := domain.IdempotencyKey{Scope: scope, Key: key, Route: route, RequestHash: hash, ExpiresAt: now.Add(s.ttl)}
+	if err := s.repo.Reserve(ctx, k, now); err != nil {
+		if errors.Is(err, ErrKeyTaken) {
+			return domain.IdempotencyKey{}, false, ErrInProgress
+		}
+		return domain.IdempotencyKey{}, false, err
+	}
+	return k, false, nil
+}
+
+// stores the response given under key, to be replayed to its retries
+func (s *service) Complete(ctx context.Context, scope, key, route string, status int, body []byte) error {
+	return s.repo.Complete(ctx, scope, key, route, status, body)
+}
+
+// frees key after a failure worth retrying, so that the retry is processed again
+func (s *service) Release(ctx context.Context, scope, key, route string) error {
+	return s.repo.Delete(ctx, scope, key, route)
+}
@@ -0,0 +1,140 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Get(ctx context.Context, scope, key, route string) (domain.IdempotencyKey, error) {
+	args := r.Called(ctx, scope, key, route)
+	return args.Get(0).(domain.IdempotencyKey), args.Error(1)
+}
+
+func (r *RepositoryMock) Reserve(ctx context.Context, k domain.IdempotencyKey, now time.Time) error {
+	args := r.Called(ctx, k, now)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Complete(ctx context.Context, scope, key, route string, status int, body []byte) error {
+	args := r.Called(ctx, scope, key, route, status, body)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, scope, key, route string) error {
+	args := r.Called(ctx, scope, key, route)
+	return args.Error(0)
+}
+
+func Test_Begin(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	newService := func(repo Repository) Service {
+		return &service{repo: repo, ttl: time.Hour, now: func() time.Time { return now }}
+	}
+	answered := domain.IdempotencyKey{Scope: "actor:alice", Key: "k1", Route: "/purchaseorders", RequestHash: "abc", Status: 201, Body: []byte(`{}`), ExpiresAt: now.Add(time.Minute)}
+
+	t.Run("reserves a new key", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		reserved := domain.IdempotencyKey{Scope: "actor:alice", Key: "k1", Route: "/purchaseorders", RequestHash: "abc", ExpiresAt: now.Add(time.Hour)}
+		repo.On("Get", ctx, "actor:alice", "k1", "/purchaseorders").Return(domain.IdempotencyKey{}, ErrNotFound)
+		repo.On("Reserve", ctx, reserved, now).Return(nil)
+
+		// act
+		k, replay, err := newService(repo).Begin(ctx, "actor:alice", "k1", "/purchaseorders", "abc")
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, replay)
+		assert.Equal(t, reserved, k)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("replays the stored response", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("Get", ctx, "actor:alice", "k1", "/purchaseorders").Return(answered, nil)
+
+		// act
+		k, replay, err := newService(repo).Begin(ctx, "actor:alice", "k1", "/purchaseorders", "abc")
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, replay)
+		assert.Equal(t, answered, k)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("refuses a different request", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("Get", ctx, "actor:alice", "k1", "/purchaseorders").Return(answered, nil)
+
+		// act
+		_, _, err := newService(repo).Begin(ctx, "actor:alice", "k1", "/purchaseorders", "def")
+
+		// assert
+		assert.Equal(t, ErrKeyReused, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("refuses while the first request is in progress", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		inProgress := answered
+		inProgress.Status = 0
+		repo.On("Get", ctx, "actor:alice", "k1", "/purchaseorders").Return(inProgress, nil)
+
+		// act
+		_, _, err := newService(repo).Begin(ctx, "actor:alice", "k1", "/purchaseorders", "abc")
+
+		// assert
+		assert.Equal(t, ErrInProgress, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reserves an expired key again", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		expired := answered
+		expired.ExpiresAt = now.Add(-time.Minute)
+		repo.On("Get", ctx, "actor:alice", "k1", "/purchaseorders").Return(expired, nil)
+		repo.On("Reserve", ctx, mock.Anything, now).Return(nil)
+
+		// act
+		_, replay, err := newService(repo).Begin(ctx, "actor:alice", "k1", "/purchaseorders", "def")
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, replay)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("loses the race for the key", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("Get", ctx, "actor:alice", "k1", "/purchaseorders").Return(domain.IdempotencyKey{}, ErrNotFound)
+		repo.On("Reserve", ctx, mock.Anything, now).Return(ErrKeyTaken)
+
+		// act
+		_, _, err := newService(repo).Begin(ctx, "actor:alice", "k1", "/purchaseorders", "abc")
+
+		// assert
+		assert.Equal(t, ErrInProgress, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func Test_NewService_DefaultTTL(t *testing.T) {
+	s := NewService(&RepositoryMock{}, 0).(*service)
+	assert.Equal(t, DefaultTTL, s.ttl)
+}
@@ -0,0 +1,142 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+)
+
+var ErrInternal = errors.New("error: internal error")
+
+// Row is a decoded and validated CSV row waiting to be stored.
+type Row struct {
+	Line   int
+	Record interface{}
+}
+
+type Repository interface {
+	// Save stores rows of the named resource in a single transaction and returns how many were stored along
+	// with the rows the database rejected. When atomic is true a single rejected row rolls back all of them.
+	Save(ctx context.Context, name string, rows []Row, atomic bool) (int, []domain.ImportRowError, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Save(ctx context.Context, name string, rows []Row, atomic bool) (int, []domain.ImportRowError, error) {
+	res := resources[name]
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, ErrInternal
+	}
+	defer tx.Rollback()
+
+	var exists *sql.Stmt
+	if res.exists != "" {
+		if exists, err = tx.PrepareContext(ctx, res.exists); err != nil {
+			return 0, nil, ErrInternal
+		}
+		defer exists.Close()
+	}
+	insert, err := tx.PrepareContext(ctx, res.insert)
+	if err != nil {
+		return 0, nil, ErrInternal
+	}
+	defer insert.Close()
+
+	var rowErrors []domain.ImportRowError
+	for _, row := range rows {
+		if exists != nil {
+			var found interface{}
+			err := exists.QueryRowContext(ctx, res.existsArgs(row.Record)...).Scan(&found)
+			switch err {
+			case nil:
+				rowErrors = append(rowErrors, domain.ImportRowError{Row: row.Line, Errors: []string{res.existsErr}})
+				continue
+			case sql.ErrNoRows:
+			default:
+				return 0, nil, ErrInternal
+			}
+		}
+
+		// a failed statement only undoes itself, the rest of the transaction stays usable
+		result, err := insert.ExecContext(ctx, res.insertArgs(row.Record)...)
+		if err != nil {
+			msg, ok := rowError(err)
+			if !ok {
+				return 0, nil, ErrInternal
+			}
+			rowErrors = append(rowErrors, domain.ImportRowError{Row: row.Line, Errors: []string{msg}})
+			continue
+		}
+
+		if err := r.record(ctx, tx, name, res, row, result); err != nil {
+			return 0, nil, ErrInternal
+		}
+	}
+
+	if atomic && len(rowErrors) > 0 {
+		return 0, rowErrors, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, ErrInternal
+	}
+	_ = cache.Invalidate(ctx, name)
+
+	return len(rows) - len(rowErrors), rowErrors, nil
+}
+
+// record adds a stored row to the audit trail.
+func (r *repository) record(ctx context.Context, tx *sql.Tx, name string, res resource, row Row, result sql.Result) error {
+	if _, ok := audit.Actor(ctx); !ok {
+		return nil
+	}
+
+	var id interface{}
+	if res.id != nil {
+		id = res.id(row.Record)
+	} else {
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		id = lastID
+	}
+	return audit.RecordAfter(ctx, tx, audit.Change{Table: name, ID: id, Action: audit.ActionCreate}, nil)
+}
+
+var referencedTable = regexp.MustCompile("REFERENCES `(\\w+)`")
+
+// rowError explains constraint violations caused by the row itself; other errors are not the row's fault.
+func rowError(err error) (string, bool) {
+	driverErr, ok := err.(*mysql.MySQLError)
+	if !ok {
+		return "", false
+	}
+
+	switch driverErr.Number {
+	case 1062:
+		return "duplicated record", true
+	case 1452:
+		if m := referencedTable.FindStringSubmatch(driverErr.Message); m != nil {
+			return "referenced " + m[1] + " record does not exist", true
+		}
+		return "referenced record does not exist", true
+	default:
+		return "", false
+	}
+}
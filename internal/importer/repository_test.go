@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Save(t *testing.T) {
+	rows := []Row{
+		{Line: 2, Record: &domain.Seller{CID: 1, CompanyName: "Meli", Address: "Street 1", Telephone: "123", Locality_id: "6700"}},
+		{Line: 3, Record: &domain.Seller{CID: 2, CompanyName: "Acme", Address: "Street 2", Telephone: "456", Locality_id: "9999"}},
+		{Line: 4, Record: &domain.Seller{CID: 3, CompanyName: "Foo", Address: "Street 3", Telephone: "789", Locality_id: "6700"}},
+	}
+	fkErr := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`db`.`sellers`, CONSTRAINT `sellers_ibfk_1` FOREIGN KEY (`locality_id`) REFERENCES `localities` (`id`))"}
+
+	expectRows := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		exists := mock.ExpectPrepare(regexp.QuoteMeta(seller.QueryExistsCid))
+		insert := mock.ExpectPrepare(regexp.QuoteMeta(seller.QueryInsert))
+		exists.ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"cid"}))
+		insert.ExpectExec().WithArgs(1, "Meli", "Street 1", "123", "6700").WillReturnResult(sqlmock.NewResult(1, 1))
+		exists.ExpectQuery().WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"cid"}))
+		insert.ExpectExec().WithArgs(2, "Acme", "Street 2", "456", "9999").WillReturnError(fkErr)
+		exists.ExpectQuery().WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"cid"}).AddRow(3))
+	}
+	expectedErrors := []domain.ImportRowError{
+		{Row: 3, Errors: []string{"referenced localities record does not exist"}},
+		{Row: 4, Errors: []string{"cid already exists"}},
+	}
+
+	t.Run("best effort commits the accepted rows", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		expectRows(mock)
+		mock.ExpectCommit()
+
+		rp := NewRepository(db)
+
+		// act
+		imported, rowErrors, err := rp.Save(context.Background(), "sellers", rows, false)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, imported)
+		assert.Equal(t, expectedErrors, rowErrors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("atomic rolls back on any rejected row", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		expectRows(mock)
+		mock.ExpectRollback()
+
+		rp := NewRepository(db)
+
+		// act
+		imported, rowErrors, err := rp.Save(context.Background(), "sellers", rows, true)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, imported)
+		assert.Equal(t, expectedErrors, rowErrors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/locality"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/product"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/seller"
+)
+
+// resource describes how a CSV row becomes a row of a table.
+// Columns are matched by the json names of the domain struct, so a file uses the same field names as the JSON API.
+type resource struct {
+	// newRecord returns a pointer to an empty domain struct
+	newRecord func() interface{}
+	// exists is a query that returns a row when the record clashes with a stored one; empty when the insert alone is enough
+	exists     string
+	existsArgs func(v interface{}) []interface{}
+	existsErr  string
+	insert     string
+	insertArgs func(v interface{}) []interface{}
+	// id returns the primary key of a record that carries its own; nil when the database assigns it
+	id func(v interface{}) interface{}
+}
+
+var resources = map[string]resource{
+	"sellers": {
+		newRecord: func() interface{} { return &domain.Seller{} },
+		exists:    seller.QueryExistsCid,
+		existsArgs: func(v interface{}) []interface{} {
+			return []interface{}{v.(*domain.Seller).CID}
+		},
+		existsErr: "cid already exists",
+		insert:    seller.QueryInsert,
+		insertArgs: func(v interface{}) []interface{} {
+			s := v.(*domain.Seller)
+			return []interface{}{s.CID, s.CompanyName, s.Address, s.Telephone, s.Locality_id}
+		},
+	},
+	"products": {
+		newRecord: func() interface{} { return &domain.Product{} },
+		exists:    product.EXISTS,
+		existsArgs: func(v interface{}) []interface{} {
+			return []interface{}{v.(*domain.Product).ProductCode}
+		},
+		existsErr: "product_code already exists",
+		insert:    product.SAVE,
+		insertArgs: func(v interface{}) []interface{} {
+			p := v.(*domain.Product)
+			return []interface{}{p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID}
+		},
+	},
+	"localities": {
+		newRecord: func() interface{} { return &domain.Locality{} },
+		insert:    locality.QueryInsert,
+		insertArgs: func(v interface{}) []interface{} {
+			l := v.(*domain.Locality)
+			return []interface{}{l.Id, l.Locality_name, l.Province_id, l.Latitude, l.Longitude}
+		},
+		id: func(v interface{}) interface{} {
+			return v.(*domain.Locality).Id
+		},
+	},
+	"warehouses": {
+		newRecord: func() interface{} { return &domain.Warehouse{} },
+		exists:    "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;",
+		existsArgs: func(v interface{}) []interface{} {
+			return []interface{}{v.(*domain.Warehouse).WarehouseCode}
+		},
+		existsErr: "warehouse_code already exists",
+		insert:    "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, latitude, longitude) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		insertArgs: func(v interface{}) []interface{} {
+			w := v.(*domain.Warehouse)
+			return []interface{}{w.Address, w.Telephone, w.WarehouseCode, w.MinimumCapacity, w.MinimumTemperature, w.LocalityID, w.Latitude, w.Longitude}
+		},
+	},
+}
+
+// Resources returns the names accepted by Import.
+func Resources() []string {
+	return []string{"sellers", "products", "localities", "warehouses"}
+}
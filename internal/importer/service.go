@@ -0,0 +1,240 @@
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Modes
+const (
+	// nothing is stored unless every row is valid and accepted by the database
+	ModeAllOrNothing = "all_or_nothing"
+	// valid rows are stored, committing every chunk, and the rest are reported
+	ModeBestEffort = "best_effort"
+)
+
+const DefaultChunkSize = 500
+
+// MaxAtomicRows caps all_or_nothing files, whose rows are all held in memory and in a single transaction.
+const MaxAtomicRows = 10000
+
+// Errors
+var (
+	ErrUnknownResource = errors.New("error: unknown import resource")
+	ErrInvalidMode     = errors.New("error: mode must be all_or_nothing or best_effort")
+	ErrInvalidFile     = errors.New("error: invalid csv file")
+	ErrUnknownColumn   = errors.New("error: unknown column")
+	ErrTooManyRows     = fmt.Errorf("error: all_or_nothing imports take at most %d rows, use best_effort for larger files", MaxAtomicRows)
+)
+
+type Options struct {
+	Mode      string
+	ChunkSize int
+}
+
+type Service interface {
+	Import(ctx context.Context, name string, file io.Reader, opt Options) (domain.ImportResult, error)
+}
+
+type service struct {
+	repository Repository
+	validate   *validator.Validate
+}
+
+func NewService(repository Repository) Service {
+	validate := validator.New()
+	// report json names, which are also the csv column names
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	})
+
+	return &service{
+		repository: repository,
+		validate:   validate,
+	}
+}
+
+// Import reads a CSV file with a header row and stores its rows as records of the named resource.
+// Every row is checked with the validation rules of the JSON API. In all_or_nothing mode the whole file is
+// stored in one transaction and only if every row is accepted. In best_effort mode rows are stored in
+// transactions of opt.ChunkSize rows and rejected rows are skipped. An all_or_nothing file with more than
+// MaxAtomicRows rows is rejected with ErrTooManyRows before anything is stored.
+func (s *service) Import(ctx context.Context, name string, file io.Reader, opt Options) (domain.ImportResult, error) {
+	res, ok := resources[name]
+	if !ok {
+		return domain.ImportResult{}, ErrUnknownResource
+	}
+	if opt.Mode == "" {
+		opt.Mode = ModeAllOrNothing
+	}
+	if opt.Mode != ModeAllOrNothing && opt.Mode != ModeBestEffort {
+		return domain.ImportResult{}, ErrInvalidMode
+	}
+	if opt.ChunkSize <= 0 {
+		opt.ChunkSize = DefaultChunkSize
+	}
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return domain.ImportResult{}, ErrInvalidFile
+	}
+	fields, err := columns(res.newRecord(), header)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	result := domain.ImportResult{Resource: name, Mode: opt.Mode, Errors: []domain.ImportRowError{}}
+	var pending []Row
+	flush := func(atomic bool) error {
+		imported, rowErrors, err := s.repository.Save(ctx, name, pending, atomic)
+		if err != nil {
+			return err
+		}
+		result.Imported += imported
+		result.Errors = append(result.Errors, rowErrors...)
+		pending = nil
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return domain.ImportResult{}, ErrInvalidFile
+		}
+		line, _ := reader.FieldPos(0)
+		result.Total++
+		if opt.Mode == ModeAllOrNothing && result.Total > MaxAtomicRows {
+			return domain.ImportResult{}, ErrTooManyRows
+		}
+
+		v := res.newRecord()
+		if msgs := s.decode(v, fields, record); len(msgs) > 0 {
+			result.Errors = append(result.Errors, domain.ImportRowError{Row: line, Errors: msgs})
+			continue
+		}
+		pending = append(pending, Row{Line: line, Record: v})
+
+		if opt.Mode == ModeBestEffort && len(pending) >= opt.ChunkSize {
+			if err := flush(false); err != nil {
+				return domain.ImportResult{}, err
+			}
+		}
+	}
+
+	switch {
+	case opt.Mode == ModeBestEffort && len(pending) > 0:
+		if err := flush(false); err != nil {
+			return domain.ImportResult{}, err
+		}
+	case opt.Mode == ModeAllOrNothing && len(result.Errors) == 0 && len(pending) > 0:
+		if err := flush(true); err != nil {
+			return domain.ImportResult{}, err
+		}
+	}
+
+	result.Failed = result.Total - result.Imported
+	return result, nil
+}
+
+// columns maps every header column to the index of the struct field with that json name.
+func columns(v interface{}, header []string) ([]int, error) {
+	t := reflect.TypeOf(v).Elem()
+	byName := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		byName[strings.Split(t.Field(i).Tag.Get("json"), ",")[0]] = i
+	}
+
+	fields := make([]int, len(header))
+	for i, col := range header {
+		idx, ok := byName[strings.TrimSpace(strings.ToLower(col))]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, col)
+		}
+		fields[i] = idx
+	}
+
+	return fields, nil
+}
+
+// decode fills v from a CSV record and validates it, returning one message per problem found.
+func (s *service) decode(v interface{}, fields []int, record []string) []string {
+	var msgs []string
+	elem := reflect.ValueOf(v).Elem()
+	for i, raw := range record {
+		if i >= len(fields) {
+			msgs = append(msgs, "row has more columns than the header")
+			break
+		}
+		field := elem.Field(fields[i])
+		name := strings.Split(elem.Type().Field(fields[i]).Tag.Get("json"), ",")[0]
+		if err := setField(field, strings.TrimSpace(raw)); err != nil {
+			msgs = append(msgs, name+": "+err.Error())
+		}
+	}
+	if len(msgs) > 0 {
+		return msgs
+	}
+
+	if err := s.validate.Struct(v); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return []string{err.Error()}
+		}
+		for _, ve := range validationErrors {
+			msgs = append(msgs, ve.Field()+": "+ve.Tag())
+		}
+	}
+
+	return msgs
+}
+
+// setField parses raw into the field; an empty value leaves the zero value.
+func setField(field reflect.Value, raw string) error {
+	if raw == "" {
+		return nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setField(ptr.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		field.SetFloat(f)
+	default:
+		return errors.New("unsupported column")
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// define a mock repository struct that implements the Repository interface for testing purposes
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Save(ctx context.Context, name string, rows []Row, atomic bool) (int, []domain.ImportRowError, error) {
+	args := r.Called(ctx, name, rows, atomic)
+	return args.Int(0), args.Get(1).([]domain.ImportRowError), args.Error(2)
+}
+
+func lat(v float64) *float64 { return &v }
+
+const localitiesCSV = `id,locality_name,province_id,latitude,longitude
+6700,Lujan,1,-34.57,-59.1
+6701,,1,,
+6702,Pilar,abc,,
+6703,Moreno,1,,`
+
+func Test_Import(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all_or_nothing stores nothing when a row is invalid", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+
+		// act
+		result, err := service.Import(ctx, "localities", strings.NewReader(localitiesCSV), Options{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ImportResult{
+			Resource: "localities", Mode: ModeAllOrNothing, Total: 4, Imported: 0, Failed: 4,
+			Errors: []domain.ImportRowError{
+				{Row: 3, Errors: []string{"locality_name: required"}},
+				{Row: 4, Errors: []string{"province_id: must be an integer"}},
+			},
+		}, result)
+		repoMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("all_or_nothing stores the whole file in one call", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		rows := []Row{
+			{Line: 2, Record: &domain.Locality{Id: "6700", Locality_name: "Lujan", Province_id: 1, Latitude: lat(-34.57), Longitude: lat(-59.1)}},
+			{Line: 3, Record: &domain.Locality{Id: "6703", Locality_name: "Moreno", Province_id: 1}},
+		}
+		repoMock.On("Save", ctx, "localities", rows, true).Return(2, []domain.ImportRowError(nil), nil)
+		file := "id,locality_name,province_id,latitude,longitude\n6700,Lujan,1,-34.57,-59.1\n6703,Moreno,1,,\n"
+
+		// act
+		result, err := service.Import(ctx, "localities", strings.NewReader(file), Options{Mode: ModeAllOrNothing, ChunkSize: 1})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, 0, result.Failed)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("best_effort stores valid rows in chunks", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, "localities", []Row{
+			{Line: 2, Record: &domain.Locality{Id: "6700", Locality_name: "Lujan", Province_id: 1, Latitude: lat(-34.57), Longitude: lat(-59.1)}},
+		}, false).Return(1, []domain.ImportRowError(nil), nil).Once()
+		repoMock.On("Save", ctx, "localities", []Row{
+			{Line: 5, Record: &domain.Locality{Id: "6703", Locality_name: "Moreno", Province_id: 1}},
+		}, false).Return(0, []domain.ImportRowError{{Row: 5, Errors: []string{"duplicated record"}}}, nil).Once()
+
+		// act
+		result, err := service.Import(ctx, "localities", strings.NewReader(localitiesCSV), Options{Mode: ModeBestEffort, ChunkSize: 1})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 3, result.Failed)
+		assert.Len(t, result.Errors, 3)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("all_or_nothing rejects files over the row cap", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		file := "id,locality_name,province_id\n" + strings.Repeat("6700,Lujan,1\n", MaxAtomicRows+1)
+
+		// act
+		_, err := service.Import(ctx, "localities", strings.NewReader(file), Options{})
+
+		// assert
+		assert.ErrorIs(t, err, ErrTooManyRows)
+		repoMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		// arrange
+		service := NewService(&RepositoryMock{})
+
+		// act
+		_, err := service.Import(ctx, "localities", strings.NewReader("id,name\n1,a\n"), Options{})
+
+		// assert
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+		assert.EqualError(t, err, "error: unknown column: name")
+	})
+
+	t.Run("unknown resource and mode", func(t *testing.T) {
+		// arrange
+		service := NewService(&RepositoryMock{})
+
+		// act
+		_, errResource := service.Import(ctx, "buyers", strings.NewReader(""), Options{})
+		_, errMode := service.Import(ctx, "sellers", strings.NewReader(""), Options{Mode: "some"})
+
+		// assert
+		assert.ErrorIs(t, errResource, ErrUnknownResource)
+		assert.ErrorIs(t, errMode, ErrInvalidMode)
+	})
+
+	t.Run("database failure", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, "localities", mock.Anything, true).Return(0, []domain.ImportRowError(nil), ErrInternal)
+
+		// act
+		_, err := service.Import(ctx, "localities", strings.NewReader("id,locality_name,province_id\n6700,Lujan,1\n"), Options{})
+
+		// assert
+		assert.True(t, errors.Is(err, ErrInternal))
+	})
+}
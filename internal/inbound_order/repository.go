@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 )
 
@@ -34,40 +35,42 @@ func NewRepository(db *sql.DB) Repository {
 
 func (r *repository) Save(ctx context.Context, i domain.InboundOrder) (int, error) {
 	query := "INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)"
-	smt, err := r.db.Prepare(query)
-	if err != nil {
-		return 0, err
-	}
-	defer smt.Close()
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "inbound_orders", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		smt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
+		defer smt.Close()
 
-	res, err := smt.Exec(&i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID)
+		res, err := smt.Exec(&i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID)
 
-	if err != nil {
-		log.Println(err.(*mysql.MySQLError).Number)
-		log.Println(err.(*mysql.MySQLError).Message)
-		errMysql := err.(*mysql.MySQLError)
-		switch errMysql.Number {
-		case 1452:
-			if strings.Contains(errMysql.Error(), "employees") {
-				return 0, ErrEmployeeNotFound
-			}
-			if strings.Contains(errMysql.Error(), "products_batches") {
-				return 0, ErrProductBatchNotFound
-			}
-			if strings.Contains(errMysql.Error(), "warehouses") {
-				return 0, ErrWarehouseNotFound
+		if err != nil {
+			log.Println(err.(*mysql.MySQLError).Number)
+			log.Println(err.(*mysql.MySQLError).Message)
+			errMysql := err.(*mysql.MySQLError)
+			switch errMysql.Number {
+			case 1452:
+				if strings.Contains(errMysql.Error(), "employees") {
+					return 0, ErrEmployeeNotFound
+				}
+				if strings.Contains(errMysql.Error(), "products_batches") {
+					return 0, ErrProductBatchNotFound
+				}
+				if strings.Contains(errMysql.Error(), "warehouses") {
+					return 0, ErrWarehouseNotFound
+				}
+			case 1062:
+				return 0, ErrOrderNumberExtists
+			default:
+				return 0, err
 			}
-		case 1062:
-			return 0, ErrOrderNumberExtists
-		default:
-			return 0, err
 		}
-	}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
 
-	return int(id), nil
+		return int(id), nil
+	})
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 )
 
 var (
@@ -26,6 +27,7 @@ func NewService(inboundOrder Repository) Service {
 }
 
 func (s *service) Create(ctx context.Context, i domain.InboundOrder) (domain.InboundOrder, error) {
+	ctx = outbox.WithEvent(ctx, outbox.InboundOrderReceived)
 	id, err := s.repository.Save(ctx, i)
 	if err != nil {
 		return domain.InboundOrder{}, err
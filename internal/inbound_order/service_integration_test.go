@@ -8,6 +8,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,9 +31,16 @@ func Test_Integration_Service_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM inbound_orders WHERE id=?")).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"id", "order_number"}).AddRow(1, "12"))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.InboundOrderReceived, "inbound_orders", "1", `{"id":1,"order_number":"12"}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		inboundOrder := domain.InboundOrder{
 			OrderDate:      "2006-01-02",
@@ -55,9 +63,11 @@ func Test_Integration_Service_Create(t *testing.T) {
 		assert.NoError(t, err)
 		defer db.Close()
 
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().
 			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "employees"})
+		mock.ExpectRollback()
 
 		inboundOrder := domain.InboundOrder{
 			OrderDate:      "2006-01-02",
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -46,7 +47,7 @@ func Test_Service_Create(t *testing.T) {
 			WarehouseID:    1,
 		}
 
-		repo.On("Save", ctx, inboundOrder).Return(1, nil)
+		repo.On("Save", outbox.WithEvent(ctx, outbox.InboundOrderReceived), inboundOrder).Return(1, nil)
 
 		inboundOrderDB, err := service.Create(ctx, inboundOrder)
 		assert.NoError(t, err)
@@ -65,7 +66,7 @@ func Test_Service_Create(t *testing.T) {
 			WarehouseID:    1,
 		}
 
-		repo.On("Save", ctx, inboundOrder).Return(0, ErrEmployeeNotFound)
+		repo.On("Save", outbox.WithEvent(ctx, outbox.InboundOrderReceived), inboundOrder).Return(0, ErrEmployeeNotFound)
 
 		inboundOrderDB, err := service.Create(ctx, inboundOrder)
 		assert.Error(t, err)
@@ -0,0 +1,129 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Errors
+var (
+	ErrInternal        = errors.New("error: internal error")
+	ErrProductNotFound = errors.New("error: product id does not exists")
+)
+
+// Queries
+// a batch is expired once its due date is in the past
+var (
+	QuerySummary = "SELECT p.id, p.description, p.id_seller, p.id_product_type, " +
+		"COALESCE(SUM(CASE WHEN pb.due_date >= CURDATE() THEN pb.current_quantity ELSE 0 END),0), " +
+		"COALESCE(SUM(CASE WHEN pb.due_date < CURDATE() THEN pb.current_quantity ELSE 0 END),0), " +
+		"COUNT(DISTINCT s.warehouse_id) " +
+		"FROM products AS p " +
+		"LEFT JOIN products_batches AS pb ON pb.product_id = p.id " +
+		"LEFT JOIN sections AS s ON s.id = pb.section_id AND s.deleted_at IS NULL " +
+		"WHERE p.deleted_at IS NULL"
+	QuerySummaryGroup = " GROUP BY p.id, p.description, p.id_seller, p.id_product_type;"
+	QueryRecords      = "SELECT w.id, COALESCE(w.warehouse_code, ''), s.id, s.section_number, pb.id, pb.batch_number, pb.due_date, pb.current_quantity, pb.due_date < CURDATE() " +
+		"FROM products_batches AS pb " +
+		"INNER JOIN sections AS s ON s.id = pb.section_id " +
+		"INNER JOIN warehouses AS w ON w.id = s.warehouse_id " +
+		"WHERE pb.product_id = ? " +
+		"ORDER BY w.id, s.id, pb.id;"
+)
+
+type Repository interface {
+	GetSummary(ctx context.Context, f domain.InventoryFilter) ([]domain.InventorySummary, error)
+	GetSummaryByProduct(ctx context.Context, productID int) (domain.InventorySummary, error)
+	GetRecordsByProduct(ctx context.Context, productID int) ([]domain.InventoryRecord, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// GetSummary returns the stock totals of every product matching the filter.
+func (r *repository) GetSummary(ctx context.Context, f domain.InventoryFilter) ([]domain.InventorySummary, error) {
+	query := QuerySummary
+	var args []interface{}
+	if f.SellerID != 0 {
+		query += " AND p.id_seller = ?"
+		args = append(args, f.SellerID)
+	}
+	if f.ProductTypeID != 0 {
+		query += " AND p.id_product_type = ?"
+		args = append(args, f.ProductTypeID)
+	}
+	query += QuerySummaryGroup
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	summaries := []domain.InventorySummary{}
+	for rows.Next() {
+		s := domain.InventorySummary{}
+		if err := rows.Scan(&s.ProductID, &s.Description, &s.SellerID, &s.ProductTypeID, &s.AvailableQuantity, &s.ExpiredQuantity, &s.WarehousesCount); err != nil {
+			return nil, ErrInternal
+		}
+		s.TotalQuantity = s.AvailableQuantity + s.ExpiredQuantity
+		summaries = append(summaries, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return summaries, nil
+}
+
+// GetSummaryByProduct returns the stock totals of a single product.
+func (r *repository) GetSummaryByProduct(ctx context.Context, productID int) (domain.InventorySummary, error) {
+	row := r.db.QueryRowContext(ctx, QuerySummary+" AND p.id = ?"+QuerySummaryGroup, productID)
+
+	s := domain.InventorySummary{}
+	err := row.Scan(&s.ProductID, &s.Description, &s.SellerID, &s.ProductTypeID, &s.AvailableQuantity, &s.ExpiredQuantity, &s.WarehousesCount)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return domain.InventorySummary{}, ErrProductNotFound
+		default:
+			return domain.InventorySummary{}, ErrInternal
+		}
+	}
+	s.TotalQuantity = s.AvailableQuantity + s.ExpiredQuantity
+
+	return s, nil
+}
+
+// GetRecordsByProduct returns every batch of a product ordered by warehouse, section and batch.
+func (r *repository) GetRecordsByProduct(ctx context.Context, productID int) ([]domain.InventoryRecord, error) {
+	rows, err := r.db.QueryContext(ctx, QueryRecords, productID)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	var records []domain.InventoryRecord
+	for rows.Next() {
+		rec := domain.InventoryRecord{}
+		if err := rows.Scan(&rec.WarehouseID, &rec.WarehouseCode, &rec.SectionID, &rec.SectionNumber, &rec.BatchID, &rec.BatchNumber, &rec.DueDate, &rec.CurrentQuantity, &rec.Expired); err != nil {
+			return nil, ErrInternal
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return records, nil
+}
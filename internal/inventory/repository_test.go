@@ -0,0 +1,136 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var summaryColumns = []string{"id", "description", "id_seller", "id_product_type", "available", "expired", "warehouses"}
+
+func Test_GetSummary(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok with filters", func(t *testing.T) {
+		// arrange
+		expected := []domain.InventorySummary{
+			{ProductID: 1, Description: "yogurt", SellerID: 3, ProductTypeID: 2, TotalQuantity: 150, AvailableQuantity: 100, ExpiredQuantity: 50, WarehousesCount: 2},
+		}
+		rows := mock.NewRows(summaryColumns).AddRow(1, "yogurt", 3, 2, 100, 50, 2)
+		mock.ExpectQuery(regexp.QuoteMeta(QuerySummary+" AND p.id_seller = ? AND p.id_product_type = ?"+QuerySummaryGroup)).
+			WithArgs(3, 2).WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		summary, err := rp.GetSummary(context.Background(), domain.InventoryFilter{SellerID: 3, ProductTypeID: 2})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, summary)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QuerySummary + QuerySummaryGroup)).WillReturnError(errors.New("db down"))
+
+		rp := NewRepository(db)
+
+		// act
+		summary, err := rp.GetSummary(context.Background(), domain.InventoryFilter{})
+
+		// assert
+		assert.ErrorIs(t, err, ErrInternal)
+		assert.Nil(t, summary)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Skips soft-deleted products and sections", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN sections AS s ON s.id = pb.section_id AND s.deleted_at IS NULL WHERE p.deleted_at IS NULL GROUP BY")).
+			WillReturnRows(mock.NewRows(summaryColumns))
+
+		rp := NewRepository(db)
+
+		// act
+		summary, err := rp.GetSummary(context.Background(), domain.InventoryFilter{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, summary)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetSummaryByProduct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(QuerySummary + " AND p.id = ?" + QuerySummaryGroup)
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		rows := mock.NewRows(summaryColumns).AddRow(1, "yogurt", 3, 2, 0, 0, 0)
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		summary, err := rp.GetSummaryByProduct(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.InventorySummary{ProductID: 1, Description: "yogurt", SellerID: 3, ProductTypeID: 2}, summary)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(query).WithArgs(9).WillReturnRows(mock.NewRows(summaryColumns))
+
+		rp := NewRepository(db)
+
+		// act
+		_, err := rp.GetSummaryByProduct(context.Background(), 9)
+
+		// assert
+		assert.ErrorIs(t, err, ErrProductNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetRecordsByProduct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// arrange
+	expected := []domain.InventoryRecord{
+		{WarehouseID: 1, WarehouseCode: "ABC123", SectionID: 4, SectionNumber: 40, BatchID: 7, BatchNumber: 700, DueDate: "2020-01-01", CurrentQuantity: 50, Expired: true},
+		{WarehouseID: 1, WarehouseCode: "ABC123", SectionID: 4, SectionNumber: 40, BatchID: 8, BatchNumber: 800, DueDate: "2099-01-01", CurrentQuantity: 20, Expired: false},
+	}
+	rows := mock.NewRows([]string{"w.id", "warehouse_code", "s.id", "section_number", "pb.id", "batch_number", "due_date", "current_quantity", "expired"})
+	for _, r := range expected {
+		rows.AddRow(r.WarehouseID, r.WarehouseCode, r.SectionID, r.SectionNumber, r.BatchID, r.BatchNumber, r.DueDate, r.CurrentQuantity, r.Expired)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(QueryRecords)).WithArgs(1).WillReturnRows(rows)
+
+	rp := NewRepository(db)
+
+	// act
+	records, err := rp.GetRecordsByProduct(context.Background(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, records)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,84 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+type Service interface {
+	GetSummary(ctx context.Context, f domain.InventoryFilter) ([]domain.InventorySummary, error)
+	GetByProduct(ctx context.Context, productID int) (domain.ProductInventory, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) GetSummary(ctx context.Context, f domain.InventoryFilter) ([]domain.InventorySummary, error) {
+	return s.repository.GetSummary(ctx, f)
+}
+
+// GetByProduct returns the product totals and its batches grouped by warehouse and section.
+func (s *service) GetByProduct(ctx context.Context, productID int) (domain.ProductInventory, error) {
+	summary, err := s.repository.GetSummaryByProduct(ctx, productID)
+	if err != nil {
+		return domain.ProductInventory{}, err
+	}
+
+	records, err := s.repository.GetRecordsByProduct(ctx, productID)
+	if err != nil {
+		return domain.ProductInventory{}, err
+	}
+
+	return domain.ProductInventory{
+		InventorySummary: summary,
+		Warehouses:       groupRecords(records),
+	}, nil
+}
+
+// groupRecords builds the warehouse -> section -> batch tree.
+// records must come ordered by warehouse and section.
+func groupRecords(records []domain.InventoryRecord) []domain.InventoryWarehouse {
+	warehouses := []domain.InventoryWarehouse{}
+	for _, rec := range records {
+		if len(warehouses) == 0 || warehouses[len(warehouses)-1].WarehouseID != rec.WarehouseID {
+			warehouses = append(warehouses, domain.InventoryWarehouse{
+				WarehouseID:   rec.WarehouseID,
+				WarehouseCode: rec.WarehouseCode,
+			})
+		}
+		w := &warehouses[len(warehouses)-1]
+
+		if len(w.Sections) == 0 || w.Sections[len(w.Sections)-1].SectionID != rec.SectionID {
+			w.Sections = append(w.Sections, domain.InventorySection{
+				SectionID:     rec.SectionID,
+				SectionNumber: rec.SectionNumber,
+			})
+		}
+		sec := &w.Sections[len(w.Sections)-1]
+
+		sec.Batches = append(sec.Batches, domain.InventoryBatch{
+			ID:              rec.BatchID,
+			BatchNumber:     rec.BatchNumber,
+			DueDate:         rec.DueDate,
+			CurrentQuantity: rec.CurrentQuantity,
+			Expired:         rec.Expired,
+		})
+		if rec.Expired {
+			sec.ExpiredQuantity += rec.CurrentQuantity
+			w.ExpiredQuantity += rec.CurrentQuantity
+		} else {
+			sec.AvailableQuantity += rec.CurrentQuantity
+			w.AvailableQuantity += rec.CurrentQuantity
+		}
+	}
+
+	return warehouses
+}
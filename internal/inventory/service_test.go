@@ -0,0 +1,103 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// define a mock repository struct that implements the Repository interface for testing purposes
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetSummary(ctx context.Context, f domain.InventoryFilter) ([]domain.InventorySummary, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.InventorySummary), args.Error(1)
+}
+func (r *RepositoryMock) GetSummaryByProduct(ctx context.Context, productID int) (domain.InventorySummary, error) {
+	args := r.Called(ctx, productID)
+	return args.Get(0).(domain.InventorySummary), args.Error(1)
+}
+func (r *RepositoryMock) GetRecordsByProduct(ctx context.Context, productID int) ([]domain.InventoryRecord, error) {
+	args := r.Called(ctx, productID)
+	return args.Get(0).([]domain.InventoryRecord), args.Error(1)
+}
+
+func Test_GetByProduct(t *testing.T) {
+	ctx := context.Background()
+	summary := domain.InventorySummary{ProductID: 1, Description: "yogurt", TotalQuantity: 100, AvailableQuantity: 70, ExpiredQuantity: 30, WarehousesCount: 2}
+
+	t.Run("OK groups batches by warehouse and section", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSummaryByProduct", ctx, 1).Return(summary, nil)
+		repoMock.On("GetRecordsByProduct", ctx, 1).Return([]domain.InventoryRecord{
+			{WarehouseID: 1, WarehouseCode: "W1", SectionID: 1, SectionNumber: 10, BatchID: 1, BatchNumber: 100, DueDate: "2020-01-01", CurrentQuantity: 30, Expired: true},
+			{WarehouseID: 1, WarehouseCode: "W1", SectionID: 1, SectionNumber: 10, BatchID: 2, BatchNumber: 200, DueDate: "2099-01-01", CurrentQuantity: 20},
+			{WarehouseID: 1, WarehouseCode: "W1", SectionID: 2, SectionNumber: 20, BatchID: 3, BatchNumber: 300, DueDate: "2099-01-01", CurrentQuantity: 10},
+			{WarehouseID: 2, WarehouseCode: "W2", SectionID: 3, SectionNumber: 30, BatchID: 4, BatchNumber: 400, DueDate: "2099-01-01", CurrentQuantity: 40},
+		}, nil)
+		service := NewService(repoMock)
+
+		expected := domain.ProductInventory{
+			InventorySummary: summary,
+			Warehouses: []domain.InventoryWarehouse{
+				{WarehouseID: 1, WarehouseCode: "W1", AvailableQuantity: 30, ExpiredQuantity: 30, Sections: []domain.InventorySection{
+					{SectionID: 1, SectionNumber: 10, AvailableQuantity: 20, ExpiredQuantity: 30, Batches: []domain.InventoryBatch{
+						{ID: 1, BatchNumber: 100, DueDate: "2020-01-01", CurrentQuantity: 30, Expired: true},
+						{ID: 2, BatchNumber: 200, DueDate: "2099-01-01", CurrentQuantity: 20},
+					}},
+					{SectionID: 2, SectionNumber: 20, AvailableQuantity: 10, Batches: []domain.InventoryBatch{
+						{ID: 3, BatchNumber: 300, DueDate: "2099-01-01", CurrentQuantity: 10},
+					}},
+				}},
+				{WarehouseID: 2, WarehouseCode: "W2", AvailableQuantity: 40, Sections: []domain.InventorySection{
+					{SectionID: 3, SectionNumber: 30, AvailableQuantity: 40, Batches: []domain.InventoryBatch{
+						{ID: 4, BatchNumber: 400, DueDate: "2099-01-01", CurrentQuantity: 40},
+					}},
+				}},
+			},
+		}
+
+		// act
+		result, err := service.GetByProduct(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("OK without stock returns no warehouses", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSummaryByProduct", ctx, 2).Return(domain.InventorySummary{ProductID: 2}, nil)
+		repoMock.On("GetRecordsByProduct", ctx, 2).Return([]domain.InventoryRecord(nil), nil)
+		service := NewService(repoMock)
+
+		// act
+		result, err := service.GetByProduct(ctx, 2)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.InventoryWarehouse{}, result.Warehouses)
+	})
+
+	t.Run("Product not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSummaryByProduct", ctx, 9).Return(domain.InventorySummary{}, ErrProductNotFound)
+		service := NewService(repoMock)
+
+		// act
+		_, err := service.GetByProduct(ctx, 9)
+
+		// assert
+		assert.ErrorIs(t, err, ErrProductNotFound)
+		repoMock.AssertNotCalled(t, "GetRecordsByProduct", ctx, 9)
+	})
+}
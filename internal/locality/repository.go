@@ -0,0 +1,336 @@
+package locality
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+var (
+	ErrIntern                = errors.New("an internal error")
+	ErrDuplicated            = errors.New("duplicated locality")
+	ErrLocalityNotFound      = errors.New("locality not found")
+	ErrProvinceNotFound      = errors.New("province not found")
+	ErrLocalityInUse         = errors.New("locality is referenced by other resources")
+	QueryGetAll              = "SELECT id, local_name, province_id, latitude, longitude FROM localities"
+	QueryGetById             = "SELECT id, local_name, province_id, latitude, longitude FROM localities WHERE id=?"
+	QueryInsert              = "INSERT INTO localities (id, local_name, province_id, latitude, longitude) VALUES (?, ?, ?, ?, ?)"
+	QueryUpdate              = "UPDATE localities SET local_name=?, province_id=?, latitude=?, longitude=?, version=version+1 WHERE id=?"
+	QueryDelete              = "DELETE FROM localities WHERE id=?"
+	QueryGetByProvince       = "SELECT id, local_name, province_id, latitude, longitude FROM localities WHERE province_id=?"
+	QueryExistsProvince      = "SELECT id FROM provinces WHERE id=?;"
+	QuerySellerAll           = "SELECT l.id, l.local_name, COUNT(s.id) FROM localities l INNER JOIN sellers s ON l.id = s.locality_id AND s.deleted_at IS NULL GROUP BY l.id, l.local_name"
+	QuerySellerByLocality    = "SELECT l.id, l.local_name, COUNT(s.id) FROM localities l INNER JOIN sellers s ON l.id = s.locality_id AND s.deleted_at IS NULL WHERE l.id=? GROUP BY l.id, l.local_name"
+	QueryWarehouseAll        = "SELECT l.id, l.local_name, COUNT(w.id) FROM localities l LEFT JOIN warehouses w ON l.id = w.locality_id AND w.deleted_at IS NULL GROUP BY l.id, l.local_name"
+	QueryWarehouseByLocality = "SELECT l.id, l.local_name, COUNT(w.id) FROM localities l LEFT JOIN warehouses w ON l.id = w.locality_id AND w.deleted_at IS NULL WHERE l.id=? GROUP BY l.id, l.local_name"
+	QueryReportAll           = "SELECT l.id, l.local_name, " +
+		"(SELECT COUNT(*) FROM sellers s WHERE s.locality_id = l.id AND s.deleted_at IS NULL), " +
+		"(SELECT COUNT(*) FROM carries c WHERE c.locality_id = l.id), " +
+		"(SELECT COUNT(*) FROM warehouses w WHERE w.locality_id = l.id AND w.deleted_at IS NULL), " +
+		"(SELECT COUNT(*) FROM buyers b WHERE b.locality_id = l.id) " +
+		"FROM localities l"
+	QueryReportByLocality = QueryReportAll + " WHERE l.id=?"
+)
+
+// Repository encapsulates the storage of a Locality.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Locality, error)
+	Get(ctx context.Context, id string) (domain.Locality, error)
+	Save(ctx context.Context, l domain.Locality) error
+	Update(ctx context.Context, l domain.Locality) error
+	Delete(ctx context.Context, id string) error
+	GetSellerAll(ctx context.Context) ([]domain.QuantitySellerByLocality, error)
+	GetSellerByLocality(ctx context.Context, id string) (domain.QuantitySellerByLocality, error)
+	GetByProvince(ctx context.Context, provinceID int) ([]domain.Locality, error)
+	ExistsProvince(ctx context.Context, provinceID int) bool
+	GetWarehouseAll(ctx context.Context) ([]domain.QuantityWarehouseByLocality, error)
+	GetWarehouseByLocality(ctx context.Context, id string) (domain.QuantityWarehouseByLocality, error)
+	GetReportAll(ctx context.Context) ([]domain.LocalityReport, error)
+	GetReportByLocality(ctx context.Context, id string) (domain.LocalityReport, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Locality, error) {
+	rows, err := r.db.Query(QueryGetAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var localities []domain.Locality
+
+	for rows.Next() {
+		l := domain.Locality{}
+		if err := rows.Scan(&l.Id, &l.Locality_name, &l.Province_id, &l.Latitude, &l.Longitude); err != nil {
+			return nil, ErrIntern
+		}
+		localities = append(localities, l)
+	}
+
+	return localities, nil
+}
+
+func (r *repository) Get(ctx context.Context, id string) (domain.Locality, error) {
+	row := r.db.QueryRow(QueryGetById, id)
+	l := domain.Locality{}
+	err := row.Scan(&l.Id, &l.Locality_name, &l.Province_id, &l.Latitude, &l.Longitude)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrLocalityNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.Locality{}, err
+	}
+
+	return l, nil
+}
+
+func (r *repository) Save(ctx context.Context, l domain.Locality) error {
+	_, err := r.mutate(ctx, audit.ActionCreate, l.Id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryInsert)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = stmt.Exec(l.Id, l.Locality_name, l.Province_id, l.Latitude, l.Longitude)
+		if err != nil {
+			driverErr, ok := err.(*mysql.MySQLError)
+			if !ok {
+				return 0, ErrIntern
+			}
+
+			switch driverErr.Number {
+			case 1062:
+				err = ErrDuplicated
+			case 1452:
+				err = ErrProvinceNotFound
+			default:
+				err = ErrIntern
+			}
+			return 0, err
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Update(ctx context.Context, l domain.Locality) error {
+	_, err := r.mutate(ctx, audit.ActionUpdate, l.Id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryUpdate)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		// MySQL reports zero affected rows when the values do not change,
+		// so existence is checked by the caller instead of here
+		_, err = stmt.Exec(l.Locality_name, l.Province_id, l.Latitude, l.Longitude, l.Id)
+		if err != nil {
+			driverErr, ok := err.(*mysql.MySQLError)
+			if ok && driverErr.Number == 1452 {
+				return 0, ErrProvinceNotFound
+			}
+			return 0, ErrIntern
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Delete(ctx context.Context, id string) error {
+	_, err := r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryDelete)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			driverErr, ok := err.(*mysql.MySQLError)
+			if ok && driverErr.Number == 1451 {
+				return 0, ErrLocalityInUse
+			}
+			return 0, ErrIntern
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		if affect < 1 {
+			return 0, ErrLocalityNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) GetSellerAll(ctx context.Context) ([]domain.QuantitySellerByLocality, error) {
+	stmt, err := r.db.Prepare(QuerySellerAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, ErrIntern
+	}
+
+	var result []domain.QuantitySellerByLocality
+
+	for rows.Next() {
+		q := domain.QuantitySellerByLocality{}
+		if err := rows.Scan(&q.Locality_id, &q.Locality_name, &q.Sellers_count); err != nil {
+			return nil, ErrIntern
+		}
+		result = append(result, q)
+	}
+
+	return result, nil
+}
+
+func (r *repository) GetSellerByLocality(ctx context.Context, id string) (domain.QuantitySellerByLocality, error) {
+	row := r.db.QueryRow(QuerySellerByLocality, id)
+	q := domain.QuantitySellerByLocality{}
+	err := row.Scan(&q.Locality_id, &q.Locality_name, &q.Sellers_count)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrLocalityNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.QuantitySellerByLocality{}, err
+	}
+
+	return q, nil
+}
+
+func (r *repository) GetByProvince(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	rows, err := r.db.Query(QueryGetByProvince, provinceID)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var localities []domain.Locality
+
+	for rows.Next() {
+		l := domain.Locality{}
+		if err := rows.Scan(&l.Id, &l.Locality_name, &l.Province_id, &l.Latitude, &l.Longitude); err != nil {
+			return nil, ErrIntern
+		}
+		localities = append(localities, l)
+	}
+
+	return localities, nil
+}
+
+func (r *repository) ExistsProvince(ctx context.Context, provinceID int) bool {
+	row := r.db.QueryRow(QueryExistsProvince, provinceID)
+	err := row.Scan(&provinceID)
+	return err == nil
+}
+
+func (r *repository) GetWarehouseAll(ctx context.Context) ([]domain.QuantityWarehouseByLocality, error) {
+	rows, err := r.db.Query(QueryWarehouseAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var result []domain.QuantityWarehouseByLocality
+
+	for rows.Next() {
+		q := domain.QuantityWarehouseByLocality{}
+		if err := rows.Scan(&q.Locality_id, &q.Locality_name, &q.Warehouses_count); err != nil {
+			return nil, ErrIntern
+		}
+		result = append(result, q)
+	}
+
+	return result, nil
+}
+
+func (r *repository) GetWarehouseByLocality(ctx context.Context, id string) (domain.QuantityWarehouseByLocality, error) {
+	row := r.db.QueryRow(QueryWarehouseByLocality, id)
+	q := domain.QuantityWarehouseByLocality{}
+	err := row.Scan(&q.Locality_id, &q.Locality_name, &q.Warehouses_count)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrLocalityNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.QuantityWarehouseByLocality{}, err
+	}
+
+	return q, nil
+}
+
+func (r *repository) GetReportAll(ctx context.Context) ([]domain.LocalityReport, error) {
+	rows, err := r.db.Query(QueryReportAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var result []domain.LocalityReport
+
+	for rows.Next() {
+		q := domain.LocalityReport{}
+		if err := rows.Scan(&q.Locality_id, &q.Locality_name, &q.Sellers_count, &q.Carries_count, &q.Warehouses_count, &q.Buyers_count); err != nil {
+			return nil, ErrIntern
+		}
+		result = append(result, q)
+	}
+
+	return result, nil
+}
+
+func (r *repository) GetReportByLocality(ctx context.Context, id string) (domain.LocalityReport, error) {
+	row := r.db.QueryRow(QueryReportByLocality, id)
+	q := domain.LocalityReport{}
+	err := row.Scan(&q.Locality_id, &q.Locality_name, &q.Sellers_count, &q.Carries_count, &q.Warehouses_count, &q.Buyers_count)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrLocalityNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.LocalityReport{}, err
+	}
+
+	return q, nil
+}
+
+// mutate runs fn as a change of the locality id recorded in the audit trail, a failure to record it being ErrIntern.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "localities", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrIntern
+	}
+	return newID, err
+}
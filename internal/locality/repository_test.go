@@ -0,0 +1,238 @@
+package locality
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		lat, long := -34.5986, -58.4376
+		expected := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 1, Latitude: &lat, Longitude: &long}
+		row := mock.NewRows([]string{"id", "local_name", "province_id", "latitude", "longitude"}).AddRow(expected.Id, expected.Locality_name, expected.Province_id, lat, long)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetById)).WithArgs("6701").WillReturnRows(row)
+
+		rp := NewRepository(db)
+
+		// act
+		l, err := rp.Get(context.Background(), "6701")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, l)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetById)).WithArgs("9999").WillReturnRows(mock.NewRows([]string{"id", "local_name", "province_id", "latitude", "longitude"}))
+
+		rp := NewRepository(db)
+
+		// act
+		l, err := rp.Get(context.Background(), "9999")
+
+		// assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+		assert.Equal(t, domain.Locality{}, l)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	l := domain.Locality{Id: "6701", Locality_name: "Villa Crespo", Province_id: 2}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WithArgs(l.Locality_name, l.Province_id, nil, nil, l.Id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Update(context.Background(), l)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Province not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WithArgs(l.Locality_name, l.Province_id, nil, nil, l.Id).WillReturnError(&mysql.MySQLError{Number: 1452})
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Update(context.Background(), l)
+
+		// assert
+		assert.Equal(t, ErrProvinceNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs("6701").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), "6701")
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs("9999").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), "9999")
+
+		// assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Referenced by sellers or carries", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs("6701").WillReturnError(&mysql.MySQLError{Number: 1451})
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), "6701")
+
+		// assert
+		assert.Equal(t, ErrLocalityInUse, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetReport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"id", "local_name", "sellers", "carries", "warehouses", "buyers"}
+
+	t.Run("Ok all", func(t *testing.T) {
+		// arrange
+		expected := []domain.LocalityReport{
+			{Locality_id: "6701", Locality_name: "Villa Crespo", Sellers_count: 2, Carries_count: 1, Warehouses_count: 0, Buyers_count: 3},
+			{Locality_id: "6702", Locality_name: "Nuñez", Sellers_count: 0, Carries_count: 0, Warehouses_count: 1, Buyers_count: 0},
+		}
+		rows := mock.NewRows(columns)
+		for _, q := range expected {
+			rows.AddRow(q.Locality_id, q.Locality_name, q.Sellers_count, q.Carries_count, q.Warehouses_count, q.Buyers_count)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(QueryReportAll)).WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		report, err := rp.GetReportAll(context.Background())
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, report)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found by locality", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryReportByLocality)).WithArgs("9999").WillReturnRows(mock.NewRows(columns))
+
+		rp := NewRepository(db)
+
+		// act
+		report, err := rp.GetReportByLocality(context.Background(), "9999")
+
+		// assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+		assert.Equal(t, domain.LocalityReport{}, report)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Skips soft-deleted sellers and warehouses", func(t *testing.T) {
+		// arrange
+		query := regexp.QuoteMeta("FROM sellers s WHERE s.locality_id = l.id AND s.deleted_at IS NULL") + ".*" +
+			regexp.QuoteMeta("FROM warehouses w WHERE w.locality_id = l.id AND w.deleted_at IS NULL")
+		mock.ExpectQuery(query).WillReturnRows(mock.NewRows(columns))
+
+		rp := NewRepository(db)
+
+		// act
+		report, err := rp.GetReportAll(context.Background())
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, report)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_CountsSkipSoftDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rp := NewRepository(db)
+	sellers := regexp.QuoteMeta("INNER JOIN sellers s ON l.id = s.locality_id AND s.deleted_at IS NULL")
+	warehouses := regexp.QuoteMeta("LEFT JOIN warehouses w ON l.id = w.locality_id AND w.deleted_at IS NULL")
+
+	t.Run("sellers", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(sellers).ExpectQuery().WillReturnRows(mock.NewRows([]string{"id", "local_name", "count"}))
+		mock.ExpectQuery(sellers).WithArgs("6700").WillReturnRows(mock.NewRows([]string{"id", "local_name", "count"}).AddRow("6700", "Lujan", 1))
+
+		// act
+		_, errAll := rp.GetSellerAll(context.Background())
+		_, errOne := rp.GetSellerByLocality(context.Background(), "6700")
+
+		// assert
+		assert.NoError(t, errAll)
+		assert.NoError(t, errOne)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("warehouses", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(warehouses).WillReturnRows(mock.NewRows([]string{"id", "local_name", "count"}))
+		mock.ExpectQuery(warehouses).WithArgs("6700").WillReturnRows(mock.NewRows([]string{"id", "local_name", "count"}).AddRow("6700", "Lujan", 0))
+
+		// act
+		_, errAll := rp.GetWarehouseAll(context.Background())
+		_, errOne := rp.GetWarehouseByLocality(context.Background(), "6700")
+
+		// assert
+		assert.NoError(t, errAll)
+		assert.NoError(t, errOne)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,98 @@
+package locality
+
+import (
+	"context"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.Locality, error)
+	GetByID(ctx context.Context, id string) (domain.Locality, error)
+	Create(ctx context.Context, l domain.Locality) error
+	Update(ctx context.Context, l domain.Locality) (domain.Locality, error)
+	Delete(ctx context.Context, id string) error
+	GetSellerAll(ctx context.Context) ([]domain.QuantitySellerByLocality, error)
+	GetSellerByLocality(ctx context.Context, id string) (domain.QuantitySellerByLocality, error)
+	GetByProvince(ctx context.Context, provinceID int) ([]domain.Locality, error)
+	GetWarehouseAll(ctx context.Context) ([]domain.QuantityWarehouseByLocality, error)
+	GetWarehouseByLocality(ctx context.Context, id string) (domain.QuantityWarehouseByLocality, error)
+	GetReportAll(ctx context.Context) ([]domain.LocalityReport, error)
+	GetReportByLocality(ctx context.Context, id string) (domain.LocalityReport, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// returns all localities
+func (service service) GetAll(ctx context.Context) ([]domain.Locality, error) {
+	return service.repo.GetAll(ctx)
+}
+
+// returns the locality specified by id
+func (service service) GetByID(ctx context.Context, id string) (domain.Locality, error) {
+	return service.repo.Get(ctx, id)
+}
+
+// adds one locality
+func (service service) Create(ctx context.Context, l domain.Locality) error {
+	return service.repo.Save(ctx, l)
+}
+
+// updates the name and province of an existing locality
+func (service service) Update(ctx context.Context, l domain.Locality) (domain.Locality, error) {
+	if err := service.repo.Update(ctx, l); err != nil {
+		return domain.Locality{}, err
+	}
+	return l, nil
+}
+
+// removes a locality, as long as no seller, carry, warehouse or buyer references it
+func (service service) Delete(ctx context.Context, id string) error {
+	return service.repo.Delete(ctx, id)
+}
+
+// returns the number of sellers of every locality
+func (service service) GetSellerAll(ctx context.Context) ([]domain.QuantitySellerByLocality, error) {
+	return service.repo.GetSellerAll(ctx)
+}
+
+// returns the number of sellers of the locality specified by id
+func (service service) GetSellerByLocality(ctx context.Context, id string) (domain.QuantitySellerByLocality, error) {
+	return service.repo.GetSellerByLocality(ctx, id)
+}
+
+// returns the localities of the province specified by provinceID
+func (service service) GetByProvince(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	if !service.repo.ExistsProvince(ctx, provinceID) {
+		return nil, ErrProvinceNotFound
+	}
+	return service.repo.GetByProvince(ctx, provinceID)
+}
+
+// returns the number of warehouses of every locality
+func (service service) GetWarehouseAll(ctx context.Context) ([]domain.QuantityWarehouseByLocality, error) {
+	return service.repo.GetWarehouseAll(ctx)
+}
+
+// returns the number of warehouses of the locality specified by id
+func (service service) GetWarehouseByLocality(ctx context.Context, id string) (domain.QuantityWarehouseByLocality, error) {
+	return service.repo.GetWarehouseByLocality(ctx, id)
+}
+
+// returns the number of sellers, carries, warehouses and buyers of every locality
+func (service service) GetReportAll(ctx context.Context) ([]domain.LocalityReport, error) {
+	return service.repo.GetReportAll(ctx)
+}
+
+// returns the number of sellers, carries, warehouses and buyers of the locality specified by id
+func (service service) GetReportByLocality(ctx context.Context, id string) (domain.LocalityReport, error) {
+	return service.repo.GetReportByLocality(ctx, id)
+}
@@ -0,0 +1,96 @@
+// Package margin reports the gross margin of products, product types and sellers from the purchase and sale
+// prices of product records.
+package margin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Errors
+var (
+	ErrInternal  = errors.New("error: internal error")
+	ErrGroupBy   = errors.New("error: group_by must be product, product_type or seller")
+	ErrDateRange = errors.New("error: from must not be after to")
+)
+
+// Queries
+// An order is priced with the latest record of its product updated on or before the order date, falling
+// back to the record it was placed for when none was. A product's current price is its latest record.
+var (
+	QueryMargins = "SELECT p.id, p.description, p.id_product_type, p.id_seller, COALESCE(o.units,0), COALESCE(o.revenue,0), COALESCE(o.cost,0), " +
+		"cur.id IS NOT NULL, COALESCE(cur.purchase_price,0), COALESCE(cur.sale_price,0) " +
+		"FROM products AS p " +
+		"LEFT JOIN (SELECT ordered.product_id, COUNT(po.id) AS units, SUM(pr.sale_price) AS revenue, SUM(pr.purchase_price) AS cost " +
+		"FROM purchase_orders AS po " +
+		"INNER JOIN product_records AS ordered ON ordered.id = po.product_record_id " +
+		"INNER JOIN product_records AS pr ON pr.id = COALESCE((SELECT r.id FROM product_records AS r " +
+		"WHERE r.product_id = ordered.product_id AND r.last_update_date <= po.order_date " +
+		"ORDER BY r.last_update_date DESC, r.id DESC LIMIT 1), ordered.id) " +
+		"WHERE 1=1%s GROUP BY ordered.product_id) AS o ON o.product_id = p.id " +
+		"LEFT JOIN product_records AS cur ON cur.id = (SELECT r.id FROM product_records AS r WHERE r.product_id = p.id " +
+		"ORDER BY r.last_update_date DESC, r.id DESC LIMIT 1) " +
+		"WHERE p.deleted_at IS NULL"
+)
+
+type Repository interface {
+	GetByProduct(ctx context.Context, f domain.MarginFilter) ([]domain.ProductMargin, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// GetByProduct returns the margin of every product matching the filter, ordered by product id.
+func (r *repository) GetByProduct(ctx context.Context, f domain.MarginFilter) ([]domain.ProductMargin, error) {
+	var period string
+	var args []interface{}
+	if f.From != "" {
+		period += " AND po.order_date >= ?"
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		period += " AND po.order_date <= ?"
+		args = append(args, f.To)
+	}
+	query := fmt.Sprintf(QueryMargins, period)
+	if f.SellerID != 0 {
+		query += " AND p.id_seller = ?"
+		args = append(args, f.SellerID)
+	}
+	if f.ProductTypeID != 0 {
+		query += " AND p.id_product_type = ?"
+		args = append(args, f.ProductTypeID)
+	}
+	query += " ORDER BY p.id;"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	var margins []domain.ProductMargin
+	for rows.Next() {
+		m := domain.ProductMargin{}
+		if err := rows.Scan(&m.ProductID, &m.Description, &m.ProductTypeID, &m.SellerID, &m.UnitsSold, &m.Revenue, &m.Cost, &m.HasPrice, &m.CurrentPurchasePrice, &m.CurrentSalePrice); err != nil {
+			return nil, ErrInternal
+		}
+		margins = append(margins, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return margins, nil
+}
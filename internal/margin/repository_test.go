@@ -0,0 +1,60 @@
+package margin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Repository_GetByProduct(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK filters the period, seller and product type", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := fmt.Sprintf(QueryMargins, " AND po.order_date >= ? AND po.order_date <= ?") + " AND p.id_seller = ? AND p.id_product_type = ? ORDER BY p.id;"
+		rows := sqlmock.NewRows([]string{"id", "description", "id_product_type", "id_seller", "units", "revenue", "cost", "has_price", "purchase_price", "sale_price"}).
+			AddRow(1, "peas", 2, 3, 4, 40.0, 30.0, true, 12.0, 10.0).
+			AddRow(5, "corn", 2, 3, 0, 0, 0, false, 0, 0)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("2023-01-01", "2023-03-31", 3, 2).WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		margins, err := repo.GetByProduct(ctx, domain.MarginFilter{SellerID: 3, ProductTypeID: 2, From: "2023-01-01", To: "2023-03-31"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ProductMargin{
+			{ProductID: 1, Description: "peas", ProductTypeID: 2, SellerID: 3, UnitsSold: 4, Revenue: 40, Cost: 30, HasPrice: true, CurrentPurchasePrice: 12, CurrentSalePrice: 10},
+			{ProductID: 5, Description: "corn", ProductTypeID: 2, SellerID: 3},
+		}, margins)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(QueryMargins, "") + " ORDER BY p.id;")).WillReturnError(errors.New("connection lost"))
+		repo := NewRepository(db)
+
+		// act
+		margins, err := repo.GetByProduct(ctx, domain.MarginFilter{})
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, margins)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,96 @@
+package margin
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Groupings of the margin report
+const (
+	ByProduct     = "product"
+	ByProductType = "product_type"
+	BySeller      = "seller"
+)
+
+type Service interface {
+	GetMargins(ctx context.Context, f domain.MarginFilter) ([]domain.Margin, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+// GetMargins returns the margins grouped as f asks, by product when it does not, ordered by id.
+func (s *service) GetMargins(ctx context.Context, f domain.MarginFilter) ([]domain.Margin, error) {
+	if f.GroupBy == "" {
+		f.GroupBy = ByProduct
+	}
+	var key func(domain.ProductMargin) int
+	switch f.GroupBy {
+	case ByProduct:
+		key = func(p domain.ProductMargin) int { return p.ProductID }
+	case ByProductType:
+		key = func(p domain.ProductMargin) int { return p.ProductTypeID }
+	case BySeller:
+		key = func(p domain.ProductMargin) int { return p.SellerID }
+	default:
+		return nil, ErrGroupBy
+	}
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return nil, ErrDateRange
+	}
+
+	products, err := s.repository.GetByProduct(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	margins := []domain.Margin{}
+	index := map[int]int{}
+	for _, p := range products {
+		i, ok := index[key(p)]
+		if !ok {
+			i = len(margins)
+			index[key(p)] = i
+			margins = append(margins, domain.Margin{ID: key(p)})
+			if f.GroupBy == ByProduct {
+				margins[i].Description = p.Description
+			}
+		}
+		m := &margins[i]
+		m.UnitsSold += p.UnitsSold
+		m.Revenue += p.Revenue
+		m.Cost += p.Cost
+		if p.HasPrice && p.CurrentSalePrice < p.CurrentPurchasePrice {
+			m.ProductsBelowCost++
+			m.BelowCost = true
+		}
+	}
+
+	for i := range margins {
+		m := &margins[i]
+		m.GrossMargin = cents(m.Revenue - m.Cost)
+		if m.Revenue != 0 {
+			m.MarginPercent = cents((m.Revenue - m.Cost) / m.Revenue * 100)
+		}
+		m.Revenue, m.Cost = cents(m.Revenue), cents(m.Cost)
+	}
+	// products come ordered by id, their types and sellers do not
+	sort.Slice(margins, func(i, j int) bool { return margins[i].ID < margins[j].ID })
+
+	return margins, nil
+}
+
+// cents rounds an amount to two decimals, prices being float columns.
+func cents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
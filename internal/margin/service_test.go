@@ -0,0 +1,129 @@
+package margin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetByProduct(ctx context.Context, f domain.MarginFilter) ([]domain.ProductMargin, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.ProductMargin), args.Error(1)
+}
+
+var products = []domain.ProductMargin{
+	{ProductID: 1, Description: "peas", ProductTypeID: 2, SellerID: 9, UnitsSold: 3, Revenue: 30, Cost: 20.001, HasPrice: true, CurrentPurchasePrice: 7, CurrentSalePrice: 10},
+	{ProductID: 4, Description: "corn", ProductTypeID: 1, SellerID: 9, UnitsSold: 1, Revenue: 5, Cost: 6, HasPrice: true, CurrentPurchasePrice: 6, CurrentSalePrice: 5},
+	{ProductID: 6, Description: "rice", ProductTypeID: 2, SellerID: 8},
+}
+
+func Test_GetMargins(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK by product by default", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetByProduct", ctx, domain.MarginFilter{GroupBy: ByProduct}).Return(products, nil)
+		service := NewService(repoMock)
+
+		// act
+		margins, err := service.GetMargins(ctx, domain.MarginFilter{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Margin{
+			{ID: 1, Description: "peas", UnitsSold: 3, Revenue: 30, Cost: 20, GrossMargin: 10, MarginPercent: 33.33},
+			{ID: 4, Description: "corn", UnitsSold: 1, Revenue: 5, Cost: 6, GrossMargin: -1, MarginPercent: -20, BelowCost: true, ProductsBelowCost: 1},
+			{ID: 6, Description: "rice"},
+		}, margins)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("OK by product type", func(t *testing.T) {
+		// arrange
+		filter := domain.MarginFilter{GroupBy: ByProductType}
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetByProduct", ctx, filter).Return(products, nil)
+		service := NewService(repoMock)
+
+		// act
+		margins, err := service.GetMargins(ctx, filter)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Margin{
+			{ID: 1, UnitsSold: 1, Revenue: 5, Cost: 6, GrossMargin: -1, MarginPercent: -20, BelowCost: true, ProductsBelowCost: 1},
+			{ID: 2, UnitsSold: 3, Revenue: 30, Cost: 20, GrossMargin: 10, MarginPercent: 33.33},
+		}, margins)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("OK by seller", func(t *testing.T) {
+		// arrange
+		filter := domain.MarginFilter{GroupBy: BySeller}
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetByProduct", ctx, filter).Return(products, nil)
+		service := NewService(repoMock)
+
+		// act
+		margins, err := service.GetMargins(ctx, filter)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Margin{
+			{ID: 8},
+			{ID: 9, UnitsSold: 4, Revenue: 35, Cost: 26, GrossMargin: 9, MarginPercent: 25.71, BelowCost: true, ProductsBelowCost: 1},
+		}, margins)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("invalid group by", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+
+		// act
+		margins, err := service.GetMargins(ctx, domain.MarginFilter{GroupBy: "warehouse"})
+
+		// assert
+		assert.Equal(t, ErrGroupBy, err)
+		assert.Nil(t, margins)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+
+		// act
+		margins, err := service.GetMargins(ctx, domain.MarginFilter{From: "2023-03-01", To: "2023-01-01"})
+
+		// assert
+		assert.Equal(t, ErrDateRange, err)
+		assert.Nil(t, margins)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetByProduct", ctx, domain.MarginFilter{GroupBy: ByProduct}).Return([]domain.ProductMargin(nil), ErrInternal)
+		service := NewService(repoMock)
+
+		// act
+		margins, err := service.GetMargins(ctx, domain.MarginFilter{})
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, margins)
+		repoMock.AssertExpectations(t)
+	})
+}
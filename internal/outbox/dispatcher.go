@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+const (
+	DefaultInterval    = time.Second
+	DefaultBatchSize   = 100
+	DefaultMaxAttempts = 10
+)
+
+// Dispatcher delivers the events of the outbox to its sinks. An event is marked published once every sink
+// has accepted it, so a sink may see an event again after a failure or a restart: delivery is at least
+// once and sinks are expected to deduplicate on the event id. Events go out in id order, a failed one
+// holding back the ones after it until it is delivered or, after maxAttempts failed deliveries, parked.
+type Dispatcher struct {
+	repo        Repository
+	sinks       []Sink
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+}
+
+func NewDispatcher(repo Repository, interval time.Duration, sinks ...Sink) *Dispatcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Dispatcher{
+		repo:        repo,
+		sinks:       sinks,
+		interval:    interval,
+		batchSize:   DefaultBatchSize,
+		maxAttempts: DefaultMaxAttempts,
+	}
+}
+
+// Run dispatches the outbox every interval until ctx is done. It is meant to run on its own goroutine.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.Dispatch(ctx); err != nil {
+			log.Printf("outbox: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch delivers the pending events until the outbox is empty or a delivery fails, and returns how
+// many were published. An event failing its last attempt is parked and the ones after it go on.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		events, err := d.repo.Pending(ctx, d.batchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, event := range events {
+			if err := d.publish(ctx, event); err != nil {
+				// the delivery error is the one worth reporting, bookkeeping failures are retried with it
+				d.repo.Failed(ctx, event.ID, err.Error())
+				if event.Attempts+1 < d.maxAttempts {
+					return published, err
+				}
+				if err := d.repo.Park(ctx, event.ID); err != nil {
+					return published, err
+				}
+				log.Printf("outbox: event %d parked after %d attempts: %v", event.ID, event.Attempts+1, err)
+				continue
+			}
+			if err := d.repo.Published(ctx, event.ID); err != nil {
+				return published, err
+			}
+			published++
+		}
+		if len(events) < d.batchSize {
+			return published, nil
+		}
+	}
+}
+
+func (d *Dispatcher) publish(ctx context.Context, event domain.Event) error {
+	for _, sink := range d.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
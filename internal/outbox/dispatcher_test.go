@@ -0,0 +1,148 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Pending(ctx context.Context, limit int) ([]domain.Event, error) {
+	args := r.Called(ctx, limit)
+	return args.Get(0).([]domain.Event), args.Error(1)
+}
+
+func (r *RepositoryMock) Published(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Failed(ctx context.Context, id int, reason string) error {
+	args := r.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Park(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+// SinkMock accepts events until failing on the event with id failOn.
+type SinkMock struct {
+	failOn    int
+	published []int
+}
+
+func (s *SinkMock) Publish(ctx context.Context, event domain.Event) error {
+	if event.ID == s.failOn {
+		return errors.New("sink unavailable")
+	}
+	s.published = append(s.published, event.ID)
+	return nil
+}
+
+func Test_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	events := []domain.Event{{ID: 1, Type: SectionUpdated}, {ID: 2, Type: PurchaseOrderCreated}, {ID: 3, Type: ProductBatchCreated}}
+
+	t.Run("publishes every pending event to every sink", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("Pending", ctx, DefaultBatchSize).Return(events, nil)
+		repo.On("Published", ctx, mock.Anything).Return(nil)
+		first, second := &SinkMock{}, &SinkMock{}
+
+		// act
+		published, err := NewDispatcher(repo, 0, first, second).Dispatch(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, published)
+		assert.Equal(t, []int{1, 2, 3}, first.published)
+		assert.Equal(t, []int{1, 2, 3}, second.published)
+		repo.AssertNumberOfCalls(t, "Published", 3)
+	})
+
+	t.Run("stops at a failed delivery", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("Pending", ctx, DefaultBatchSize).Return(events, nil)
+		repo.On("Published", ctx, 1).Return(nil)
+		repo.On("Failed", ctx, 2, "sink unavailable").Return(nil)
+		sink := &SinkMock{failOn: 2}
+
+		// act
+		published, err := NewDispatcher(repo, 0, sink).Dispatch(ctx)
+
+		// assert
+		assert.EqualError(t, err, "sink unavailable")
+		assert.Equal(t, 1, published)
+		assert.Equal(t, []int{1}, sink.published)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("parks an event out of attempts and goes on", func(t *testing.T) {
+		// arrange
+		exhausted := []domain.Event{events[0], {ID: 2, Type: PurchaseOrderCreated, Attempts: DefaultMaxAttempts - 1}, events[2]}
+		repo := &RepositoryMock{}
+		repo.On("Pending", ctx, DefaultBatchSize).Return(exhausted, nil)
+		repo.On("Published", ctx, 1).Return(nil)
+		repo.On("Failed", ctx, 2, "sink unavailable").Return(nil)
+		repo.On("Park", ctx, 2).Return(nil)
+		repo.On("Published", ctx, 3).Return(nil)
+		sink := &SinkMock{failOn: 2}
+
+		// act
+		published, err := NewDispatcher(repo, 0, sink).Dispatch(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []int{1, 3}, sink.published)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reads the outbox again after a full batch", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("Pending", ctx, 2).Return(events[:2], nil).Once()
+		repo.On("Pending", ctx, 2).Return(events[2:], nil).Once()
+		repo.On("Published", ctx, mock.Anything).Return(nil)
+		d := NewDispatcher(repo, 0, &SinkMock{})
+		d.batchSize = 2
+
+		// act
+		published, err := d.Dispatch(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, published)
+		repo.AssertExpectations(t)
+	})
+}
+
+func Test_WriterSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewWriterSink(&out)
+
+	err := sink.Publish(context.Background(), domain.Event{ID: 1, Type: SectionUpdated, Resource: "sections", ResourceID: "4", Payload: []byte(`{"id":4}`)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1,"type":"SectionUpdated","resource":"sections","resource_id":"4","payload":{"id":4},"created_at":"0001-01-01T00:00:00Z"}`+"\n", out.String())
+}
+
+func Test_NewSink(t *testing.T) {
+	_, err := NewSink("kafka://events")
+	assert.Equal(t, ErrUnknownSink, err)
+
+	_, err = NewSink("")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+// Package outbox publishes the domain events the services emit. An event is written to the outbox table
+// in the same transaction as its change, by audit.Mutate, and the Dispatcher later delivers it to the
+// configured sinks, so that an event is published if and only if its change is committed.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// Event types
+const (
+	PurchaseOrderCreated = "PurchaseOrderCreated"
+	ProductBatchCreated  = "ProductBatchCreated"
+	ProductBatchUpdated  = "ProductBatchUpdated"
+	InboundOrderReceived = "InboundOrderReceived"
+	SectionUpdated       = "SectionUpdated"
+)
+
+var QueryInsert = "INSERT INTO outbox (event_type, resource, resource_id, payload, created_at) VALUES (?, ?, ?, ?, UTC_TIMESTAMP());"
+
+// Execer runs the insert of an event on the transaction of its change.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type eventKey struct{}
+
+// WithEvent returns a context whose change emits an event of the given type. A service sets it before
+// calling the repository method making the change.
+func WithEvent(ctx context.Context, eventType string) context.Context {
+	return context.WithValue(ctx, eventKey{}, eventType)
+}
+
+// Emitted returns the type of the event the change made with ctx emits, if any.
+func Emitted(ctx context.Context) (string, bool) {
+	eventType, ok := ctx.Value(eventKey{}).(string)
+	return eventType, ok && eventType != ""
+}
+
+// Write stores the event emitted with ctx about the row id of table, with row, as the change left it, for
+// payload. It does nothing when ctx emits no event.
+func Write(ctx context.Context, ex Execer, table string, id interface{}, row map[string]interface{}) error {
+	eventType, ok := Emitted(ctx)
+	if !ok {
+		return nil
+	}
+
+	payload, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	_, err = ex.ExecContext(ctx, QueryInsert, eventType, table, fmt.Sprint(id), string(payload))
+	return err
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Write(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	row := map[string]interface{}{"id": 4, "section_number": 12}
+
+	t.Run("writes the emitted event", func(t *testing.T) {
+		// arrange
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).
+			WithArgs(SectionUpdated, "sections", "4", `{"id":4,"section_number":12}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		// act
+		err := Write(WithEvent(context.Background(), SectionUpdated), db, "sections", 4, row)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("writes nothing without an event", func(t *testing.T) {
+		// act
+		err := Write(context.Background(), db, "sections", 4, row)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
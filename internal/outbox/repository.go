@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqltime"
+)
+
+// maxErrorLength is the size of the last_error column.
+const maxErrorLength = 500
+
+var (
+	ErrIntern = errors.New("an internal error")
+
+	QueryPending   = "SELECT id, event_type, resource, resource_id, payload, " + sqltime.Column("created_at") + ", attempts FROM outbox WHERE published_at IS NULL AND parked_at IS NULL ORDER BY id LIMIT ?;"
+	QueryPublished = "UPDATE outbox SET published_at=UTC_TIMESTAMP() WHERE id=?;"
+	QueryFailed    = "UPDATE outbox SET attempts=attempts+1, last_error=? WHERE id=?;"
+	QueryParked    = "UPDATE outbox SET parked_at=UTC_TIMESTAMP() WHERE id=?;"
+)
+
+// Repository reads the events waiting in the outbox and tracks their delivery.
+type Repository interface {
+	Pending(ctx context.Context, limit int) ([]domain.Event, error)
+	Published(ctx context.Context, id int) error
+	Failed(ctx context.Context, id int, reason string) error
+	Park(ctx context.Context, id int) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// returns the oldest events neither published nor parked, at most limit of them
+func (r *repository) Pending(ctx context.Context, limit int) ([]domain.Event, error) {
+	rows, err := r.db.QueryContext(ctx, QueryPending, limit)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	events := []domain.Event{}
+	for rows.Next() {
+		e := domain.Event{}
+		var payload sql.NullString
+		var createdAt string
+		if err := rows.Scan(&e.ID, &e.Type, &e.Resource, &e.ResourceID, &payload, &createdAt, &e.Attempts); err != nil {
+			return nil, ErrIntern
+		}
+		var err error
+		if e.CreatedAt, err = sqltime.Parse(createdAt); err != nil {
+			return nil, ErrIntern
+		}
+		if payload.Valid {
+			e.Payload = []byte(payload.String)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrIntern
+	}
+
+	return events, nil
+}
+
+// marks the event as delivered to every sink
+func (r *repository) Published(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, QueryPublished, id); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
+
+// counts a failed delivery of the event and keeps why it failed
+func (r *repository) Failed(ctx context.Context, id int, reason string) error {
+	if len(reason) > maxErrorLength {
+		reason = reason[:maxErrorLength]
+	}
+	if _, err := r.db.ExecContext(ctx, QueryFailed, reason, id); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
+
+// takes the event out of the pending ones, so that it no longer holds back the events after it
+func (r *repository) Park(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, QueryParked, id); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
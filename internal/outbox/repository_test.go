@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pending(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	createdAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	rows := mock.NewRows([]string{"id", "event_type", "resource", "resource_id", "payload", "created_at", "attempts"}).
+		AddRow(1, SectionUpdated, "sections", "4", `{"id":4}`, "2023-03-01 10:00:00", 2)
+	mock.ExpectQuery(regexp.QuoteMeta(QueryPending)).WithArgs(100).WillReturnRows(rows)
+
+	// act
+	events, err := NewRepository(db).Pending(context.Background(), 100)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{{ID: 1, Type: SectionUpdated, Resource: "sections", ResourceID: "4", Payload: []byte(`{"id":4}`), CreatedAt: createdAt, Attempts: 2}}, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Failed(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	reason := strings.Repeat("x", maxErrorLength+10)
+	mock.ExpectExec(regexp.QuoteMeta(QueryFailed)).WithArgs(reason[:maxErrorLength], 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	err = NewRepository(db).Failed(context.Background(), 1, reason)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Park(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(QueryParked)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	err = NewRepository(db).Park(context.Background(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+var ErrUnknownSink = errors.New("unknown outbox sink")
+
+// Sink receives the events of the outbox. Publish returns nil only once the event is safely handed over;
+// an error makes the dispatcher retry it later.
+type Sink interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// writerSink writes each event as a line of JSON, for development.
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Publish(ctx context.Context, event domain.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// NewSink builds the sink named by spec: "stdout", the default when spec is empty, or "file:<path>" to
+// append the events to a file.
+func NewSink(spec string) (Sink, error) {
+	switch {
+	case spec == "" || spec == "stdout":
+		return NewWriterSink(os.Stdout), nil
+	case strings.HasPrefix(spec, "file:"):
+		f, err := os.OpenFile(strings.TrimPrefix(spec, "file:"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return NewWriterSink(f), nil
+	}
+	return nil, ErrUnknownSink
+}
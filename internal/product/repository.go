@@ -3,18 +3,26 @@ package product
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqlin"
 )
 
 // Repository encapsulates the storage of a Product.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Product, error)
 	Get(ctx context.Context, id int) (domain.Product, error)
+	GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error)
 	Exists(ctx context.Context, productCode string) bool
 	Save(ctx context.Context, p domain.Product) (int, error)
 	Update(ctx context.Context, p domain.Product) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	HardDelete(ctx context.Context, id int) error
 	ValidateProductID(ctx context.Context, pid int) bool
 	GetOneReport(ctx context.Context, id int) (int, string, error)
 	GetAllReports(ctx context.Context) ([]domain.Report, error)
@@ -28,6 +36,14 @@ type repository struct {
 // Queries
 var (
 	GET_ALL = `
+		SELECT
+			id,description,expiration_rate,freezing_rate,height,lenght,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller
+		FROM
+			products
+		WHERE
+			deleted_at IS NULL;
+	`
+	GET_ALL_WITH_DELETED = `
 		SELECT
 			id,description,expiration_rate,freezing_rate,height,lenght,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller
 		FROM
@@ -39,7 +55,16 @@ var (
 		FROM
 			products
 		WHERE
-			id=?;
+			id=? AND deleted_at IS NULL;
+	`
+	// formatted with the placeholders of the seller ids
+	GET_BY_SELLERS = `
+		SELECT
+			id,description,expiration_rate,freezing_rate,height,lenght,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller
+		FROM
+			products
+		WHERE
+			id_seller IN (%s) AND deleted_at IS NULL;
 	`
 	EXISTS = `SELECT product_code FROM products WHERE product_code=?;`
 	SAVE   = `
@@ -52,26 +77,35 @@ var (
 		UPDATE
 			products
 		SET 
-			description=?, expiration_rate=?, freezing_rate=?, height=?, lenght=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, id_product_type=?, id_seller=? 
+			description=?, expiration_rate=?, freezing_rate=?, height=?, lenght=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, id_product_type=?, id_seller=?, version=version+1
 		WHERE
 			id=?;
 	`
-	DELETE         = `DELETE FROM products WHERE id=?;`
-	VALIDATE       = `SELECT COUNT(id) FROM products WHERE id = ?;`
+	DELETE         = `UPDATE products SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL;`
+	RESTORE        = `UPDATE products SET deleted_at=NULL, version=version+1 WHERE id=? AND deleted_at IS NOT NULL;`
+	HARD_DELETE    = `DELETE FROM products WHERE id=?;`
+	VALIDATE       = `SELECT COUNT(id) FROM products WHERE id = ? AND deleted_at IS NULL;`
 	GET_ONE_REPORT = `
 		SELECT COUNT(pr.id), p.description FROM product_records pr
 		INNER JOIN products p ON pr.product_id = p.id
-		WHERE pr.product_id = ?
+		WHERE pr.product_id = ? AND p.deleted_at IS NULL
 		GROUP BY p.id;
 	`
 	GET_ALL_REPORTS = `
 		SELECT COUNT(pr.id), p.description, p.id FROM product_records pr
 		LEFT JOIN products p ON pr.product_id = p.id
+		WHERE p.deleted_at IS NULL
 		GROUP BY p.id;
 	`
 	STORE_TYPE = `INSERT INTO product_types(name) VALUES (?);`
 )
 
+// Dependents are the rows whose foreign keys block removing a product for good.
+var Dependents = []softdelete.Reference{
+	{Name: "product batches", Table: "products_batches", Column: "product_id"},
+	{Name: "product records", Table: "product_records", Column: "product_id"},
+}
+
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
 		db: db,
@@ -79,11 +113,20 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
-	stmt, err := r.db.Prepare(GET_ALL)
+	return r.getAll(GET_ALL)
+}
+
+// returns every product, soft deleted ones included
+func (r *repository) GetAllWithDeleted(ctx context.Context) ([]domain.Product, error) {
+	return r.getAll(GET_ALL_WITH_DELETED)
+}
+
+func (r *repository) getAll(query string, args ...interface{}) ([]domain.Product, error) {
+	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return []domain.Product{}, err
 	}
-	rows, err := stmt.Query()
+	rows, err := stmt.Query(args...)
 	if err != nil {
 		return []domain.Product{}, err
 	}
@@ -116,6 +159,15 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
 	return p, nil
 }
 
+// returns the products of the given sellers
+func (r *repository) GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error) {
+	if len(sellerIDs) == 0 {
+		return nil, nil
+	}
+	placeholders, args := sqlin.Ints(sellerIDs)
+	return r.getAll(fmt.Sprintf(GET_BY_SELLERS, placeholders), args...)
+}
+
 func (r *repository) Exists(ctx context.Context, productCode string) bool {
 	row := r.db.QueryRow(EXISTS, productCode)
 	err := row.Scan(&productCode)
@@ -123,82 +175,153 @@ func (r *repository) Exists(ctx context.Context, productCode string) bool {
 }
 
 func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
-	stmt, err := r.db.Prepare(SAVE)
-	if err != nil {
-		return 0, err
-	}
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "products", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(SAVE)
+		if err != nil {
+			return 0, err
+		}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
-	if err != nil {
-		return 0, err
-	}
+		res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+		if err != nil {
+			return 0, err
+		}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
 
-	return int(id), nil
+		return int(id), nil
+	})
 }
 
 func (r *repository) Update(ctx context.Context, p domain.Product) error {
-	stmt, err := r.db.Prepare(UPDATE)
-	if err != nil {
-		return err
-	}
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "products", ID: p.ID, Action: audit.ActionUpdate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(UPDATE)
+		if err != nil {
+			return 0, err
+		}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID)
-	if err != nil {
-		return err
-	}
+		res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID)
+		if err != nil {
+			return 0, err
+		}
 
-	_, err = res.RowsAffected()
-	if err != nil {
-		return err
-	}
+		_, err = res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
 
-	return nil
+		return 0, nil
+	})
+	return err
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(DELETE)
-	if err != nil {
-		return err
-	}
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "products", ID: id, Action: audit.ActionDelete}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(DELETE)
+		if err != nil {
+			return 0, err
+		}
 
-	res, err := stmt.Exec(id)
-	if err != nil {
-		return err
-	}
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, err
+		}
 
-	affect, err := res.RowsAffected()
-	if err != nil {
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// clears the deletion mark of a soft deleted product
+func (r *repository) Restore(ctx context.Context, id int) error {
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "products", ID: id, Action: audit.ActionRestore}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(RESTORE)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// removes the product row, refusing while any Dependents point at it
+func (r *repository) HardDelete(ctx context.Context, id int) error {
+	if err := softdelete.Dependents(ctx, r.db, id, Dependents...); err != nil {
 		return err
 	}
 
-	if affect < 1 {
-		return ErrNotFound
-	}
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "products", ID: id, Action: audit.ActionHardDelete}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(HARD_DELETE)
+		if err != nil {
+			return 0, err
+		}
 
-	return nil
+		res, err := stmt.Exec(id)
+		if err != nil {
+			if softdelete.IsReferenced(err) {
+				return 0, softdelete.ErrHasDependents
+			}
+			return 0, err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
 }
 
 // Product Type
 // creates a new product type and returns its id
 func (r *repository) StoreType(ctx context.Context, name string) (int, error) {
-	stmt, err := r.db.Prepare(STORE_TYPE)
-	if err != nil {
-		return 0, err
-	}
-	result, err := stmt.Exec(name)
-	if err != nil {
-		return 0, err
-	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
-	return int(id), nil
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "product_types", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(STORE_TYPE)
+		if err != nil {
+			return 0, err
+		}
+		result, err := stmt.Exec(name)
+		if err != nil {
+			return 0, err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+		return int(id), nil
+	})
 }
 
 // Product Record Reports
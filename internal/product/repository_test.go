@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -225,6 +226,29 @@ func TestRepoGetAllReports_FailsQuery(t *testing.T) {
 	assert.Equal(t, []domain.Report{}, reports)
 }
 
+func TestRepoReports_SkipSoftDeleted(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectPrepare(regexp.QuoteMeta("WHERE pr.product_id = ? AND p.deleted_at IS NULL")).ExpectQuery().
+		WillReturnRows(mock.NewRows([]string{"COUNT(pr.id)", "description"}).AddRow(1, "desc"))
+	mock.ExpectPrepare(regexp.QuoteMeta("WHERE p.deleted_at IS NULL")).ExpectQuery().
+		WillReturnRows(mock.NewRows([]string{"COUNT(pr.id)", "description", "id"}))
+
+	repo := NewRepository(db)
+
+	// Act
+	_, _, errOne := repo.GetOneReport(context.Background(), 12)
+	_, errAll := repo.GetAllReports(context.Background())
+
+	// Assert
+	assert.NoError(t, errOne)
+	assert.NoError(t, errAll)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRepoStoreType_Ok(t *testing.T) {
 	// Arrange
 	db, mock, err := sqlmock.New()
@@ -335,6 +359,51 @@ func TestRepoGetAll_Ok(t *testing.T) {
 	assert.Equal(t, expected, products)
 }
 
+func TestRepoGetBySellerIDs_Ok(t *testing.T) {
+	// Arrange
+	expected := []domain.Product{
+		{ID: 1, Description: "pepe", ExpirationRate: 12, FreezingRate: 13, Height: 12.1, Length: 10.9, Netweight: 1111.11, ProductCode: "UNIQUE", RecomFreezTemp: -10.2, Width: 172, ProductTypeID: 2, SellerID: 1},
+		{ID: 2, Description: "not_pepe", ExpirationRate: 212, FreezingRate: 321, Height: 0.18, Length: 332.1, Netweight: 12312.1323, ProductCode: "OTHER", RecomFreezTemp: -50, Width: 172, ProductTypeID: 2, SellerID: 3},
+	}
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := mock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "lenght", "netweight", "product_code", "recommended_freezing_temperature", "width", "id_product_type", "id_seller"})
+	rows.AddRow(1, "pepe", 12, 13, 12.1, 10.9, 1111.11, "UNIQUE", -10.2, 172, 2, 1)
+	rows.AddRow(2, "not_pepe", 212, 321, 0.18, 332.1, 12312.1323, "OTHER", -50, 172, 2, 3)
+	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(GET_BY_SELLERS, "?,?"))).ExpectQuery().WithArgs(1, 3).WillReturnRows(rows)
+
+	repo := NewRepository(db)
+
+	// Act
+	products, err := repo.GetBySellerIDs(context.Background(), []int{1, 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepoGetBySellerIDs_None(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRepository(db)
+
+	// Act
+	// should not query at all
+	products, err := repo.GetBySellerIDs(context.Background(), nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRepoGetAll_FailsPrepare(t *testing.T) {
 	// Arrange
 	db, mock, err := sqlmock.New()
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 // Errors
@@ -12,14 +15,19 @@ var (
 	ErrNotFound = errors.New("product not found")
 	ErrDatabase = errors.New("database error")
 	ErrExists   = errors.New("product code already exists")
+	ErrTypeName = errors.New("product type name already exists")
 )
 
 type Service interface {
 	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Product, error)
 	GetByID(ctx context.Context, id int) (domain.Product, error)
+	GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error)
 	Create(ctx context.Context, p domain.Product) (domain.Product, error)
 	Update(ctx context.Context, p domain.Product) (domain.Product, error)
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (domain.Product, error)
+	HardDelete(ctx context.Context, id int) error
 	ValidateProductID(ctx context.Context, pid int) bool
 	GetOneReport(ctx context.Context, id int) ([]domain.Report, error)
 	GetAllReports(ctx context.Context) ([]domain.Report, error)
@@ -43,6 +51,15 @@ func (s *service) GetAll(ctx context.Context) ([]domain.Product, error) {
 	return products, nil
 }
 
+// returns all products, soft deleted ones included
+func (s *service) GetAllWithDeleted(ctx context.Context) ([]domain.Product, error) {
+	products, err := s.r.GetAllWithDeleted(ctx)
+	if err != nil {
+		return []domain.Product{}, ErrDatabase
+	}
+	return products, nil
+}
+
 // returns product specified by id parameter
 func (s *service) GetByID(ctx context.Context, id int) (domain.Product, error) {
 	product, err := s.r.Get(ctx, id)
@@ -52,6 +69,11 @@ func (s *service) GetByID(ctx context.Context, id int) (domain.Product, error) {
 	return product, nil
 }
 
+// returns the products of the given sellers
+func (s *service) GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error) {
+	return s.r.GetBySellerIDs(ctx, sellerIDs)
+}
+
 // adds one product to product list and returns it
 func (s *service) Create(ctx context.Context, p domain.Product) (domain.Product, error) {
 	// returns an error if provided product code is already in use
@@ -77,7 +99,10 @@ func (s *service) Update(ctx context.Context, p domain.Product) (domain.Product,
 	}
 	// update product in database and return it or an error
 	err := s.r.Update(ctx, p)
-	if err != nil {
+	switch {
+	case err == etag.ErrPreconditionFailed:
+		return domain.Product{}, err
+	case err != nil:
 		return domain.Product{}, ErrDatabase
 	}
 	return p, nil
@@ -87,8 +112,35 @@ func (s *service) Update(ctx context.Context, p domain.Product) (domain.Product,
 func (s *service) Delete(ctx context.Context, id int) error {
 	err := s.r.Delete(ctx, id)
 	switch {
+	case err == ErrNotFound, err == etag.ErrPreconditionFailed:
+		return err
+	case err != nil:
+		return ErrDatabase
+	}
+	return nil
+}
+
+// restores a soft deleted product and returns it
+func (s *service) Restore(ctx context.Context, id int) (domain.Product, error) {
+	if _, err := s.r.Get(ctx, id); err == nil {
+		return domain.Product{}, softdelete.ErrNotDeleted
+	}
+	err := s.r.Restore(ctx, id)
+	switch {
 	case err == ErrNotFound:
-		return ErrNotFound
+		return domain.Product{}, ErrNotFound
+	case err != nil:
+		return domain.Product{}, ErrDatabase
+	}
+	return s.GetByID(ctx, id)
+}
+
+// removes product with specified id for good, unless batches or records still reference it
+func (s *service) HardDelete(ctx context.Context, id int) error {
+	err := s.r.HardDelete(ctx, id)
+	switch {
+	case err == ErrNotFound, errors.Is(err, softdelete.ErrHasDependents):
+		return err
 	case err != nil:
 		return ErrDatabase
 	}
@@ -99,6 +151,10 @@ func (s *service) Delete(ctx context.Context, id int) error {
 func (s *service) CreateType(ctx context.Context, name string) (int, error) {
 	id, err := s.r.StoreType(ctx, name)
 	if err != nil {
+		// product type names are unique
+		if driverErr, ok := err.(*mysql.MySQLError); ok && driverErr.Number == 1062 {
+			return 0, ErrTypeName
+		}
 		return 0, ErrDatabase
 	}
 	return id, nil
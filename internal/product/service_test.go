@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,6 +29,9 @@ func (d stubRepo) GetAll(ctx context.Context) ([]domain.Product, error) {
 func (d stubRepo) Get(ctx context.Context, id int) (domain.Product, error) {
 	return d.P, d.Err
 }
+func (d stubRepo) GetBySellerIDs(ctx context.Context, sellerIDs []int) ([]domain.Product, error) {
+	return d.Products, d.Err
+}
 func (d stubRepo) Exists(ctx context.Context, productCode string) bool {
 	return d.Exist
 }
@@ -40,6 +44,15 @@ func (d stubRepo) Update(ctx context.Context, p domain.Product) error {
 func (d stubRepo) Delete(ctx context.Context, id int) error {
 	return d.Err
 }
+func (d stubRepo) GetAllWithDeleted(ctx context.Context) ([]domain.Product, error) {
+	return d.Products, d.Err
+}
+func (d stubRepo) Restore(ctx context.Context, id int) error {
+	return d.Err
+}
+func (d stubRepo) HardDelete(ctx context.Context, id int) error {
+	return d.Err
+}
 func (d stubRepo) ValidateProductID(ctx context.Context, pid int) bool {
 	return d.ValidID
 }
@@ -387,3 +400,16 @@ func TestCreateType_ErrDatabase(t *testing.T) {
 	assert.Equal(t, 0, typeId)
 	assert.Equal(t, ErrDatabase, err)
 }
+
+func TestCreateType_ErrTypeName(t *testing.T) {
+	s, c := createTestService(stubRepo{
+		Id:  1,
+		Err: &mysql.MySQLError{Number: 1062},
+	})
+	// should return 0, ErrTypeName
+	typeId, err := s.CreateType(c, "pepe")
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, typeId)
+	assert.Equal(t, ErrTypeName, err)
+}
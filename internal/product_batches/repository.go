@@ -4,25 +4,35 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqlin"
 )
 
 var (
 	ErrExistsBatchNumber = errors.New("error: batch number already exists")
 	ErrProductNotFound   = errors.New("error: product id does not exists")
 	ErrSectionNotFound   = errors.New("error: section id does not exists")
+	ErrCapacityExceeded  = errors.New("error: section does not have enough capacity")
 	ErrInternal          = errors.New("error: internal error")
 )
 
 var (
-	createQuery = "INSERT INTO products_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	// formatted with the placeholders of the product ids
+	getByProductIDsQuery = "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id FROM products_batches WHERE product_id IN (%s);"
+	createQuery          = "INSERT INTO products_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	lockSectionQuery     = "SELECT current_capacity, maximum_capacity FROM sections WHERE id=? FOR UPDATE;"
+	addCapacityQuery     = "UPDATE sections SET current_capacity=current_capacity+?, version=version+1 WHERE id=?;"
 )
 
 type Repository interface {
 	Create(ctx context.Context, p domain.ProductBatches) (int, error)
+	GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error)
 }
 
 type repository struct {
@@ -35,41 +45,116 @@ func NewRepository(db *sql.DB) Repository {
 	}
 }
 
+// Create stores the batch and takes its current quantity out of the capacity of its section, the
+// same rule transfers follow. Both are applied atomically in the transaction the service opens to
+// emit ProductBatchCreated.
 func (r *repository) Create(ctx context.Context, p domain.ProductBatches) (int, error) {
-	stmt, err := r.db.Prepare(createQuery)
-	if err != nil {
-		return 0, ErrInternal
-	}
-	defer stmt.Close()
+	id, err := r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(createQuery)
+		if err != nil {
+			return 0, ErrInternal
+		}
+		defer stmt.Close()
 
-	res, err := stmt.Exec(&p.BatchNumber, &p.CurrentQuantity, &p.CurrentTemperature, &p.DueDate, &p.InitialQuantity, &p.ManufacturingDate, &p.ManufacturingHour, &p.MinumumTemperature, &p.ProductID, &p.SectionID)
+		res, err := stmt.Exec(&p.BatchNumber, &p.CurrentQuantity, &p.CurrentTemperature, &p.DueDate, &p.InitialQuantity, &p.ManufacturingDate, &p.ManufacturingHour, &p.MinumumTemperature, &p.ProductID, &p.SectionID)
 
-	if err != nil {
-		// log.Println(err.(*mysql.MySQLError).Number, err.(*mysql.MySQLError).Message)
-		errMysql := err.(*mysql.MySQLError)
-		switch errMysql.Number {
-		case 1452:
-			if strings.Contains(errMysql.Error(), "`products`") {
-				return 0, ErrProductNotFound
-			} else if strings.Contains(errMysql.Error(), "`sections`") {
-				return 0, ErrSectionNotFound
+		if err != nil {
+			// log.Println(err.(*mysql.MySQLError).Number, err.(*mysql.MySQLError).Message)
+			errMysql := err.(*mysql.MySQLError)
+			switch errMysql.Number {
+			case 1452:
+				if strings.Contains(errMysql.Error(), "`products`") {
+					return 0, ErrProductNotFound
+				} else if strings.Contains(errMysql.Error(), "`sections`") {
+					return 0, ErrSectionNotFound
+				}
+			case 1062:
+				return 0, ErrExistsBatchNumber
+			default:
+				return 0, ErrInternal
 			}
-		case 1062:
-			return 0, ErrExistsBatchNumber
-		default:
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil || rows != 1 {
 			return 0, ErrInternal
 		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, ErrInternal
+		}
+
+		if err := reserve(ctx, ex, p.SectionID, p.CurrentQuantity); err != nil {
+			return 0, err
+		}
+
+		return int(id), nil
+	})
+	if err == nil {
+		_ = cache.Invalidate(ctx, "sections")
 	}
+	return id, err
+}
 
-	rows, err := res.RowsAffected()
-	if err != nil || rows != 1 {
-		return 0, ErrInternal
+// reserve takes units out of the capacity of the section, ErrCapacityExceeded when they do not fit.
+// The section stays locked until the batch is stored, so concurrent batches cannot both fit.
+func reserve(ctx context.Context, ex audit.Execer, sectionID, units int) error {
+	s := domain.Section{ID: sectionID}
+	err := ex.QueryRowContext(ctx, lockSectionQuery, sectionID).Scan(&s.CurrentCapacity, &s.MaximumCapacity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrSectionNotFound
+		}
+		return ErrInternal
+	}
+	if !s.Fits(units) {
+		return ErrCapacityExceeded
+	}
+
+	var before map[string]interface{}
+	if _, ok := audit.Actor(ctx); ok {
+		if before, err = audit.Snapshot(ctx, ex, "sections", sectionID); err != nil {
+			return ErrInternal
+		}
+	}
+	if _, err := ex.ExecContext(ctx, addCapacityQuery, units, sectionID); err != nil {
+		return ErrInternal
 	}
+	if err := audit.RecordAfter(ctx, ex, audit.Change{Table: "sections", ID: sectionID, Action: audit.ActionUpdate}, before); err != nil {
+		return ErrInternal
+	}
+	return nil
+}
 
-	id, err := res.LastInsertId()
+// returns the batches of the given products
+func (r *repository) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	placeholders, args := sqlin.Ints(productIDs)
+	rows, err := r.db.Query(fmt.Sprintf(getByProductIDsQuery, placeholders), args...)
 	if err != nil {
-		return 0, ErrInternal
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	var batches []domain.ProductBatches
+	for rows.Next() {
+		b := domain.ProductBatches{}
+		if err := rows.Scan(&b.ID, &b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinumumTemperature, &b.ProductID, &b.SectionID); err != nil {
+			return nil, ErrInternal
+		}
+		batches = append(batches, b)
 	}
+	return batches, nil
+}
 
-	return int(id), nil
+// mutate runs fn as a change of the product batch id recorded in the audit trail, a failure to record it being ErrInternal.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "products_batches", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrInternal
+	}
+	return newID, err
 }
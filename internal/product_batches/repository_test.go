@@ -3,6 +3,7 @@ package product_batches
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -40,6 +41,9 @@ func Test_Create(t *testing.T) {
 		// arrange
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(lockSectionQuery)).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(90, 100))
+		mock.ExpectExec(regexp.QuoteMeta(addCapacityQuery)).WithArgs(10, 1).WillReturnResult(sqlmock.NewResult(0, 1))
 
 		// act
 		lastId, err := r.Create(ctx, data)
@@ -50,6 +54,22 @@ func Test_Create(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("Capacity: ErrCapacityExceeded", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(lockSectionQuery)).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(91, 100))
+
+		// act
+		lastId, err := r.Create(ctx, data)
+
+		// assert
+		assert.Equal(t, 0, lastId)
+		assert.Equal(t, ErrCapacityExceeded, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("Prepare: ErrInternal", func(t *testing.T) {
 		// arrange
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
@@ -155,3 +175,48 @@ func Test_Create(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func Test_GetByProductIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db)
+	ctx := context.Background()
+	query := fmt.Sprintf(getByProductIDsQuery, "?,?")
+	columns := []string{"id", "batch_number", "current_quantity", "current_temperature", "due_date", "initial_quantity", "manufacturing_date", "manufacturing_hour", "minumum_temperature", "product_id", "section_id"}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expected := []domain.ProductBatches{
+			{ID: 1, BatchNumber: 111, CurrentQuantity: 200, CurrentTemperature: 20, DueDate: "2022-04-04", InitialQuantity: 10, ManufacturingDate: "2020-04-04", ManufacturingHour: "10:00:00", MinumumTemperature: 5, ProductID: 1, SectionID: 1},
+			{ID: 2, BatchNumber: 112, CurrentQuantity: 100, CurrentTemperature: 18, DueDate: "2022-05-04", InitialQuantity: 10, ManufacturingDate: "2020-05-04", ManufacturingHour: "11:00:00", MinumumTemperature: 5, ProductID: 3, SectionID: 2},
+		}
+		rows := mock.NewRows(columns)
+		for _, b := range expected {
+			rows.AddRow(b.ID, b.BatchNumber, b.CurrentQuantity, b.CurrentTemperature, b.DueDate, b.InitialQuantity, b.ManufacturingDate, b.ManufacturingHour, b.MinumumTemperature, b.ProductID, b.SectionID)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 3).WillReturnRows(rows)
+
+		// act
+		batches, err := r.GetByProductIDs(ctx, []int{1, 3})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, batches)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Query: ErrInternal", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 3).WillReturnError(sql.ErrConnDone)
+
+		// act
+		batches, err := r.GetByProductIDs(ctx, []int{1, 3})
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Empty(t, batches)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
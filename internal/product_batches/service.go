@@ -4,10 +4,12 @@ import (
 	"context"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 )
 
 type Service interface {
 	Create(ctx context.Context, productBatches domain.ProductBatches) (domain.ProductBatches, error)
+	GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error)
 }
 
 type service struct {
@@ -21,6 +23,7 @@ func NewService(r Repository) Service {
 }
 
 func (s *service) Create(ctx context.Context, productBatches domain.ProductBatches) (domain.ProductBatches, error) {
+	ctx = outbox.WithEvent(ctx, outbox.ProductBatchCreated)
 	id, err := s.r.Create(ctx, productBatches)
 	if err != nil {
 		return domain.ProductBatches{}, err
@@ -30,3 +33,8 @@ func (s *service) Create(ctx context.Context, productBatches domain.ProductBatch
 
 	return productBatches, nil
 }
+
+// returns the batches of the given products
+func (s *service) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error) {
+	return s.r.GetByProductIDs(ctx, productIDs)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,8 +67,18 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(lockSectionQuery)).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity"}).AddRow(0, 100))
+		mock.ExpectExec(regexp.QuoteMeta(addCapacityQuery)).WithArgs(10, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"id", "batch_number"}).AddRow(1, 1234))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.ProductBatchCreated, "products_batches", "1", `{"batch_number":1234,"id":1}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -80,8 +91,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("Prepare: ErrInternal", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			WillReturnError(ErrInternal)
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -95,8 +108,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("Exec: ErrProductNotFound", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1452, Message: "`products`"})
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -110,8 +125,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("Exec: ErrSectionNotFound", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1452, Message: "`sections`"})
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -125,8 +142,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("Exec: ErrExistsBatchNumber", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{Number: 1062})
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -140,8 +159,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("Exec: ErrErrInternal", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnError(&mysql.MySQLError{})
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -155,8 +176,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("RowsAffected: ErrInternal", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -170,8 +193,10 @@ func Test_Create_Service_Integration(t *testing.T) {
 
 	t.Run("LastInsertId: ErrInternal", func(t *testing.T) {
 		// arrange
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
 			ExpectExec().WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
+		mock.ExpectRollback()
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,6 +25,11 @@ func (r *repositoryTest) Create(ctx context.Context, productBatches domain.Produ
 	return args.Get(0).(int), args.Error(1)
 }
 
+func (r *repositoryTest) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatches, error) {
+	args := r.Called(ctx, productIDs)
+	return args.Get(0).([]domain.ProductBatches), args.Error(1)
+}
+
 func Test_Create_Service(t *testing.T) {
 	ctx := context.Background()
 
@@ -45,7 +51,7 @@ func Test_Create_Service(t *testing.T) {
 		// arrange
 		r := NewRepositoryTest()
 		s := NewService(r)
-		r.On("Create", ctx, data).Return(1, nil)
+		r.On("Create", outbox.WithEvent(ctx, outbox.ProductBatchCreated), data).Return(1, nil)
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -60,7 +66,7 @@ func Test_Create_Service(t *testing.T) {
 		// arrange
 		r := NewRepositoryTest()
 		s := NewService(r)
-		r.On("Create", ctx, data).Return(0, ErrInternal)
+		r.On("Create", outbox.WithEvent(ctx, outbox.ProductBatchCreated), data).Return(0, ErrInternal)
 
 		// act
 		productBatches, err := s.Create(ctx, data)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 )
 
@@ -41,20 +42,22 @@ func (r *repository) ValidateProductID(ctx context.Context, pid int) bool {
 }
 
 func (r *repository) Store(ctx context.Context, pr domain.ProductRecord) (int, error) {
-	stmt, err := r.db.Prepare(STORE)
-	if err != nil {
-		return 0, err
-	}
-
-	result, err := stmt.Exec(pr.LastUpdateDate, pr.PurchasePrice, pr.SalePrice, pr.ProductID)
-	if err != nil {
-		return 0, err
-	}
-
-	id, err := result.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
-
-	return int(id), nil
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "product_records", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(STORE)
+		if err != nil {
+			return 0, err
+		}
+
+		result, err := stmt.Exec(pr.LastUpdateDate, pr.PurchasePrice, pr.SalePrice, pr.ProductID)
+		if err != nil {
+			return 0, err
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+
+		return int(id), nil
+	})
 }
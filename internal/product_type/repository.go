@@ -0,0 +1,230 @@
+package product_type
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+var (
+	ErrIntern        = errors.New("an internal error")
+	ErrDuplicated    = errors.New("duplicated product type name")
+	ErrNotFound      = errors.New("product type not found")
+	ErrHasProducts   = errors.New("product type is referenced by products")
+	ErrHasSections   = errors.New("product type is referenced by sections")
+	ErrInUse         = errors.New("product type is referenced by other resources")
+	QueryGetAll      = "SELECT id, name FROM product_types"
+	QueryGetById     = "SELECT id, name FROM product_types WHERE id=?;"
+	QueryInsert      = "INSERT INTO product_types (name) VALUES (?)"
+	QueryUpdate      = "UPDATE product_types SET name=?, version=version+1 WHERE id=?"
+	QueryDelete      = "DELETE FROM product_types WHERE id=?"
+	QueryCountRefs   = "SELECT (SELECT COUNT(*) FROM products WHERE id_product_type=?), (SELECT COUNT(*) FROM sections WHERE id_product_type=?)"
+	QueryGetProducts = "SELECT id, description, expiration_rate, freezing_rate, height, lenght, netweight, product_code, recommended_freezing_temperature, width, id_product_type, id_seller FROM products WHERE id_product_type=? AND deleted_at IS NULL"
+	QueryGetSections = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id_product_type=? AND deleted_at IS NULL"
+)
+
+// Repository encapsulates the storage of a ProductType.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.ProductType, error)
+	Get(ctx context.Context, id int) (domain.ProductType, error)
+	Save(ctx context.Context, pt domain.ProductType) (int, error)
+	Update(ctx context.Context, pt domain.ProductType) error
+	Delete(ctx context.Context, id int) error
+	CountReferences(ctx context.Context, id int) (products int, sections int, err error)
+	GetProducts(ctx context.Context, id int) ([]domain.Product, error)
+	GetSections(ctx context.Context, id int) ([]domain.Section, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	rows, err := r.db.Query(QueryGetAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var types []domain.ProductType
+
+	for rows.Next() {
+		pt := domain.ProductType{}
+		if err := rows.Scan(&pt.ID, &pt.Name); err != nil {
+			return nil, ErrIntern
+		}
+		types = append(types, pt)
+	}
+
+	return types, nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	row := r.db.QueryRow(QueryGetById, id)
+	pt := domain.ProductType{}
+	err := row.Scan(&pt.ID, &pt.Name)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.ProductType{}, err
+	}
+
+	return pt, nil
+}
+
+func (r *repository) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	return r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryInsert)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(pt.Name)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		return int(id), nil
+	})
+}
+
+func (r *repository) Update(ctx context.Context, pt domain.ProductType) error {
+	_, err := r.mutate(ctx, audit.ActionUpdate, pt.ID, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryUpdate)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		_, err = stmt.Exec(pt.Name, pt.ID)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryDelete)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// returns how many products and sections reference the product type
+func (r *repository) CountReferences(ctx context.Context, id int) (int, int, error) {
+	var products, sections int
+	if err := r.db.QueryRow(QueryCountRefs, id, id).Scan(&products, &sections); err != nil {
+		return 0, 0, ErrIntern
+	}
+
+	return products, sections, nil
+}
+
+func (r *repository) GetProducts(ctx context.Context, id int) ([]domain.Product, error) {
+	rows, err := r.db.Query(QueryGetProducts, id)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	products := []domain.Product{}
+
+	for rows.Next() {
+		p := domain.Product{}
+		if err := rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID); err != nil {
+			return nil, ErrIntern
+		}
+		products = append(products, p)
+	}
+
+	return products, nil
+}
+
+func (r *repository) GetSections(ctx context.Context, id int) ([]domain.Section, error) {
+	rows, err := r.db.Query(QueryGetSections, id)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	sections := []domain.Section{}
+
+	for rows.Next() {
+		s := domain.Section{}
+		if err := rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID); err != nil {
+			return nil, ErrIntern
+		}
+		sections = append(sections, s)
+	}
+
+	return sections, nil
+}
+
+// mutate runs fn as a change of the product type id recorded in the audit trail, a failure to record it being ErrIntern.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "product_types", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrIntern
+	}
+	return newID, err
+}
+
+// translates MySQL constraint violations into package errors
+func mapDriverError(err error) error {
+	driverErr, ok := err.(*mysql.MySQLError)
+	if !ok {
+		return ErrIntern
+	}
+
+	switch driverErr.Number {
+	case 1062:
+		return ErrDuplicated
+	case 1451:
+		return ErrInUse
+	default:
+		return ErrIntern
+	}
+}
@@ -0,0 +1,153 @@
+package product_type
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs("frozen").WillReturnResult(sqlmock.NewResult(3, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		id, err := rp.Save(context.Background(), domain.ProductType{Name: "frozen"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs("frozen").WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		rp := NewRepository(db)
+
+		// act
+		_, err := rp.Save(context.Background(), domain.ProductType{Name: "frozen"})
+
+		// assert
+		assert.ErrorIs(t, err, ErrDuplicated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 9)
+
+		// assert
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Referenced", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(1).WillReturnError(&mysql.MySQLError{Number: 1451})
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 1)
+
+		// assert
+		assert.ErrorIs(t, err, ErrInUse)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_CountReferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// arrange
+	mock.ExpectQuery(regexp.QuoteMeta(QueryCountRefs)).WithArgs(1, 1).WillReturnRows(mock.NewRows([]string{"products", "sections"}).AddRow(0, 2))
+
+	rp := NewRepository(db)
+
+	// act
+	products, sections, err := rp.CountReferences(context.Background(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, products)
+	assert.Equal(t, 2, sections)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// arrange
+	expected := []domain.Product{
+		{ID: 3, Description: "yogurt", ExpirationRate: 1, FreezingRate: 2, Height: 3, Length: 4, Netweight: 5, ProductCode: "Y1", RecomFreezTemp: 6, Width: 7, ProductTypeID: 1, SellerID: 2},
+	}
+	rows := mock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "lenght", "netweight", "product_code", "recommended_freezing_temperature", "width", "id_product_type", "id_seller"})
+	for _, p := range expected {
+		rows.AddRow(p.ID, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM products WHERE id_product_type=? AND deleted_at IS NULL")).WithArgs(1).WillReturnRows(rows)
+
+	rp := NewRepository(db)
+
+	// act
+	products, err := rp.GetProducts(context.Background(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetSections(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// arrange
+	expected := []domain.Section{
+		{ID: 1, SectionNumber: 10, CurrentTemperature: 2, MinimumTemperature: 1, CurrentCapacity: 5, MinimumCapacity: 1, MaximumCapacity: 20, WarehouseID: 4, ProductTypeID: 1},
+	}
+	rows := mock.NewRows([]string{"id", "section_number", "current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity", "warehouse_id", "id_product_type"})
+	for _, s := range expected {
+		rows.AddRow(s.ID, s.SectionNumber, s.CurrentTemperature, s.MinimumTemperature, s.CurrentCapacity, s.MinimumCapacity, s.MaximumCapacity, s.WarehouseID, s.ProductTypeID)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sections WHERE id_product_type=? AND deleted_at IS NULL")).WithArgs(1).WillReturnRows(rows)
+
+	rp := NewRepository(db)
+
+	// act
+	sections, err := rp.GetSections(context.Background(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sections)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
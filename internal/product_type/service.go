@@ -0,0 +1,97 @@
+package product_type
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.ProductType, error)
+	GetByID(ctx context.Context, id int) (domain.ProductType, error)
+	Create(ctx context.Context, pt domain.ProductType) (domain.ProductType, error)
+	Update(ctx context.Context, pt domain.ProductType) (domain.ProductType, error)
+	Delete(ctx context.Context, id int) error
+	GetProducts(ctx context.Context, id int) ([]domain.Product, error)
+	GetSections(ctx context.Context, id int) ([]domain.Section, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// returns all product types
+func (s *service) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// returns the product type specified by id
+func (s *service) GetByID(ctx context.Context, id int) (domain.ProductType, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// adds one product type and returns it with its database-defined id
+func (s *service) Create(ctx context.Context, pt domain.ProductType) (domain.ProductType, error) {
+	// surrounding spaces would otherwise bypass the unique name constraint
+	pt.Name = strings.TrimSpace(pt.Name)
+
+	id, err := s.repo.Save(ctx, pt)
+	if err != nil {
+		return domain.ProductType{}, err
+	}
+
+	pt.ID = id
+	return pt, nil
+}
+
+// renames the product type specified by pt.ID and returns it
+func (s *service) Update(ctx context.Context, pt domain.ProductType) (domain.ProductType, error) {
+	pt.Name = strings.TrimSpace(pt.Name)
+
+	if err := s.repo.Update(ctx, pt); err != nil {
+		return domain.ProductType{}, err
+	}
+
+	return pt, nil
+}
+
+// deletes the product type specified by id; fails while products or sections reference it
+func (s *service) Delete(ctx context.Context, id int) error {
+	products, sections, err := s.repo.CountReferences(ctx, id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case products > 0:
+		return ErrHasProducts
+	case sections > 0:
+		return ErrHasSections
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// returns the products of the product type specified by id
+func (s *service) GetProducts(ctx context.Context, id int) ([]domain.Product, error) {
+	if _, err := s.repo.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetProducts(ctx, id)
+}
+
+// returns the sections that store the product type specified by id
+func (s *service) GetSections(ctx context.Context, id int) ([]domain.Section, error) {
+	if _, err := s.repo.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetSections(ctx, id)
+}
@@ -0,0 +1,149 @@
+package product_type
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// define a mock repository struct that implements the Repository interface for testing purposes
+type RepositoryMock struct {
+	mock.Mock
+	Repository
+}
+
+func (r *RepositoryMock) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.ProductType), args.Error(1)
+}
+func (r *RepositoryMock) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	args := r.Called(ctx, pt)
+	return args.Get(0).(int), args.Error(1)
+}
+func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+func (r *RepositoryMock) CountReferences(ctx context.Context, id int) (int, int, error) {
+	args := r.Called(ctx, id)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+func (r *RepositoryMock) GetProducts(ctx context.Context, id int) ([]domain.Product, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
+func Test_Create_ProductType(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK trims the name", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, domain.ProductType{Name: "frozen"}).Return(1, nil)
+
+		// act
+		created, err := service.Create(ctx, domain.ProductType{Name: " frozen  "})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ProductType{ID: 1, Name: "frozen"}, created)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, domain.ProductType{Name: "frozen"}).Return(0, ErrDuplicated)
+
+		// act
+		_, err := service.Create(ctx, domain.ProductType{Name: "frozen"})
+
+		// assert
+		assert.ErrorIs(t, err, ErrDuplicated)
+	})
+}
+
+func Test_Delete_ProductType(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("CountReferences", ctx, 1).Return(0, 0, nil)
+		repoMock.On("Delete", ctx, 1).Return(nil)
+
+		// act
+		err := service.Delete(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Blocked by products", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("CountReferences", ctx, 1).Return(3, 1, nil)
+
+		// act
+		err := service.Delete(ctx, 1)
+
+		// assert
+		assert.ErrorIs(t, err, ErrHasProducts)
+		repoMock.AssertNotCalled(t, "Delete", ctx, 1)
+	})
+
+	t.Run("Blocked by sections", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("CountReferences", ctx, 1).Return(0, 2, nil)
+
+		// act
+		err := service.Delete(ctx, 1)
+
+		// assert
+		assert.ErrorIs(t, err, ErrHasSections)
+		repoMock.AssertNotCalled(t, "Delete", ctx, 1)
+	})
+}
+
+func Test_GetProducts_ProductType(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("Get", ctx, 9).Return(domain.ProductType{}, ErrNotFound)
+
+		// act
+		_, err := service.GetProducts(ctx, 9)
+
+		// assert
+		assert.ErrorIs(t, err, ErrNotFound)
+		repoMock.AssertNotCalled(t, "GetProducts", ctx, 9)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+		repoMock.On("Get", ctx, 1).Return(domain.ProductType{ID: 1, Name: "frozen"}, nil)
+		repoMock.On("GetProducts", ctx, 1).Return([]domain.Product{{ID: 5, ProductTypeID: 1}}, nil)
+
+		// act
+		products, err := service.GetProducts(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Product{{ID: 5, ProductTypeID: 1}}, products)
+	})
+}
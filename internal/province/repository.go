@@ -0,0 +1,194 @@
+package province
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+var (
+	ErrIntern          = errors.New("an internal error")
+	ErrDuplicated      = errors.New("duplicated province")
+	ErrNotFound        = errors.New("province not found")
+	ErrCountryNotFound = errors.New("country not found")
+	ErrHasLocalities   = errors.New("province has localities")
+	QueryGetAll        = "SELECT id, province_name, country_id FROM provinces"
+	QueryGetById       = "SELECT id, province_name, country_id FROM provinces WHERE id=?;"
+	QueryGetByCountry  = "SELECT id, province_name, country_id FROM provinces WHERE country_id=?"
+	QueryExistsCountry = "SELECT id FROM countries WHERE id=?;"
+	QueryInsert        = "INSERT INTO provinces (province_name, country_id) VALUES (?, ?)"
+	QueryUpdate        = "UPDATE provinces SET province_name=?, country_id=?, version=version+1 WHERE id=?"
+	QueryDelete        = "DELETE FROM provinces WHERE id=?"
+)
+
+// Repository encapsulates the storage of a Province.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Province, error)
+	Get(ctx context.Context, id int) (domain.Province, error)
+	GetByCountry(ctx context.Context, countryID int) ([]domain.Province, error)
+	ExistsCountry(ctx context.Context, countryID int) bool
+	Save(ctx context.Context, p domain.Province) (int, error)
+	Update(ctx context.Context, p domain.Province) error
+	Delete(ctx context.Context, id int) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Province, error) {
+	return r.query(QueryGetAll)
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Province, error) {
+	row := r.db.QueryRow(QueryGetById, id)
+	p := domain.Province{}
+	err := row.Scan(&p.Id, &p.Province_name, &p.Country_id)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			err = ErrNotFound
+		default:
+			err = ErrIntern
+		}
+		return domain.Province{}, err
+	}
+
+	return p, nil
+}
+
+func (r *repository) GetByCountry(ctx context.Context, countryID int) ([]domain.Province, error) {
+	return r.query(QueryGetByCountry, countryID)
+}
+
+func (r *repository) ExistsCountry(ctx context.Context, countryID int) bool {
+	row := r.db.QueryRow(QueryExistsCountry, countryID)
+	err := row.Scan(&countryID)
+	return err == nil
+}
+
+func (r *repository) Save(ctx context.Context, p domain.Province) (int, error) {
+	return r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryInsert)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(p.Province_name, p.Country_id)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		return int(id), nil
+	})
+}
+
+func (r *repository) Update(ctx context.Context, p domain.Province) error {
+	_, err := r.mutate(ctx, audit.ActionUpdate, p.Id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryUpdate)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		_, err = stmt.Exec(p.Province_name, p.Country_id, p.Id)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryDelete)
+		if err != nil {
+			return 0, ErrIntern
+		}
+		defer stmt.Close()
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, mapDriverError(err)
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// runs a provinces SELECT and scans every row
+func (r *repository) query(query string, args ...interface{}) ([]domain.Province, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	var provinces []domain.Province
+
+	for rows.Next() {
+		p := domain.Province{}
+		if err := rows.Scan(&p.Id, &p.Province_name, &p.Country_id); err != nil {
+			return nil, ErrIntern
+		}
+		provinces = append(provinces, p)
+	}
+
+	return provinces, nil
+}
+
+// mutate runs fn as a change of the province id recorded in the audit trail, a failure to record it being ErrIntern.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "provinces", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrIntern
+	}
+	return newID, err
+}
+
+// translates MySQL constraint violations into package errors
+func mapDriverError(err error) error {
+	driverErr, ok := err.(*mysql.MySQLError)
+	if !ok {
+		return ErrIntern
+	}
+
+	switch driverErr.Number {
+	case 1062:
+		return ErrDuplicated
+	case 1451:
+		return ErrHasLocalities
+	case 1452:
+		return ErrCountryNotFound
+	default:
+		return ErrIntern
+	}
+}
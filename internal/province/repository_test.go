@@ -0,0 +1,179 @@
+package province
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetByCountry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expected := []domain.Province{
+			{Id: 1, Province_name: "Buenos Aires", Country_id: 1},
+			{Id: 2, Province_name: "Córdoba", Country_id: 1},
+		}
+		rows := mock.NewRows([]string{"id", "province_name", "country_id"})
+		for _, p := range expected {
+			rows.AddRow(p.Id, p.Province_name, p.Country_id)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByCountry)).WithArgs(1).WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		provinces, err := rp.GetByCountry(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, provinces)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Internal Error", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByCountry)).WithArgs(1).WillReturnError(ErrIntern)
+
+		rp := NewRepository(db)
+
+		// act
+		provinces, err := rp.GetByCountry(context.Background(), 1)
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.Nil(t, provinces)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expected := domain.Province{Id: 1, Province_name: "Buenos Aires", Country_id: 1}
+		row := mock.NewRows([]string{"id", "province_name", "country_id"}).AddRow(expected.Id, expected.Province_name, expected.Country_id)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetById)).WithArgs(1).WillReturnRows(row)
+
+		rp := NewRepository(db)
+
+		// act
+		p, err := rp.Get(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, p)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetById)).WithArgs(9).WillReturnRows(mock.NewRows([]string{"id", "province_name", "country_id"}))
+
+		rp := NewRepository(db)
+
+		// act
+		p, err := rp.Get(context.Background(), 9)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.Equal(t, domain.Province{}, p)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	province := domain.Province{Province_name: "Buenos Aires", Country_id: 1}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs(province.Province_name, province.Country_id).WillReturnResult(sqlmock.NewResult(4, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		id, err := rp.Save(context.Background(), province)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs(province.Province_name, province.Country_id).WillReturnError(&mysql.MySQLError{Number: 1062})
+
+		rp := NewRepository(db)
+
+		// act
+		_, err := rp.Save(context.Background(), province)
+
+		// assert
+		assert.Equal(t, ErrDuplicated, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Country not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryInsert)).ExpectExec().WithArgs(province.Province_name, province.Country_id).WillReturnError(&mysql.MySQLError{Number: 1452})
+
+		rp := NewRepository(db)
+
+		// act
+		_, err := rp.Save(context.Background(), province)
+
+		// assert
+		assert.Equal(t, ErrCountryNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Referenced by localities", func(t *testing.T) {
+		// arrange
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(1).WillReturnError(&mysql.MySQLError{Number: 1451})
+
+		rp := NewRepository(db)
+
+		// act
+		err := rp.Delete(context.Background(), 1)
+
+		// assert
+		assert.Equal(t, ErrHasLocalities, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,75 @@
+package province
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.Province, error)
+	GetByID(ctx context.Context, id int) (domain.Province, error)
+	GetByCountry(ctx context.Context, countryID int) ([]domain.Province, error)
+	Create(ctx context.Context, p domain.Province) (domain.Province, error)
+	Update(ctx context.Context, p domain.Province) (domain.Province, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// returns all provinces
+func (s *service) GetAll(ctx context.Context) ([]domain.Province, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// returns the province specified by id
+func (s *service) GetByID(ctx context.Context, id int) (domain.Province, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// returns the provinces of the country specified by countryID
+func (s *service) GetByCountry(ctx context.Context, countryID int) ([]domain.Province, error) {
+	if !s.repo.ExistsCountry(ctx, countryID) {
+		return nil, ErrCountryNotFound
+	}
+	return s.repo.GetByCountry(ctx, countryID)
+}
+
+// adds one province and returns it with its database-defined id
+func (s *service) Create(ctx context.Context, p domain.Province) (domain.Province, error) {
+	// surrounding spaces would otherwise bypass the unique (country, name) constraint
+	p.Province_name = strings.TrimSpace(p.Province_name)
+
+	id, err := s.repo.Save(ctx, p)
+	if err != nil {
+		return domain.Province{}, err
+	}
+
+	p.Id = id
+	return p, nil
+}
+
+// updates the province specified by p.Id and returns it
+func (s *service) Update(ctx context.Context, p domain.Province) (domain.Province, error) {
+	p.Province_name = strings.TrimSpace(p.Province_name)
+
+	if err := s.repo.Update(ctx, p); err != nil {
+		return domain.Province{}, err
+	}
+
+	return p, nil
+}
+
+// deletes the province specified by id; fails while localities reference it
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
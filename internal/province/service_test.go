@@ -0,0 +1,103 @@
+package province
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// define a mock repository struct that implements the Repository interface for testing purposes
+type RepositoryMock struct {
+	mock.Mock
+	Repository
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetByCountry(ctx context.Context, countryID int) ([]domain.Province, error) {
+	args := r.Mock.Called(ctx, countryID)
+	return args.Get(0).([]domain.Province), args.Error(1)
+}
+func (r *RepositoryMock) ExistsCountry(ctx context.Context, countryID int) bool {
+	args := r.Mock.Called(ctx, countryID)
+	return args.Bool(0)
+}
+func (r *RepositoryMock) Save(ctx context.Context, p domain.Province) (int, error) {
+	args := r.Mock.Called(ctx, p)
+	return args.Get(0).(int), args.Error(1)
+}
+
+func Test_GetByCountry_Province(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		expected := []domain.Province{{Id: 1, Province_name: "Buenos Aires", Country_id: 1}}
+		repoMock.On("ExistsCountry", ctx, 1).Return(true)
+		repoMock.On("GetByCountry", ctx, 1).Return(expected, nil)
+
+		// act
+		provinces, err := service.GetByCountry(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, provinces)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Country not found", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("ExistsCountry", ctx, 9).Return(false)
+
+		// act
+		provinces, err := service.GetByCountry(ctx, 9)
+
+		// assert
+		assert.Equal(t, ErrCountryNotFound, err)
+		assert.Nil(t, provinces)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+}
+
+func Test_Create_Province(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK trims the name", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, domain.Province{Province_name: "Buenos Aires", Country_id: 1}).Return(2, nil)
+
+		// act
+		created, err := service.Create(ctx, domain.Province{Province_name: " Buenos Aires ", Country_id: 1})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Province{Id: 2, Province_name: "Buenos Aires", Country_id: 1}, created)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Duplicated", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Save", ctx, domain.Province{Province_name: "Buenos Aires", Country_id: 1}).Return(0, ErrDuplicated)
+
+		// act
+		created, err := service.Create(ctx, domain.Province{Province_name: "Buenos Aires", Country_id: 1})
+
+		// assert
+		assert.Equal(t, ErrDuplicated, err)
+		assert.Equal(t, domain.Province{}, created)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+}
@@ -3,10 +3,12 @@ package purchaseorder
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	//"strings"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 )
 
@@ -29,39 +31,41 @@ func NewRepository(db *sql.DB) Repository {
 func (r *repository) Save(ctx context.Context, purchOrd domain.Purchase_Orders) (int, error) {
 	query := "INSERT INTO purchase_orders(order_number, order_date, tracking_code, buyer_id, product_record_id, order_status_id) VALUES (?,?,?,?,?,?);"
 
-	statement, err := r.db.Prepare(query)
+	return r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		statement, err := ex.Prepare(query)
 
-	if err != nil {
-		return 0, ErrDatabase
-	}
-
-	res, err := statement.Exec(&purchOrd.Order_number,
-		&purchOrd.Order_date,
-		&purchOrd.Tracking_code,
-		&purchOrd.Buyer_id,
-		&purchOrd.Product_record_id,
-		&purchOrd.Order_Status_id)
-
-	if err != nil {
-		errMysql, err2 := err.(*mysql.MySQLError)
-		if !err2 {
+		if err != nil {
 			return 0, ErrDatabase
 		}
-		switch errMysql.Number {
-		case 1062:
-			return 0, ErrExists
-		default:
-			return 0, ErrDatabase
+
+		res, err := statement.Exec(&purchOrd.Order_number,
+			&purchOrd.Order_date,
+			&purchOrd.Tracking_code,
+			&purchOrd.Buyer_id,
+			&purchOrd.Product_record_id,
+			&purchOrd.Order_Status_id)
+
+		if err != nil {
+			errMysql, err2 := err.(*mysql.MySQLError)
+			if !err2 {
+				return 0, ErrDatabase
+			}
+			switch errMysql.Number {
+			case 1062:
+				return 0, ErrExists
+			default:
+				return 0, ErrDatabase
+			}
 		}
-	}
 
-	id, err := res.LastInsertId()
+		id, err := res.LastInsertId()
 
-	if err != nil {
-		return 0, ErrDatabase
-	}
-	purchOrd.ID = int(id)
-	return int(id), nil
+		if err != nil {
+			return 0, ErrDatabase
+		}
+		purchOrd.ID = int(id)
+		return int(id), nil
+	})
 }
 
 func (r *repository) Exists(ctx context.Context, id int) bool {
@@ -82,3 +86,12 @@ func (r *repository) ExistsBuyer(ctx context.Context, id int) bool {
 	err := row.Scan(&id)
 	return err == nil
 }
+
+// mutate runs fn as a change of the purchase order id recorded in the audit trail, a failure to record it being ErrDatabase.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "purchase_orders", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrDatabase
+	}
+	return newID, err
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	//"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internl/purchaseorder"
 )
 
@@ -37,6 +38,7 @@ func (s *service) Create(ctx context.Context, purchOrd domain.Purchase_Orders) (
 		return domain.Purchase_Orders{}, ErrBuyerNotFound
 	}
 
+	ctx = outbox.WithEvent(ctx, outbox.PurchaseOrderCreated)
 	id, err := s.r.Save(ctx, purchOrd)
 
 	if err != nil {
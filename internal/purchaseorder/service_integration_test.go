@@ -7,6 +7,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,7 +38,14 @@ func TestIntegrationCreate(t *testing.T) {
 		row.AddRow(1)
 
 		mock.ExpectQuery(regexp.QuoteMeta(queryExist)).WillReturnRows(row)
+		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM purchase_orders WHERE id=?")).WithArgs(1).
+			WillReturnRows(mock.NewRows([]string{"id", "order_number"}).AddRow(1, "12345"))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.PurchaseOrderCreated, "purchase_orders", "1", `{"id":1,"order_number":"12345"}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		newPurchOrd, err := service.Create(ctx, purchOrder)
 
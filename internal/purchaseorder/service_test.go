@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -55,7 +56,7 @@ func TestCreate(t *testing.T) {
 		serv := NewService(repoMockPurchOrd)
 
 		repoMockPurchOrd.On("ExistsBuyer", ctx, newPurchaseOrder.Buyer_id).Return(true)
-		repoMockPurchOrd.On("Save", ctx, newPurchaseOrder).Return(1, nil)
+		repoMockPurchOrd.On("Save", outbox.WithEvent(ctx, outbox.PurchaseOrderCreated), newPurchaseOrder).Return(1, nil)
 
 		//act
 		id, err := serv.Create(ctx, newPurchaseOrder)
@@ -88,7 +89,7 @@ func TestCreate(t *testing.T) {
 		serv := NewService(repoMockPurchOrd)
 
 		repoMockPurchOrd.On("ExistsBuyer", ctx, newPurchaseOrder.Buyer_id).Return(true)
-		repoMockPurchOrd.On("Save", ctx, newPurchaseOrder).Return(0, ErrDatabase)
+		repoMockPurchOrd.On("Save", outbox.WithEvent(ctx, outbox.PurchaseOrderCreated), newPurchaseOrder).Return(0, ErrDatabase)
 
 		//act
 		id, err := serv.Create(ctx, newPurchaseOrder)
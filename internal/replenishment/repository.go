@@ -0,0 +1,233 @@
+// Package replenishment keeps the reorder points of products in warehouses and suggests what to order
+// for those whose stock fell below them.
+package replenishment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Errors
+var (
+	ErrInternal          = errors.New("error: internal error")
+	ErrNotFound          = errors.New("error: reorder point not found")
+	ErrWarehouseNotFound = errors.New("error: warehouse id does not exists")
+	ErrProductNotFound   = errors.New("error: product id does not exists")
+	ErrSafetyStock       = errors.New("error: safety_stock must not be greater than reorder_point")
+	ErrDays              = errors.New("error: days and cover_days must be at most 365")
+)
+
+// Queries
+// the stock is that of the unexpired batches in the sections of the warehouse; purchase orders are not
+// tied to a warehouse, so the consumption read is that of the product across all of them, along with its
+// stock across all of them and the number of warehouses with a reorder point for it to split it by
+var (
+	QueryExistsWarehouse = "SELECT COUNT(*) FROM warehouses WHERE id = ? AND deleted_at IS NULL;"
+	QueryExistsProduct   = "SELECT COUNT(*) FROM products WHERE id = ? AND deleted_at IS NULL;"
+	QueryGetAll          = "SELECT id, warehouse_id, product_id, reorder_point, safety_stock FROM reorder_points WHERE warehouse_id = ? ORDER BY product_id;"
+	QueryFind            = "SELECT id FROM reorder_points WHERE warehouse_id = ? AND product_id = ?;"
+	QueryInsert          = "INSERT INTO reorder_points(warehouse_id, product_id, reorder_point, safety_stock) VALUES (?,?,?,?);"
+	QueryUpdate          = "UPDATE reorder_points SET reorder_point = ?, safety_stock = ? WHERE id = ?;"
+	QueryDelete          = "DELETE FROM reorder_points WHERE id = ?;"
+	QueryStock           = "SELECT rp.id, rp.warehouse_id, rp.product_id, rp.reorder_point, rp.safety_stock, p.description, s.id, s.company_name, " +
+		"COALESCE(st.stock,0), COALESCE(c.consumed,0), COALESCE(t.stock,0), COALESCE(n.warehouses,1) " +
+		"FROM reorder_points AS rp " +
+		"INNER JOIN warehouses AS w ON w.id = rp.warehouse_id " +
+		"INNER JOIN products AS p ON p.id = rp.product_id " +
+		"INNER JOIN sellers AS s ON s.id = p.id_seller " +
+		"LEFT JOIN (SELECT sc.warehouse_id, pb.product_id, SUM(pb.current_quantity) AS stock " +
+		"FROM products_batches AS pb INNER JOIN sections AS sc ON sc.id = pb.section_id " +
+		"WHERE pb.due_date >= CURDATE() AND sc.deleted_at IS NULL GROUP BY sc.warehouse_id, pb.product_id) AS st " +
+		"ON st.warehouse_id = rp.warehouse_id AND st.product_id = rp.product_id " +
+		"LEFT JOIN (SELECT pb.product_id, SUM(pb.current_quantity) AS stock " +
+		"FROM products_batches AS pb INNER JOIN sections AS sc ON sc.id = pb.section_id INNER JOIN warehouses AS sw ON sw.id = sc.warehouse_id " +
+		"WHERE pb.due_date >= CURDATE() AND sc.deleted_at IS NULL AND sw.deleted_at IS NULL GROUP BY pb.product_id) AS t ON t.product_id = rp.product_id " +
+		"LEFT JOIN (SELECT np.product_id, COUNT(*) AS warehouses " +
+		"FROM reorder_points AS np INNER JOIN warehouses AS nw ON nw.id = np.warehouse_id " +
+		"WHERE nw.deleted_at IS NULL GROUP BY np.product_id) AS n ON n.product_id = rp.product_id " +
+		"LEFT JOIN (SELECT pr.product_id, COUNT(po.id) AS consumed " +
+		"FROM purchase_orders AS po INNER JOIN product_records AS pr ON pr.id = po.product_record_id " +
+		"WHERE po.order_date >= ? GROUP BY pr.product_id) AS c ON c.product_id = rp.product_id " +
+		"WHERE w.deleted_at IS NULL AND p.deleted_at IS NULL"
+	QueryStockOrder = " ORDER BY rp.warehouse_id, rp.product_id;"
+)
+
+type Repository interface {
+	ExistsWarehouse(ctx context.Context, id int) (bool, error)
+	ExistsProduct(ctx context.Context, id int) (bool, error)
+	GetAll(ctx context.Context, warehouseID int) ([]domain.ReorderPoint, error)
+	Save(ctx context.Context, rp domain.ReorderPoint) (domain.ReorderPoint, error)
+	Delete(ctx context.Context, warehouseID, productID int) error
+	GetStock(ctx context.Context, warehouseID int, since string) ([]domain.ReorderStock, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) ExistsWarehouse(ctx context.Context, id int) (bool, error) {
+	return r.exists(ctx, QueryExistsWarehouse, id)
+}
+
+func (r *repository) ExistsProduct(ctx context.Context, id int) (bool, error) {
+	return r.exists(ctx, QueryExistsProduct, id)
+}
+
+func (r *repository) exists(ctx context.Context, query string, id int) (bool, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		return false, ErrInternal
+	}
+	return count > 0, nil
+}
+
+// GetAll returns the reorder points of the warehouse, ordered by product.
+func (r *repository) GetAll(ctx context.Context, warehouseID int) ([]domain.ReorderPoint, error) {
+	rows, err := r.db.QueryContext(ctx, QueryGetAll, warehouseID)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	points := []domain.ReorderPoint{}
+	for rows.Next() {
+		rp := domain.ReorderPoint{}
+		if err := rows.Scan(&rp.ID, &rp.WarehouseID, &rp.ProductID, &rp.ReorderPoint, &rp.SafetyStock); err != nil {
+			return nil, ErrInternal
+		}
+		points = append(points, rp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return points, nil
+}
+
+// Save sets the reorder point of the product in the warehouse, creating it when there is none, and
+// returns it with its id.
+func (r *repository) Save(ctx context.Context, rp domain.ReorderPoint) (domain.ReorderPoint, error) {
+	id, err := r.find(ctx, rp.WarehouseID, rp.ProductID)
+	switch {
+	case err == ErrNotFound:
+		rp.ID, err = r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+			res, err := ex.ExecContext(ctx, QueryInsert, rp.WarehouseID, rp.ProductID, rp.ReorderPoint, rp.SafetyStock)
+			if err != nil {
+				return 0, ErrInternal
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return 0, ErrInternal
+			}
+			return int(id), nil
+		})
+	case err == nil:
+		rp.ID = id
+		_, err = r.mutate(ctx, audit.ActionUpdate, id, func(ex audit.Execer) (int, error) {
+			if _, err := ex.ExecContext(ctx, QueryUpdate, rp.ReorderPoint, rp.SafetyStock, id); err != nil {
+				return 0, ErrInternal
+			}
+			return 0, nil
+		})
+	}
+	if err != nil {
+		return domain.ReorderPoint{}, err
+	}
+
+	return rp, nil
+}
+
+// Delete removes the reorder point of the product in the warehouse.
+func (r *repository) Delete(ctx context.Context, warehouseID, productID int) error {
+	id, err := r.find(ctx, warehouseID, productID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		if _, err := ex.ExecContext(ctx, QueryDelete, id); err != nil {
+			return 0, ErrInternal
+		}
+		return 0, nil
+	})
+	return err
+}
+
+// GetStock returns every reorder point, or those of warehouseID alone unless it is zero, with the stock
+// of the product in the warehouse and the share of its orders since the date that falls to the warehouse.
+func (r *repository) GetStock(ctx context.Context, warehouseID int, since string) ([]domain.ReorderStock, error) {
+	query := QueryStock
+	args := []interface{}{since}
+	if warehouseID != 0 {
+		query += " AND rp.warehouse_id = ?"
+		args = append(args, warehouseID)
+	}
+	query += QueryStockOrder
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	var stock []domain.ReorderStock
+	for rows.Next() {
+		s := domain.ReorderStock{}
+		var consumed, totalStock, warehouses int
+		if err := rows.Scan(&s.ID, &s.WarehouseID, &s.ProductID, &s.ReorderPoint.ReorderPoint, &s.SafetyStock, &s.Description, &s.SellerID, &s.SellerCompanyName, &s.Stock, &consumed, &totalStock, &warehouses); err != nil {
+			return nil, ErrInternal
+		}
+		s.Consumed = share(consumed, s.Stock, totalStock, warehouses)
+		stock = append(stock, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return stock, nil
+}
+
+// share is the part of the consumption of a product across every warehouse that falls to one holding
+// stock of it: in proportion to that stock, or evenly between the warehouses with a reorder point for
+// the product when none holds any.
+func share(consumed, stock, totalStock, warehouses int) int {
+	if totalStock > 0 {
+		return int(math.Round(float64(consumed) * float64(stock) / float64(totalStock)))
+	}
+	if warehouses < 1 {
+		warehouses = 1
+	}
+	return int(math.Round(float64(consumed) / float64(warehouses)))
+}
+
+// find returns the id of the reorder point of the product in the warehouse, or ErrNotFound.
+func (r *repository) find(ctx context.Context, warehouseID, productID int) (int, error) {
+	var id int
+	err := r.db.QueryRowContext(ctx, QueryFind, warehouseID, productID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, ErrInternal
+	}
+	return id, nil
+}
+
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "reorder_points", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrInternal
+	}
+	return newID, err
+}
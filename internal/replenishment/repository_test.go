@@ -0,0 +1,239 @@
+package replenishment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Repository_ExistsWarehouse(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(QueryExistsWarehouse)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(QueryExistsWarehouse)).WithArgs(2).WillReturnError(errors.New("connection lost"))
+	repo := NewRepository(db)
+
+	// act
+	found, errFound := repo.ExistsWarehouse(context.Background(), 1)
+	_, errFailed := repo.ExistsWarehouse(context.Background(), 2)
+
+	// assert
+	assert.NoError(t, errFound)
+	assert.True(t, found)
+	assert.Equal(t, ErrInternal, errFailed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Repository_GetAll(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "reorder_point", "safety_stock"}).
+		AddRow(1, 2, 5, 40, 10).
+		AddRow(3, 2, 8, 15, 0)
+	mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll)).WithArgs(2).WillReturnRows(rows)
+	repo := NewRepository(db)
+
+	// act
+	points, err := repo.GetAll(context.Background(), 2)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ReorderPoint{
+		{ID: 1, WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10},
+		{ID: 3, WarehouseID: 2, ProductID: 8, ReorderPoint: 15},
+	}, points)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Repository_Save(t *testing.T) {
+	ctx := context.Background()
+	rp := domain.ReorderPoint{WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}
+
+	t.Run("OK creates", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryFind)).WithArgs(2, 5).WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WithArgs(2, 5, 40, 10).WillReturnResult(sqlmock.NewResult(7, 1))
+		repo := NewRepository(db)
+
+		// act
+		saved, err := repo.Save(ctx, rp)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, saved.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("OK updates", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryFind)).WithArgs(2, 5).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdate)).WithArgs(40, 10, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		repo := NewRepository(db)
+
+		// act
+		saved, err := repo.Save(ctx, rp)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, saved.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryFind)).WithArgs(2, 5).WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WillReturnError(errors.New("connection lost"))
+		repo := NewRepository(db)
+
+		// act
+		saved, err := repo.Save(ctx, rp)
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Equal(t, domain.ReorderPoint{}, saved)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Repository_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryFind)).WithArgs(2, 5).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(QueryDelete)).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+		repo := NewRepository(db)
+
+		// act
+		err = repo.Delete(ctx, 2, 5)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryFind)).WithArgs(2, 9).WillReturnError(sql.ErrNoRows)
+		repo := NewRepository(db)
+
+		// act
+		err = repo.Delete(ctx, 2, 9)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+var stockColumns = []string{"id", "warehouse_id", "product_id", "reorder_point", "safety_stock", "description", "seller_id", "company_name", "stock", "consumed", "total_stock", "warehouses"}
+
+func Test_Repository_GetStock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK of a warehouse", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows(stockColumns).
+			AddRow(1, 2, 5, 40, 10, "peas", 4, "Acme", 12, 60, 12, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryStock+" AND rp.warehouse_id = ?"+QueryStockOrder)).WithArgs("2023-03-02", 2).WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		stock, err := repo.GetStock(ctx, 2, "2023-03-02")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ReorderStock{{
+			ReorderPoint:      domain.ReorderPoint{ID: 1, WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10},
+			Description:       "peas",
+			SellerID:          4,
+			SellerCompanyName: "Acme",
+			Stock:             12,
+			Consumed:          60,
+		}}, stock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("consumption split between two warehouses", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// 60 peas ordered across warehouses 2 and 3, which hold 30 and 10 of the 40 in stock; carrots
+		// are out of stock in both, so their 9 orders are split evenly
+		rows := sqlmock.NewRows(stockColumns).
+			AddRow(1, 2, 5, 40, 10, "peas", 4, "Acme", 30, 60, 40, 2).
+			AddRow(2, 2, 6, 20, 5, "carrots", 4, "Acme", 0, 9, 0, 2).
+			AddRow(3, 3, 5, 40, 10, "peas", 4, "Acme", 10, 60, 40, 2).
+			AddRow(4, 3, 6, 20, 5, "carrots", 4, "Acme", 0, 9, 0, 2)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryStock + QueryStockOrder)).WithArgs("2023-03-02").WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		stock, err := repo.GetStock(ctx, 0, "2023-03-02")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, stock, 4)
+		assert.Equal(t, 45, stock[0].Consumed)
+		assert.Equal(t, 5, stock[1].Consumed)
+		assert.Equal(t, 15, stock[2].Consumed)
+		assert.Equal(t, 5, stock[3].Consumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryStock + QueryStockOrder)).WithArgs("2023-03-02").WillReturnError(errors.New("connection lost"))
+		repo := NewRepository(db)
+
+		// act
+		stock, err := repo.GetStock(ctx, 0, "2023-03-02")
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, stock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
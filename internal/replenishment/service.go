@@ -0,0 +1,130 @@
+package replenishment
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Report defaults and bounds
+const (
+	DefaultDays      = 30
+	DefaultCoverDays = 14
+	MaxDays          = 365
+)
+
+type Service interface {
+	GetReorderPoints(ctx context.Context, warehouseID int) ([]domain.ReorderPoint, error)
+	SaveReorderPoint(ctx context.Context, rp domain.ReorderPoint) (domain.ReorderPoint, error)
+	DeleteReorderPoint(ctx context.Context, warehouseID, productID int) error
+	GetSuggestions(ctx context.Context, f domain.ReplenishmentFilter) ([]domain.ReplenishmentSuggestion, error)
+}
+
+type service struct {
+	repository Repository
+	now        func() time.Time
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+		now:        time.Now,
+	}
+}
+
+func (s *service) GetReorderPoints(ctx context.Context, warehouseID int) ([]domain.ReorderPoint, error) {
+	if err := s.checkWarehouse(ctx, warehouseID); err != nil {
+		return nil, err
+	}
+	return s.repository.GetAll(ctx, warehouseID)
+}
+
+// SaveReorderPoint sets the reorder point of a product in a warehouse, both of which must exist.
+func (s *service) SaveReorderPoint(ctx context.Context, rp domain.ReorderPoint) (domain.ReorderPoint, error) {
+	if rp.SafetyStock > rp.ReorderPoint {
+		return domain.ReorderPoint{}, ErrSafetyStock
+	}
+	if err := s.checkWarehouse(ctx, rp.WarehouseID); err != nil {
+		return domain.ReorderPoint{}, err
+	}
+	exists, err := s.repository.ExistsProduct(ctx, rp.ProductID)
+	if err != nil {
+		return domain.ReorderPoint{}, err
+	}
+	if !exists {
+		return domain.ReorderPoint{}, ErrProductNotFound
+	}
+
+	return s.repository.Save(ctx, rp)
+}
+
+func (s *service) DeleteReorderPoint(ctx context.Context, warehouseID, productID int) error {
+	return s.repository.Delete(ctx, warehouseID, productID)
+}
+
+// GetSuggestions returns the products whose stock is below their reorder point, in every warehouse or in
+// f.WarehouseID alone, with the quantity to order. The order brings the stock up to the safety stock
+// plus the average daily consumption of the last f.Days times f.CoverDays, and at least to the reorder
+// point.
+func (s *service) GetSuggestions(ctx context.Context, f domain.ReplenishmentFilter) ([]domain.ReplenishmentSuggestion, error) {
+	if f.Days == 0 {
+		f.Days = DefaultDays
+	}
+	if f.CoverDays == 0 {
+		f.CoverDays = DefaultCoverDays
+	}
+	if f.Days < 0 || f.Days > MaxDays || f.CoverDays < 0 || f.CoverDays > MaxDays {
+		return nil, ErrDays
+	}
+	if f.WarehouseID != 0 {
+		if err := s.checkWarehouse(ctx, f.WarehouseID); err != nil {
+			return nil, err
+		}
+	}
+
+	// the consumption covers the last f.Days complete days and today's orders so far
+	since := s.now().UTC().AddDate(0, 0, -f.Days).Format("2006-01-02")
+	stock, err := s.repository.GetStock(ctx, f.WarehouseID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []domain.ReplenishmentSuggestion{}
+	for _, st := range stock {
+		if st.Stock >= st.ReorderPoint.ReorderPoint {
+			continue
+		}
+		daily := float64(st.Consumed) / float64(f.Days)
+		target := int(math.Ceil(daily*float64(f.CoverDays))) + st.SafetyStock
+		if target < st.ReorderPoint.ReorderPoint {
+			target = st.ReorderPoint.ReorderPoint
+		}
+		suggestions = append(suggestions, domain.ReplenishmentSuggestion{
+			WarehouseID:       st.WarehouseID,
+			ProductID:         st.ProductID,
+			Description:       st.Description,
+			SellerID:          st.SellerID,
+			SellerCompanyName: st.SellerCompanyName,
+			Stock:             st.Stock,
+			ReorderPoint:      st.ReorderPoint.ReorderPoint,
+			SafetyStock:       st.SafetyStock,
+			DailyConsumption:  math.Round(daily*100) / 100,
+			SuggestedQuantity: target - st.Stock,
+		})
+	}
+
+	return suggestions, nil
+}
+
+func (s *service) checkWarehouse(ctx context.Context, id int) error {
+	exists, err := s.repository.ExistsWarehouse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrWarehouseNotFound
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package replenishment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) ExistsWarehouse(ctx context.Context, id int) (bool, error) {
+	args := r.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+func (r *RepositoryMock) ExistsProduct(ctx context.Context, id int) (bool, error) {
+	args := r.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+func (r *RepositoryMock) GetAll(ctx context.Context, warehouseID int) ([]domain.ReorderPoint, error) {
+	args := r.Called(ctx, warehouseID)
+	return args.Get(0).([]domain.ReorderPoint), args.Error(1)
+}
+func (r *RepositoryMock) Save(ctx context.Context, rp domain.ReorderPoint) (domain.ReorderPoint, error) {
+	args := r.Called(ctx, rp)
+	return args.Get(0).(domain.ReorderPoint), args.Error(1)
+}
+func (r *RepositoryMock) Delete(ctx context.Context, warehouseID, productID int) error {
+	args := r.Called(ctx, warehouseID, productID)
+	return args.Error(0)
+}
+func (r *RepositoryMock) GetStock(ctx context.Context, warehouseID int, since string) ([]domain.ReorderStock, error) {
+	args := r.Called(ctx, warehouseID, since)
+	return args.Get(0).([]domain.ReorderStock), args.Error(1)
+}
+
+func newTestService(repo Repository) *service {
+	return &service{repository: repo, now: func() time.Time { return time.Date(2023, 4, 1, 10, 30, 0, 0, time.UTC) }}
+}
+
+func Test_SaveReorderPoint(t *testing.T) {
+	ctx := context.Background()
+	rp := domain.ReorderPoint{WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		saved := rp
+		saved.ID = 7
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 2).Return(true, nil)
+		repoMock.On("ExistsProduct", ctx, 5).Return(true, nil)
+		repoMock.On("Save", ctx, rp).Return(saved, nil)
+		service := newTestService(repoMock)
+
+		// act
+		result, err := service.SaveReorderPoint(ctx, rp)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, saved, result)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("safety stock over the reorder point", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := newTestService(repoMock)
+
+		// act
+		_, err := service.SaveReorderPoint(ctx, domain.ReorderPoint{WarehouseID: 2, ProductID: 5, ReorderPoint: 10, SafetyStock: 11})
+
+		// assert
+		assert.Equal(t, ErrSafetyStock, err)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("warehouse not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 2).Return(false, nil)
+		service := newTestService(repoMock)
+
+		// act
+		_, err := service.SaveReorderPoint(ctx, rp)
+
+		// assert
+		assert.Equal(t, ErrWarehouseNotFound, err)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 2).Return(true, nil)
+		repoMock.On("ExistsProduct", ctx, 5).Return(false, nil)
+		service := newTestService(repoMock)
+
+		// act
+		_, err := service.SaveReorderPoint(ctx, rp)
+
+		// assert
+		assert.Equal(t, ErrProductNotFound, err)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func Test_GetReorderPoints(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		points := []domain.ReorderPoint{{ID: 1, WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}}
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 2).Return(true, nil)
+		repoMock.On("GetAll", ctx, 2).Return(points, nil)
+		service := newTestService(repoMock)
+
+		// act
+		result, err := service.GetReorderPoints(ctx, 2)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, points, result)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("warehouse not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 9).Return(false, nil)
+		service := newTestService(repoMock)
+
+		// act
+		result, err := service.GetReorderPoints(ctx, 9)
+
+		// assert
+		assert.Equal(t, ErrWarehouseNotFound, err)
+		assert.Nil(t, result)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func Test_GetSuggestions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK suggests the products below their reorder point", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 2).Return(true, nil)
+		repoMock.On("GetStock", ctx, 2, "2023-03-02").Return([]domain.ReorderStock{
+			// 2 a day for 14 days plus 10 is short of the reorder point, which sets the target
+			{ReorderPoint: domain.ReorderPoint{ID: 1, WarehouseID: 2, ProductID: 5, ReorderPoint: 40, SafetyStock: 10}, Description: "peas", SellerID: 4, SellerCompanyName: "Acme", Stock: 12, Consumed: 60},
+			// 5 a day for 14 days plus 10
+			{ReorderPoint: domain.ReorderPoint{ID: 2, WarehouseID: 2, ProductID: 6, ReorderPoint: 30, SafetyStock: 10}, Description: "corn", SellerID: 3, SellerCompanyName: "Farm", Stock: 0, Consumed: 150},
+			{ReorderPoint: domain.ReorderPoint{ID: 3, WarehouseID: 2, ProductID: 7, ReorderPoint: 30, SafetyStock: 10}, Description: "rice", SellerID: 3, SellerCompanyName: "Farm", Stock: 30, Consumed: 900},
+		}, nil)
+		service := newTestService(repoMock)
+
+		// act
+		suggestions, err := service.GetSuggestions(ctx, domain.ReplenishmentFilter{WarehouseID: 2})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ReplenishmentSuggestion{
+			{WarehouseID: 2, ProductID: 5, Description: "peas", SellerID: 4, SellerCompanyName: "Acme", Stock: 12, ReorderPoint: 40, SafetyStock: 10, DailyConsumption: 2, SuggestedQuantity: 28},
+			{WarehouseID: 2, ProductID: 6, Description: "corn", SellerID: 3, SellerCompanyName: "Farm", Stock: 0, ReorderPoint: 30, SafetyStock: 10, DailyConsumption: 5, SuggestedQuantity: 80},
+		}, suggestions)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("OK every warehouse over the given days", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetStock", ctx, 0, "2023-03-25").Return([]domain.ReorderStock{
+			{ReorderPoint: domain.ReorderPoint{WarehouseID: 1, ProductID: 5, ReorderPoint: 10}, Stock: 4, Consumed: 10},
+		}, nil)
+		service := newTestService(repoMock)
+
+		// act
+		suggestions, err := service.GetSuggestions(ctx, domain.ReplenishmentFilter{Days: 7, CoverDays: 7})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ReplenishmentSuggestion{
+			{WarehouseID: 1, ProductID: 5, Stock: 4, ReorderPoint: 10, DailyConsumption: 1.43, SuggestedQuantity: 6},
+		}, suggestions)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("days too long", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := newTestService(repoMock)
+
+		// act
+		suggestions, err := service.GetSuggestions(ctx, domain.ReplenishmentFilter{Days: MaxDays + 1})
+
+		// assert
+		assert.Equal(t, ErrDays, err)
+		assert.Nil(t, suggestions)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("warehouse not found", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("ExistsWarehouse", ctx, 9).Return(false, nil)
+		service := newTestService(repoMock)
+
+		// act
+		suggestions, err := service.GetSuggestions(ctx, domain.ReplenishmentFilter{WarehouseID: 9})
+
+		// assert
+		assert.Equal(t, ErrWarehouseNotFound, err)
+		assert.Nil(t, suggestions)
+		repoMock.AssertExpectations(t)
+	})
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqlin"
 )
 
 // Errors
@@ -20,30 +24,47 @@ var (
 )
 
 var (
-	GetAllQuery = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
-	GetByID     = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=?;"
+	GetAllQuery            = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE deleted_at IS NULL;"
+	GetAllWithDeletedQuery = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
+	GetByID                = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=? AND deleted_at IS NULL;"
+	// formatted with the placeholders of the ids
+	GetByIDsQuery = "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id IN (%s) AND deleted_at IS NULL;"
 	// Products quantity of each Section
 	GetReportQuery = "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s " +
 		"LEFT JOIN products_batches as pb ON s.id = pb.section_id " +
+		"WHERE s.deleted_at IS NULL " +
 		"GROUP BY s.id, s.section_number;"
 	// Products quantity for a certain section
 	GetReportQueryByID = "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s " +
 		"LEFT JOIN products_batches as pb ON s.id = pb.section_id " +
-		"WHERE s.id = ? " +
+		"WHERE s.id = ? AND s.deleted_at IS NULL " +
 		"GROUP BY s.id, s.section_number;"
-	CreateQuery = "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
-	UpdateQuery = "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=? WHERE id=?;"
-	DeleteQuery = "DELETE FROM sections WHERE id=?;"
+	CreateQuery     = "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
+	UpdateQuery     = "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=?, version=version+1 WHERE id=?;"
+	DeleteQuery     = "UPDATE sections SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL;"
+	RestoreQuery    = "UPDATE sections SET deleted_at=NULL, version=version+1 WHERE id=? AND deleted_at IS NOT NULL;"
+	HardDeleteQuery = "DELETE FROM sections WHERE id=?;"
 )
 
+// Dependents are the rows whose foreign keys block removing a section for good.
+var Dependents = []softdelete.Reference{
+	{Name: "product batches", Table: "products_batches", Column: "section_id"},
+	{Name: "transfers out", Table: "transfers", Column: "origin_section_id"},
+	{Name: "transfers in", Table: "transfers", Column: "destination_section_id"},
+}
+
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Section, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Section, error)
 	GetByID(ctx context.Context, id int) (domain.Section, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error)
 	GetAllReportProducts(ctx context.Context) ([]domain.SectionReportProducts, error)
 	GetReportProductsByID(ctx context.Context, id int) ([]domain.SectionReportProducts, error)
 	Create(ctx context.Context, s domain.Section) (int, error)
 	Update(ctx context.Context, s domain.Section) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	HardDelete(ctx context.Context, id int) error
 }
 
 type repository struct {
@@ -59,7 +80,15 @@ func NewRepository(db *sql.DB) Repository {
 // ------------------------------- READ ---------------------------------
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
-	rows, err := r.db.Query(GetAllQuery)
+	return r.getAll(GetAllQuery)
+}
+
+func (r *repository) GetAllWithDeleted(ctx context.Context) ([]domain.Section, error) {
+	return r.getAll(GetAllWithDeletedQuery)
+}
+
+func (r *repository) getAll(query string, args ...interface{}) ([]domain.Section, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, ErrInternal
 	}
@@ -94,6 +123,15 @@ func (r *repository) GetByID(ctx context.Context, id int) (domain.Section, error
 	return s, nil
 }
 
+// returns the sections among ids, in no particular order
+func (r *repository) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := sqlin.Ints(ids)
+	return r.getAll(fmt.Sprintf(GetByIDsQuery, placeholders), args...)
+}
+
 func (r *repository) GetAllReportProducts(ctx context.Context) ([]domain.SectionReportProducts, error) {
 	var reports []domain.SectionReportProducts
 
@@ -136,94 +174,160 @@ func (r *repository) GetReportProductsByID(ctx context.Context, id int) ([]domai
 // -------------------------------- WRITE --------------------------------
 
 func (r *repository) Create(ctx context.Context, s domain.Section) (int, error) {
-	stmt, err := r.db.Prepare(CreateQuery)
-	if err != nil {
-		return 0, ErrInternal
-	}
-	defer stmt.Close()
+	return r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(CreateQuery)
+		if err != nil {
+			return 0, ErrInternal
+		}
+		defer stmt.Close()
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
-	if err != nil {
-		// log.Println(err.(*mysql.MySQLError).Number)
-		// log.Println(err.(*mysql.MySQLError).Message)
-		errMysql := err.(*mysql.MySQLError)
-		switch errMysql.Number {
-		case 1452:
-			if strings.Contains(errMysql.Error(), "`warehouses`") {
-				return 0, ErrWareHouseNotFound
-			} else if strings.Contains(errMysql.Error(), "`product_types`") {
-				return 0, ErrProductTypeNotFound
+		res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+		if err != nil {
+			// log.Println(err.(*mysql.MySQLError).Number)
+			// log.Println(err.(*mysql.MySQLError).Message)
+			errMysql := err.(*mysql.MySQLError)
+			switch errMysql.Number {
+			case 1452:
+				if strings.Contains(errMysql.Error(), "`warehouses`") {
+					return 0, ErrWareHouseNotFound
+				} else if strings.Contains(errMysql.Error(), "`product_types`") {
+					return 0, ErrProductTypeNotFound
+				}
+			case 1062:
+				return 0, ErrExistsSectionNumber
+			default:
+				return 0, ErrInternal
 			}
-		case 1062:
-			return 0, ErrExistsSectionNumber
-		default:
-			return 0, ErrInternal
 		}
-	}
 
-	rows, err := res.RowsAffected()
-	if err != nil || rows != 1 {
-		return 0, ErrInternal
-	}
+		rows, err := res.RowsAffected()
+		if err != nil || rows != 1 {
+			return 0, ErrInternal
+		}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, ErrInternal
-	}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, ErrInternal
+		}
 
-	return int(id), nil
+		return int(id), nil
+	})
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Section) error {
-	stmt, err := r.db.Prepare(UpdateQuery)
-	if err != nil {
-		return ErrInternal
-	}
-	defer stmt.Close()
+	_, err := r.mutate(ctx, audit.ActionUpdate, s.ID, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(UpdateQuery)
+		if err != nil {
+			return 0, ErrInternal
+		}
+		defer stmt.Close()
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID)
-	if err != nil {
-		// log.Println(err.(*mysql.MySQLError).Number, err.(*mysql.MySQLError).Message)
-		errMysql := err.(*mysql.MySQLError)
-		switch errMysql.Number {
-		case 1452:
-			if strings.Contains(errMysql.Error(), "`warehouses`") {
-				return ErrWareHouseNotFound
-			} else if strings.Contains(errMysql.Error(), "`product_types`") {
-				return ErrProductTypeNotFound
+		res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID)
+		if err != nil {
+			// log.Println(err.(*mysql.MySQLError).Number, err.(*mysql.MySQLError).Message)
+			errMysql := err.(*mysql.MySQLError)
+			switch errMysql.Number {
+			case 1452:
+				if strings.Contains(errMysql.Error(), "`warehouses`") {
+					return 0, ErrWareHouseNotFound
+				} else if strings.Contains(errMysql.Error(), "`product_types`") {
+					return 0, ErrProductTypeNotFound
+				}
+			case 1062:
+				return 0, ErrExistsSectionNumber
+			default:
+				return 0, ErrInternal
 			}
-		case 1062:
-			return ErrExistsSectionNumber
-		default:
-			return ErrInternal
 		}
-	}
 
-	_, err = res.RowsAffected()
-	if err != nil {
-		return ErrInternal
-	}
+		_, err = res.RowsAffected()
+		if err != nil {
+			return 0, ErrInternal
+		}
 
-	return nil
+		return 0, nil
+	})
+	return err
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(DeleteQuery)
+		if err != nil {
+			return 0, ErrInternal
+		}
+		defer stmt.Close()
 
-	stmt, err := r.db.Prepare(DeleteQuery)
-	if err != nil {
-		return ErrInternal
-	}
-	defer stmt.Close()
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, ErrInternal
+		}
 
-	res, err := stmt.Exec(id)
-	if err != nil {
-		return ErrInternal
-	}
+		affect, err := res.RowsAffected()
+		if err != nil || affect < 1 {
+			return 0, ErrInternal
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionRestore, id, func(ex audit.Execer) (int, error) {
+		res, err := ex.Exec(RestoreQuery, id)
+		if err != nil {
+			return 0, ErrInternal
+		}
 
-	affect, err := res.RowsAffected()
-	if err != nil || affect < 1 {
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, ErrInternal
+		}
+		if affect < 1 {
+			return 0, ErrSectionNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+func (r *repository) HardDelete(ctx context.Context, id int) error {
+	if err := softdelete.Dependents(ctx, r.db, id, Dependents...); err != nil {
+		if errors.Is(err, softdelete.ErrHasDependents) {
+			return err
+		}
 		return ErrInternal
 	}
 
-	return nil
+	_, err := r.mutate(ctx, audit.ActionHardDelete, id, func(ex audit.Execer) (int, error) {
+		res, err := ex.Exec(HardDeleteQuery, id)
+		if err != nil {
+			if softdelete.IsReferenced(err) {
+				return 0, softdelete.ErrHasDependents
+			}
+			return 0, ErrInternal
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, ErrInternal
+		}
+		if affect < 1 {
+			return 0, ErrSectionNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// mutate runs fn as a change of the section id recorded in the audit trail, a failure to record it being ErrInternal.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "sections", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrInternal
+	}
+	return newID, err
 }
@@ -3,6 +3,7 @@ package section
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -31,7 +32,7 @@ func Test_GetAll(t *testing.T) {
 	r := NewRepository(db)
 	ctx := context.Background()
 
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE deleted_at IS NULL;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -105,7 +106,7 @@ func Test_GetByID(t *testing.T) {
 	r := NewRepository(db)
 	ctx := context.Background()
 
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=?;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=? AND deleted_at IS NULL;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -152,6 +153,48 @@ func Test_GetByID(t *testing.T) {
 	})
 }
 
+func Test_GetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db)
+	ctx := context.Background()
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expected := []domain.Section{
+			{ID: 1, SectionNumber: 1, CurrentTemperature: 15, MinimumTemperature: -20, CurrentCapacity: 20, MinimumCapacity: 5, MaximumCapacity: 50, WarehouseID: 1, ProductTypeID: 1},
+			{ID: 4, SectionNumber: 3, CurrentTemperature: 25, MinimumTemperature: -10, CurrentCapacity: 10, MinimumCapacity: 2, MaximumCapacity: 20, WarehouseID: 2, ProductTypeID: 1},
+		}
+		rows := mock.NewRows([]string{"id", "section_number", " current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity", "warehouse_id", "id_product_type"})
+		for _, d := range expected {
+			rows.AddRow(d.ID, d.SectionNumber, d.CurrentTemperature, d.MinimumTemperature, d.CurrentCapacity, d.MinimumCapacity, d.MaximumCapacity, d.WarehouseID, d.ProductTypeID)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(GetByIDsQuery, "?,?"))).
+			WithArgs(1, 4).
+			WillReturnRows(rows)
+
+		// act
+		sections, err := r.GetByIDs(ctx, []int{1, 4})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sections)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No ids", func(t *testing.T) {
+		// act
+		sections, err := r.GetByIDs(ctx, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, sections)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func Test_GetAllReportProducts(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -169,7 +212,7 @@ func Test_GetAllReportProducts(t *testing.T) {
 	r := NewRepository(db)
 	ctx := context.Background()
 
-	query := "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s LEFT JOIN products_batches as pb ON s.id = pb.section_id GROUP BY s.id, s.section_number;"
+	query := "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s LEFT JOIN products_batches as pb ON s.id = pb.section_id WHERE s.deleted_at IS NULL GROUP BY s.id, s.section_number;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -245,7 +288,7 @@ func Test_GetReportProductsByID(t *testing.T) {
 	r := NewRepository(db)
 	ctx := context.Background()
 
-	query := "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s LEFT JOIN products_batches as pb ON s.id = pb.section_id WHERE s.id = ? GROUP BY s.id, s.section_number;"
+	query := "SELECT s.id, s.section_number, COALESCE(sum(pb.current_quantity),0) FROM sections as s LEFT JOIN products_batches as pb ON s.id = pb.section_id WHERE s.id = ? AND s.deleted_at IS NULL GROUP BY s.id, s.section_number;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -455,7 +498,7 @@ func Test_Update(t *testing.T) {
 	r := NewRepository(db)
 	ctx := context.Background()
 
-	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=? WHERE id=?;"
+	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=?, version=version+1 WHERE id=?;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
@@ -565,7 +608,7 @@ func Test_Delete(t *testing.T) {
 	r := NewRepository(db)
 	ctx := context.Background()
 
-	query := "DELETE FROM sections WHERE id=?;"
+	query := "UPDATE sections SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL;"
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
 		mock.ExpectPrepare(regexp.QuoteMeta(query)).
@@ -621,3 +664,72 @@ func Test_Delete(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func Test_GetAllWithDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db)
+	ctx := context.Background()
+
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		rows := sqlmock.NewRows([]string{"id", "section_number", "current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity", "warehouse_id", "id_product_type"}).
+			AddRow(1, 1, 15, -20, 20, 5, 50, 1, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnRows(rows)
+
+		// act
+		sections, err := r.GetAllWithDeleted(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, sections, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_HardDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db)
+	ctx := context.Background()
+
+	query := "DELETE FROM sections WHERE id=?;"
+	expectCounts := func(counts ...int) {
+		for i, ref := range Dependents {
+			count := "SELECT COUNT(*) FROM " + ref.Table + " WHERE " + ref.Column + "=?"
+			mock.ExpectQuery(regexp.QuoteMeta(count)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(counts[i]))
+		}
+	}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		expectCounts(0, 0, 0)
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// act
+		err = r.HardDelete(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Dependents: ErrHasDependents", func(t *testing.T) {
+		// arrange
+		expectCounts(4, 0, 1)
+
+		// act
+		err = r.HardDelete(ctx, 1)
+
+		// assert
+		assert.EqualError(t, err, "blocked by dependents: 4 product batches, 1 transfers in")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
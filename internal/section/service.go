@@ -4,15 +4,21 @@ import (
 	"context"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 type Service interface {
 	GetAll(ctx context.Context) ([]domain.Section, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Section, error)
 	GetByID(ctx context.Context, id int) (domain.Section, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error)
 	GetReportProducts(ctx context.Context, id int) ([]domain.SectionReportProducts, error)
 	Create(ctx context.Context, section domain.Section) (domain.Section, error)
 	Update(ctx context.Context, section domain.Section) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (domain.Section, error)
+	HardDelete(ctx context.Context, id int) error
 }
 
 type service struct {
@@ -35,6 +41,14 @@ func (s *service) GetAll(ctx context.Context) ([]domain.Section, error) {
 	return sections, nil
 }
 
+func (s *service) GetAllWithDeleted(ctx context.Context) ([]domain.Section, error) {
+	sections, err := s.r.GetAllWithDeleted(ctx)
+	if err != nil {
+		return []domain.Section{}, err
+	}
+	return sections, nil
+}
+
 func (s *service) GetByID(ctx context.Context, id int) (domain.Section, error) {
 	section, err := s.r.GetByID(ctx, id)
 	if err != nil {
@@ -43,6 +57,11 @@ func (s *service) GetByID(ctx context.Context, id int) (domain.Section, error) {
 	return section, nil
 }
 
+// returns the sections among ids, in no particular order
+func (s *service) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	return s.r.GetByIDs(ctx, ids)
+}
+
 func (s *service) GetReportProducts(ctx context.Context, id int) ([]domain.SectionReportProducts, error) {
 	var report []domain.SectionReportProducts
 	var err error
@@ -75,6 +94,7 @@ func (s *service) Create(ctx context.Context, section domain.Section) (domain.Se
 
 func (s *service) Update(ctx context.Context, section domain.Section) error {
 	// Validate unique section_numeber
+	ctx = outbox.WithEvent(ctx, outbox.SectionUpdated)
 	err := s.r.Update(ctx, section)
 	if err != nil {
 		return err
@@ -84,6 +104,7 @@ func (s *service) Update(ctx context.Context, section domain.Section) error {
 }
 
 func (s *service) Delete(ctx context.Context, id int) error {
+	ctx = outbox.WithEvent(ctx, outbox.SectionUpdated)
 	err := s.r.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -91,3 +112,20 @@ func (s *service) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (s *service) Restore(ctx context.Context, id int) (domain.Section, error) {
+	// Only a soft deleted section can be restored
+	if _, err := s.r.GetByID(ctx, id); err == nil {
+		return domain.Section{}, softdelete.ErrNotDeleted
+	}
+
+	if err := s.r.Restore(outbox.WithEvent(ctx, outbox.SectionUpdated), id); err != nil {
+		return domain.Section{}, err
+	}
+
+	return s.r.GetByID(ctx, id)
+}
+
+func (s *service) HardDelete(ctx context.Context, id int) error {
+	return s.r.HardDelete(ctx, id)
+}
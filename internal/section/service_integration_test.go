@@ -30,7 +30,7 @@ func Test_GetAll_Service_Integration(t *testing.T) {
 	s := NewService(r)
 	ctx := context.Background()
 
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE deleted_at IS NULL;"
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
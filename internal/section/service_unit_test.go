@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,6 +29,11 @@ func (r *repositoryTest) GetByID(ctx context.Context, id int) (domain.Section, e
 	return args.Get(0).(domain.Section), args.Error(1)
 }
 
+func (r *repositoryTest) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+
 func (r *repositoryTest) GetAllReportProducts(ctx context.Context) ([]domain.SectionReportProducts, error) {
 	args := r.Called(ctx)
 	return args.Get(0).([]domain.SectionReportProducts), args.Error(1)
@@ -49,6 +55,18 @@ func (r *repositoryTest) Delete(ctx context.Context, id int) error {
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+func (r *repositoryTest) GetAllWithDeleted(ctx context.Context) ([]domain.Section, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+func (r *repositoryTest) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+func (r *repositoryTest) HardDelete(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
 
 // ------------------------------- READ ---------------------------------
 
@@ -281,7 +299,7 @@ func Test_Update_Service(t *testing.T) {
 		// arrange
 		r := NewRepositoryTest()
 		s := NewService(r)
-		r.On("Update", ctx, data).Return(nil)
+		r.On("Update", outbox.WithEvent(ctx, outbox.SectionUpdated), data).Return(nil)
 
 		// act
 		err := s.Update(ctx, data)
@@ -295,7 +313,7 @@ func Test_Update_Service(t *testing.T) {
 		// arrange
 		r := NewRepositoryTest()
 		s := NewService(r)
-		r.On("Update", ctx, data).Return(ErrInternal)
+		r.On("Update", outbox.WithEvent(ctx, outbox.SectionUpdated), data).Return(ErrInternal)
 
 		// act
 		err := s.Update(ctx, data)
@@ -314,7 +332,7 @@ func Test_Delete_Service(t *testing.T) {
 		// arrange
 		r := NewRepositoryTest()
 		s := NewService(r)
-		r.On("Delete", ctx, id).Return(nil)
+		r.On("Delete", outbox.WithEvent(ctx, outbox.SectionUpdated), id).Return(nil)
 
 		// act
 		err := s.Delete(ctx, id)
@@ -328,7 +346,7 @@ func Test_Delete_Service(t *testing.T) {
 		// arrange
 		r := NewRepositoryTest()
 		s := NewService(r)
-		r.On("Delete", ctx, id).Return(ErrInternal)
+		r.On("Delete", outbox.WithEvent(ctx, outbox.SectionUpdated), id).Return(ErrInternal)
 
 		// act
 		err := s.Delete(ctx, id)
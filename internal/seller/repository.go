@@ -6,7 +6,9 @@ import (
 	"errors"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 var (
@@ -14,22 +16,59 @@ var (
 	ErrDuplicated      = errors.New("duplicated locality")
 	ErrInvalidLocality = errors.New("invalid id locality")
 	ErrNotFound        = errors.New("seller not found")
-	QueryGetAll        = "SELECT id,cid,company_name,address,telephone,locality_id FROM sellers"
-	QueryGetById       = "SELECT id,cid,company_name,address,telephone,locality_id FROM sellers WHERE id=?;"
+	QueryGetAll        = "SELECT id,cid,company_name,address,telephone,locality_id FROM sellers WHERE deleted_at IS NULL"
+	QueryGetAllDeleted = "SELECT id,cid,company_name,address,telephone,locality_id FROM sellers"
+	QueryGetById       = "SELECT id,cid,company_name,address,telephone,locality_id FROM sellers WHERE id=? AND deleted_at IS NULL;"
 	QueryExistsCid     = "SELECT cid FROM sellers WHERE cid=?;"
 	QueryInsert        = "INSERT INTO sellers (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"
-	QueryUpdate        = "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=?"
-	QueryDelete        = "DELETE FROM sellers WHERE id=?"
+	QueryUpdate        = "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=?, locality_id=?, version=version+1 WHERE id=?"
+	QueryDelete        = "UPDATE sellers SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
+	QueryRestore       = "UPDATE sellers SET deleted_at=NULL, version=version+1 WHERE id=? AND deleted_at IS NOT NULL"
+	QueryHardDelete    = "DELETE FROM sellers WHERE id=?"
+	// each total is summed up on its own, joining them first would multiply the rows
+	QueryReport = "SELECT s.id, s.cid, s.company_name, COALESCE(p.products,0) AS product_count, COALESCE(b.units,0) AS units_in_stock, " +
+		"COALESCE(b.expired,0) AS expired_batches, COALESCE(o.units,0) AS units_sold, COALESCE(o.revenue,0) AS revenue " +
+		"FROM sellers AS s " +
+		"LEFT JOIN (SELECT id_seller, COUNT(*) AS products FROM products WHERE deleted_at IS NULL GROUP BY id_seller) AS p ON p.id_seller = s.id " +
+		"LEFT JOIN (SELECT p.id_seller, SUM(pb.current_quantity) AS units, SUM(pb.due_date < CURDATE()) AS expired " +
+		"FROM products_batches AS pb INNER JOIN products AS p ON p.id = pb.product_id GROUP BY p.id_seller) AS b ON b.id_seller = s.id " +
+		"LEFT JOIN (SELECT p.id_seller, COUNT(po.id) AS units, SUM(pr.sale_price) AS revenue " +
+		"FROM purchase_orders AS po INNER JOIN product_records AS pr ON pr.id = po.product_record_id INNER JOIN products AS p ON p.id = pr.product_id GROUP BY p.id_seller) AS o ON o.id_seller = s.id " +
+		"WHERE s.deleted_at IS NULL"
+	QueryCountSellers = "SELECT COUNT(*) FROM sellers WHERE deleted_at IS NULL;"
 )
 
+// reportSort maps the fields seller reports can be sorted by to their columns.
+var reportSort = map[string]string{
+	"id":              "s.id",
+	"cid":             "s.cid",
+	"company_name":    "s.company_name",
+	"product_count":   "product_count",
+	"units_in_stock":  "units_in_stock",
+	"expired_batches": "expired_batches",
+	"units_sold":      "units_sold",
+	"revenue":         "revenue",
+}
+
+// Dependents are the rows whose foreign keys block removing a seller for good.
+var Dependents = []softdelete.Reference{
+	{Name: "products", Table: "products", Column: "id_seller"},
+}
+
 // Repository encapsulates the storage of a Seller.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Seller, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Seller, error)
 	Get(ctx context.Context, id int) (domain.Seller, error)
 	Exists(ctx context.Context, cid int) bool
 	Save(ctx context.Context, s domain.Seller) (int, error)
 	Update(ctx context.Context, s domain.Seller) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	HardDelete(ctx context.Context, id int) error
+	GetReport(ctx context.Context, id int) (domain.SellerReport, error)
+	GetReports(ctx context.Context, q domain.SellerReportQuery) ([]domain.SellerReport, error)
+	Count(ctx context.Context) (int, error)
 }
 
 type repository struct {
@@ -43,7 +82,16 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	rows, err := r.db.Query(QueryGetAll)
+	return r.getAll(QueryGetAll)
+}
+
+// GetAllWithDeleted returns every seller, soft deleted ones included.
+func (r *repository) GetAllWithDeleted(ctx context.Context) ([]domain.Seller, error) {
+	return r.getAll(QueryGetAllDeleted)
+}
+
+func (r *repository) getAll(query string) ([]domain.Seller, error) {
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, ErrIntern
 	}
@@ -84,75 +132,188 @@ func (r *repository) Exists(ctx context.Context, cid int) bool {
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
-	stmt, err := r.db.Prepare(QueryInsert)
-	if err != nil {
-		return 0, ErrIntern
-	}
+	return r.mutate(ctx, audit.ActionCreate, nil, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryInsert)
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.Locality_id)
+		if err != nil {
+			driverErr, ok := err.(*mysql.MySQLError)
+			if !ok {
+				err = ErrIntern
+				return 0, err
+			}
+
+			switch driverErr.Number {
+			case 1452:
+				err = ErrInvalidLocality
+			default:
+				err = ErrIntern
+			}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.Locality_id)
-	if err != nil {
-		driverErr, ok := err.(*mysql.MySQLError)
-		if !ok {
-			err = ErrIntern
 			return 0, err
 		}
 
-		switch driverErr.Number {
-		case 1452:
-			err = ErrInvalidLocality
-		default:
-			err = ErrIntern
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, ErrIntern
 		}
 
-		return 0, err
-	}
+		return int(id), nil
+	})
+}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, ErrIntern
-	}
+func (r *repository) Update(ctx context.Context, s domain.Seller) error {
+	_, err := r.mutate(ctx, audit.ActionUpdate, s.ID, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryUpdate)
+		if err != nil {
+			return 0, ErrIntern
+		}
 
-	return int(id), nil
+		res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.Locality_id, s.ID)
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		_, err = res.RowsAffected()
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		return 0, nil
+	})
+	return err
 }
 
-func (r *repository) Update(ctx context.Context, s domain.Seller) error {
-	stmt, err := r.db.Prepare(QueryUpdate)
-	if err != nil {
+func (r *repository) Delete(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionDelete, id, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(QueryDelete)
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		return 0, rowAffected(res)
+	})
+	return err
+}
+
+// Restore clears the deletion mark of a soft deleted seller.
+func (r *repository) Restore(ctx context.Context, id int) error {
+	_, err := r.mutate(ctx, audit.ActionRestore, id, func(ex audit.Execer) (int, error) {
+		res, err := ex.Exec(QueryRestore, id)
+		if err != nil {
+			return 0, ErrIntern
+		}
+
+		return 0, rowAffected(res)
+	})
+	return err
+}
+
+// HardDelete removes the seller row, refusing while any Dependents point at it.
+func (r *repository) HardDelete(ctx context.Context, id int) error {
+	if err := softdelete.Dependents(ctx, r.db, id, Dependents...); err != nil {
+		if errors.Is(err, softdelete.ErrHasDependents) {
+			return err
+		}
 		return ErrIntern
 	}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.Locality_id, s.ID)
-	if err != nil {
-		return ErrIntern
+	_, err := r.mutate(ctx, audit.ActionHardDelete, id, func(ex audit.Execer) (int, error) {
+		res, err := ex.Exec(QueryHardDelete, id)
+		if err != nil {
+			if softdelete.IsReferenced(err) {
+				return 0, softdelete.ErrHasDependents
+			}
+			return 0, ErrIntern
+		}
+
+		return 0, rowAffected(res)
+	})
+	return err
+}
+
+// mutate runs fn as a change of the seller id recorded in the audit trail, a failure to record it being ErrIntern.
+func (r *repository) mutate(ctx context.Context, action string, id interface{}, fn func(ex audit.Execer) (int, error)) (int, error) {
+	newID, err := audit.Mutate(ctx, r.db, audit.Change{Table: "sellers", ID: id, Action: action}, fn)
+	if errors.Is(err, audit.ErrRecord) {
+		return 0, ErrIntern
 	}
+	return newID, err
+}
 
-	_, err = res.RowsAffected()
+// rowAffected returns ErrNotFound when the statement matched no seller.
+func rowAffected(res sql.Result) error {
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return ErrIntern
 	}
 
+	if affect < 1 {
+		return ErrNotFound
+	}
+
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(QueryDelete)
+// GetReport returns the report of a single seller.
+func (r *repository) GetReport(ctx context.Context, id int) (domain.SellerReport, error) {
+	row := r.db.QueryRowContext(ctx, QueryReport+" AND s.id = ?;", id)
+
+	s := domain.SellerReport{}
+	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.ProductCount, &s.UnitsInStock, &s.ExpiredBatches, &s.UnitsSold, &s.Revenue)
 	if err != nil {
-		return ErrIntern
+		if err == sql.ErrNoRows {
+			return domain.SellerReport{}, ErrNotFound
+		}
+		return domain.SellerReport{}, ErrIntern
 	}
 
-	res, err := stmt.Exec(id)
-	if err != nil {
-		return ErrIntern
+	return s, nil
+}
+
+// GetReports returns a page of seller reports, ties broken by seller id so pages do not overlap.
+// q must be validated: its sort field is one of reportSort and its page and size are positive.
+func (r *repository) GetReports(ctx context.Context, q domain.SellerReportQuery) ([]domain.SellerReport, error) {
+	direction := " ASC"
+	if q.Descending {
+		direction = " DESC"
 	}
+	query := QueryReport + " ORDER BY " + reportSort[q.Sort] + direction + ", s.id LIMIT ? OFFSET ?;"
 
-	affect, err := res.RowsAffected()
+	rows, err := r.db.QueryContext(ctx, query, q.PageSize, (q.Page-1)*q.PageSize)
 	if err != nil {
-		return ErrIntern
+		return nil, ErrIntern
 	}
+	defer rows.Close()
 
-	if affect < 1 {
-		return ErrNotFound
+	reports := []domain.SellerReport{}
+	for rows.Next() {
+		s := domain.SellerReport{}
+		if err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.ProductCount, &s.UnitsInStock, &s.ExpiredBatches, &s.UnitsSold, &s.Revenue); err != nil {
+			return nil, ErrIntern
+		}
+		reports = append(reports, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrIntern
 	}
 
-	return nil
+	return reports, nil
+}
+
+// Count returns how many sellers are not deleted.
+func (r *repository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, QueryCountSellers).Scan(&count); err != nil {
+		return 0, ErrIntern
+	}
+	return count, nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
@@ -428,4 +429,142 @@ func Test_Delete(t *testing.T) {
 		assert.Equal(t, ErrNotFound, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("OK recorded in the audit trail", func(t *testing.T) {
+		// arrange
+		snapshot := regexp.QuoteMeta("SELECT * FROM sellers WHERE id=?")
+		mock.ExpectBegin()
+		mock.ExpectQuery(snapshot).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(1, nil))
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryDelete)).ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(snapshot).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(1, "2023-03-01 10:00:00"))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("alice", "sellers", "1", audit.ActionDelete, `{"deleted_at":null}`, `{"deleted_at":"2023-03-01 10:00:00"}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		rp := NewRepository(db)
+
+		ctx := audit.WithActor(context.Background(), "alice")
+		// act
+		err = rp.Delete(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error recording the audit trail", func(t *testing.T) {
+		// arrange
+		mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+
+		rp := NewRepository(db)
+
+		ctx := audit.WithActor(context.Background(), "alice")
+		// act
+		err = rp.Delete(ctx, 1)
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetReport(t *testing.T) {
+	columns := []string{"id", "cid", "company_name", "product_count", "units_in_stock", "expired_batches", "units_sold", "revenue"}
+
+	t.Run("Ok", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expected := domain.SellerReport{ID: 1, CID: 1, CompanyName: "Mercado Libre", ProductCount: 3, UnitsInStock: 120, ExpiredBatches: 1, UnitsSold: 4, Revenue: 80.5}
+		row := mock.NewRows(columns).AddRow(1, 1, "Mercado Libre", 3, 120, 1, 4, 80.5)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryReport + " AND s.id = ?;")).WithArgs(1).WillReturnRows(row)
+
+		rp := NewRepository(db)
+
+		// act
+		report, err := rp.GetReport(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, report)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error not found", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryReport + " AND s.id = ?;")).WithArgs(9).WillReturnRows(mock.NewRows(columns))
+
+		rp := NewRepository(db)
+
+		// act
+		_, err = rp.GetReport(context.Background(), 9)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetReports(t *testing.T) {
+	t.Run("Ok sorted and paged", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := mock.NewRows([]string{"id", "cid", "company_name", "product_count", "units_in_stock", "expired_batches", "units_sold", "revenue"}).
+			AddRow(2, 2, "Coto", 1, 10, 0, 9, 300.0)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryReport+" ORDER BY revenue DESC, s.id LIMIT ? OFFSET ?;")).WithArgs(10, 20).WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		reports, err := rp.GetReports(context.Background(), domain.SellerReportQuery{Sort: "revenue", Descending: true, Page: 3, PageSize: 10})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.SellerReport{{ID: 2, CID: 2, CompanyName: "Coto", ProductCount: 1, UnitsInStock: 10, UnitsSold: 9, Revenue: 300}}, reports)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(QueryReport+" ORDER BY s.company_name ASC, s.id LIMIT ? OFFSET ?;")).WithArgs(20, 0).WillReturnError(sql.ErrConnDone)
+
+		rp := NewRepository(db)
+
+		// act
+		reports, err := rp.GetReports(context.Background(), domain.SellerReportQuery{Sort: "company_name", Page: 1, PageSize: 20})
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+		assert.Nil(t, reports)
+	})
+}
+
+func Test_Count(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(QueryCountSellers)).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(7))
+
+	rp := NewRepository(db)
+
+	count, err := rp.Count(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
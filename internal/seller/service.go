@@ -3,21 +3,35 @@ package seller
 import (
 	"context"
 	"errors"
+	"math"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 // Errors
 var (
 	ErrConflict = errors.New("error conflic")
+	ErrSort     = errors.New("sort must be one of id, cid, company_name, product_count, units_in_stock, expired_batches, units_sold or revenue")
+)
+
+// Report pages
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
 )
 
 type Service interface {
 	GetAll(context.Context) ([]domain.Seller, error)
+	GetAllWithDeleted(context.Context) ([]domain.Seller, error)
 	GetByID(context.Context, int) (domain.Seller, error)
 	Create(context.Context, domain.Seller) (int, error)
 	Update(context.Context, domain.Seller) error
 	Delete(context.Context, int) error
+	Restore(context.Context, int) (domain.Seller, error)
+	HardDelete(context.Context, int) error
+	GetReport(context.Context, int) (domain.SellerReport, error)
+	GetReports(context.Context, domain.SellerReportQuery) (domain.SellerReportPage, error)
 }
 
 type service struct {
@@ -39,6 +53,11 @@ func (service service) GetAll(ctx context.Context) (sellers []domain.Seller, err
 	return
 }
 
+// Returns all sellers, soft deleted ones included
+func (service service) GetAllWithDeleted(ctx context.Context) ([]domain.Seller, error) {
+	return service.repo.GetAllWithDeleted(ctx)
+}
+
 // returns seller specified by id parameter
 func (service service) GetByID(ctx context.Context, id int) (seller domain.Seller, err error) {
 	seller, err = service.repo.Get(ctx, id)
@@ -82,3 +101,62 @@ func (service service) Delete(ctx context.Context, id int) error {
 	}
 	return nil
 }
+
+// restores a soft deleted seller and returns it
+func (service service) Restore(ctx context.Context, id int) (domain.Seller, error) {
+	if _, err := service.repo.Get(ctx, id); err == nil {
+		return domain.Seller{}, softdelete.ErrNotDeleted
+	}
+	if err := service.repo.Restore(ctx, id); err != nil {
+		return domain.Seller{}, err
+	}
+	return service.repo.Get(ctx, id)
+}
+
+// removes seller with specified id for good
+func (service service) HardDelete(ctx context.Context, id int) error {
+	return service.repo.HardDelete(ctx, id)
+}
+
+// returns the catalog and sales report of the seller specified by id
+func (service service) GetReport(ctx context.Context, id int) (domain.SellerReport, error) {
+	report, err := service.repo.GetReport(ctx, id)
+	if err != nil {
+		return domain.SellerReport{}, err
+	}
+	report.Revenue = math.Round(report.Revenue*100) / 100
+	return report, nil
+}
+
+// returns a page of seller reports, sorted by seller id unless q says otherwise
+func (service service) GetReports(ctx context.Context, q domain.SellerReportQuery) (domain.SellerReportPage, error) {
+	if q.Sort == "" {
+		q.Sort = "id"
+	}
+	if _, ok := reportSort[q.Sort]; !ok {
+		return domain.SellerReportPage{}, ErrSort
+	}
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPageSize
+	}
+	if q.PageSize > MaxPageSize {
+		q.PageSize = MaxPageSize
+	}
+
+	total, err := service.repo.Count(ctx)
+	if err != nil {
+		return domain.SellerReportPage{}, err
+	}
+	reports, err := service.repo.GetReports(ctx, q)
+	if err != nil {
+		return domain.SellerReportPage{}, err
+	}
+	for i := range reports {
+		reports[i].Revenue = math.Round(reports[i].Revenue*100) / 100
+	}
+
+	return domain.SellerReportPage{Sellers: reports, Page: q.Page, PageSize: q.PageSize, Total: total}, nil
+}
@@ -43,6 +43,18 @@ func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
 	args := r.Mock.Called(ctx, id)
 	return args.Error(0)
 }
+func (r *RepositoryMock) GetReport(ctx context.Context, id int) (domain.SellerReport, error) {
+	args := r.Mock.Called(ctx, id)
+	return args.Get(0).(domain.SellerReport), args.Error(1)
+}
+func (r *RepositoryMock) GetReports(ctx context.Context, q domain.SellerReportQuery) ([]domain.SellerReport, error) {
+	args := r.Mock.Called(ctx, q)
+	return args.Get(0).([]domain.SellerReport), args.Error(1)
+}
+func (r *RepositoryMock) Count(ctx context.Context) (int, error) {
+	args := r.Mock.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
 
 func Test_GetAll_Seller(t *testing.T) {
 	repoMock := NewRepositoryMock()
@@ -272,3 +284,98 @@ func Test_DeleteSeller(t *testing.T) {
 		assert.True(t, repoMock.AssertExpectations(t))
 	})
 }
+
+func Test_GetReport_Seller(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK rounds the revenue", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("GetReport", ctx, 1).Return(domain.SellerReport{ID: 1, UnitsSold: 3, Revenue: 30.300000001}, nil)
+
+		// act
+		report, err := service.GetReport(ctx, 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SellerReport{ID: 1, UnitsSold: 3, Revenue: 30.3}, report)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("GetReport", ctx, 9).Return(domain.SellerReport{}, ErrNotFound)
+
+		// act
+		_, err := service.GetReport(ctx, 9)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func Test_GetReports_Seller(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK defaults", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		reports := []domain.SellerReport{{ID: 1}, {ID: 2}}
+		repoMock.On("Count", ctx).Return(2, nil)
+		repoMock.On("GetReports", ctx, domain.SellerReportQuery{Sort: "id", Page: 1, PageSize: DefaultPageSize}).Return(reports, nil)
+
+		// act
+		page, err := service.GetReports(ctx, domain.SellerReportQuery{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SellerReportPage{Sellers: reports, Page: 1, PageSize: DefaultPageSize, Total: 2}, page)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("OK page size capped", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Count", ctx).Return(0, nil)
+		repoMock.On("GetReports", ctx, domain.SellerReportQuery{Sort: "revenue", Descending: true, Page: 3, PageSize: MaxPageSize}).Return([]domain.SellerReport{}, nil)
+
+		// act
+		page, err := service.GetReports(ctx, domain.SellerReportQuery{Sort: "revenue", Descending: true, Page: 3, PageSize: 1000})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, MaxPageSize, page.PageSize)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Invalid sort", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+
+		// act
+		_, err := service.GetReports(ctx, domain.SellerReportQuery{Sort: "telephone"})
+
+		// assert
+		assert.Equal(t, ErrSort, err)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		// arrange
+		repoMock := NewRepositoryMock()
+		service := NewService(repoMock)
+		repoMock.On("Count", ctx).Return(0, ErrIntern)
+
+		// act
+		_, err := service.GetReports(ctx, domain.SellerReportQuery{})
+
+		// assert
+		assert.Equal(t, ErrIntern, err)
+	})
+}
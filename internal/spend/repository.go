@@ -0,0 +1,162 @@
+// Package spend reports what buyers spend on purchase orders, each order costing the sale price of the
+// product record it was placed for.
+package spend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Errors
+var (
+	ErrInternal      = errors.New("error: internal error")
+	ErrBuyerNotFound = errors.New("error: buyer id does not exists")
+	ErrDateRange     = errors.New("error: from must not be after to")
+)
+
+// Queries
+// the period filters go in the join conditions, so buyers without orders in it still show up
+var (
+	QuerySpend = "SELECT b.id, b.card_number_id, b.first_name, b.last_name, COUNT(po.id), COALESCE(SUM(pr.sale_price),0), MIN(po.order_date), MAX(po.order_date) " +
+		"FROM buyers AS b " +
+		"LEFT JOIN purchase_orders AS po ON po.buyer_id = b.id%s " +
+		"LEFT JOIN product_records AS pr ON pr.id = po.product_record_id"
+	QuerySpendGroup = " GROUP BY b.id, b.card_number_id, b.first_name, b.last_name ORDER BY b.id;"
+	QueryProducts   = "SELECT po.buyer_id, p.id, p.description, COUNT(po.id), SUM(pr.sale_price) " +
+		"FROM purchase_orders AS po " +
+		"INNER JOIN product_records AS pr ON pr.id = po.product_record_id " +
+		"INNER JOIN products AS p ON p.id = pr.product_id " +
+		"WHERE 1=1"
+	QueryProductsGroup = " GROUP BY po.buyer_id, p.id, p.description ORDER BY po.buyer_id, SUM(pr.sale_price) DESC, COUNT(po.id) DESC, p.id;"
+	// a buyer's cohort is the month of their first order ever, whatever the period
+	QueryCohorts = "SELECT f.cohort, COUNT(DISTINCT f.buyer_id), COUNT(DISTINCT po.buyer_id), COUNT(po.id), COALESCE(SUM(pr.sale_price),0) " +
+		"FROM (SELECT buyer_id, DATE_FORMAT(MIN(order_date), '%%Y-%%m') AS cohort FROM purchase_orders GROUP BY buyer_id) AS f " +
+		"LEFT JOIN purchase_orders AS po ON po.buyer_id = f.buyer_id%s " +
+		"LEFT JOIN product_records AS pr ON pr.id = po.product_record_id"
+	QueryCohortsGroup = " GROUP BY f.cohort ORDER BY f.cohort;"
+)
+
+type Repository interface {
+	GetSpend(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerSpend, error)
+	GetProducts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerProductSpend, error)
+	GetCohorts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerCohort, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// period returns the conditions bounding po.order_date and their arguments.
+func period(f domain.BuyerSpendFilter) (string, []interface{}) {
+	var conditions string
+	var args []interface{}
+	if f.From != "" {
+		conditions += " AND po.order_date >= ?"
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		conditions += " AND po.order_date <= ?"
+		args = append(args, f.To)
+	}
+	return conditions, args
+}
+
+// GetSpend returns the orders and spend of every buyer, or of f.BuyerID alone, in the period.
+func (r *repository) GetSpend(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerSpend, error) {
+	conditions, args := period(f)
+	query := fmt.Sprintf(QuerySpend, conditions)
+	if f.BuyerID != 0 {
+		query += " WHERE b.id = ?"
+		args = append(args, f.BuyerID)
+	}
+	query += QuerySpendGroup
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	buyers := []domain.BuyerSpend{}
+	for rows.Next() {
+		b := domain.BuyerSpend{}
+		var first, last sql.NullString
+		if err := rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.PurchaseOrderCount, &b.TotalSpend, &first, &last); err != nil {
+			return nil, ErrInternal
+		}
+		b.FirstOrderDate, b.LastOrderDate = first.String, last.String
+		buyers = append(buyers, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return buyers, nil
+}
+
+// GetProducts returns what each buyer spent per product in the period, ordered by buyer and then by spend.
+func (r *repository) GetProducts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerProductSpend, error) {
+	conditions, args := period(f)
+	query := QueryProducts + conditions
+	if f.BuyerID != 0 {
+		query += " AND po.buyer_id = ?"
+		args = append(args, f.BuyerID)
+	}
+	query += QueryProductsGroup
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	var products []domain.BuyerProductSpend
+	for rows.Next() {
+		p := domain.BuyerProductSpend{}
+		if err := rows.Scan(&p.BuyerID, &p.ProductID, &p.Description, &p.PurchaseOrderCount, &p.TotalSpend); err != nil {
+			return nil, ErrInternal
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return products, nil
+}
+
+// GetCohorts returns the buyers who ever ordered grouped by the month of their first order, with the
+// orders and spend of the period.
+func (r *repository) GetCohorts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerCohort, error) {
+	conditions, args := period(f)
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(QueryCohorts, conditions)+QueryCohortsGroup, args...)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	cohorts := []domain.BuyerCohort{}
+	for rows.Next() {
+		c := domain.BuyerCohort{}
+		if err := rows.Scan(&c.Cohort, &c.Buyers, &c.ActiveBuyers, &c.PurchaseOrderCount, &c.TotalSpend); err != nil {
+			return nil, ErrInternal
+		}
+		cohorts = append(cohorts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrInternal
+	}
+
+	return cohorts, nil
+}
@@ -0,0 +1,135 @@
+package spend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Repository_GetSpend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK buyers without orders have no dates", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := fmt.Sprintf(QuerySpend, " AND po.order_date >= ? AND po.order_date <= ?") + " WHERE b.id = ?" + QuerySpendGroup
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "orders", "spend", "first", "last"}).
+			AddRow(1, "C1", "Ana", "Diaz", 2, 80.5, "2023-01-05", "2023-02-01").
+			AddRow(2, "C2", "Luis", "Paz", 0, 0, nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("2023-01-01", "2023-03-31", 1).WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		buyers, err := repo.GetSpend(ctx, domain.BuyerSpendFilter{BuyerID: 1, From: "2023-01-01", To: "2023-03-31"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BuyerSpend{
+			{ID: 1, CardNumberID: "C1", FirstName: "Ana", LastName: "Diaz", PurchaseOrderCount: 2, TotalSpend: 80.5, FirstOrderDate: "2023-01-05", LastOrderDate: "2023-02-01"},
+			{ID: 2, CardNumberID: "C2", FirstName: "Luis", LastName: "Paz"},
+		}, buyers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(QuerySpend, "") + QuerySpendGroup)).WillReturnError(errors.New("connection lost"))
+		repo := NewRepository(db)
+
+		// act
+		buyers, err := repo.GetSpend(ctx, domain.BuyerSpendFilter{})
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, buyers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Repository_GetProducts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := QueryProducts + " AND po.order_date <= ? AND po.buyer_id = ?" + QueryProductsGroup
+		rows := sqlmock.NewRows([]string{"buyer_id", "id", "description", "orders", "spend"}).
+			AddRow(1, 7, "peas", 2, 50.0).
+			AddRow(1, 8, "corn", 1, 30.5)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("2023-03-31", 1).WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		products, err := repo.GetProducts(ctx, domain.BuyerSpendFilter{BuyerID: 1, To: "2023-03-31"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BuyerProductSpend{
+			{BuyerID: 1, ProductID: 7, Description: "peas", PurchaseOrderCount: 2, TotalSpend: 50},
+			{BuyerID: 1, ProductID: 8, Description: "corn", PurchaseOrderCount: 1, TotalSpend: 30.5},
+		}, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_Repository_GetCohorts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		query := "SELECT f.cohort, COUNT(DISTINCT f.buyer_id), COUNT(DISTINCT po.buyer_id), COUNT(po.id), COALESCE(SUM(pr.sale_price),0) " +
+			"FROM (SELECT buyer_id, DATE_FORMAT(MIN(order_date), '%Y-%m') AS cohort FROM purchase_orders GROUP BY buyer_id) AS f " +
+			"LEFT JOIN purchase_orders AS po ON po.buyer_id = f.buyer_id AND po.order_date >= ? " +
+			"LEFT JOIN product_records AS pr ON pr.id = po.product_record_id GROUP BY f.cohort ORDER BY f.cohort;"
+		rows := sqlmock.NewRows([]string{"cohort", "buyers", "active", "orders", "spend"}).
+			AddRow("2022-11", 3, 1, 2, 100.0)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("2023-01-01").WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		cohorts, err := repo.GetCohorts(ctx, domain.BuyerSpendFilter{From: "2023-01-01"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BuyerCohort{{Cohort: "2022-11", Buyers: 3, ActiveBuyers: 1, PurchaseOrderCount: 2, TotalSpend: 100}}, cohorts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		// arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"cohort", "buyers"}).AddRow("2022-11", 3)
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(QueryCohorts, "") + QueryCohortsGroup)).WillReturnRows(rows)
+		repo := NewRepository(db)
+
+		// act
+		cohorts, err := repo.GetCohorts(ctx, domain.BuyerSpendFilter{})
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, cohorts)
+	})
+}
@@ -0,0 +1,99 @@
+package spend
+
+import (
+	"context"
+	"math"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+// Top products listed per buyer
+const (
+	DefaultTopProducts = 3
+	MaxTopProducts     = 20
+)
+
+type Service interface {
+	GetSpend(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerSpend, error)
+	GetCohorts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerCohort, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+// GetSpend returns the spend of the buyers along with the products they spent the most on.
+func (s *service) GetSpend(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerSpend, error) {
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return nil, ErrDateRange
+	}
+	if f.TopProducts <= 0 {
+		f.TopProducts = DefaultTopProducts
+	}
+	if f.TopProducts > MaxTopProducts {
+		f.TopProducts = MaxTopProducts
+	}
+
+	buyers, err := s.repository.GetSpend(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if f.BuyerID != 0 && len(buyers) == 0 {
+		return nil, ErrBuyerNotFound
+	}
+
+	products, err := s.repository.GetProducts(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	top := map[int][]domain.BuyerProductSpend{}
+	for _, p := range products {
+		if len(top[p.BuyerID]) < f.TopProducts {
+			p.TotalSpend = cents(p.TotalSpend)
+			top[p.BuyerID] = append(top[p.BuyerID], p)
+		}
+	}
+
+	for i := range buyers {
+		b := &buyers[i]
+		b.TotalSpend = cents(b.TotalSpend)
+		if b.PurchaseOrderCount > 0 {
+			b.AverageOrderValue = cents(b.TotalSpend / float64(b.PurchaseOrderCount))
+		}
+		b.TopProducts = top[b.ID]
+		if b.TopProducts == nil {
+			b.TopProducts = []domain.BuyerProductSpend{}
+		}
+	}
+	return buyers, nil
+}
+
+func (s *service) GetCohorts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerCohort, error) {
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return nil, ErrDateRange
+	}
+
+	cohorts, err := s.repository.GetCohorts(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cohorts {
+		c := &cohorts[i]
+		c.TotalSpend = cents(c.TotalSpend)
+		if c.Buyers > 0 {
+			c.AverageSpendPerBuyer = cents(c.TotalSpend / float64(c.Buyers))
+		}
+	}
+	return cohorts, nil
+}
+
+// cents rounds an amount to two decimals, sale prices being float columns.
+func cents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
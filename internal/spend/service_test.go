@@ -0,0 +1,164 @@
+package spend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetSpend(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerSpend, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.BuyerSpend), args.Error(1)
+}
+func (r *RepositoryMock) GetProducts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerProductSpend, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.BuyerProductSpend), args.Error(1)
+}
+func (r *RepositoryMock) GetCohorts(ctx context.Context, f domain.BuyerSpendFilter) ([]domain.BuyerCohort, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.BuyerCohort), args.Error(1)
+}
+
+func Test_GetSpend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK averages and keeps the top products of each buyer", func(t *testing.T) {
+		// arrange
+		filter := domain.BuyerSpendFilter{From: "2023-01-01", TopProducts: 2}
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSpend", ctx, filter).Return([]domain.BuyerSpend{
+			{ID: 1, FirstName: "Ana", PurchaseOrderCount: 3, TotalSpend: 100.000001, FirstOrderDate: "2023-01-05", LastOrderDate: "2023-02-01"},
+			{ID: 2, FirstName: "Luis"},
+		}, nil)
+		repoMock.On("GetProducts", ctx, filter).Return([]domain.BuyerProductSpend{
+			{BuyerID: 1, ProductID: 7, Description: "peas", PurchaseOrderCount: 1, TotalSpend: 50},
+			{BuyerID: 1, ProductID: 8, Description: "corn", PurchaseOrderCount: 1, TotalSpend: 30},
+			{BuyerID: 1, ProductID: 9, Description: "rice", PurchaseOrderCount: 1, TotalSpend: 20},
+		}, nil)
+		service := NewService(repoMock)
+
+		// act
+		buyers, err := service.GetSpend(ctx, filter)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BuyerSpend{
+			{ID: 1, FirstName: "Ana", PurchaseOrderCount: 3, TotalSpend: 100, AverageOrderValue: 33.33, FirstOrderDate: "2023-01-05", LastOrderDate: "2023-02-01", TopProducts: []domain.BuyerProductSpend{
+				{BuyerID: 1, ProductID: 7, Description: "peas", PurchaseOrderCount: 1, TotalSpend: 50},
+				{BuyerID: 1, ProductID: 8, Description: "corn", PurchaseOrderCount: 1, TotalSpend: 30},
+			}},
+			{ID: 2, FirstName: "Luis", TopProducts: []domain.BuyerProductSpend{}},
+		}, buyers)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("OK top products defaults and caps", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSpend", ctx, domain.BuyerSpendFilter{TopProducts: DefaultTopProducts}).Return([]domain.BuyerSpend{}, nil)
+		repoMock.On("GetProducts", ctx, domain.BuyerSpendFilter{TopProducts: DefaultTopProducts}).Return([]domain.BuyerProductSpend{}, nil)
+		repoMock.On("GetSpend", ctx, domain.BuyerSpendFilter{TopProducts: MaxTopProducts}).Return([]domain.BuyerSpend{}, nil)
+		repoMock.On("GetProducts", ctx, domain.BuyerSpendFilter{TopProducts: MaxTopProducts}).Return([]domain.BuyerProductSpend{}, nil)
+		service := NewService(repoMock)
+
+		// act
+		_, errDefault := service.GetSpend(ctx, domain.BuyerSpendFilter{})
+		_, errCapped := service.GetSpend(ctx, domain.BuyerSpendFilter{TopProducts: 500})
+
+		// assert
+		assert.NoError(t, errDefault)
+		assert.NoError(t, errCapped)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("buyer not found", func(t *testing.T) {
+		// arrange
+		filter := domain.BuyerSpendFilter{BuyerID: 9, TopProducts: DefaultTopProducts}
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSpend", ctx, filter).Return([]domain.BuyerSpend{}, nil)
+		service := NewService(repoMock)
+
+		// act
+		_, err := service.GetSpend(ctx, domain.BuyerSpendFilter{BuyerID: 9})
+
+		// assert
+		assert.Equal(t, ErrBuyerNotFound, err)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+
+		// act
+		_, err := service.GetSpend(ctx, domain.BuyerSpendFilter{From: "2023-02-01", To: "2023-01-01"})
+
+		// assert
+		assert.Equal(t, ErrDateRange, err)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		filter := domain.BuyerSpendFilter{TopProducts: DefaultTopProducts}
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetSpend", ctx, filter).Return([]domain.BuyerSpend{{ID: 1}}, nil)
+		repoMock.On("GetProducts", ctx, filter).Return([]domain.BuyerProductSpend{}, ErrInternal)
+		service := NewService(repoMock)
+
+		// act
+		buyers, err := service.GetSpend(ctx, domain.BuyerSpendFilter{})
+
+		// assert
+		assert.Equal(t, ErrInternal, err)
+		assert.Nil(t, buyers)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func Test_GetCohorts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK averages the spend per buyer", func(t *testing.T) {
+		// arrange
+		filter := domain.BuyerSpendFilter{From: "2023-01-01", To: "2023-12-31"}
+		repoMock := &RepositoryMock{}
+		repoMock.On("GetCohorts", ctx, filter).Return([]domain.BuyerCohort{
+			{Cohort: "2022-11", Buyers: 3, ActiveBuyers: 1, PurchaseOrderCount: 2, TotalSpend: 100},
+			{Cohort: "2023-01", Buyers: 2, ActiveBuyers: 2, PurchaseOrderCount: 5, TotalSpend: 250.5},
+		}, nil)
+		service := NewService(repoMock)
+
+		// act
+		cohorts, err := service.GetCohorts(ctx, filter)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BuyerCohort{
+			{Cohort: "2022-11", Buyers: 3, ActiveBuyers: 1, PurchaseOrderCount: 2, TotalSpend: 100, AverageSpendPerBuyer: 33.33},
+			{Cohort: "2023-01", Buyers: 2, ActiveBuyers: 2, PurchaseOrderCount: 5, TotalSpend: 250.5, AverageSpendPerBuyer: 125.25},
+		}, cohorts)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("from after to", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		service := NewService(repoMock)
+
+		// act
+		_, err := service.GetCohorts(ctx, domain.BuyerSpendFilter{From: "2023-02-01", To: "2023-01-01"})
+
+		// assert
+		assert.Equal(t, ErrDateRange, err)
+		repoMock.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,298 @@
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/cache"
+)
+
+// Errors
+var (
+	ErrInternal             = errors.New("error: internal error")
+	ErrTransferNotFound     = errors.New("error: transfer id does not exists")
+	ErrBatchNotFound        = errors.New("error: product batch id does not exists")
+	ErrSectionNotFound      = errors.New("error: destination section id does not exists")
+	ErrEmployeeNotFound     = errors.New("error: employee id does not exists")
+	ErrSameSection          = errors.New("error: batch is already stored in the destination section")
+	ErrInsufficientQuantity = errors.New("error: quantity exceeds the batch current quantity")
+	ErrProductTypeMismatch  = errors.New("error: destination section does not store this product type")
+	ErrCapacityExceeded     = errors.New("error: destination section does not have enough capacity")
+	ErrInvalidQuantity      = errors.New("error: quantity must be greater than zero")
+)
+
+// Queries
+var (
+	QueryGetAll  = "SELECT id, transfer_date, product_batch_id, destination_batch_id, origin_section_id, destination_section_id, quantity, employee_id FROM transfers;"
+	QueryGetByID = "SELECT id, transfer_date, product_batch_id, destination_batch_id, origin_section_id, destination_section_id, quantity, employee_id FROM transfers WHERE id=?;"
+	// rows read inside the transfer are locked until it commits
+	QueryLockBatch = "SELECT pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.manufacturing_date, pb.manufacturing_hour, pb.minumum_temperature, pb.product_id, pb.section_id, p.id_product_type " +
+		"FROM products_batches AS pb INNER JOIN products AS p ON p.id = pb.product_id WHERE pb.id=? FOR UPDATE;"
+	QueryLockSection    = "SELECT current_capacity, maximum_capacity, id_product_type FROM sections WHERE id=? AND deleted_at IS NULL FOR UPDATE;"
+	QueryExistsEmployee = "SELECT id FROM employees WHERE id=? AND deleted_at IS NULL;"
+	QueryMoveBatch      = "UPDATE products_batches SET section_id=? WHERE id=?;"
+	QueryReduceBatch    = "UPDATE products_batches SET current_quantity=current_quantity-? WHERE id=?;"
+	// a plain read, the unique index on batch_number settles concurrent splits taking the same number
+	QueryNextBatch      = "SELECT COALESCE(MAX(batch_number),0)+1 FROM products_batches;"
+	QuerySplitBatch     = "INSERT INTO products_batches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minumum_temperature, product_id, section_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	QueryUpdateCapacity = "UPDATE sections SET current_capacity=current_capacity+?, version=version+1 WHERE id=?;"
+	QueryInsert         = "INSERT INTO transfers (transfer_date, product_batch_id, destination_batch_id, origin_section_id, destination_section_id, quantity, employee_id) VALUES (?, ?, ?, ?, ?, ?, ?);"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.Transfer, error)
+	Get(ctx context.Context, id int) (domain.Transfer, error)
+	Transfer(ctx context.Context, t domain.Transfer) (domain.Transfer, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// batch is the locked state of the origin batch.
+type batch struct {
+	domain.ProductBatches
+	productTypeID int
+}
+
+// splitAttempts is how many batch numbers a split tries before giving up, each one past the last
+// taken by a concurrent split.
+const splitAttempts = 5
+
+// section is the locked state of the destination section.
+type section struct {
+	domain.Section
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.Transfer, error) {
+	rows, err := r.db.QueryContext(ctx, QueryGetAll)
+	if err != nil {
+		return nil, ErrInternal
+	}
+	defer rows.Close()
+
+	transfers := []domain.Transfer{}
+	for rows.Next() {
+		t := domain.Transfer{}
+		if err := rows.Scan(&t.ID, &t.TransferDate, &t.ProductBatchID, &t.DestinationBatchID, &t.OriginSectionID, &t.DestinationSectionID, &t.Quantity, &t.EmployeeID); err != nil {
+			return nil, ErrInternal
+		}
+		transfers = append(transfers, t)
+	}
+
+	return transfers, nil
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Transfer, error) {
+	row := r.db.QueryRowContext(ctx, QueryGetByID, id)
+
+	t := domain.Transfer{}
+	err := row.Scan(&t.ID, &t.TransferDate, &t.ProductBatchID, &t.DestinationBatchID, &t.OriginSectionID, &t.DestinationSectionID, &t.Quantity, &t.EmployeeID)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return domain.Transfer{}, ErrTransferNotFound
+		default:
+			return domain.Transfer{}, ErrInternal
+		}
+	}
+
+	return t, nil
+}
+
+// Transfer moves t.Quantity units of the batch to the destination section and records the
+// transfer document. Moving every unit moves the whole batch. A partial move splits the batch:
+// the moved units become a new batch in the destination section, announced like any created batch.
+// The origin batch is announced as updated, and so are both sections, whose capacity changes.
+// Every step runs in a single transaction, so either all of it is applied or none, and every row it
+// changes is recorded in the audit trail and the outbox of that transaction.
+func (r *repository) Transfer(ctx context.Context, t domain.Transfer) (domain.Transfer, error) {
+	if t.Quantity <= 0 {
+		return domain.Transfer{}, ErrInvalidQuantity
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Transfer{}, ErrInternal
+	}
+	defer tx.Rollback()
+
+	b, err := lockBatch(ctx, tx, t.ProductBatchID)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	s, err := lockSection(ctx, tx, t.DestinationSectionID)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	var employeeID int
+	if err := tx.QueryRowContext(ctx, QueryExistsEmployee, t.EmployeeID).Scan(&employeeID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Transfer{}, ErrEmployeeNotFound
+		}
+		return domain.Transfer{}, ErrInternal
+	}
+
+	switch {
+	case b.SectionID == t.DestinationSectionID:
+		return domain.Transfer{}, ErrSameSection
+	case t.Quantity > b.CurrentQuantity:
+		return domain.Transfer{}, ErrInsufficientQuantity
+	case b.productTypeID != s.ProductTypeID:
+		return domain.Transfer{}, ErrProductTypeMismatch
+	case !s.Fits(t.Quantity):
+		return domain.Transfer{}, ErrCapacityExceeded
+	}
+	t.OriginSectionID = b.SectionID
+
+	if t.Quantity == b.CurrentQuantity {
+		// whole batch: it keeps its id and only changes section
+		if err := update(ctx, tx, outbox.ProductBatchUpdated, "products_batches", t.ProductBatchID, QueryMoveBatch, t.DestinationSectionID, t.ProductBatchID); err != nil {
+			return domain.Transfer{}, err
+		}
+		t.DestinationBatchID = t.ProductBatchID
+	} else {
+		t.DestinationBatchID, err = splitBatch(ctx, tx, b, t)
+		if err != nil {
+			return domain.Transfer{}, err
+		}
+	}
+
+	if err := update(ctx, tx, outbox.SectionUpdated, "sections", t.OriginSectionID, QueryUpdateCapacity, -t.Quantity, t.OriginSectionID); err != nil {
+		return domain.Transfer{}, err
+	}
+	if err := update(ctx, tx, outbox.SectionUpdated, "sections", t.DestinationSectionID, QueryUpdateCapacity, t.Quantity, t.DestinationSectionID); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	res, err := tx.ExecContext(ctx, QueryInsert, t.TransferDate, t.ProductBatchID, t.DestinationBatchID, t.OriginSectionID, t.DestinationSectionID, t.Quantity, t.EmployeeID)
+	if err != nil {
+		return domain.Transfer{}, ErrInternal
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return domain.Transfer{}, ErrInternal
+	}
+	t.ID = int(id)
+
+	if err := audit.RecordAfter(ctx, tx, audit.Change{Table: "transfers", ID: t.ID, Action: audit.ActionCreate}, nil); err != nil {
+		return domain.Transfer{}, ErrInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Transfer{}, ErrInternal
+	}
+	// the batch moved, so did the quantities reported per section
+	_ = cache.Invalidate(ctx, "transfers", "products_batches", "sections")
+
+	return t, nil
+}
+
+func lockBatch(ctx context.Context, tx *sql.Tx, id int) (batch, error) {
+	b := batch{}
+	b.ID = id
+	err := tx.QueryRowContext(ctx, QueryLockBatch, id).Scan(&b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinumumTemperature, &b.ProductID, &b.SectionID, &b.productTypeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return batch{}, ErrBatchNotFound
+		}
+		return batch{}, ErrInternal
+	}
+	return b, nil
+}
+
+func lockSection(ctx context.Context, tx *sql.Tx, id int) (section, error) {
+	s := section{}
+	s.ID = id
+	err := tx.QueryRowContext(ctx, QueryLockSection, id).Scan(&s.CurrentCapacity, &s.MaximumCapacity, &s.ProductTypeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return section{}, ErrSectionNotFound
+		}
+		return section{}, ErrInternal
+	}
+	return s, nil
+}
+
+// splitBatch takes t.Quantity units out of the origin batch into a new batch stored in the
+// destination section, emits its ProductBatchCreated event and returns the new batch id.
+func splitBatch(ctx context.Context, tx *sql.Tx, b batch, t domain.Transfer) (int, error) {
+	if err := update(ctx, tx, outbox.ProductBatchUpdated, "products_batches", b.ID, QueryReduceBatch, t.Quantity, b.ID); err != nil {
+		return 0, err
+	}
+
+	var batchNumber int
+	if err := tx.QueryRowContext(ctx, QueryNextBatch).Scan(&batchNumber); err != nil {
+		return 0, ErrInternal
+	}
+
+	// a duplicate number only fails the insert, not the transaction, so the next one is tried
+	var res sql.Result
+	var err error
+	for attempt := 0; attempt < splitAttempts; attempt++ {
+		res, err = tx.ExecContext(ctx, QuerySplitBatch, batchNumber, t.Quantity, b.CurrentTemperature, b.DueDate, t.Quantity, b.ManufacturingDate, b.ManufacturingHour, b.MinumumTemperature, b.ProductID, t.DestinationSectionID)
+		if mysqlErr, ok := err.(*mysql.MySQLError); !ok || mysqlErr.Number != 1062 {
+			break
+		}
+		batchNumber++
+	}
+	if err != nil {
+		return 0, ErrInternal
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, ErrInternal
+	}
+
+	created, err := audit.Snapshot(ctx, tx, "products_batches", id)
+	if err != nil {
+		return 0, ErrInternal
+	}
+	if err := audit.Record(ctx, tx, audit.Change{Table: "products_batches", ID: id, Action: audit.ActionCreate}, nil, created); err != nil {
+		return 0, ErrInternal
+	}
+	if err := outbox.Write(outbox.WithEvent(ctx, outbox.ProductBatchCreated), tx, "products_batches", id, created); err != nil {
+		return 0, ErrInternal
+	}
+
+	return int(id), nil
+}
+
+// update runs query, a statement changing the row of table with the given id, records the change in
+// the audit trail of tx and writes an event of eventType about the row it left to the outbox of tx.
+// The row is only read before the change when there is an actor to record.
+func update(ctx context.Context, tx *sql.Tx, eventType, table string, id int, query string, args ...interface{}) error {
+	var before map[string]interface{}
+	if _, ok := audit.Actor(ctx); ok {
+		var err error
+		if before, err = audit.Snapshot(ctx, tx, table, id); err != nil {
+			return ErrInternal
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return ErrInternal
+	}
+	after, err := audit.Snapshot(ctx, tx, table, id)
+	if err != nil {
+		return ErrInternal
+	}
+	if err := audit.Record(ctx, tx, audit.Change{Table: table, ID: id, Action: audit.ActionUpdate}, before, after); err != nil {
+		return ErrInternal
+	}
+	if err := outbox.Write(outbox.WithEvent(ctx, eventType), tx, table, id, after); err != nil {
+		return ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,359 @@
+package transfer
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+var batchColumns = []string{"batch_number", "current_quantity", "current_temperature", "due_date", "manufacturing_date", "manufacturing_hour", "minumum_temperature", "product_id", "section_id", "id_product_type"}
+
+// expectLocks sets up the reads done at the start of every transfer: batch 7 with 100 units
+// of product type 2 in section 1, and destination section 3.
+func expectLocks(mock sqlmock.Sqlmock, destinationType, currentCapacity, maximumCapacity int) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(QueryLockBatch)).WithArgs(7).
+		WillReturnRows(mock.NewRows(batchColumns).AddRow(700, 100, 5, "2024-01-01", "2023-01-01", "10:00:00", 2, 4, 1, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(QueryLockSection)).WithArgs(3).
+		WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity", "id_product_type"}).AddRow(currentCapacity, maximumCapacity, destinationType))
+	mock.ExpectQuery(regexp.QuoteMeta(QueryExistsEmployee)).WithArgs(5).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(5))
+}
+
+// expectUpdated sets up the read of the row of table a transfer changed and the event announcing it.
+func expectUpdated(mock sqlmock.Sqlmock, eventType, table string, id int, row *sqlmock.Rows, payload string) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM " + table + " WHERE id=?")).WithArgs(id).WillReturnRows(row)
+	mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+		WithArgs(eventType, table, strconv.Itoa(id), payload).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func Test_Transfer(t *testing.T) {
+	request := domain.Transfer{TransferDate: "2023-05-01 10:00:00", ProductBatchID: 7, DestinationSectionID: 3, Quantity: 100, EmployeeID: 5}
+
+	t.Run("Whole batch moves section", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		expectLocks(mock, 2, 0, 100)
+		mock.ExpectExec(regexp.QuoteMeta(QueryMoveBatch)).WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.ProductBatchUpdated, "products_batches", 7, mock.NewRows([]string{"id", "section_id"}).AddRow(7, 3), `{"id":7,"section_id":3}`)
+		// capacity changes bump the section version, so a concurrent If-Match edit cannot overwrite them
+		updateCapacity := regexp.QuoteMeta("UPDATE sections SET current_capacity=current_capacity+?, version=version+1 WHERE id=?;")
+		mock.ExpectExec(updateCapacity).WithArgs(-100, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.SectionUpdated, "sections", 1, mock.NewRows([]string{"id", "current_capacity"}).AddRow(1, 0), `{"current_capacity":0,"id":1}`)
+		mock.ExpectExec(updateCapacity).WithArgs(100, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.SectionUpdated, "sections", 3, mock.NewRows([]string{"id", "current_capacity"}).AddRow(3, 100), `{"current_capacity":100,"id":3}`)
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WithArgs("2023-05-01 10:00:00", 7, 7, 1, 3, 100, 5).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		rp := NewRepository(db)
+
+		// act
+		result, err := rp.Transfer(context.Background(), request)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Transfer{ID: 1, TransferDate: "2023-05-01 10:00:00", ProductBatchID: 7, DestinationBatchID: 7, OriginSectionID: 1, DestinationSectionID: 3, Quantity: 100, EmployeeID: 5}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Partial quantity splits the batch", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		partial := request
+		partial.Quantity = 30
+		expectLocks(mock, 2, 50, 100)
+		mock.ExpectExec(regexp.QuoteMeta(QueryReduceBatch)).WithArgs(30, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.ProductBatchUpdated, "products_batches", 7, mock.NewRows([]string{"id", "current_quantity"}).AddRow(7, 70), `{"current_quantity":70,"id":7}`)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryNextBatch)).WillReturnRows(mock.NewRows([]string{"next"}).AddRow(901))
+		mock.ExpectExec(regexp.QuoteMeta(QuerySplitBatch)).WithArgs(901, 30, 5, "2024-01-01", 30, "2023-01-01", "10:00:00", 2, 4, 3).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(12).
+			WillReturnRows(mock.NewRows([]string{"id", "batch_number", "current_quantity"}).AddRow(12, 901, 30))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.ProductBatchCreated, "products_batches", "12", `{"batch_number":901,"current_quantity":30,"id":12}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdateCapacity)).WithArgs(-30, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.SectionUpdated, "sections", 1, mock.NewRows([]string{"id", "current_capacity"}).AddRow(1, 70), `{"current_capacity":70,"id":1}`)
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdateCapacity)).WithArgs(30, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.SectionUpdated, "sections", 3, mock.NewRows([]string{"id", "current_capacity"}).AddRow(3, 80), `{"current_capacity":80,"id":3}`)
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WithArgs("2023-05-01 10:00:00", 7, 12, 1, 3, 30, 5).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		rp := NewRepository(db)
+
+		// act
+		result, err := rp.Transfer(context.Background(), partial)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 12, result.DestinationBatchID)
+		assert.Equal(t, 30, result.Quantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Split retries a batch number taken concurrently", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		partial := request
+		partial.Quantity = 30
+		expectLocks(mock, 2, 50, 100)
+		mock.ExpectExec(regexp.QuoteMeta(QueryReduceBatch)).WithArgs(30, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.ProductBatchUpdated, "products_batches", 7, mock.NewRows([]string{"id", "current_quantity"}).AddRow(7, 70), `{"current_quantity":70,"id":7}`)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryNextBatch)).WillReturnRows(mock.NewRows([]string{"next"}).AddRow(901))
+		mock.ExpectExec(regexp.QuoteMeta(QuerySplitBatch)).WithArgs(901, 30, 5, "2024-01-01", 30, "2023-01-01", "10:00:00", 2, 4, 3).WillReturnError(&mysql.MySQLError{Number: 1062})
+		mock.ExpectExec(regexp.QuoteMeta(QuerySplitBatch)).WithArgs(902, 30, 5, "2024-01-01", 30, "2023-01-01", "10:00:00", 2, 4, 3).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(12).
+			WillReturnRows(mock.NewRows([]string{"id", "batch_number"}).AddRow(12, 902))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.ProductBatchCreated, "products_batches", "12", `{"batch_number":902,"id":12}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdateCapacity)).WithArgs(-30, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.SectionUpdated, "sections", 1, mock.NewRows([]string{"id", "current_capacity"}).AddRow(1, 70), `{"current_capacity":70,"id":1}`)
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdateCapacity)).WithArgs(30, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectUpdated(mock, outbox.SectionUpdated, "sections", 3, mock.NewRows([]string{"id", "current_capacity"}).AddRow(3, 80), `{"current_capacity":80,"id":3}`)
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WithArgs("2023-05-01 10:00:00", 7, 12, 1, 3, 30, 5).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		rp := NewRepository(db)
+
+		// act
+		result, err := rp.Transfer(context.Background(), partial)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 12, result.DestinationBatchID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Audits every changed row", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		partial := request
+		partial.Quantity = 30
+		ctx := audit.WithActor(context.Background(), "ops")
+		batchRow := func(quantity int) *sqlmock.Rows {
+			return mock.NewRows([]string{"id", "current_quantity"}).AddRow(7, quantity)
+		}
+		sectionRow := func(id, capacity int) *sqlmock.Rows {
+			return mock.NewRows([]string{"id", "current_capacity"}).AddRow(id, capacity)
+		}
+		expectLocks(mock, 2, 50, 100)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(7).WillReturnRows(batchRow(100))
+		mock.ExpectExec(regexp.QuoteMeta(QueryReduceBatch)).WithArgs(30, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(7).WillReturnRows(batchRow(70))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("ops", "products_batches", "7", audit.ActionUpdate, `{"current_quantity":100}`, `{"current_quantity":70}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.ProductBatchUpdated, "products_batches", "7", `{"current_quantity":70,"id":7}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(QueryNextBatch)).WillReturnRows(mock.NewRows([]string{"next"}).AddRow(901))
+		mock.ExpectExec(regexp.QuoteMeta(QuerySplitBatch)).WithArgs(901, 30, 5, "2024-01-01", 30, "2023-01-01", "10:00:00", 2, 4, 3).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products_batches WHERE id=?")).WithArgs(12).
+			WillReturnRows(mock.NewRows([]string{"id", "current_quantity"}).AddRow(12, 30))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("ops", "products_batches", "12", audit.ActionCreate, nil, `{"current_quantity":30,"id":12}`).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.ProductBatchCreated, "products_batches", "12", `{"current_quantity":30,"id":12}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM sections WHERE id=?")).WithArgs(1).WillReturnRows(sectionRow(1, 80))
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdateCapacity)).WithArgs(-30, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM sections WHERE id=?")).WithArgs(1).WillReturnRows(sectionRow(1, 50))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("ops", "sections", "1", audit.ActionUpdate, `{"current_capacity":80}`, `{"current_capacity":50}`).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.SectionUpdated, "sections", "1", `{"current_capacity":50,"id":1}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM sections WHERE id=?")).WithArgs(3).WillReturnRows(sectionRow(3, 50))
+		mock.ExpectExec(regexp.QuoteMeta(QueryUpdateCapacity)).WithArgs(30, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM sections WHERE id=?")).WithArgs(3).WillReturnRows(sectionRow(3, 80))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("ops", "sections", "3", audit.ActionUpdate, `{"current_capacity":50}`, `{"current_capacity":80}`).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(regexp.QuoteMeta(outbox.QueryInsert)).
+			WithArgs(outbox.SectionUpdated, "sections", "3", `{"current_capacity":80,"id":3}`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(QueryInsert)).WithArgs("2023-05-01 10:00:00", 7, 12, 1, 3, 30, 5).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM transfers WHERE id=?")).WithArgs(2).
+			WillReturnRows(mock.NewRows([]string{"id", "quantity"}).AddRow(2, 30))
+		mock.ExpectExec(regexp.QuoteMeta(audit.QueryInsert)).
+			WithArgs("ops", "transfers", "2", audit.ActionCreate, nil, `{"id":2,"quantity":30}`).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		rp := NewRepository(db)
+
+		// act
+		_, err = rp.Transfer(ctx, partial)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rules roll back", func(t *testing.T) {
+		cases := []struct {
+			name            string
+			quantity        int
+			destinationType int
+			currentCapacity int
+			expected        error
+		}{
+			{name: "capacity exceeded", quantity: 60, destinationType: 2, currentCapacity: 50, expected: ErrCapacityExceeded},
+			{name: "product type mismatch", quantity: 10, destinationType: 9, currentCapacity: 0, expected: ErrProductTypeMismatch},
+			{name: "insufficient quantity", quantity: 101, destinationType: 2, currentCapacity: 0, expected: ErrInsufficientQuantity},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				defer db.Close()
+
+				// arrange
+				req := request
+				req.Quantity = c.quantity
+				expectLocks(mock, c.destinationType, c.currentCapacity, 100)
+				mock.ExpectRollback()
+
+				rp := NewRepository(db)
+
+				// act
+				_, err = rp.Transfer(context.Background(), req)
+
+				// assert
+				assert.ErrorIs(t, err, c.expected)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+		}
+	})
+
+	t.Run("Zero quantity", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		empty := request
+		empty.Quantity = 0
+
+		rp := NewRepository(db)
+
+		// act
+		_, err = rp.Transfer(context.Background(), empty)
+
+		// assert
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Batch not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(QueryLockBatch)).WithArgs(7).WillReturnRows(mock.NewRows(batchColumns))
+		mock.ExpectRollback()
+
+		rp := NewRepository(db)
+
+		// act
+		_, err = rp.Transfer(context.Background(), request)
+
+		// assert
+		assert.ErrorIs(t, err, ErrBatchNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Deleted destination section", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(QueryLockBatch)).WithArgs(7).
+			WillReturnRows(mock.NewRows(batchColumns).AddRow(700, 100, 5, "2024-01-01", "2023-01-01", "10:00:00", 2, 4, 1, 2))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sections WHERE id=? AND deleted_at IS NULL FOR UPDATE")).WithArgs(3).
+			WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity", "id_product_type"}))
+		mock.ExpectRollback()
+
+		rp := NewRepository(db)
+
+		// act
+		_, err = rp.Transfer(context.Background(), request)
+
+		// assert
+		assert.ErrorIs(t, err, ErrSectionNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Deleted employee", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		// arrange
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(QueryLockBatch)).WithArgs(7).
+			WillReturnRows(mock.NewRows(batchColumns).AddRow(700, 100, 5, "2024-01-01", "2023-01-01", "10:00:00", 2, 4, 1, 2))
+		mock.ExpectQuery(regexp.QuoteMeta(QueryLockSection)).WithArgs(3).
+			WillReturnRows(mock.NewRows([]string{"current_capacity", "maximum_capacity", "id_product_type"}).AddRow(0, 100, 2))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM employees WHERE id=? AND deleted_at IS NULL")).WithArgs(5).
+			WillReturnRows(mock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		rp := NewRepository(db)
+
+		// act
+		_, err = rp.Transfer(context.Background(), request)
+
+		// assert
+		assert.ErrorIs(t, err, ErrEmployeeNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetTransfer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WithArgs(9).
+			WillReturnRows(mock.NewRows([]string{"id", "transfer_date", "product_batch_id", "destination_batch_id", "origin_section_id", "destination_section_id", "quantity", "employee_id"}))
+
+		rp := NewRepository(db)
+
+		// act
+		_, err := rp.Get(context.Background(), 9)
+
+		// assert
+		assert.ErrorIs(t, err, ErrTransferNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
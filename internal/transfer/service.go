@@ -0,0 +1,45 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.Transfer, error)
+	GetByID(ctx context.Context, id int) (domain.Transfer, error)
+	Create(ctx context.Context, req domain.TransferRequest) (domain.Transfer, error)
+}
+
+type service struct {
+	repository Repository
+	now        func() time.Time
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+		now:        time.Now,
+	}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.Transfer, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *service) GetByID(ctx context.Context, id int) (domain.Transfer, error) {
+	return s.repository.Get(ctx, id)
+}
+
+// Create performs the transfer and returns the recorded document, dated now.
+func (s *service) Create(ctx context.Context, req domain.TransferRequest) (domain.Transfer, error) {
+	return s.repository.Transfer(ctx, domain.Transfer{
+		TransferDate:         s.now().Format("2006-01-02 15:04:05"),
+		ProductBatchID:       req.ProductBatchID,
+		DestinationSectionID: req.DestinationSectionID,
+		Quantity:             req.Quantity,
+		EmployeeID:           req.EmployeeID,
+	})
+}
@@ -0,0 +1,64 @@
+package transfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// define a mock repository struct that implements the Repository interface for testing purposes
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context) ([]domain.Transfer, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Transfer), args.Error(1)
+}
+func (r *RepositoryMock) Get(ctx context.Context, id int) (domain.Transfer, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.Transfer), args.Error(1)
+}
+func (r *RepositoryMock) Transfer(ctx context.Context, t domain.Transfer) (domain.Transfer, error) {
+	args := r.Called(ctx, t)
+	return args.Get(0).(domain.Transfer), args.Error(1)
+}
+
+func Test_CreateTransfer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK dates the document", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		expected := domain.Transfer{TransferDate: "2023-05-01 10:30:00", ProductBatchID: 7, DestinationSectionID: 3, Quantity: 30, EmployeeID: 5}
+		created := expected
+		created.ID = 1
+		repoMock.On("Transfer", ctx, expected).Return(created, nil)
+		svc := &service{repository: repoMock, now: func() time.Time { return time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC) }}
+
+		// act
+		result, err := svc.Create(ctx, domain.TransferRequest{ProductBatchID: 7, DestinationSectionID: 3, Quantity: 30, EmployeeID: 5})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, created, result)
+		assert.True(t, repoMock.AssertExpectations(t))
+	})
+
+	t.Run("Rule violation", func(t *testing.T) {
+		// arrange
+		repoMock := &RepositoryMock{}
+		repoMock.On("Transfer", ctx, mock.Anything).Return(domain.Transfer{}, ErrCapacityExceeded)
+		svc := NewService(repoMock)
+
+		// act
+		_, err := svc.Create(ctx, domain.TransferRequest{ProductBatchID: 7, DestinationSectionID: 3, EmployeeID: 5})
+
+		// assert
+		assert.ErrorIs(t, err, ErrCapacityExceeded)
+	})
+}
@@ -4,23 +4,41 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/audit"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqlin"
 )
 
 // Repository encapsulates the storage of a warehouse.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Warehouse, error)
+	GetAllByFilter(ctx context.Context, f domain.WarehouseFilter) ([]domain.Warehouse, error)
 	Get(ctx context.Context, id int) (domain.Warehouse, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error)
 	Exists(ctx context.Context, warehouseCode string) bool
+	ExistsLocality(ctx context.Context, localityID string) bool
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	HardDelete(ctx context.Context, id int) error
+	GetWithCoordinates(ctx context.Context) ([]domain.Warehouse, error)
+	GetLocalityCoordinates(ctx context.Context, localityID string) (lat, long *float64, err error)
 }
 
 type repository struct {
 	db *sql.DB
 }
 
+// Dependents are the rows whose foreign keys block removing a warehouse for good.
+var Dependents = []softdelete.Reference{
+	{Name: "employees", Table: "employees", Column: "warehouse_id"},
+	{Name: "sections", Table: "sections", Column: "warehouse_id"},
+	{Name: "inbound orders", Table: "inbound_orders", Column: "warehouse_id"},
+}
+
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
 		db: db,
@@ -28,17 +46,64 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	query := "SELECT * FROM warehouses"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses WHERE deleted_at IS NULL"
+	return r.getAll(query)
+}
+
+// returns every warehouse, soft deleted ones included
+func (r *repository) GetAllWithDeleted(ctx context.Context) ([]domain.Warehouse, error) {
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses"
+	return r.getAll(query)
+}
+
+func (r *repository) getAll(query string, args ...interface{}) ([]domain.Warehouse, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var warehouses []domain.Warehouse
+
+	for rows.Next() {
+		w := domain.Warehouse{}
+		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.Latitude, &w.Longitude)
+		warehouses = append(warehouses, w)
+	}
+
+	return warehouses, nil
+}
+
+// returns the warehouses located in the given locality, province or country
+func (r *repository) GetAllByFilter(ctx context.Context, f domain.WarehouseFilter) ([]domain.Warehouse, error) {
+	query := "SELECT w.id, w.address, w.telephone, w.warehouse_code, w.minimum_capacity, w.minimum_temperature, w.locality_id, w.latitude, w.longitude FROM warehouses w " +
+		"INNER JOIN localities l ON w.locality_id = l.id INNER JOIN provinces p ON l.province_id = p.id WHERE w.deleted_at IS NULL"
+	var args []interface{}
+	if f.LocalityID != "" {
+		query += " AND l.id=?"
+		args = append(args, f.LocalityID)
+	}
+	if f.ProvinceID != 0 {
+		query += " AND p.id=?"
+		args = append(args, f.ProvinceID)
+	}
+	if f.CountryID != 0 {
+		query += " AND p.country_id=?"
+		args = append(args, f.CountryID)
+	}
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var warehouses []domain.Warehouse
 
 	for rows.Next() {
 		w := domain.Warehouse{}
-		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+		if err := rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.Latitude, &w.Longitude); err != nil {
+			return nil, err
+		}
 		warehouses = append(warehouses, w)
 	}
 
@@ -46,10 +111,10 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
-	query := "SELECT * FROM warehouses WHERE id=?;"
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses WHERE id=? AND deleted_at IS NULL;"
 	row := r.db.QueryRow(query, id)
 	w := domain.Warehouse{}
-	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.Latitude, &w.Longitude)
 	if err != nil {
 		return domain.Warehouse{}, err
 	}
@@ -57,6 +122,16 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error)
 	return w, nil
 }
 
+// returns the warehouses among ids, in no particular order
+func (r *repository) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := sqlin.Ints(ids)
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses WHERE id IN (" + placeholders + ") AND deleted_at IS NULL"
+	return r.getAll(query, args...)
+}
+
 func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
 	query := "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;"
 	row := r.db.QueryRow(query, warehouseCode)
@@ -64,66 +139,178 @@ func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
 	return err == nil
 }
 
+func (r *repository) ExistsLocality(ctx context.Context, localityID string) bool {
+	query := "SELECT id FROM localities WHERE id=?;"
+	row := r.db.QueryRow(query, localityID)
+	err := row.Scan(&localityID)
+	return err == nil
+}
+
 func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error) {
-	query := "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return 0, err //devuelve valor por defecto
-	}
+	query := "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, latitude, longitude) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	return audit.Mutate(ctx, r.db, audit.Change{Table: "warehouses", Action: audit.ActionCreate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err //devuelve valor por defecto
+		}
 
-	res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
-	if err != nil {
-		return 0, err
-	}
+		res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.Latitude, &w.Longitude)
+		if err != nil {
+			return 0, err
+		}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
 
-	return int(id), nil
+		return int(id), nil
+	})
 }
 
 func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
-	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
-		return err
-	}
+	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=?, latitude=?, longitude=?, version=version+1 WHERE id=?"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "warehouses", ID: w.ID, Action: audit.ActionUpdate}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
 
-	res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.ID)
-	if err != nil {
-		return err
-	}
+		res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, w.Latitude, w.Longitude, &w.ID)
+		if err != nil {
+			return 0, err
+		}
 
-	_, err = res.RowsAffected()
-	if err != nil {
-		return err
-	}
+		_, err = res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
 
-	return nil
+		return 0, nil
+	})
+	return err
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM warehouses WHERE id=?"
-	stmt, err := r.db.Prepare(query)
-	if err != nil {
+	query := "UPDATE warehouses SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "warehouses", ID: id, Action: audit.ActionDelete}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// clears the deletion mark of a soft deleted warehouse
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE warehouses SET deleted_at=NULL, version=version+1 WHERE id=? AND deleted_at IS NOT NULL"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "warehouses", ID: id, Action: audit.ActionRestore}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			return 0, err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// removes the warehouse row, refusing while any Dependents point at it
+func (r *repository) HardDelete(ctx context.Context, id int) error {
+	if err := softdelete.Dependents(ctx, r.db, id, Dependents...); err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	query := "DELETE FROM warehouses WHERE id=?"
+	_, err := audit.Mutate(ctx, r.db, audit.Change{Table: "warehouses", ID: id, Action: audit.ActionHardDelete}, func(ex audit.Execer) (int, error) {
+		stmt, err := ex.Prepare(query)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := stmt.Exec(id)
+		if err != nil {
+			if softdelete.IsReferenced(err) {
+				return 0, softdelete.ErrHasDependents
+			}
+			return 0, err
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, ErrNotFound
+		}
+
+		return 0, nil
+	})
+	return err
+}
+
+// returns the warehouses that have both latitude and longitude set
+func (r *repository) GetWithCoordinates(ctx context.Context) ([]domain.Warehouse, error) {
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND deleted_at IS NULL"
+	rows, err := r.db.Query(query)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	affect, err := res.RowsAffected()
-	if err != nil {
-		return err
+	var warehouses []domain.Warehouse
+
+	for rows.Next() {
+		w := domain.Warehouse{}
+		if err := rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.Latitude, &w.Longitude); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, w)
 	}
 
-	if affect < 1 {
-		return ErrNotFound
+	return warehouses, nil
+}
+
+// returns the coordinates of a locality, nil when they are not set
+func (r *repository) GetLocalityCoordinates(ctx context.Context, localityID string) (lat, long *float64, err error) {
+	query := "SELECT latitude, longitude FROM localities WHERE id=?;"
+	row := r.db.QueryRow(query, localityID)
+	err = row.Scan(&lat, &long)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrLocalityNotFound
 	}
 
-	return nil
+	return lat, long, err
 }
@@ -7,17 +7,23 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	QueryGetAll  = "SELECT * FROM warehouses"
-	QueryGetByID = "SELECT * FROM warehouses WHERE id=?;"
+	QueryGetAll  = "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses WHERE deleted_at IS NULL"
+	QueryGetByID = "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, COALESCE(locality_id, ''), latitude, longitude FROM warehouses WHERE id=? AND deleted_at IS NULL;"
 	QueryExist   = "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;"
-	QuerySave    = "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"
-	QueryUpdate  = "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"
-	QueryDelete  = "DELETE FROM warehouses WHERE id=?"
+	QuerySave    = "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, latitude, longitude) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	QueryUpdate  = "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=?, latitude=?, longitude=?, version=version+1 WHERE id=?"
+	QueryDelete  = "UPDATE warehouses SET deleted_at=NOW(), version=version+1 WHERE id=? AND deleted_at IS NULL"
+	QueryRestore = "UPDATE warehouses SET deleted_at=NULL, version=version+1 WHERE id=? AND deleted_at IS NOT NULL"
+	QueryHard    = "DELETE FROM warehouses WHERE id=?"
+
+	QueryExistLocality = "SELECT id FROM localities WHERE id=?;"
 )
 
 func Test_GetAllWh(t *testing.T) {
@@ -30,14 +36,14 @@ func Test_GetAllWh(t *testing.T) {
 		//arrange
 
 		expected := []domain.Warehouse{
-			{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22},
-			{ID: 2, Address: "Calle 12 #32-21", Telephone: "2254586,", WarehouseCode: "ABC456", MinimumCapacity: 10, MinimumTemperature: 22},
+			{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"},
+			{ID: 2, Address: "Calle 12 #32-21", Telephone: "2254586,", WarehouseCode: "ABC456", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"},
 		}
 
-		rows := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
+		rows := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
 
 		for _, f := range expected {
-			rows.AddRow(f.ID, f.Address, f.Telephone, f.WarehouseCode, f.MinimumCapacity, f.MinimumTemperature)
+			rows.AddRow(f.ID, f.Address, f.Telephone, f.WarehouseCode, f.MinimumCapacity, f.MinimumTemperature, f.LocalityID, f.Latitude, f.Longitude)
 		}
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll)).WillReturnRows(rows)
@@ -86,10 +92,10 @@ func Test_GetWh(t *testing.T) {
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
-		expected := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		expected := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
-		row := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
-		row.AddRow(expected.ID, expected.Address, expected.Telephone, expected.WarehouseCode, expected.MinimumCapacity, expected.MinimumTemperature)
+		row := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
+		row.AddRow(expected.ID, expected.Address, expected.Telephone, expected.WarehouseCode, expected.MinimumCapacity, expected.MinimumTemperature, expected.LocalityID, expected.Latitude, expected.Longitude)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WithArgs(1).WillReturnRows(row)
 		rp := NewRepository(db)
@@ -134,7 +140,7 @@ func Test_GetWh(t *testing.T) {
 
 		expected := domain.Warehouse{}
 
-		row := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
+		row := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WithArgs(1).WillReturnRows(row)
 
@@ -186,10 +192,10 @@ func Test_SaveWh(t *testing.T) {
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 0, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 0, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 		expected := 1
 
-		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).ExpectExec().WithArgs(wareH.Address, wareH.Telephone, wareH.WarehouseCode, wareH.MinimumCapacity, wareH.MinimumTemperature).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).ExpectExec().WithArgs(wareH.Address, wareH.Telephone, wareH.WarehouseCode, wareH.MinimumCapacity, wareH.MinimumTemperature, wareH.LocalityID, wareH.Latitude, wareH.Longitude).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		rp := NewRepository(db)
 
@@ -205,7 +211,7 @@ func Test_SaveWh(t *testing.T) {
 
 	t.Run("Internal Error", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 		expected := 0
 
 		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).ExpectExec().WillReturnError(ErrBD)
@@ -225,7 +231,7 @@ func Test_SaveWh(t *testing.T) {
 
 	t.Run("Error Prepare", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 		expected := 0
 
 		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).WillReturnError(ErrBD)
@@ -251,7 +257,7 @@ func Test_Update(t *testing.T) {
 	defer db.Close()
 	t.Run("OK", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
 		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -268,7 +274,7 @@ func Test_Update(t *testing.T) {
 
 	t.Run("Error prepare", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
 		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).WillReturnError(ErrBD)
 
@@ -286,7 +292,7 @@ func Test_Update(t *testing.T) {
 
 	t.Run("Error exec", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
 		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WillReturnError(ErrBD)
 
@@ -304,7 +310,7 @@ func Test_Update(t *testing.T) {
 
 	t.Run("Error result", func(t *testing.T) {
 		// arrange
-		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+		wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
 		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WillReturnResult(sqlmock.NewErrorResult(ErrBD))
 
@@ -397,3 +403,173 @@ func Test_Delete(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func Test_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	t.Run("OK", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryRestore)).ExpectExec().WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+
+		rp := NewRepository(db)
+		err = rp.Restore(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error not deleted or not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryRestore)).ExpectExec().WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+
+		rp := NewRepository(db)
+		err = rp.Restore(context.Background(), 1)
+
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_HardDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expectCounts := func(counts ...int) {
+		for i, ref := range Dependents {
+			query := "SELECT COUNT(*) FROM " + ref.Table + " WHERE " + ref.Column + "=?"
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(counts[i]))
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		expectCounts(0, 0, 0)
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryHard)).ExpectExec().WithArgs(1).WillReturnResult(driver.RowsAffected(1))
+
+		rp := NewRepository(db)
+		err = rp.HardDelete(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error dependents", func(t *testing.T) {
+		expectCounts(2, 0, 5)
+
+		rp := NewRepository(db)
+		err = rp.HardDelete(context.Background(), 1)
+
+		assert.ErrorIs(t, err, softdelete.ErrHasDependents)
+		assert.EqualError(t, err, "blocked by dependents: 2 employees, 5 inbound orders")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error referenced meanwhile", func(t *testing.T) {
+		expectCounts(0, 0, 0)
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryHard)).ExpectExec().WithArgs(1).WillReturnError(&mysql.MySQLError{Number: 1451})
+
+		rp := NewRepository(db)
+		err = rp.HardDelete(context.Background(), 1)
+
+		assert.Equal(t, softdelete.ErrHasDependents, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error not found", func(t *testing.T) {
+		expectCounts(0, 0, 0)
+		mock.ExpectPrepare(regexp.QuoteMeta(QueryHard)).ExpectExec().WithArgs(1).WillReturnResult(driver.RowsAffected(0))
+
+		rp := NewRepository(db)
+		err = rp.HardDelete(context.Background(), 1)
+
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetWithCoordinates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	lat, long := -34.6037, -58.3816
+	expected := []domain.Warehouse{
+		{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700", Latitude: &lat, Longitude: &long},
+	}
+
+	rows := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
+	rows.AddRow(1, "Calle 23 #4-45", "2245678", "ABC123", 10, 22, "6700", lat, long)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE latitude IS NOT NULL AND longitude IS NOT NULL")).WillReturnRows(rows)
+
+	rp := NewRepository(db)
+
+	// act
+	wareH, err := rp.GetWithCoordinates(context.Background())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, wareH)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetLocalityCoordinates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	query := "SELECT latitude, longitude FROM localities WHERE id=?;"
+
+	t.Run("Without coordinates", func(t *testing.T) {
+		// arrange
+		rows := mock.NewRows([]string{"latitude", "longitude"}).AddRow(nil, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("6700").WillReturnRows(rows)
+
+		rp := NewRepository(db)
+
+		// act
+		lat, long, err := rp.GetLocalityCoordinates(context.Background(), "6700")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Nil(t, lat)
+		assert.Nil(t, long)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("9999").WillReturnRows(mock.NewRows([]string{"latitude", "longitude"}))
+
+		rp := NewRepository(db)
+
+		// act
+		_, _, err := rp.GetLocalityCoordinates(context.Background(), "9999")
+
+		// assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_GetAllByFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expected := []domain.Warehouse{
+		{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"},
+	}
+
+	rows := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
+	rows.AddRow(1, "Calle 23 #4-45", "2245678", "ABC123", 10, 22, "6700", nil, nil)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE w.deleted_at IS NULL AND p.id=? AND p.country_id=?")).WithArgs(1, 2).WillReturnRows(rows)
+
+	rp := NewRepository(db)
+
+	// act
+	wareH, err := rp.GetAllByFilter(context.Background(), domain.WarehouseFilter{ProvinceID: 1, CountryID: 2})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, wareH)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,8 +3,12 @@ package warehouse
 import (
 	"context"
 	"errors"
+	"math"
+	"sort"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/etag"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 )
 
 // Errors
@@ -13,15 +17,28 @@ var (
 	ErrBD         = errors.New("warehouse is empty")
 	ErrExist      = errors.New("Warehouse already exist")
 	ErrBadRequest = errors.New("bad request")
+	// locality related
+	ErrLocalityNotFound = errors.New("locality not found")
+	ErrNoCoordinates    = errors.New("locality has no coordinates")
 )
 
+// mean radius of the Earth used to compute great-circle distances
+const earthRadiusKm = 6371.0
+
 type Service interface {
 	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	GetAllWithDeleted(ctx context.Context) ([]domain.Warehouse, error)
+	GetAllByFilter(ctx context.Context, f domain.WarehouseFilter) ([]domain.Warehouse, error)
 	Get(ctx context.Context, id int) (domain.Warehouse, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error)
 	Create(ctx context.Context, w domain.Warehouse) (wareH domain.Warehouse, err error)
 	//Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) (wareH domain.Warehouse, err error)
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (domain.Warehouse, error)
+	HardDelete(ctx context.Context, id int) error
+	GetNearest(ctx context.Context, lat, long, maxDistanceKm float64) ([]domain.WarehouseDistance, error)
+	GetNearestToLocality(ctx context.Context, localityID string, maxDistanceKm float64) ([]domain.WarehouseDistance, error)
 }
 
 type service struct {
@@ -45,6 +62,24 @@ func (s *service) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
 	return l, nil
 }
 
+// return all warehouse, soft deleted ones included
+func (s *service) GetAllWithDeleted(ctx context.Context) ([]domain.Warehouse, error) {
+	l, err := s.r.GetAllWithDeleted(ctx)
+	if err != nil {
+		return []domain.Warehouse{}, ErrBD
+	}
+	return l, nil
+}
+
+// return the warehouses located in a locality, province or country
+func (s *service) GetAllByFilter(ctx context.Context, f domain.WarehouseFilter) ([]domain.Warehouse, error) {
+	l, err := s.r.GetAllByFilter(ctx, f)
+	if err != nil {
+		return []domain.Warehouse{}, ErrBD
+	}
+	return l, nil
+}
+
 // returns product specified by id
 func (s *service) Get(ctx context.Context, id int) (domain.Warehouse, error) {
 	w, err := s.r.Get(ctx, id)
@@ -55,6 +90,15 @@ func (s *service) Get(ctx context.Context, id int) (domain.Warehouse, error) {
 
 }
 
+// return the warehouses among ids, in no particular order
+func (s *service) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	l, err := s.r.GetByIDs(ctx, ids)
+	if err != nil {
+		return []domain.Warehouse{}, ErrBD
+	}
+	return l, nil
+}
+
 // adds one warehouse to warehouse list and returns it
 func (s *service) Create(ctx context.Context, w domain.Warehouse) (wareH domain.Warehouse, err error) {
 
@@ -62,6 +106,10 @@ func (s *service) Create(ctx context.Context, w domain.Warehouse) (wareH domain.
 	if s.r.Exists(ctx, w.WarehouseCode) {
 		return domain.Warehouse{}, ErrExist
 	}
+	// returns an error if the locality does not exist
+	if !s.r.ExistsLocality(ctx, w.LocalityID) {
+		return domain.Warehouse{}, ErrLocalityNotFound
+	}
 	// create warehouse in database and save its id
 	id, er := s.r.Save(ctx, w)
 
@@ -85,10 +133,17 @@ func (s *service) Update(ctx context.Context, w domain.Warehouse) (wareH domain.
 	if s.r.Exists(ctx, w.WarehouseCode) && wPre.WarehouseCode != w.WarehouseCode {
 		return domain.Warehouse{}, ErrExist
 	}
+	// returns an error if the locality does not exist
+	if !s.r.ExistsLocality(ctx, w.LocalityID) {
+		return domain.Warehouse{}, ErrLocalityNotFound
+	}
 
 	//update warehouse in database and return an error
 	er := s.r.Update(ctx, w)
 
+	if er == etag.ErrPreconditionFailed {
+		return domain.Warehouse{}, er
+	}
 	if er != nil {
 		return domain.Warehouse{}, ErrBD
 	}
@@ -100,12 +155,92 @@ func (s *service) Update(ctx context.Context, w domain.Warehouse) (wareH domain.
 func (s *service) Delete(ctx context.Context, id int) (err error) {
 	er := s.r.Delete(ctx, id)
 
+	if er == ErrNotFound || er == etag.ErrPreconditionFailed {
+		return er
+	}
+
+	if er != nil {
+		return ErrBD
+	}
+	return nil
+}
+
+// restores a soft deleted warehouse and returns it
+func (s *service) Restore(ctx context.Context, id int) (domain.Warehouse, error) {
+	if _, err := s.r.Get(ctx, id); err == nil {
+		return domain.Warehouse{}, softdelete.ErrNotDeleted
+	}
+	er := s.r.Restore(ctx, id)
 	if er == ErrNotFound {
-		return ErrNotFound
+		return domain.Warehouse{}, ErrNotFound
+	}
+	if er != nil {
+		return domain.Warehouse{}, ErrBD
 	}
+	return s.Get(ctx, id)
+}
 
+// removes warehouse with specified id for good, unless employees, sections or inbound orders reference it
+func (s *service) HardDelete(ctx context.Context, id int) error {
+	er := s.r.HardDelete(ctx, id)
+	if er == ErrNotFound || errors.Is(er, softdelete.ErrHasDependents) {
+		return er
+	}
 	if er != nil {
 		return ErrBD
 	}
 	return nil
 }
+
+// returns the warehouses with coordinates ordered by great-circle distance to the given point,
+// skipping those farther than maxDistanceKm when it is greater than zero
+func (s *service) GetNearest(ctx context.Context, lat, long, maxDistanceKm float64) ([]domain.WarehouseDistance, error) {
+	warehouses, err := s.r.GetWithCoordinates(ctx)
+	if err != nil {
+		return nil, ErrBD
+	}
+
+	nearest := []domain.WarehouseDistance{}
+	for _, w := range warehouses {
+		distance := greatCircleDistance(lat, long, *w.Latitude, *w.Longitude)
+		if maxDistanceKm > 0 && distance > maxDistanceKm {
+			continue
+		}
+		nearest = append(nearest, domain.WarehouseDistance{Warehouse: w, DistanceKm: distance})
+	}
+
+	sort.SliceStable(nearest, func(i, j int) bool {
+		return nearest[i].DistanceKm < nearest[j].DistanceKm
+	})
+
+	return nearest, nil
+}
+
+// same as GetNearest, using the coordinates of the locality specified by id
+func (s *service) GetNearestToLocality(ctx context.Context, localityID string, maxDistanceKm float64) ([]domain.WarehouseDistance, error) {
+	lat, long, err := s.r.GetLocalityCoordinates(ctx, localityID)
+	if err != nil {
+		if err == ErrLocalityNotFound {
+			return nil, ErrLocalityNotFound
+		}
+		return nil, ErrBD
+	}
+
+	if lat == nil || long == nil {
+		return nil, ErrNoCoordinates
+	}
+
+	return s.GetNearest(ctx, *lat, *long, maxDistanceKm)
+}
+
+// haversine distance in kilometers between two points given in degrees
+func greatCircleDistance(lat1, long1, lat2, long2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLong := toRad(long2 - long1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -25,14 +25,14 @@ func Test_IntegrationGetAllWh(t *testing.T) {
 		//arrange
 
 		expected := []domain.Warehouse{
-			{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22},
-			{ID: 2, Address: "Calle 12 #32-21", Telephone: "2254586,", WarehouseCode: "ABC456", MinimumCapacity: 10, MinimumTemperature: 22},
+			{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"},
+			{ID: 2, Address: "Calle 12 #32-21", Telephone: "2254586,", WarehouseCode: "ABC456", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"},
 		}
 
-		rows := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
+		rows := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
 
 		for _, f := range expected {
-			rows.AddRow(f.ID, f.Address, f.Telephone, f.WarehouseCode, f.MinimumCapacity, f.MinimumTemperature)
+			rows.AddRow(f.ID, f.Address, f.Telephone, f.WarehouseCode, f.MinimumCapacity, f.MinimumTemperature, f.LocalityID, f.Latitude, f.Longitude)
 		}
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetAll)).WillReturnRows(rows)
@@ -76,13 +76,13 @@ func Test_Integration_GetWh(t *testing.T) {
 	repoMock := NewRepository(db)
 	service := NewService(repoMock)
 
-	expected := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
-	row := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
+	expected := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
+	row := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
 
-		row.AddRow(expected.ID, expected.Address, expected.Telephone, expected.WarehouseCode, expected.MinimumCapacity, expected.MinimumTemperature)
+		row.AddRow(expected.ID, expected.Address, expected.Telephone, expected.WarehouseCode, expected.MinimumCapacity, expected.MinimumTemperature, expected.LocalityID, expected.Latitude, expected.Longitude)
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WithArgs(1).WillReturnRows(row)
 
 		// act
@@ -124,14 +124,15 @@ func Test_Integration_CreateWh(t *testing.T) {
 	repoMock := NewRepository(db)
 	service := NewService(repoMock)
 
-	wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+	wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
 	t.Run("Ok", func(t *testing.T) {
 		// arrange
 
 		//expected := 1
 
-		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).ExpectExec().WithArgs(wareH.Address, wareH.Telephone, wareH.WarehouseCode, wareH.MinimumCapacity, wareH.MinimumTemperature).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistLocality)).WithArgs("6700").WillReturnRows(mock.NewRows([]string{"id"}).AddRow("6700"))
+		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).ExpectExec().WithArgs(wareH.Address, wareH.Telephone, wareH.WarehouseCode, wareH.MinimumCapacity, wareH.MinimumTemperature, wareH.LocalityID, wareH.Latitude, wareH.Longitude).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		// act
 		wareHObt, err := service.Create(ctx, wareH)
@@ -162,6 +163,7 @@ func Test_Integration_CreateWh(t *testing.T) {
 
 		expected := domain.Warehouse{}
 
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistLocality)).WithArgs("6700").WillReturnRows(mock.NewRows([]string{"id"}).AddRow("6700"))
 		mock.ExpectPrepare(regexp.QuoteMeta(QuerySave)).ExpectExec().WillReturnError(ErrBD)
 
 		// act
@@ -185,19 +187,21 @@ func Test_Integration_Update(t *testing.T) {
 	repoMock := NewRepository(db)
 	service := NewService(repoMock)
 
-	wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22}
+	wareH := domain.Warehouse{ID: 1, Address: "Calle 23 #4-45", Telephone: "2245678,", WarehouseCode: "ABC123", MinimumCapacity: 10, MinimumTemperature: 22, LocalityID: "6700"}
 
 	t.Run("OK", func(t *testing.T) {
 		// arrange
 		row := mock.NewRows([]string{"warehouse_code"})
 		row.AddRow(1)
 
-		row2 := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
-		row2.AddRow(1, "Calle 23 #4-45", "2245678,", "ABC123", 10, 22)
+		row2 := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
+		row2.AddRow(1, "Calle 23 #4-45", "2245678,", "ABC123", 10, 22, "6700", nil, nil)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WillReturnRows(row2)
 		mock.ExpectQuery(regexp.QuoteMeta(QueryExist)).WillReturnRows(row)
 
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistLocality)).WithArgs("6700").WillReturnRows(mock.NewRows([]string{"id"}).AddRow("6700"))
+
 		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
 
 		// act
@@ -213,8 +217,8 @@ func Test_Integration_Update(t *testing.T) {
 		row := mock.NewRows([]string{"warehouse_code"})
 		row.AddRow(1)
 
-		row2 := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
-		row2.AddRow(1, "Calle 23 #4-45", "2245678,", "ABC1234", 10, 22)
+		row2 := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
+		row2.AddRow(1, "Calle 23 #4-45", "2245678,", "ABC1234", 10, 22, "6700", nil, nil)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WillReturnRows(row2)
 		mock.ExpectQuery(regexp.QuoteMeta(QueryExist)).WillReturnRows(row)
@@ -233,11 +237,12 @@ func Test_Integration_Update(t *testing.T) {
 		//row := mock.NewRows([]string{"warehouse_code"})
 		//row.AddRow(1)
 
-		row2 := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
-		row2.AddRow(1, "Calle 23 #4-45", "2245678,", "ABC1234", 10, 22)
+		row2 := mock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "latitude", "longitude"})
+		row2.AddRow(1, "Calle 23 #4-45", "2245678,", "ABC1234", 10, 22, "6700", nil, nil)
 
 		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WillReturnRows(row2)
 		//mock.ExpectQuery(regexp.QuoteMeta(QueryExist)).WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(QueryExistLocality)).WithArgs("6700").WillReturnRows(mock.NewRows([]string{"id"}).AddRow("6700"))
 		mock.ExpectPrepare(regexp.QuoteMeta(QueryUpdate)).WillReturnError(ErrBD)
 
 		// act
@@ -2,9 +2,12 @@ package warehouse
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/softdelete"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,6 +33,11 @@ func (r *repositoryTest) GetAll(ctx context.Context) ([]domain.Warehouse, error)
 	return args.Get(0).([]domain.Warehouse), args.Error(1)
 }
 
+func (r *repositoryTest) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+
 func (r *repositoryTest) Exists(ctx context.Context, wareHCode string) bool {
 	args := r.Called(ctx, wareHCode)
 	return args.Get(0).(bool)
@@ -46,6 +54,35 @@ func (r *repositoryTest) Delete(ctx context.Context, id int) error {
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+func (r *repositoryTest) GetAllWithDeleted(ctx context.Context) ([]domain.Warehouse, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+func (r *repositoryTest) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+func (r *repositoryTest) HardDelete(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *repositoryTest) GetAllByFilter(ctx context.Context, f domain.WarehouseFilter) ([]domain.Warehouse, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+func (r *repositoryTest) ExistsLocality(ctx context.Context, localityID string) bool {
+	args := r.Called(ctx, localityID)
+	return args.Get(0).(bool)
+}
+func (r *repositoryTest) GetWithCoordinates(ctx context.Context) ([]domain.Warehouse, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+func (r *repositoryTest) GetLocalityCoordinates(ctx context.Context, localityID string) (*float64, *float64, error) {
+	args := r.Called(ctx, localityID)
+	return args.Get(0).(*float64), args.Get(1).(*float64), args.Error(2)
+}
 
 func TestGetAllWService(t *testing.T) {
 
@@ -140,7 +177,8 @@ func TestCreateWService(t *testing.T) {
 		Telephone:          "2243570",
 		WarehouseCode:      "AB210",
 		MinimumCapacity:    10,
-		MinimumTemperature: 15}
+		MinimumTemperature: 15,
+		LocalityID:         "6700"}
 
 	//inicio casos test
 
@@ -150,6 +188,7 @@ func TestCreateWService(t *testing.T) {
 		r := NewWarehouseRepository()
 		s := NewService(r)
 		r.On("Exists", ctx, data.WarehouseCode).Return(false)
+		r.On("ExistsLocality", ctx, data.LocalityID).Return(true)
 		r.On("Save", ctx, data).Return(data.ID, nil)
 
 		//act
@@ -187,6 +226,7 @@ func TestCreateWService(t *testing.T) {
 		r := NewWarehouseRepository()
 		s := NewService(r)
 		r.On("Exists", ctx, data.WarehouseCode).Return(false)
+		r.On("ExistsLocality", ctx, data.LocalityID).Return(true)
 		r.On("Save", ctx, data).Return(0, ErrBD)
 
 		//act
@@ -199,6 +239,25 @@ func TestCreateWService(t *testing.T) {
 		assert.True(t, r.AssertExpectations(t))
 
 	})
+
+	t.Run("create_locality_non_existent", func(t *testing.T) {
+
+		//arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("Exists", ctx, data.WarehouseCode).Return(false)
+		r.On("ExistsLocality", ctx, data.LocalityID).Return(false)
+
+		//act
+		wareH, err := s.Create(ctx, data)
+
+		//assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+		assert.Empty(t, wareH)
+		assert.True(t, r.AssertExpectations(t))
+		r.AssertNotCalled(t, "Save", ctx, data)
+
+	})
 }
 
 func TestUpdateWService(t *testing.T) {
@@ -210,7 +269,8 @@ func TestUpdateWService(t *testing.T) {
 		Telephone:          "2243570",
 		WarehouseCode:      "AB210",
 		MinimumCapacity:    10,
-		MinimumTemperature: 15}
+		MinimumTemperature: 15,
+		LocalityID:         "6700"}
 
 	t.Run("update_ok", func(t *testing.T) {
 		//arrange
@@ -219,6 +279,7 @@ func TestUpdateWService(t *testing.T) {
 		r.On("Get", ctx, data.ID).Return(data, nil)
 		//verifica si existe en otro lado y es diferente al que tenia anteriormente
 		r.On("Exists", ctx, data.WarehouseCode).Return(false)
+		r.On("ExistsLocality", ctx, data.LocalityID).Return(true)
 		r.On("Update", ctx, data).Return(nil)
 
 		//act
@@ -249,6 +310,61 @@ func TestUpdateWService(t *testing.T) {
 
 	})
 
+	t.Run("update_locality_non_existent", func(t *testing.T) {
+		//arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("Get", ctx, data.ID).Return(data, nil)
+		r.On("Exists", ctx, data.WarehouseCode).Return(true)
+		r.On("ExistsLocality", ctx, data.LocalityID).Return(false)
+
+		//act
+		wareH, err := s.Update(ctx, data)
+
+		//assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+		assert.Empty(t, wareH)
+		assert.True(t, r.AssertExpectations(t))
+
+	})
+
+}
+
+func TestGetAllByFilterWService(t *testing.T) {
+
+	ctx := context.Background()
+	filter := domain.WarehouseFilter{ProvinceID: 1}
+	data := []domain.Warehouse{
+		{ID: 4, Address: "Calle 33 # 34-25", Telephone: "2243567", WarehouseCode: "AB201", MinimumCapacity: 10, MinimumTemperature: 15, LocalityID: "6700"},
+	}
+
+	t.Run("find_by_province", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetAllByFilter", ctx, filter).Return(data, nil)
+
+		// act
+		wareH, err := s.GetAllByFilter(ctx, filter)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, data, wareH)
+		assert.True(t, r.AssertExpectations(t))
+	})
+
+	t.Run("find_by_province_err", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetAllByFilter", ctx, filter).Return([]domain.Warehouse{}, ErrNotFound)
+
+		// act
+		_, err := s.GetAllByFilter(ctx, filter)
+
+		// assert
+		assert.Equal(t, ErrBD, err)
+	})
 }
 
 func TestDeleteWService(t *testing.T) {
@@ -289,3 +405,177 @@ func TestDeleteWService(t *testing.T) {
 	})
 
 }
+
+func TestRestoreWService(t *testing.T) {
+	ctx := context.Background()
+	data := domain.Warehouse{ID: 8, Address: "Calle 33 # 34-25", WarehouseCode: "AB201"}
+
+	t.Run("restore_ok", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("Get", ctx, 8).Return(domain.Warehouse{}, ErrNotFound).Once()
+		r.On("Restore", ctx, 8).Return(nil)
+		r.On("Get", ctx, 8).Return(data, nil).Once()
+
+		// act
+		wareH, err := s.Restore(ctx, 8)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, data, wareH)
+		assert.True(t, r.AssertExpectations(t))
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("Get", ctx, 8).Return(data, nil)
+
+		// act
+		_, err := s.Restore(ctx, 8)
+
+		// assert
+		assert.Equal(t, softdelete.ErrNotDeleted, err)
+		r.AssertNotCalled(t, "Restore", ctx, 8)
+	})
+
+	t.Run("restore_not_found", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("Get", ctx, 8).Return(domain.Warehouse{}, ErrNotFound)
+		r.On("Restore", ctx, 8).Return(ErrNotFound)
+
+		// act
+		_, err := s.Restore(ctx, 8)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestHardDeleteWService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("hard_delete_dependents", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		blocked := fmt.Errorf("%w: 2 employees", softdelete.ErrHasDependents)
+		r.On("HardDelete", ctx, 8).Return(blocked)
+
+		// act
+		err := s.HardDelete(ctx, 8)
+
+		// assert
+		assert.Equal(t, blocked, err)
+	})
+
+	t.Run("hard_delete_internal", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("HardDelete", ctx, 8).Return(errors.New("connection lost"))
+
+		// act
+		err := s.HardDelete(ctx, 8)
+
+		// assert
+		assert.Equal(t, ErrBD, err)
+	})
+}
+
+func TestNearestWService(t *testing.T) {
+
+	ctx := context.Background()
+	coord := func(v float64) *float64 { return &v }
+
+	// Buenos Aires, Rosario and Córdoba
+	data := []domain.Warehouse{
+		{ID: 1, WarehouseCode: "CBA", Latitude: coord(-31.4201), Longitude: coord(-64.1888)},
+		{ID: 2, WarehouseCode: "BUE", Latitude: coord(-34.6037), Longitude: coord(-58.3816)},
+		{ID: 3, WarehouseCode: "ROS", Latitude: coord(-32.9442), Longitude: coord(-60.6505)},
+	}
+
+	t.Run("ordered_by_distance", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetWithCoordinates", ctx).Return(data, nil)
+
+		// act
+		nearest, err := s.GetNearest(ctx, -34.6037, -58.3816, 0)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(nearest))
+		assert.Equal(t, "BUE", nearest[0].WarehouseCode)
+		assert.Equal(t, "ROS", nearest[1].WarehouseCode)
+		assert.Equal(t, "CBA", nearest[2].WarehouseCode)
+		assert.InDelta(t, 0, nearest[0].DistanceKm, 0.001)
+		assert.InDelta(t, 646, nearest[2].DistanceKm, 5)
+		assert.True(t, r.AssertExpectations(t))
+	})
+
+	t.Run("max_distance", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetWithCoordinates", ctx).Return(data, nil)
+
+		// act
+		nearest, err := s.GetNearest(ctx, -34.6037, -58.3816, 400)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(nearest))
+		assert.Equal(t, "BUE", nearest[0].WarehouseCode)
+		assert.Equal(t, "ROS", nearest[1].WarehouseCode)
+	})
+
+	t.Run("from_locality", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetLocalityCoordinates", ctx, "5000").Return(coord(-31.4201), coord(-64.1888), nil)
+		r.On("GetWithCoordinates", ctx).Return(data, nil)
+
+		// act
+		nearest, err := s.GetNearestToLocality(ctx, "5000", 0)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "CBA", nearest[0].WarehouseCode)
+		assert.True(t, r.AssertExpectations(t))
+	})
+
+	t.Run("locality_without_coordinates", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetLocalityCoordinates", ctx, "5000").Return((*float64)(nil), (*float64)(nil), nil)
+
+		// act
+		nearest, err := s.GetNearestToLocality(ctx, "5000", 0)
+
+		// assert
+		assert.Equal(t, ErrNoCoordinates, err)
+		assert.Nil(t, nearest)
+		r.AssertNotCalled(t, "GetWithCoordinates", ctx)
+	})
+
+	t.Run("locality_non_existent", func(t *testing.T) {
+		// arrange
+		r := NewWarehouseRepository()
+		s := NewService(r)
+		r.On("GetLocalityCoordinates", ctx, "9999").Return((*float64)(nil), (*float64)(nil), ErrLocalityNotFound)
+
+		// act
+		_, err := s.GetNearestToLocality(ctx, "9999", 0)
+
+		// assert
+		assert.Equal(t, ErrLocalityNotFound, err)
+	})
+}
@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/pkg/sqltime"
+)
+
+// maxErrorLength is the size of the last_error column.
+const maxErrorLength = 500
+
+var (
+	ErrNotFound         = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	ErrIntern           = errors.New("an internal error")
+
+	QueryCreate  = "INSERT INTO webhooks (url, event_types, secret, created_at) VALUES (?, ?, ?, ?);"
+	QueryGetAll  = "SELECT id, url, event_types, " + sqltime.Column("created_at") + " FROM webhooks ORDER BY id;"
+	QueryGetByID = "SELECT id, url, event_types, " + sqltime.Column("created_at") + " FROM webhooks WHERE id=?;"
+	QueryDelete  = "DELETE FROM webhooks WHERE id=?;"
+
+	// one delivery per webhook subscribed to the event, ignoring those already enqueued by a previous
+	// dispatch of the same event
+	QueryEnqueue = "INSERT IGNORE INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at) " +
+		"SELECT id, ?, ?, ?, ?, 0, ?, ? FROM webhooks WHERE FIND_IN_SET(?, event_types);"
+
+	queryDelivery = "SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.response_status, d.last_error, " +
+		sqltime.Column("d.next_attempt_at") + ", " + sqltime.Column("d.delivered_at") + ", " + sqltime.Column("d.created_at") + ", w.url, w.secret " +
+		"FROM webhook_deliveries d JOIN webhooks w ON w.id=d.webhook_id"
+	QueryDue           = queryDelivery + " WHERE d.status=? AND d.next_attempt_at<=? ORDER BY d.id LIMIT ?;"
+	QueryGetDelivery   = queryDelivery + " WHERE d.webhook_id=? AND d.id=?;"
+	QueryGetDeliveries = queryDelivery + " WHERE d.webhook_id=? ORDER BY d.id DESC LIMIT ?;"
+	QuerySaveAttempt   = "UPDATE webhook_deliveries SET status=?, attempts=?, response_status=?, last_error=?, next_attempt_at=?, delivered_at=? WHERE id=?;"
+)
+
+// Delivery is a delivery along with what it takes to post it.
+type Delivery struct {
+	domain.WebhookDelivery
+	Payload []byte
+	URL     string
+	Secret  string
+}
+
+// Repository stores the webhooks and their deliveries. Webhooks stay out of the audit trail, whose
+// snapshots would copy their secrets.
+type Repository interface {
+	Create(ctx context.Context, w domain.Webhook) (int, error)
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	GetByID(ctx context.Context, id int) (domain.Webhook, error)
+	Delete(ctx context.Context, id int) error
+	Enqueue(ctx context.Context, event domain.Event, payload []byte, now time.Time) error
+	Due(ctx context.Context, now time.Time, limit int) ([]Delivery, error)
+	GetDelivery(ctx context.Context, webhookID, id int) (Delivery, error)
+	GetDeliveries(ctx context.Context, webhookID, limit int) ([]domain.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, d domain.WebhookDelivery) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, w domain.Webhook) (int, error) {
+	res, err := r.db.ExecContext(ctx, QueryCreate, w.URL, strings.Join(w.EventTypes, ","), w.Secret, w.CreatedAt)
+	if err != nil {
+		return 0, ErrIntern
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, ErrIntern
+	}
+	return int(id), nil
+}
+
+// returns the webhooks without their secrets
+func (r *repository) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, QueryGetAll)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, ErrIntern
+		}
+		webhooks = append(webhooks, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrIntern
+	}
+
+	return webhooks, nil
+}
+
+// returns the webhook without its secret
+func (r *repository) GetByID(ctx context.Context, id int) (domain.Webhook, error) {
+	w, err := scanWebhook(r.db.QueryRowContext(ctx, QueryGetByID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, ErrNotFound
+		}
+		return domain.Webhook{}, ErrIntern
+	}
+	return w, nil
+}
+
+// deletes the webhook along with its deliveries
+func (r *repository) Delete(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, QueryDelete, id)
+	if err != nil {
+		return ErrIntern
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return ErrIntern
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// creates a pending delivery of event, due at now, for every webhook subscribed to its type
+func (r *repository) Enqueue(ctx context.Context, event domain.Event, payload []byte, now time.Time) error {
+	if _, err := r.db.ExecContext(ctx, QueryEnqueue, event.ID, event.Type, string(payload), StatusPending, now, now, event.Type); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
+
+// returns the pending deliveries due at now, oldest first
+func (r *repository) Due(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, QueryDue, StatusPending, now, limit)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, ErrIntern
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrIntern
+	}
+
+	return deliveries, nil
+}
+
+func (r *repository) GetDelivery(ctx context.Context, webhookID, id int) (Delivery, error) {
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, QueryGetDelivery, webhookID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Delivery{}, ErrDeliveryNotFound
+		}
+		return Delivery{}, ErrIntern
+	}
+	return d, nil
+}
+
+// returns the latest deliveries of the webhook, newest first
+func (r *repository) GetDeliveries(ctx context.Context, webhookID, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, QueryGetDeliveries, webhookID, limit)
+	if err != nil {
+		return nil, ErrIntern
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, ErrIntern
+		}
+		deliveries = append(deliveries, d.WebhookDelivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrIntern
+	}
+
+	return deliveries, nil
+}
+
+// stores the outcome of an attempt to post the delivery
+func (r *repository) SaveAttempt(ctx context.Context, d domain.WebhookDelivery) error {
+	var responseStatus, lastError interface{}
+	if d.ResponseStatus != 0 {
+		responseStatus = d.ResponseStatus
+	}
+	if d.LastError != "" {
+		if len(d.LastError) > maxErrorLength {
+			d.LastError = d.LastError[:maxErrorLength]
+		}
+		lastError = d.LastError
+	}
+	if _, err := r.db.ExecContext(ctx, QuerySaveAttempt, d.Status, d.Attempts, responseStatus, lastError, d.NextAttemptAt, d.DeliveredAt, d.ID); err != nil {
+		return ErrIntern
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebhook(s scanner) (domain.Webhook, error) {
+	w := domain.Webhook{}
+	var eventTypes, createdAt string
+	if err := s.Scan(&w.ID, &w.URL, &eventTypes, &createdAt); err != nil {
+		return domain.Webhook{}, err
+	}
+	var err error
+	if w.CreatedAt, err = sqltime.Parse(createdAt); err != nil {
+		return domain.Webhook{}, err
+	}
+	w.EventTypes = strings.Split(eventTypes, ",")
+	return w, nil
+}
+
+func scanDelivery(s scanner) (Delivery, error) {
+	d := Delivery{}
+	var responseStatus sql.NullInt64
+	var lastError, deliveredAt sql.NullString
+	var nextAttemptAt, createdAt string
+	err := s.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &responseStatus, &lastError,
+		&nextAttemptAt, &deliveredAt, &createdAt, &d.URL, &d.Secret)
+	if err != nil {
+		return Delivery{}, err
+	}
+	d.ResponseStatus = int(responseStatus.Int64)
+	d.LastError = lastError.String
+	if d.NextAttemptAt, err = sqltime.Parse(nextAttemptAt); err != nil {
+		return Delivery{}, err
+	}
+	if d.DeliveredAt, err = sqltime.ParseNull(deliveredAt); err != nil {
+		return Delivery{}, err
+	}
+	if d.CreatedAt, err = sqltime.Parse(createdAt); err != nil {
+		return Delivery{}, err
+	}
+	return d, nil
+}
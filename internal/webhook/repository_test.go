@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+var deliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts", "response_status", "last_error",
+	"next_attempt_at", "delivered_at", "created_at", "url", "secret"}
+
+func Test_Enqueue(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(QueryEnqueue)).
+		WithArgs(7, outbox.SectionUpdated, `{}`, StatusPending, now, now, outbox.SectionUpdated).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	// act
+	err = NewRepository(db).Enqueue(context.Background(), domain.Event{ID: 7, Type: outbox.SectionUpdated}, []byte(`{}`), now)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Due(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	// the dates are selected as text, like every connection answers them whatever its parseTime
+	rows := mock.NewRows(deliveryColumns).
+		AddRow(3, 1, 7, outbox.SectionUpdated, []byte(`{}`), StatusPending, 2, 503, "webhook answered 503", "2023-03-01 10:00:00", nil, "2023-03-01 10:00:00", "https://partner.example/hook", secret)
+	mock.ExpectQuery(regexp.QuoteMeta(QueryDue)).WithArgs(StatusPending, now, 10).WillReturnRows(rows)
+
+	// act
+	due, err := NewRepository(db).Due(context.Background(), now, 10)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []Delivery{{
+		WebhookDelivery: domain.WebhookDelivery{ID: 3, WebhookID: 1, EventID: 7, EventType: outbox.SectionUpdated, Status: StatusPending, Attempts: 2,
+			ResponseStatus: 503, LastError: "webhook answered 503", NextAttemptAt: now, CreatedAt: now},
+		Payload: []byte(`{}`),
+		URL:     "https://partner.example/hook",
+		Secret:  secret,
+	}}, due)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetByID_Webhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	createdAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("splits the event types", func(t *testing.T) {
+		// arrange
+		rows := mock.NewRows([]string{"id", "url", "event_types", "created_at"}).
+			AddRow(1, "https://partner.example/hook", "PurchaseOrderCreated,SectionUpdated", "2023-03-01 10:00:00")
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WithArgs(1).WillReturnRows(rows)
+
+		// act
+		w, err := NewRepository(db).GetByID(context.Background(), 1)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Webhook{ID: 1, URL: "https://partner.example/hook", EventTypes: []string{outbox.PurchaseOrderCreated, outbox.SectionUpdated}, CreatedAt: createdAt}, w)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// arrange
+		mock.ExpectQuery(regexp.QuoteMeta(QueryGetByID)).WithArgs(2).WillReturnRows(mock.NewRows([]string{"id"}))
+
+		// act
+		_, err := NewRepository(db).GetByID(context.Background(), 2)
+
+		// assert
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_SaveAttempt(t *testing.T) {
+	// arrange
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(QuerySaveAttempt)).
+		WithArgs(StatusDelivered, 1, 200, nil, now, &now, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// act
+	err = NewRepository(db).SaveAttempt(context.Background(), domain.WebhookDelivery{ID: 3, Status: StatusDelivered, Attempts: 1, ResponseStatus: 200, NextAttemptAt: now, DeliveredAt: &now})
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,288 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+)
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// Headers of a delivery. The signature is "sha256=" followed by the hex HMAC-SHA256, under the webhook
+// secret, of the timestamp header, a dot and the body, so that a receiver can also refuse old replays.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+const (
+	MaxAttempts     = 8
+	BaseBackoff     = 30 * time.Second
+	MaxBackoff      = 6 * time.Hour
+	DeliveriesLimit = 100
+	ClientTimeout   = 10 * time.Second
+	dueBatchSize    = 100
+)
+
+var (
+	ErrUnknownEventType = errors.New("unknown event type")
+	ErrForbiddenURL     = errors.New("webhook url must be http or https and reach a public address")
+)
+
+// eventTypes are the events a webhook can subscribe to.
+var eventTypes = map[string]bool{
+	outbox.PurchaseOrderCreated: true,
+	outbox.ProductBatchCreated:  true,
+	outbox.ProductBatchUpdated:  true,
+	outbox.InboundOrderReceived: true,
+	outbox.SectionUpdated:       true,
+}
+
+type Service interface {
+	Create(ctx context.Context, w domain.Webhook) (domain.Webhook, error)
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	GetByID(ctx context.Context, id int) (domain.Webhook, error)
+	Delete(ctx context.Context, id int) error
+	GetDeliveries(ctx context.Context, webhookID int) ([]domain.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int) (domain.WebhookDelivery, error)
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   Repository
+	client *http.Client
+	lookup func(ctx context.Context, host string) ([]net.IP, error)
+	now    func() time.Time
+}
+
+// NewService posts the deliveries with client or, when nil, with a client giving up after ClientTimeout
+// that only connects to public addresses.
+func NewService(repo Repository, client *http.Client) Service {
+	if client == nil {
+		client = publicClient()
+	}
+	return &service{
+		repo:   repo,
+		client: client,
+		lookup: func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		},
+		now: time.Now,
+	}
+}
+
+// registers the webhook and returns it without its secret
+func (s *service) Create(ctx context.Context, w domain.Webhook) (domain.Webhook, error) {
+	for _, eventType := range w.EventTypes {
+		if !eventTypes[eventType] {
+			return domain.Webhook{}, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+		}
+	}
+	if err := s.checkURL(ctx, w.URL); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	w.CreatedAt = s.now().UTC().Truncate(time.Second)
+	id, err := s.repo.Create(ctx, w)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	w.ID = id
+	w.Secret = ""
+	return w, nil
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *service) GetByID(ctx context.Context, id int) (domain.Webhook, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// returns the latest deliveries of the webhook, at most DeliveriesLimit of them
+func (s *service) GetDeliveries(ctx context.Context, webhookID int) ([]domain.WebhookDelivery, error) {
+	if _, err := s.repo.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveries(ctx, webhookID, DeliveriesLimit)
+}
+
+// posts the delivery again right away, whatever its status, and returns its outcome
+func (s *service) Redeliver(ctx context.Context, webhookID, deliveryID int) (domain.WebhookDelivery, error) {
+	d, err := s.repo.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return s.attempt(ctx, d)
+}
+
+// posts the deliveries whose next attempt is due and returns how many were delivered
+func (s *service) DeliverDue(ctx context.Context) (int, error) {
+	due, err := s.repo.Due(ctx, s.now(), dueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range due {
+		result, err := s.attempt(ctx, d)
+		if err != nil {
+			return delivered, err
+		}
+		if result.Status == StatusDelivered {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// attempt posts d and stores the outcome: delivered on a 2xx answer, otherwise pending again after the
+// backoff of its attempts, or failed once it has run out of them.
+func (s *service) attempt(ctx context.Context, d Delivery) (domain.WebhookDelivery, error) {
+	result := d.WebhookDelivery
+	result.Attempts++
+	result.LastError = ""
+
+	now := s.now()
+	status, err := s.post(ctx, d, now)
+	result.ResponseStatus = status
+	switch {
+	case err == nil:
+		result.Status = StatusDelivered
+		result.DeliveredAt = &now
+	case result.Attempts >= MaxAttempts:
+		result.Status = StatusFailed
+		result.LastError = err.Error()
+	default:
+		result.Status = StatusPending
+		result.LastError = err.Error()
+		result.NextAttemptAt = now.Add(Backoff(result.Attempts))
+	}
+
+	if err := s.repo.SaveAttempt(ctx, result); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return result, nil
+}
+
+// post sends the payload of d to its webhook and returns the status it got back, failing unless it is 2xx.
+func (s *service) post(ctx context.Context, d Delivery, now time.Time) (int, error) {
+	// the host may resolve elsewhere since the webhook was registered
+	if err := s.checkURL(ctx, d.URL); err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, d.EventType)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(d.ID))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(d.Secret, timestamp, d.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook answered %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// checkURL refuses to post to rawURL unless it is http or https and its host only resolves to public
+// addresses, so that a webhook cannot be used to reach the loopback, private or link-local network.
+func (s *service) checkURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrForbiddenURL
+	}
+	ips, err := s.lookup(ctx, u.Hostname())
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("%w: %s does not resolve", ErrForbiddenURL, u.Hostname())
+	}
+	for _, ip := range ips {
+		if !public(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenURL, u.Hostname(), ip)
+		}
+	}
+	return nil
+}
+
+func public(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// publicClient refuses to connect to an address checkURL would not accept, which also covers redirects
+// and a host resolving differently between the check and the connection. It connects directly rather
+// than through a proxy, so that the address checked is the receiver's.
+func publicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: ClientTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !public(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenURL, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: ClientTimeout, Transport: transport}
+}
+
+// Sign returns the signature header of a delivery of body sent at timestamp, in Unix seconds.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns how long to wait after the given number of failed attempts: BaseBackoff doubled for
+// every attempt after the first, at most MaxBackoff.
+func Backoff(attempts int) time.Duration {
+	wait := BaseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= MaxBackoff {
+			return MaxBackoff
+		}
+	}
+	return wait
+}
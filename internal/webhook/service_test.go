@@ -0,0 +1,360 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Create(ctx context.Context, w domain.Webhook) (int, error) {
+	args := r.Called(ctx, w)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, id int) (domain.Webhook, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Enqueue(ctx context.Context, event domain.Event, payload []byte, now time.Time) error {
+	args := r.Called(ctx, event, payload, now)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Due(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	args := r.Called(ctx, now, limit)
+	return args.Get(0).([]Delivery), args.Error(1)
+}
+
+func (r *RepositoryMock) GetDelivery(ctx context.Context, webhookID, id int) (Delivery, error) {
+	args := r.Called(ctx, webhookID, id)
+	return args.Get(0).(Delivery), args.Error(1)
+}
+
+func (r *RepositoryMock) GetDeliveries(ctx context.Context, webhookID, limit int) ([]domain.WebhookDelivery, error) {
+	args := r.Called(ctx, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (r *RepositoryMock) SaveAttempt(ctx context.Context, d domain.WebhookDelivery) error {
+	args := r.Called(ctx, d)
+	return args.Error(0)
+}
+
+// receiver is a partner endpoint answering status and keeping the requests it got.
+type receiver struct {
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	w.WriteHeader(rc.status)
+}
+
+const secret = "0123456789abcdef"
+
+// resolveTo is a lookup answering ip for every host.
+func resolveTo(ip string) func(ctx context.Context, host string) ([]net.IP, error) {
+	return func(ctx context.Context, host string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP(ip)}, nil
+	}
+}
+
+// newTestService takes every host for a public one, so that it posts to the local test receivers.
+func newTestService(repo Repository, now time.Time) *service {
+	return &service{repo: repo, client: http.DefaultClient, lookup: resolveTo("203.0.113.10"), now: func() time.Time { return now }}
+}
+
+func Test_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":7,"type":"SectionUpdated"}`)
+	due := func(url string, attempts int) Delivery {
+		return Delivery{
+			WebhookDelivery: domain.WebhookDelivery{ID: 3, WebhookID: 1, EventID: 7, EventType: outbox.SectionUpdated, Status: StatusPending, Attempts: attempts},
+			Payload:         payload,
+			URL:             url,
+			Secret:          secret,
+		}
+	}
+
+	t.Run("posts a signed delivery", func(t *testing.T) {
+		// arrange
+		rc := &receiver{status: http.StatusOK}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+		repo := &RepositoryMock{}
+		repo.On("Due", ctx, now, dueBatchSize).Return([]Delivery{due(server.URL, 0)}, nil)
+		repo.On("SaveAttempt", ctx, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.Status == StatusDelivered && d.Attempts == 1 && d.ResponseStatus == http.StatusOK && d.DeliveredAt.Equal(now)
+		})).Return(nil)
+
+		// act
+		delivered, err := newTestService(repo, now).DeliverDue(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Len(t, rc.requests, 1)
+		req := rc.requests[0]
+		timestamp, err := strconv.ParseInt(req.Header.Get(TimestampHeader), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, now.Unix(), timestamp)
+		assert.Equal(t, Sign(secret, timestamp, rc.bodies[0]), req.Header.Get(SignatureHeader))
+		assert.Equal(t, outbox.SectionUpdated, req.Header.Get(EventHeader))
+		assert.Equal(t, "3", req.Header.Get(DeliveryHeader))
+		assert.Equal(t, payload, rc.bodies[0])
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("retries a refused delivery later", func(t *testing.T) {
+		// arrange
+		rc := &receiver{status: http.StatusServiceUnavailable}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+		repo := &RepositoryMock{}
+		repo.On("Due", ctx, now, dueBatchSize).Return([]Delivery{due(server.URL, 2)}, nil)
+		repo.On("SaveAttempt", ctx, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.Status == StatusPending && d.Attempts == 3 && d.ResponseStatus == http.StatusServiceUnavailable &&
+				d.LastError == "webhook answered 503" && d.NextAttemptAt.Equal(now.Add(4*BaseBackoff)) && d.DeliveredAt == nil
+		})).Return(nil)
+
+		// act
+		delivered, err := newTestService(repo, now).DeliverDue(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Len(t, rc.requests, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		// arrange
+		rc := &receiver{status: http.StatusInternalServerError}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+		repo := &RepositoryMock{}
+		repo.On("Due", ctx, now, dueBatchSize).Return([]Delivery{due(server.URL, MaxAttempts-1)}, nil)
+		repo.On("SaveAttempt", ctx, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.Status == StatusFailed && d.Attempts == MaxAttempts
+		})).Return(nil)
+
+		// act
+		_, err := newTestService(repo, now).DeliverDue(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("refuses a host now resolving to a private address", func(t *testing.T) {
+		// arrange
+		rc := &receiver{status: http.StatusOK}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+		repo := &RepositoryMock{}
+		repo.On("Due", ctx, now, dueBatchSize).Return([]Delivery{due(server.URL, 0)}, nil)
+		repo.On("SaveAttempt", ctx, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.Status == StatusPending && d.ResponseStatus == 0 && d.LastError != ""
+		})).Return(nil)
+		s := newTestService(repo, now)
+		s.lookup = resolveTo("10.0.0.5")
+
+		// act
+		delivered, err := s.DeliverDue(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Empty(t, rc.requests)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		// arrange
+		server := httptest.NewServer(&receiver{})
+		server.Close()
+		repo := &RepositoryMock{}
+		repo.On("Due", ctx, now, dueBatchSize).Return([]Delivery{due(server.URL, 0)}, nil)
+		repo.On("SaveAttempt", ctx, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.Status == StatusPending && d.ResponseStatus == 0 && d.LastError != ""
+		})).Return(nil)
+
+		// act
+		_, err := newTestService(repo, now).DeliverDue(ctx)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func Test_Redeliver(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("posts a failed delivery again", func(t *testing.T) {
+		// arrange
+		rc := &receiver{status: http.StatusAccepted}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+		repo := &RepositoryMock{}
+		failed := Delivery{
+			WebhookDelivery: domain.WebhookDelivery{ID: 3, WebhookID: 1, Status: StatusFailed, Attempts: MaxAttempts, LastError: "webhook answered 500"},
+			Payload:         []byte(`{}`),
+			URL:             server.URL,
+			Secret:          secret,
+		}
+		repo.On("GetDelivery", ctx, 1, 3).Return(failed, nil)
+		repo.On("SaveAttempt", ctx, mock.Anything).Return(nil)
+
+		// act
+		d, err := newTestService(repo, now).Redeliver(ctx, 1, 3)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, StatusDelivered, d.Status)
+		assert.Equal(t, MaxAttempts+1, d.Attempts)
+		assert.Empty(t, d.LastError)
+		assert.Len(t, rc.requests, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown delivery", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		repo.On("GetDelivery", ctx, 1, 3).Return(Delivery{}, ErrDeliveryNotFound)
+
+		// act
+		_, err := newTestService(repo, now).Redeliver(ctx, 1, 3)
+
+		// assert
+		assert.Equal(t, ErrDeliveryNotFound, err)
+	})
+}
+
+func Test_Create_Webhook(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("hides the secret", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+		w := domain.Webhook{URL: "https://partner.example/hook", EventTypes: []string{outbox.PurchaseOrderCreated}, Secret: secret}
+		stored := w
+		stored.CreatedAt = now
+		repo.On("Create", ctx, stored).Return(4, nil)
+
+		// act
+		created, err := newTestService(repo, now).Create(ctx, w)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, created.ID)
+		assert.Empty(t, created.Secret)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		// arrange
+		repo := &RepositoryMock{}
+
+		// act
+		_, err := newTestService(repo, now).Create(ctx, domain.Webhook{EventTypes: []string{"SellerCreated"}})
+
+		// assert
+		assert.True(t, errors.Is(err, ErrUnknownEventType))
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("forbidden url", func(t *testing.T) {
+		cases := []struct {
+			name, url, resolves string
+		}{
+			{"scheme other than http", "file:///etc/passwd", "203.0.113.10"},
+			{"gopher scheme", "gopher://partner.example/hook", "203.0.113.10"},
+			{"loopback", "http://localhost:8080/hook", "127.0.0.1"},
+			{"private network", "https://partner.example/hook", "192.168.1.20"},
+			{"link-local metadata", "http://169.254.169.254/latest", "169.254.169.254"},
+			{"ipv6 loopback", "http://[::1]/hook", "::1"},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// arrange
+				repo := &RepositoryMock{}
+				s := newTestService(repo, now)
+				s.lookup = resolveTo(c.resolves)
+
+				// act
+				_, err := s.Create(ctx, domain.Webhook{URL: c.url, EventTypes: []string{outbox.PurchaseOrderCreated}, Secret: secret})
+
+				// assert
+				assert.ErrorIs(t, err, ErrForbiddenURL)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func Test_PublicClient(t *testing.T) {
+	server := httptest.NewServer(&receiver{status: http.StatusOK})
+	defer server.Close()
+
+	_, err := publicClient().Get(server.URL)
+
+	assert.ErrorIs(t, err, ErrForbiddenURL)
+}
+
+func Test_Backoff(t *testing.T) {
+	assert.Equal(t, BaseBackoff, Backoff(1))
+	assert.Equal(t, 2*BaseBackoff, Backoff(2))
+	assert.Equal(t, 8*BaseBackoff, Backoff(4))
+	assert.Equal(t, MaxBackoff, Backoff(20))
+}
+
+func Test_Sink(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	event := domain.Event{ID: 7, Type: outbox.SectionUpdated, Resource: "sections", ResourceID: "4", Payload: []byte(`{"id":4}`), CreatedAt: now}
+	repo := &RepositoryMock{}
+	repo.On("Enqueue", ctx, event, []byte(`{"id":7,"type":"SectionUpdated","resource":"sections","resource_id":"4","payload":{"id":4},"created_at":"2023-03-01T10:00:00Z"}`), now).Return(nil)
+	s := &sink{repo: repo, now: func() time.Time { return now }}
+
+	// act
+	err := s.Publish(ctx, event)
+
+	// assert
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
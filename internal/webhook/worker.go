@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/domain"
+	"github.com/mercadolibre/fury_bootcamp-go-w7-s4-8-3/internal/outbox"
+)
+
+const DefaultInterval = 5 * time.Second
+
+// sink turns the events of the outbox into deliveries to the webhooks subscribed to them.
+type sink struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewSink returns the outbox sink feeding the webhooks. Enqueuing an event twice is harmless, so it suits
+// the at least once dispatch of the outbox.
+func NewSink(repo Repository) outbox.Sink {
+	return &sink{repo: repo, now: time.Now}
+}
+
+func (s *sink) Publish(ctx context.Context, event domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.repo.Enqueue(ctx, event, payload, s.now())
+}
+
+// Worker posts the due deliveries every interval.
+type Worker struct {
+	service  Service
+	interval time.Duration
+}
+
+func NewWorker(service Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Worker{service: service, interval: interval}
+}
+
+// Run delivers until ctx is done. It is meant to run on its own goroutine.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.service.DeliverDue(ctx); err != nil {
+			log.Printf("webhook: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+    Normalizes the free-text province_name and country_name columns of localities
+    into countries and provinces tables, and makes localities reference provinces(id).
+
+    Names are trimmed and compared with the column collation (case and accent
+    insensitive by default), so "Buenos Aires", "buenos aires " and "Buenos Áires"
+    collapse into a single row. Abbreviations cannot be detected that way, so they
+    are listed in province_aliases and rewritten before deduplicating.
+*/
+
+create table countries(
+    `id` int not null primary key auto_increment,
+    country_name varchar(50) not null unique
+);
+
+create table provinces(
+    `id` int not null primary key auto_increment,
+    province_name varchar(50) not null,
+    country_id int not null,
+    unique (country_id, province_name),
+    foreign key (country_id) references countries(id)
+);
+
+create temporary table province_aliases(
+    alias varchar(50) not null primary key,
+    canonical varchar(50) not null
+);
+
+insert into province_aliases (alias, canonical) values
+    ('Bs. As.', 'Buenos Aires'),
+    ('Bs As', 'Buenos Aires'),
+    ('BsAs', 'Buenos Aires'),
+    ('Pcia. de Buenos Aires', 'Buenos Aires');
+
+update localities
+set province_name = trim(province_name),
+    country_name = trim(country_name);
+
+update localities l
+inner join province_aliases a on l.province_name = a.alias
+set l.province_name = a.canonical;
+
+insert into countries (country_name)
+select min(country_name) from localities
+group by country_name;
+
+insert into provinces (province_name, country_id)
+select min(l.province_name), c.id from localities l
+inner join countries c on c.country_name = l.country_name
+group by l.province_name, c.id;
+
+alter table localities add column province_id int null;
+
+update localities l
+inner join countries c on c.country_name = l.country_name
+inner join provinces p on p.province_name = l.province_name and p.country_id = c.id
+set l.province_id = p.id;
+
+alter table localities
+    modify province_id int not null,
+    add foreign key (province_id) references provinces(id),
+    drop column province_name,
+    drop column country_name;
+
+drop temporary table province_aliases;
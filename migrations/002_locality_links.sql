@@ -0,0 +1,11 @@
+/*
+    Lets warehouses and buyers point at the locality they are located in, so
+    the unified locality report can count them next to sellers and carries.
+    Both columns are optional: existing rows keep a null locality.
+*/
+
+alter table warehouses add column locality_id varchar(50) null;
+alter table warehouses add foreign key (locality_id) references localities(id);
+
+alter table buyers add column locality_id varchar(50) null;
+alter table buyers add foreign key (locality_id) references localities(id);
@@ -0,0 +1,7 @@
+/*
+    Optional coordinates, in decimal degrees, used to rank warehouses by
+    great-circle distance. Rows without them are skipped by the lookup.
+*/
+
+alter table localities add column latitude double null, add column longitude double null;
+alter table warehouses add column latitude double null, add column longitude double null;
@@ -0,0 +1,22 @@
+/*
+    Transfer documents. Each row records a move of stock from one section to
+    another: the origin batch, the batch that holds the stock afterwards (the
+    same one when the whole batch moved, a new one when it was split), the
+    quantity and the employee who performed it.
+*/
+
+create table transfers(
+    `id` int not null primary key auto_increment,
+    transfer_date datetime not null,
+    product_batch_id int not null,
+    destination_batch_id int not null,
+    origin_section_id int not null,
+    destination_section_id int not null,
+    quantity int not null,
+    employee_id int not null,
+    foreign key (product_batch_id) references products_batches(id),
+    foreign key (destination_batch_id) references products_batches(id),
+    foreign key (origin_section_id) references sections(id),
+    foreign key (destination_section_id) references sections(id),
+    foreign key (employee_id) references employees(id)
+);
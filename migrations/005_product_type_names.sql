@@ -0,0 +1,6 @@
+/*
+    Product type names identify a type across the API, so they must be unique.
+    Existing duplicates have to be merged before applying this migration.
+*/
+
+alter table product_types add unique (`name`);
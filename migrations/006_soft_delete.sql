@@ -0,0 +1,11 @@
+/*
+    Master data is soft deleted: DELETE stamps deleted_at and default reads
+    skip stamped rows, so history keeps its references. Rows are removed for
+    good only through the hard delete endpoints once nothing points at them.
+*/
+
+alter table sellers add column deleted_at datetime null;
+alter table products add column deleted_at datetime null;
+alter table warehouses add column deleted_at datetime null;
+alter table employees add column deleted_at datetime null;
+alter table sections add column deleted_at datetime null;
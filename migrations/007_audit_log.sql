@@ -0,0 +1,19 @@
+/*
+    Every create, update and delete made through the API is recorded with who
+    made it and the columns it changed, in the same transaction as the change.
+    before_data and after_data hold only the changed columns; a create has no
+    before_data and a hard delete no after_data.
+*/
+
+create table audit_log(
+    `id` int not null primary key auto_increment,
+    actor varchar(100) not null,
+    created_at datetime not null,
+    resource varchar(50) not null,
+    resource_id varchar(50) not null,
+    action varchar(20) not null,
+    before_data json null,
+    after_data json null,
+    index audit_log_resource (resource, resource_id),
+    index audit_log_created_at (created_at)
+);
@@ -0,0 +1,16 @@
+/*
+    Rows that can be edited carry a version, bumped by every update, soft
+    delete and restore. GET answers it as an ETag and PATCH or DELETE sent
+    with If-Match only apply while the row still has that version, so that
+    concurrent edits cannot silently overwrite each other.
+*/
+
+alter table countries add column version int not null default 1;
+alter table provinces add column version int not null default 1;
+alter table localities add column version int not null default 1;
+alter table sellers add column version int not null default 1;
+alter table product_types add column version int not null default 1;
+alter table products add column version int not null default 1;
+alter table warehouses add column version int not null default 1;
+alter table employees add column version int not null default 1;
+alter table sections add column version int not null default 1;
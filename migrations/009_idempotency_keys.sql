@@ -0,0 +1,17 @@
+/*
+    POSTs sent with an Idempotency-Key header are remembered per key and route
+    until expires_at, with a hash of the request and the response given to it,
+    so that a retry is answered the same way instead of creating a duplicate.
+    status is null while the first request is still being processed.
+*/
+
+create table idempotency_keys(
+    idempotency_key varchar(255) not null,
+    route varchar(100) not null,
+    request_hash char(64) not null,
+    status int null,
+    body blob null,
+    expires_at datetime not null,
+    primary key (idempotency_key, route),
+    index idempotency_keys_expires_at (expires_at)
+);
@@ -0,0 +1,20 @@
+/*
+    Domain events are written to the outbox in the same transaction as the
+    change they describe and delivered to the configured sinks by the outbox
+    dispatcher, at least once and in id order. published_at stays null until
+    every sink has accepted the event; attempts and last_error track the
+    failed deliveries.
+*/
+
+create table outbox(
+    `id` int not null primary key auto_increment,
+    event_type varchar(100) not null,
+    resource varchar(50) not null,
+    resource_id varchar(50) not null,
+    payload json null,
+    created_at datetime not null,
+    published_at datetime null,
+    attempts int not null default 0,
+    last_error varchar(500) null,
+    index outbox_published_at (published_at)
+);
@@ -0,0 +1,33 @@
+/*
+    Partners register webhooks for the domain event types they want. The
+    webhook sink of the outbox dispatcher turns every event into one delivery
+    per subscribed webhook, which the webhook worker posts, signed with the
+    webhook secret, retrying with exponential backoff until it is delivered or
+    runs out of attempts. event_types is a comma separated list.
+*/
+
+create table webhooks(
+    `id` int not null primary key auto_increment,
+    url varchar(500) not null,
+    event_types varchar(500) not null,
+    secret varchar(255) not null,
+    created_at datetime not null
+);
+
+create table webhook_deliveries(
+    `id` int not null primary key auto_increment,
+    webhook_id int not null,
+    event_id int not null,
+    event_type varchar(100) not null,
+    payload json not null,
+    status varchar(20) not null,
+    attempts int not null default 0,
+    response_status int null,
+    last_error varchar(500) null,
+    next_attempt_at datetime not null,
+    delivered_at datetime null,
+    created_at datetime not null,
+    unique webhook_deliveries_event (webhook_id, event_id),
+    index webhook_deliveries_due (status, next_attempt_at),
+    foreign key (webhook_id) references webhooks(id) on delete cascade
+);
@@ -0,0 +1,17 @@
+/*
+    Demand forecasts stored by the forecast job for reporting. Every run
+    replaces all of them with one row per product and day of the horizon,
+    holding the moving-average and the exponential-smoothing forecast of the
+    purchase orders of that day.
+*/
+
+create table product_forecasts(
+    `id` int not null primary key auto_increment,
+    product_id int not null,
+    forecast_date date not null,
+    moving_average float not null,
+    exponential_smoothing float not null,
+    generated_at datetime not null,
+    unique product_forecasts_day (product_id, forecast_date),
+    foreign key (product_id) references products(id)
+);
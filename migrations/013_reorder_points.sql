@@ -0,0 +1,17 @@
+/*
+    Reorder points per product and warehouse. A product whose unexpired stock
+    in the warehouse falls below reorder_point shows up in the replenishment
+    report, which suggests ordering enough to cover the recent consumption
+    on top of safety_stock.
+*/
+
+create table reorder_points(
+    `id` int not null primary key auto_increment,
+    warehouse_id int not null,
+    product_id int not null,
+    reorder_point int not null,
+    safety_stock int not null,
+    unique reorder_points_product (warehouse_id, product_id),
+    foreign key (warehouse_id) references warehouses(id),
+    foreign key (product_id) references products(id)
+);
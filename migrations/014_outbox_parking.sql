@@ -0,0 +1,8 @@
+/*
+    An event the sinks keep refusing is parked once it has used up its
+    delivery attempts, so that the events after it still go out. Parked
+    events keep their attempts and last_error; clearing parked_at puts one
+    back in line.
+*/
+
+alter table outbox add column parked_at datetime null;
@@ -0,0 +1,10 @@
+/*
+    Idempotency keys belong to the caller that sent them, told apart by a
+    hash of its API key or else by its actor, so that two callers picking
+    the same key on the same route do not get each other's responses.
+    Keys stored before this migration keep an empty scope and expire as
+    usual.
+*/
+
+alter table idempotency_keys add column scope varchar(255) not null default '' first;
+alter table idempotency_keys drop primary key, add primary key (scope, idempotency_key, route);
@@ -0,0 +1,66 @@
+// Package cache keeps computed responses for a while and drops them as soon as a table they were read
+// from is written. Entries are tagged with those tables and writes invalidate the tags of the tables they
+// touch, which audit.Mutate does for every change made with a context carrying a cache.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTTL is how long an entry is served when nothing invalidates it sooner.
+const DefaultTTL = 5 * time.Minute
+
+// Key is the context key holding the cache the writes made with the context invalidate. Like the audit
+// actor it is a string so that it can be set on the gin context the handlers pass down.
+const Key = "cache.cache"
+
+// Entry is a value along with when it was stored and for how long it is served.
+type Entry struct {
+	Value    []byte
+	StoredAt time.Time
+	TTL      time.Duration
+}
+
+// Age is how long ago the entry was stored.
+func (e Entry) Age(now time.Time) time.Duration {
+	return now.Sub(e.StoredAt)
+}
+
+// Expired reports whether the entry is no longer served at now.
+func (e Entry) Expired(now time.Time) bool {
+	return !now.Before(e.StoredAt.Add(e.TTL))
+}
+
+// Cache stores entries under keys. Memory is the in-process implementation; a cache shared between
+// instances, such as Redis, can be swapped in by implementing it.
+type Cache interface {
+	// Get returns the entry stored under key, false when there is none or it expired.
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	// Set stores entry under key until it expires or one of tags is invalidated.
+	Set(ctx context.Context, key string, entry Entry, tags ...string) error
+	// Invalidate drops every entry stored with any of tags.
+	Invalidate(ctx context.Context, tags ...string) error
+}
+
+// WithCache returns a context whose writes invalidate the entries of c, for callers outside the API.
+func WithCache(ctx context.Context, c Cache) context.Context {
+	return context.WithValue(ctx, Key, c)
+}
+
+// From returns the cache the writes made with ctx invalidate, if any.
+func From(ctx context.Context) (Cache, bool) {
+	c, ok := ctx.Value(Key).(Cache)
+	return c, ok
+}
+
+// Invalidate drops the entries read from tables from the cache of ctx, if it carries one. It is called
+// once a write is committed; a read that started before it may still store the rows from before, which
+// are then served until their TTL runs out or the next write to those tables.
+func Invalidate(ctx context.Context, tables ...string) error {
+	c, ok := From(ctx)
+	if !ok {
+		return nil
+	}
+	return c.Invalidate(ctx, tables...)
+}
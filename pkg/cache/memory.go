@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaxEntries bounds the entries a Memory holds.
+const DefaultMaxEntries = 1024
+
+// Memory is a Cache held in the memory of the process. Expired entries are dropped when read, and all of
+// them whenever it is full; while still full nothing more is stored.
+type Memory struct {
+	mu         sync.Mutex
+	entries    map[string]Entry
+	tags       map[string]map[string]bool
+	maxEntries int
+	now        func() time.Time
+}
+
+// NewMemory returns an empty Memory holding up to maxEntries, DefaultMaxEntries when it is not positive.
+func NewMemory(maxEntries int) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &Memory{
+		entries:    map[string]Entry{},
+		tags:       map[string]map[string]bool{},
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (Entry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return Entry{}, false, nil
+	}
+	if entry.Expired(m.now()) {
+		delete(m.entries, key)
+		return Entry{}, false, nil
+	}
+	return entry, true, nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, entry Entry, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entries[key]; !ok && len(m.entries) >= m.maxEntries {
+		m.sweep()
+		if len(m.entries) >= m.maxEntries {
+			return nil
+		}
+	}
+
+	m.entries[key] = entry
+	for _, tag := range tags {
+		if m.tags[tag] == nil {
+			m.tags[tag] = map[string]bool{}
+		}
+		m.tags[tag][key] = true
+	}
+	return nil
+}
+
+func (m *Memory) Invalidate(ctx context.Context, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			delete(m.entries, key)
+		}
+		delete(m.tags, tag)
+	}
+	return nil
+}
+
+// sweep drops the expired entries and the tags left without any. Callers hold mu.
+func (m *Memory) sweep() {
+	now := m.now()
+	for key, entry := range m.entries {
+		if entry.Expired(now) {
+			delete(m.entries, key)
+		}
+	}
+	for tag, keys := range m.tags {
+		for key := range keys {
+			if _, ok := m.entries[key]; !ok {
+				delete(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMemory(maxEntries int) (*Memory, *time.Time) {
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory(maxEntries)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func Test_Memory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("served until it expires", func(t *testing.T) {
+		// arrange
+		m, now := newTestMemory(0)
+		m.Set(ctx, "a", Entry{Value: []byte("1"), StoredAt: *now, TTL: time.Minute})
+
+		// act
+		entry, ok, err := m.Get(ctx, "a")
+		*now = now.Add(time.Minute)
+		_, okExpired, _ := m.Get(ctx, "a")
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), entry.Value)
+		assert.False(t, okExpired)
+	})
+
+	t.Run("invalidate drops the entries of the tag only", func(t *testing.T) {
+		// arrange
+		m, now := newTestMemory(0)
+		m.Set(ctx, "sections report", Entry{StoredAt: *now, TTL: time.Minute}, "sections", "products_batches")
+		m.Set(ctx, "carries report", Entry{StoredAt: *now, TTL: time.Minute}, "localities", "carries")
+
+		// act
+		err := m.Invalidate(ctx, "products_batches")
+
+		// assert
+		assert.NoError(t, err)
+		_, ok, _ := m.Get(ctx, "sections report")
+		assert.False(t, ok)
+		_, ok, _ = m.Get(ctx, "carries report")
+		assert.True(t, ok)
+	})
+
+	t.Run("full makes room from the expired entries", func(t *testing.T) {
+		// arrange
+		m, now := newTestMemory(2)
+		m.Set(ctx, "a", Entry{StoredAt: *now, TTL: time.Second}, "t")
+		m.Set(ctx, "b", Entry{StoredAt: *now, TTL: time.Minute}, "t")
+		*now = now.Add(time.Second)
+
+		// act
+		m.Set(ctx, "c", Entry{StoredAt: *now, TTL: time.Minute}, "t")
+		m.Set(ctx, "d", Entry{StoredAt: *now, TTL: time.Minute}, "t")
+
+		// assert
+		_, ok, _ := m.Get(ctx, "c")
+		assert.True(t, ok)
+		_, ok, _ = m.Get(ctx, "d")
+		assert.False(t, ok, "nothing expired to make room for d")
+		assert.Len(t, m.entries, 2)
+	})
+}
+
+func Test_Invalidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without cache", func(t *testing.T) {
+		assert.NoError(t, Invalidate(ctx, "sellers"))
+	})
+
+	t.Run("with cache", func(t *testing.T) {
+		// arrange
+		m, now := newTestMemory(0)
+		m.Set(ctx, "a", Entry{StoredAt: *now, TTL: time.Minute}, "sellers")
+
+		// act
+		err := Invalidate(WithCache(ctx, m), "sellers")
+
+		// assert
+		assert.NoError(t, err)
+		_, ok, _ := m.Get(ctx, "a")
+		assert.False(t, ok)
+	})
+}
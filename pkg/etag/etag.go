@@ -0,0 +1,85 @@
+// Package etag holds the optimistic concurrency shared by the versioned resources: every write bumps the
+// version column of its row, GET answers that version as an ETag and a write sent with If-Match only
+// applies while the row still has the version the client read.
+package etag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPreconditionFailed   = errors.New("the resource was modified since it was read, fetch it again and retry")
+	ErrPreconditionRequired = errors.New("the If-Match header with the ETag of the resource is required")
+)
+
+// VersionKey is the context key holding the version a write expects, set from If-Match. Like the audit
+// actor it is a string so that it can be set on the gin context the handlers pass down.
+const VersionKey = "etag.version"
+
+// Querier reads a row on the database or on the transaction of a write.
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Format returns the ETag of a version.
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// Parse returns the version of an If-Match value. "*" matches any version and is reported as absent, while
+// a value that is no ETag of ours, such as a weak one, never matches and is reported as version 0.
+func Parse(ifMatch string) (version int, ok bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, false
+	}
+	version, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil || version < 1 || !strings.HasPrefix(ifMatch, `"`) {
+		return 0, true
+	}
+	return version, true
+}
+
+// WithVersion returns a context whose writes only apply to rows still at version.
+func WithVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, VersionKey, version)
+}
+
+// Expected returns the version the writes made with ctx expect, if any.
+func Expected(ctx context.Context) (int, bool) {
+	version, ok := ctx.Value(VersionKey).(int)
+	return version, ok
+}
+
+// Version reads the version of the row of table with the given id.
+func Version(ctx context.Context, q Querier, table string, id interface{}) (int, error) {
+	var version int
+	err := q.QueryRowContext(ctx, "SELECT version FROM "+table+" WHERE id=?", id).Scan(&version)
+	return version, err
+}
+
+// Check locks the row of table with the given id until the transaction of q ends and returns
+// ErrPreconditionFailed when its version is not the one ctx expects. A missing row passes, leaving the
+// write to report it is not found.
+func Check(ctx context.Context, q Querier, table string, id interface{}) error {
+	expected, ok := Expected(ctx)
+	if !ok {
+		return nil
+	}
+
+	var version int
+	err := q.QueryRowContext(ctx, "SELECT version FROM "+table+" WHERE id=? FOR UPDATE", id).Scan(&version)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return err
+	case version != expected:
+		return ErrPreconditionFailed
+	}
+	return nil
+}
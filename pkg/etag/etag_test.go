@@ -0,0 +1,92 @@
+package etag
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		ifMatch string
+		version int
+		ok      bool
+	}{
+		{ifMatch: "", ok: false},
+		{ifMatch: "*", ok: false},
+		{ifMatch: `"3"`, version: 3, ok: true},
+		{ifMatch: ` "12" `, version: 12, ok: true},
+		{ifMatch: `W/"3"`, version: 0, ok: true},
+		{ifMatch: `3`, version: 0, ok: true},
+		{ifMatch: `"abc"`, version: 0, ok: true},
+	}
+	for _, c := range cases {
+		version, ok := Parse(c.ifMatch)
+		assert.Equal(t, c.version, version, c.ifMatch)
+		assert.Equal(t, c.ok, ok, c.ifMatch)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	version, ok := Parse(Format(7))
+	assert.True(t, ok)
+	assert.Equal(t, 7, version)
+}
+
+func TestCheck(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT version FROM sellers WHERE id=? FOR UPDATE")
+
+	t.Run("no expected version", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		assert.NoError(t, Check(context.Background(), db, "sellers", 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("current version", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+
+		assert.NoError(t, Check(WithVersion(context.Background(), 3), db, "sellers", 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+
+		assert.Equal(t, ErrPreconditionFailed, Check(WithVersion(context.Background(), 3), db, "sellers", 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing row is left to the write", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+		assert.NoError(t, Check(WithVersion(context.Background(), 3), db, "sellers", 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		errDB := errors.New("connection lost")
+		mock.ExpectQuery(query).WithArgs(1).WillReturnError(errDB)
+
+		assert.Equal(t, errDB, Check(WithVersion(context.Background(), 3), db, "sellers", 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
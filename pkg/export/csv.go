@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func writeCSV(w io.Writer, t table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.header()); err != nil {
+		return err
+	}
+
+	record := make([]string, len(t.columns))
+	for i := 0; i < t.len(); i++ {
+		for j, c := range t.row(i) {
+			record[j] = csvValue(c)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		if (i+1)%FlushRows == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			flush(w)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvValue(c interface{}) string {
+	switch v := c.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// escapeFormula prefixes text a spreadsheet application would evaluate as a formula with a single quote,
+// so it is shown as text instead (CSV injection). A sign followed by a digit is left alone, so numbers
+// and phone numbers such as -5 or +54 11 read as they are.
+func escapeFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '@', '\t', '\r':
+		return "'" + s
+	case '+', '-':
+		if len(s) > 1 && !strings.ContainsRune("0123456789", rune(s[1])) {
+			return "'" + s
+		}
+	}
+	return s
+}
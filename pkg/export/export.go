@@ -0,0 +1,222 @@
+// Package export writes report data as CSV or xlsx spreadsheets.
+//
+// Reports are slices of structs (or a single struct). Every exported field becomes a column named after
+// its json tag, so a spreadsheet uses the same names as the JSON response. Embedded structs are flattened
+// and nested values are written as JSON. In CSV, text cells that a spreadsheet application would evaluate
+// as a formula are prefixed with a single quote; xlsx cells are inline strings, which are never evaluated.
+// Both formats are flushed every FlushRows rows, so a large report reaches the client while it is written.
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Formats
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Content types
+const (
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// FlushRows is how many rows are written between flushes of the writer.
+const FlushRows = 100
+
+var ErrUnsupportedFormat = errors.New("format must be json, csv or xlsx")
+
+// Negotiate returns the export format requested by the format query value or, when it is empty, by the
+// Accept header. An empty format means the caller should answer JSON.
+func Negotiate(format, accept string) (string, error) {
+	switch strings.ToLower(format) {
+	case CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	case "", "json":
+		if format != "" {
+			return "", nil
+		}
+	default:
+		return "", ErrUnsupportedFormat
+	}
+
+	for _, media := range strings.Split(accept, ",") {
+		switch strings.TrimSpace(strings.Split(media, ";")[0]) {
+		case ContentTypeCSV:
+			return CSV, nil
+		case ContentTypeXLSX:
+			return XLSX, nil
+		case "application/json":
+			return "", nil
+		}
+	}
+
+	return "", nil
+}
+
+// ContentType returns the media type of a format.
+func ContentType(format string) string {
+	if format == XLSX {
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV + "; charset=utf-8"
+}
+
+// Write encodes data in the given format. data is a slice of structs or a single struct. When w has a
+// Flush method, as an http.ResponseWriter does, it is called every FlushRows rows.
+func Write(w io.Writer, format string, data interface{}) error {
+	t := newTable(data)
+	switch format {
+	case CSV:
+		return writeCSV(w, t)
+	case XLSX:
+		return writeXLSX(w, t)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+// table gives row-by-row access to report data without copying it.
+type table struct {
+	columns []column
+	rows    reflect.Value
+	single  bool
+}
+
+func newTable(data interface{}) table {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	t := table{rows: v}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		elem := v.Type().Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		t.columns = columns(elem, nil)
+	case reflect.Struct:
+		t.single = true
+		t.columns = columns(v.Type(), nil)
+	}
+
+	return t
+}
+
+func (t table) len() int {
+	switch {
+	case t.single:
+		return 1
+	case t.rows.IsValid() && (t.rows.Kind() == reflect.Slice || t.rows.Kind() == reflect.Array):
+		return t.rows.Len()
+	default:
+		return 0
+	}
+}
+
+func (t table) header() []string {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// row returns the cell values of row i; nil stands for an empty cell.
+func (t table) row(i int) []interface{} {
+	v := t.rows
+	if !t.single {
+		v = t.rows.Index(i)
+	}
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	cells := make([]interface{}, len(t.columns))
+	for j, c := range t.columns {
+		cells[j] = cell(v.FieldByIndex(c.index))
+	}
+	return cells
+}
+
+// columns lists the exported fields of t, named after their json tags.
+func columns(t reflect.Type, parent []int) []column {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		// like encoding/json, the fields of an untagged embedded struct are promoted even if its type is unexported
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			cols = append(cols, columns(f.Type, index)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		cols = append(cols, column{name: name, index: index})
+	}
+	return cols
+}
+
+// cell converts a field into a string, a number, a bool or nil.
+func cell(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprint(v.Interface())
+		}
+		return string(b)
+	}
+}
+
+// flush sends what was written so far to the client when w can do it.
+func flush(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type base struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type reportRow struct {
+	base
+	Price   float64  `json:"price"`
+	Active  bool     `json:"active"`
+	Rate    *float64 `json:"rate,omitempty"`
+	Tags    []string `json:"tags"`
+	ignored string
+	Hidden  string `json:"-"`
+}
+
+func Test_Negotiate(t *testing.T) {
+	cases := []struct {
+		format, accept, expected string
+		err                      error
+	}{
+		{format: "", accept: "", expected: ""},
+		{format: "", accept: "application/json", expected: ""},
+		{format: "", accept: "text/csv;q=0.9, application/json", expected: CSV},
+		{format: "", accept: ContentTypeXLSX, expected: XLSX},
+		{format: "CSV", accept: ContentTypeXLSX, expected: CSV},
+		{format: "json", accept: "text/csv", expected: ""},
+		{format: "pdf", err: ErrUnsupportedFormat},
+	}
+	for _, c := range cases {
+		format, err := Negotiate(c.format, c.accept)
+		assert.Equal(t, c.err, err, c)
+		assert.Equal(t, c.expected, format, c)
+	}
+}
+
+func Test_WriteCSV(t *testing.T) {
+	rate := 0.5
+	rows := []reportRow{
+		{base: base{ID: 1, Name: "a, b"}, Price: 10.25, Active: true, Rate: &rate, Tags: []string{"x"}},
+		{base: base{ID: 2, Name: "c"}},
+	}
+
+	var buf bytes.Buffer
+	err := Write(&buf, CSV, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name,price,active,rate,tags\n"+
+		"1,\"a, b\",10.25,true,0.5,\"[\"\"x\"\"]\"\n"+
+		"2,c,0,false,,null\n", buf.String())
+}
+
+func Test_WriteCSV_SingleStruct(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, CSV, base{ID: 3, Name: "one"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name\n3,one\n", buf.String())
+}
+
+func Test_WriteCSV_EscapesFormulas(t *testing.T) {
+	rows := []base{
+		{ID: 1, Name: "=HYPERLINK(\"http://x\")"},
+		{ID: 2, Name: "+A1"},
+		{ID: 3, Name: "-SUM(A1)"},
+		{ID: 4, Name: "@SUM(A1)"},
+		{ID: -5, Name: "a=b"},
+		{ID: 6, Name: "-5"},
+		{ID: 7, Name: "+54 11 4444 5555"},
+	}
+
+	var buf bytes.Buffer
+	err := Write(&buf, CSV, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name\n"+
+		"1,\"'=HYPERLINK(\"\"http://x\"\")\"\n"+
+		"2,'+A1\n"+
+		"3,'-SUM(A1)\n"+
+		"4,'@SUM(A1)\n"+
+		"-5,a=b\n"+
+		"6,-5\n"+
+		"7,+54 11 4444 5555\n", buf.String())
+}
+
+func Test_WriteXLSX(t *testing.T) {
+	rows := []base{{ID: 1, Name: "<tom & jerry>"}, {ID: 2, Name: "=1+1"}}
+
+	var buf bytes.Buffer
+	err := Write(&buf, XLSX, rows)
+	assert.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	var names []string
+	var sheet string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, names)
+	assert.True(t, strings.Contains(sheet, `<row><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c><c t="inlineStr"><is><t xml:space="preserve">name</t></is></c></row>`))
+	assert.True(t, strings.Contains(sheet, `<row><c><v>1</v></c><c t="inlineStr"><is><t xml:space="preserve">&lt;tom &amp; jerry&gt;</t></is></c></row>`))
+	assert.True(t, strings.Contains(sheet, `<row><c><v>2</v></c><c t="inlineStr"><is><t xml:space="preserve">=1+1</t></is></c></row>`))
+}
+
+// flushRecorder counts the flushes asked for and how much was written before the first one.
+type flushRecorder struct {
+	bytes.Buffer
+	flushes      int
+	firstFlushAt int
+}
+
+func (f *flushRecorder) Flush() {
+	if f.flushes == 0 {
+		f.firstFlushAt = f.Len()
+	}
+	f.flushes++
+}
+
+func Test_Write_FlushesEveryFlushRows(t *testing.T) {
+	rows := make([]base, 2*FlushRows+1)
+	for i := range rows {
+		rows[i] = base{ID: i, Name: "row"}
+	}
+
+	for _, format := range []string{CSV, XLSX} {
+		var w flushRecorder
+		err := Write(&w, format, rows)
+
+		assert.NoError(t, err, format)
+		assert.Equal(t, 2, w.flushes, format)
+		assert.Greater(t, w.firstFlushAt, 0, format)
+		assert.Less(t, w.firstFlushAt, w.Len(), format)
+	}
+}
@@ -0,0 +1,123 @@
+package export
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// The smallest set of parts a spreadsheet application needs to open a workbook with a single sheet.
+// Strings are written inline, so no shared strings table is needed.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="report" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func writeXLSX(w io.Writer, t table) error {
+	zw := zip.NewWriter(w)
+	// keep the compressor of the last part created, the sheet, to flush the rows it holds
+	var sheetCompressor *flate.Writer
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		fw, err := flate.NewWriter(out, flate.DefaultCompression)
+		sheetCompressor = fw
+		return fw, err
+	})
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(t.columns))
+	for i, name := range t.header() {
+		header[i] = name
+	}
+	if err := writeXLSXRow(sheet, header); err != nil {
+		return err
+	}
+	for i := 0; i < t.len(); i++ {
+		if err := writeXLSXRow(sheet, t.row(i)); err != nil {
+			return err
+		}
+		if (i+1)%FlushRows == 0 {
+			if err := sheetCompressor.Flush(); err != nil {
+				return err
+			}
+			if err := zw.Flush(); err != nil {
+				return err
+			}
+			flush(w)
+		}
+	}
+
+	if _, err := io.WriteString(sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeXLSXRow(w io.Writer, cells []interface{}) error {
+	if _, err := io.WriteString(w, "<row>"); err != nil {
+		return err
+	}
+	for _, c := range cells {
+		var err error
+		switch v := c.(type) {
+		case nil:
+			_, err = io.WriteString(w, "<c/>")
+		case bool:
+			b := "0"
+			if v {
+				b = "1"
+			}
+			_, err = io.WriteString(w, `<c t="b"><v>`+b+`</v></c>`)
+		case int64:
+			_, err = io.WriteString(w, "<c><v>"+strconv.FormatInt(v, 10)+"</v></c>")
+		case uint64:
+			_, err = io.WriteString(w, "<c><v>"+strconv.FormatUint(v, 10)+"</v></c>")
+		case float64:
+			_, err = io.WriteString(w, "<c><v>"+strconv.FormatFloat(v, 'f', -1, 64)+"</v></c>")
+		case string:
+			if _, err = io.WriteString(w, `<c t="inlineStr"><is><t xml:space="preserve">`); err == nil {
+				if err = xml.EscapeText(w, []byte(v)); err == nil {
+					_, err = io.WriteString(w, "</t></is></c>")
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "</row>")
+	return err
+}
@@ -0,0 +1,150 @@
+// Package ratelimit bounds how often each client calls the API with token buckets: a bucket holds up to
+// Burst tokens, refills at Rate tokens a second and every request takes one. Clients get a bucket per
+// class of route, so that a flood of writes does not starve their reads.
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidPolicy = errors.New("invalid rate limit policy")
+
+// Class is a kind of route limited apart from the others.
+type Class string
+
+const (
+	Read   Class = "read"
+	Write  Class = "write"
+	Report Class = "report"
+)
+
+// Limit is the bucket of a class: Rate tokens a second up to Burst.
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+func (l Limit) valid() bool {
+	return l.Rate > 0 && l.Burst >= 1
+}
+
+// Limits are the limits of a client, a nil class taking the default one.
+type Limits struct {
+	Read   *Limit `json:"read,omitempty"`
+	Write  *Limit `json:"write,omitempty"`
+	Report *Limit `json:"report,omitempty"`
+}
+
+func (l Limits) of(class Class) *Limit {
+	switch class {
+	case Write:
+		return l.Write
+	case Report:
+		return l.Report
+	default:
+		return l.Read
+	}
+}
+
+// Policy holds the limits of every client: those of the API keys it names, and Default for the rest,
+// clients without a key being told apart by their IP.
+type Policy struct {
+	Default Limits            `json:"default"`
+	Keys    map[string]Limits `json:"keys,omitempty"`
+}
+
+// DefaultPolicy lets a client read 20 times a second, write 5 times and run a report once, with room
+// for bursts.
+var DefaultPolicy = Policy{
+	Default: Limits{
+		Read:   &Limit{Rate: 20, Burst: 40},
+		Write:  &Limit{Rate: 5, Burst: 10},
+		Report: &Limit{Rate: 1, Burst: 5},
+	},
+}
+
+// ParsePolicy reads a policy written in JSON, such as
+//
+//	{"default": {"write": {"rate": 2, "burst": 5}}, "keys": {"erp": {"write": {"rate": 50, "burst": 100}}}}
+//
+// Classes left out take those of DefaultPolicy. An empty spec is DefaultPolicy.
+func ParsePolicy(spec string) (Policy, error) {
+	if spec == "" {
+		return DefaultPolicy, nil
+	}
+	var policy Policy
+	if err := json.Unmarshal([]byte(spec), &policy); err != nil {
+		return Policy{}, fmt.Errorf("%w: %v", ErrInvalidPolicy, err)
+	}
+	for _, class := range []Class{Read, Write, Report} {
+		if policy.Default.of(class) == nil {
+			policy.Default.set(class, DefaultPolicy.Default.of(class))
+		}
+		if !policy.Default.of(class).valid() {
+			return Policy{}, fmt.Errorf("%w: default %s needs a positive rate and burst", ErrInvalidPolicy, class)
+		}
+		for key, limits := range policy.Keys {
+			if limit := limits.of(class); limit != nil && !limit.valid() {
+				return Policy{}, fmt.Errorf("%w: %s of key %q needs a positive rate and burst", ErrInvalidPolicy, class, key)
+			}
+		}
+	}
+	return policy, nil
+}
+
+func (l *Limits) set(class Class, limit *Limit) {
+	switch class {
+	case Write:
+		l.Write = limit
+	case Report:
+		l.Report = limit
+	default:
+		l.Read = limit
+	}
+}
+
+// Result is the state of a bucket after a request took, or failed to take, a token from it.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until a refused request would be allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. Memory keeps them in the process; a store shared between instances, such as
+// Redis, can be plugged in by implementing Take atomically.
+type Store interface {
+	// Take takes a token from the bucket under key, refilled as limit says since it was last taken from.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Limiter applies a policy on the buckets of a store.
+type Limiter struct {
+	store  Store
+	policy Policy
+}
+
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{store: store, policy: policy}
+}
+
+// Allow takes a token for a request of class made with apiKey from ip. A key the policy names has
+// buckets of its own; any other request is limited by its IP, so that sending made up keys gains nothing.
+func (l *Limiter) Allow(ctx context.Context, apiKey, ip string, class Class) (Result, error) {
+	limit := *l.policy.Default.of(class)
+	client := "ip:" + ip
+	if limits, ok := l.policy.Keys[apiKey]; ok && apiKey != "" {
+		client = "key:" + apiKey
+		if own := limits.of(class); own != nil {
+			limit = *own
+		}
+	}
+	return l.store.Take(ctx, string(class)+":"+client, limit)
+}
@@ -0,0 +1,163 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMemory() (*Memory, *time.Time) {
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func Test_Memory_Take(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Rate: 2, Burst: 3}
+
+	t.Run("burst then refused", func(t *testing.T) {
+		// arrange
+		m, _ := newTestMemory()
+
+		// act
+		var results []Result
+		for i := 0; i < 4; i++ {
+			r, err := m.Take(ctx, "a", limit)
+			assert.NoError(t, err)
+			results = append(results, r)
+		}
+
+		// assert
+		assert.Equal(t, []int{2, 1, 0, 0}, []int{results[0].Remaining, results[1].Remaining, results[2].Remaining, results[3].Remaining})
+		assert.True(t, results[2].Allowed)
+		assert.False(t, results[3].Allowed)
+		assert.Equal(t, 3, results[3].Limit)
+		assert.Equal(t, 500*time.Millisecond, results[3].RetryAfter)
+		assert.Equal(t, 1500*time.Millisecond, results[3].Reset)
+	})
+
+	t.Run("refills with time", func(t *testing.T) {
+		// arrange
+		m, now := newTestMemory()
+		for i := 0; i < 3; i++ {
+			m.Take(ctx, "a", limit)
+		}
+
+		// act
+		*now = now.Add(500 * time.Millisecond)
+		r, err := m.Take(ctx, "a", limit)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, r.Allowed)
+		assert.Equal(t, 0, r.Remaining)
+	})
+
+	t.Run("keys have buckets of their own", func(t *testing.T) {
+		// arrange
+		m, _ := newTestMemory()
+		for i := 0; i < 3; i++ {
+			m.Take(ctx, "a", limit)
+		}
+
+		// act
+		r, err := m.Take(ctx, "b", limit)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, r.Allowed)
+	})
+
+	t.Run("full buckets are swept", func(t *testing.T) {
+		// arrange
+		m, now := newTestMemory()
+		m.Take(ctx, "a", limit)
+		*now = now.Add(time.Second)
+
+		// act
+		for i := 1; i < sweepEvery; i++ {
+			m.Take(ctx, "b", Limit{Rate: 1000, Burst: 1})
+		}
+
+		// assert
+		assert.NotContains(t, m.buckets, "a")
+	})
+}
+
+func Test_ParsePolicy(t *testing.T) {
+	t.Run("empty is the default", func(t *testing.T) {
+		policy, err := ParsePolicy("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultPolicy, policy)
+	})
+
+	t.Run("classes left out take the default", func(t *testing.T) {
+		policy, err := ParsePolicy(`{"default": {"write": {"rate": 2, "burst": 5}}, "keys": {"erp": {"write": {"rate": 50, "burst": 100}}}}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Limit{Rate: 2, Burst: 5}, *policy.Default.Write)
+		assert.Equal(t, *DefaultPolicy.Default.Read, *policy.Default.Read)
+		assert.Equal(t, Limit{Rate: 50, Burst: 100}, *policy.Keys["erp"].Write)
+		assert.Nil(t, policy.Keys["erp"].Read)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, spec := range []string{`{`, `{"default": {"read": {"rate": 0, "burst": 5}}}`, `{"keys": {"erp": {"report": {"rate": 1, "burst": 0}}}}`} {
+			_, err := ParsePolicy(spec)
+
+			assert.True(t, errors.Is(err, ErrInvalidPolicy), spec)
+		}
+	})
+}
+
+type storeMock struct {
+	keys   []string
+	limits []Limit
+}
+
+func (s *storeMock) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.keys = append(s.keys, key)
+	s.limits = append(s.limits, limit)
+	return Result{Allowed: true}, nil
+}
+
+func Test_Limiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	erpWrite := Limit{Rate: 50, Burst: 100}
+	policy := DefaultPolicy
+	policy.Keys = map[string]Limits{"erp": {Write: &erpWrite}}
+
+	cases := []struct {
+		name   string
+		apiKey string
+		class  Class
+		key    string
+		limit  Limit
+	}{
+		{"client without key", "", Write, "write:ip:10.0.0.1", *DefaultPolicy.Default.Write},
+		{"unknown key goes by ip", "made-up", Write, "write:ip:10.0.0.1", *DefaultPolicy.Default.Write},
+		{"known key", "erp", Write, "write:key:erp", erpWrite},
+		{"known key without the class", "erp", Report, "report:key:erp", *DefaultPolicy.Default.Report},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			store := &storeMock{}
+			limiter := NewLimiter(store, policy)
+
+			// act
+			_, err := limiter.Allow(ctx, c.apiKey, "10.0.0.1", c.class)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, []string{c.key}, store.keys)
+			assert.Equal(t, []Limit{c.limit}, store.limits)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package softdelete holds what the soft deletable resources share: the errors their handlers map and
+// the dependents check run before a row is removed for good.
+package softdelete
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var (
+	ErrNotDeleted    = errors.New("not deleted")
+	ErrHasDependents = errors.New("blocked by dependents")
+)
+
+// Reference is a foreign key pointing at a soft deletable row, reported by Name when it blocks a hard delete.
+type Reference struct {
+	Name   string
+	Table  string
+	Column string
+}
+
+// Dependents counts the rows referencing id and, when any does, returns an error wrapping ErrHasDependents
+// that names them, e.g. "blocked by dependents: 3 products". Soft deleted dependents count too, since their
+// foreign keys still point at the row.
+func Dependents(ctx context.Context, db *sql.DB, id int, refs ...Reference) error {
+	var blocking []string
+	for _, ref := range refs {
+		var count int
+		query := "SELECT COUNT(*) FROM " + ref.Table + " WHERE " + ref.Column + "=?"
+		if err := db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			blocking = append(blocking, fmt.Sprintf("%d %s", count, ref.Name))
+		}
+	}
+	if len(blocking) > 0 {
+		return fmt.Errorf("%w: %s", ErrHasDependents, strings.Join(blocking, ", "))
+	}
+	return nil
+}
+
+// IsReferenced reports whether err is MySQL refusing a delete because a foreign key still points at the
+// row, which happens when a dependent is added between the count and the delete.
+func IsReferenced(err error) bool {
+	driverErr, ok := err.(*mysql.MySQLError)
+	return ok && driverErr.Number == 1451
+}
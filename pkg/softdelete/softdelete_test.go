@@ -0,0 +1,67 @@
+package softdelete
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+var refs = []Reference{
+	{Name: "products", Table: "products", Column: "id_seller"},
+	{Name: "transfers", Table: "transfers", Column: "employee_id"},
+}
+
+func expectCount(mock sqlmock.Sqlmock, query string, count int) {
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func TestDependents(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		expectCount(mock, "SELECT COUNT(*) FROM products WHERE id_seller=?", 0)
+		expectCount(mock, "SELECT COUNT(*) FROM transfers WHERE employee_id=?", 0)
+
+		err = Dependents(context.Background(), db, 1, refs...)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("blocking", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		expectCount(mock, "SELECT COUNT(*) FROM products WHERE id_seller=?", 3)
+		expectCount(mock, "SELECT COUNT(*) FROM transfers WHERE employee_id=?", 1)
+
+		err = Dependents(context.Background(), db, 1, refs...)
+
+		assert.ErrorIs(t, err, ErrHasDependents)
+		assert.EqualError(t, err, "blocked by dependents: 3 products, 1 transfers")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products WHERE id_seller=?")).WillReturnError(errors.New("connection lost"))
+
+		err = Dependents(context.Background(), db, 1, refs...)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrHasDependents)
+	})
+}
+
+func TestIsReferenced(t *testing.T) {
+	assert.True(t, IsReferenced(&mysql.MySQLError{Number: 1451}))
+	assert.False(t, IsReferenced(&mysql.MySQLError{Number: 1452}))
+	assert.False(t, IsReferenced(errors.New("1451")))
+}
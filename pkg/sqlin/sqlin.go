@@ -0,0 +1,13 @@
+// Package sqlin builds the "IN (...)" lists of the queries reading many rows by id at once.
+package sqlin
+
+import "strings"
+
+// Ints returns the placeholders of an IN list of ids, e.g. "?,?,?", and the arguments binding them.
+func Ints(ids []int) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
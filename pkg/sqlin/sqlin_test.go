@@ -0,0 +1,21 @@
+package sqlin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInts(t *testing.T) {
+	placeholders, args := Ints([]int{4, 2, 9})
+
+	assert.Equal(t, "?,?,?", placeholders)
+	assert.Equal(t, []interface{}{4, 2, 9}, args)
+}
+
+func TestInts_Empty(t *testing.T) {
+	placeholders, args := Ints(nil)
+
+	assert.Equal(t, "", placeholders)
+	assert.Empty(t, args)
+}
@@ -0,0 +1,42 @@
+// Package sqltime reads DATE and DATETIME columns the way the rest of the repositories do, as text, so
+// that they scan the same whatever the parseTime setting of the connection. Queries select a column
+// through DATE_FORMAT, see Column, and Parse turns the text back into a time.
+package sqltime
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Layout is the layout of the text Column selects, the one MySQL writes DATETIME values in.
+const Layout = "2006-01-02 15:04:05"
+
+// Column returns the select expression of a DATETIME column as text, e.g.
+// "DATE_FORMAT(created_at, '%Y-%m-%d %H:%i:%s')".
+func Column(column string) string {
+	return "DATE_FORMAT(" + column + ", '%Y-%m-%d %H:%i:%s')"
+}
+
+// Date returns the select expression of a DATE column as text in the layout "2006-01-02".
+func Date(column string) string {
+	return "DATE_FORMAT(" + column + ", '%Y-%m-%d')"
+}
+
+// Parse returns the time of a column selected with Column, in UTC. Columns read through this package must
+// be written in UTC too: with UTC_TIMESTAMP() rather than NOW(), which follows the time zone of the
+// session, or as a time.Time the driver formats in its loc, UTC unless the DSN sets another one.
+func Parse(s string) (time.Time, error) {
+	return time.ParseInLocation(Layout, s, time.UTC)
+}
+
+// ParseNull is Parse for a nullable column, nil when it is NULL.
+func ParseNull(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	t, err := Parse(s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
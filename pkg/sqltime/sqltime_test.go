@@ -0,0 +1,37 @@
+package sqltime
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumn(t *testing.T) {
+	assert.Equal(t, "DATE_FORMAT(d.created_at, '%Y-%m-%d %H:%i:%s')", Column("d.created_at"))
+	assert.Equal(t, "DATE_FORMAT(po.order_date, '%Y-%m-%d')", Date("po.order_date"))
+}
+
+func TestParse(t *testing.T) {
+	parsed, err := Parse("2023-03-01 10:00:05")
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 5, 0, time.UTC), parsed)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse("2023-03-01T10:00:05Z")
+
+	assert.Error(t, err)
+}
+
+func TestParseNull(t *testing.T) {
+	parsed, err := ParseNull(sql.NullString{})
+	assert.NoError(t, err)
+	assert.Nil(t, parsed)
+
+	parsed, err = ParseNull(sql.NullString{String: "2023-03-01 10:00:05", Valid: true})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 5, 0, time.UTC), *parsed)
+}